@@ -2,7 +2,6 @@ module github.com/joincivil/id-hub
 
 require (
 	github.com/99designs/gqlgen v0.10.1
-	github.com/Jeffail/tunny v0.0.0-20190930221602-f13eb662a36a
 	github.com/agnivade/levenshtein v1.0.3 // indirect
 	github.com/allegro/bigcache v1.2.1
 	github.com/cpuguy83/go-md2man/v2 v2.0.0 // indirect
@@ -29,6 +28,7 @@ require (
 	github.com/machinebox/graphql v0.2.2
 	github.com/matryer/is v1.2.0 // indirect
 	github.com/mitchellh/mapstructure v1.1.2 // indirect
+	github.com/mr-tron/base58 v1.1.2
 	github.com/multiformats/go-multihash v0.0.9
 	github.com/nats-io/nats-streaming-server v0.17.0
 	github.com/nats-io/stan.go v0.6.0
@@ -40,6 +40,7 @@ require (
 	github.com/sergi/go-diff v1.1.0 // indirect
 	github.com/stretchr/objx v0.2.0 // indirect
 	github.com/stretchr/testify v1.4.0
+	github.com/syndtr/goleveldb v1.0.0
 	github.com/urfave/cli v1.22.1
 	github.com/vektah/gqlparser v1.1.2
 	golang.org/x/crypto v0.0.0-20200210222208-86ce3cb69678 // indirect
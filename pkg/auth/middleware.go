@@ -102,7 +102,7 @@ func ForContext(ctx context.Context, ds *did.Service, pks []did.DocPublicKey) (
 	// If did and key found, then pull doc for DID to check the signature
 	// If no did and key passed, then check incoming list of pks to check signature
 	if didStr != "" {
-		err = VerifyEcdsaRequestSignatureWithDid(ds, keyType, signature, ts, didStr, gracePeriod)
+		err = VerifyEcdsaRequestSignatureWithDid(ctx, ds, keyType, signature, ts, didStr, gracePeriod)
 		if err != nil {
 			return nil, err
 		}
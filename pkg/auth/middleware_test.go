@@ -1,7 +1,10 @@
 package auth
 
 import (
+	"context"
 	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
 	"encoding/hex"
 	"fmt"
 	"net/http"
@@ -12,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/joincivil/id-hub/pkg/did"
 	"github.com/joincivil/id-hub/pkg/did/ethuri"
+	"github.com/joincivil/id-hub/pkg/did/key"
 	"github.com/joincivil/id-hub/pkg/linkeddata"
 	"github.com/joincivil/id-hub/pkg/utils"
 
@@ -133,7 +137,7 @@ func TestForContext(t *testing.T) {
 
 	d := buildTestDocument(privKey)
 
-	err := ethURI.SaveDocument(d)
+	err := ethURI.SaveDocument(d, 0)
 	if err != nil {
 		t.Fatalf("Should have not gotten error saving doc")
 	}
@@ -230,7 +234,7 @@ func TestForContextDidWithKeyFragment(t *testing.T) {
 	privKey, _ := crypto.GenerateKey()
 	d := buildTestDocument(privKey)
 
-	err := ethURI.SaveDocument(d)
+	err := ethURI.SaveDocument(d, 0)
 	if err != nil {
 		t.Fatalf("Should have not gotten error saving doc")
 	}
@@ -267,7 +271,7 @@ func TestForContextDidWithInvalidKeyFragment(t *testing.T) {
 	privKey, _ := crypto.GenerateKey()
 	d := buildTestDocument(privKey)
 
-	err := ethURI.SaveDocument(d)
+	err := ethURI.SaveDocument(d, 0)
 	if err != nil {
 		t.Fatalf("Should have not gotten error saving doc")
 	}
@@ -335,7 +339,7 @@ func TestForContextBadSignature(t *testing.T) {
 	privKey, _ := crypto.GenerateKey()
 	d := buildTestDocument(privKey)
 
-	err := ethURI.SaveDocument(d)
+	err := ethURI.SaveDocument(d, 0)
 	if err != nil {
 		t.Fatalf("Should have not gotten error saving doc")
 	}
@@ -361,6 +365,42 @@ func TestForContextBadSignature(t *testing.T) {
 	_, _ = client.Do(req)
 }
 
+func TestVerifyWithDidNotAuthentication(t *testing.T) {
+	ds, ethURI := initService()
+
+	privKey, _ := crypto.GenerateKey()
+	d := buildTestDocument(privKey)
+	// keys-1 is a verification method of the did but not an authentication
+	d.Authentications = d.Authentications[1:]
+
+	err := ethURI.SaveDocument(d, 0)
+	if err != nil {
+		t.Fatalf("Should have not gotten error saving doc")
+	}
+
+	ts := ctime.CurrentEpochSecsInInt()
+	didWithFragment := d.PublicKeys[0].ID.String()
+	signature, err := SignEcdsaRequestMessage(privKey, didWithFragment, ts)
+	if err != nil {
+		t.Fatalf("Should have generated a signature")
+	}
+	err = VerifyEcdsaRequestSignatureWithDid(context.Background(), ds, linkeddata.SuiteTypeSecp256k1Verification,
+		signature, ts, didWithFragment, DefaultRequestGracePeriodSecs)
+	if err == nil {
+		t.Errorf("Should not have verified with a key that is not an authentication")
+	}
+
+	signature, err = SignEcdsaRequestMessage(privKey, d.ID.String(), ts)
+	if err != nil {
+		t.Fatalf("Should have generated a signature")
+	}
+	err = VerifyEcdsaRequestSignatureWithDid(context.Background(), ds, linkeddata.SuiteTypeSecp256k1Verification,
+		signature, ts, d.ID.String(), DefaultRequestGracePeriodSecs)
+	if err == nil {
+		t.Errorf("Should not have verified with the keys that are not authentications")
+	}
+}
+
 func buildTestDocument(privKey *ecdsa.PrivateKey) *did.Document {
 	doc := &did.Document{}
 	pubKeyBys := crypto.FromECDSAPub(&privKey.PublicKey)
@@ -415,3 +455,59 @@ func buildTestDocument(privKey *ecdsa.PrivateKey) *did.Document {
 
 	return doc
 }
+
+func TestVerifyWithEd25519DidKey(t *testing.T) {
+	ds := did.NewService([]did.Resolver{key.NewResolver()})
+
+	pubKey, privKey, _ := ed25519.GenerateKey(rand.Reader)
+	d, err := key.DIDFromEd25519PubKey(pubKey)
+	if err != nil {
+		t.Fatalf("Should have made a did: err: %v", err)
+	}
+
+	ts := ctime.CurrentEpochSecsInInt()
+	signature := hex.EncodeToString(ed25519.Sign(privKey, []byte(RequestMessage(d.String(), ts))))
+	err = VerifyEcdsaRequestSignatureWithDid(context.Background(), ds, linkeddata.SuiteTypeSecp256k1Verification,
+		signature, ts, d.String(), DefaultRequestGracePeriodSecs)
+	if err != ErrNoRequestSigningKeys {
+		t.Errorf("Should have rejected the Ed25519 did:key: err: %v", err)
+	}
+}
+
+func TestVerifyWithAddressKey(t *testing.T) {
+	ds, ethURI := initService()
+
+	privKey, _ := crypto.GenerateKey()
+	d := buildTestDocument(privKey)
+	// An owner key like those of did:ethr documents, with only the address
+	address := crypto.PubkeyToAddress(privKey.PublicKey).Hex()
+	d.PublicKeys[0].Type = linkeddata.SuiteTypeSecp256k1Verification2018
+	d.PublicKeys[0].PublicKeyHex = nil
+	d.PublicKeys[0].EthereumAddress = &address
+
+	err := ethURI.SaveDocument(d, 0)
+	if err != nil {
+		t.Fatalf("Should have not gotten error saving doc: err: %v", err)
+	}
+
+	ts := ctime.CurrentEpochSecsInInt()
+	for _, didStr := range []string{d.ID.String(), d.PublicKeys[0].ID.String()} {
+		signature, err := SignEcdsaRequestMessage(privKey, didStr, ts)
+		if err != nil {
+			t.Fatalf("Should have generated a signature")
+		}
+		err = VerifyEcdsaRequestSignatureWithDid(context.Background(), ds, linkeddata.SuiteTypeSecp256k1Verification,
+			signature, ts, didStr, DefaultRequestGracePeriodSecs)
+		if err != nil {
+			t.Errorf("Should have verified with the address of the key %v: err: %v", didStr, err)
+		}
+	}
+
+	otherKey, _ := crypto.GenerateKey()
+	signature, _ := SignEcdsaRequestMessage(otherKey, d.ID.String(), ts)
+	err = VerifyEcdsaRequestSignatureWithDid(context.Background(), ds, linkeddata.SuiteTypeSecp256k1Verification,
+		signature, ts, d.ID.String(), DefaultRequestGracePeriodSecs)
+	if err == nil {
+		t.Errorf("Should not have verified a signature of another key")
+	}
+}
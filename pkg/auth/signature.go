@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"encoding/hex"
 	"fmt"
@@ -19,6 +20,11 @@ import (
 	"github.com/joincivil/id-hub/pkg/linkeddata"
 )
 
+// ErrNoRequestSigningKeys is returned when a did has no keys of a type that can
+// sign requests. Request signatures only support secp256k1 keys, so Ed25519 and
+// P-256 keys, such as those of Ed25519 did:key dids, can not sign requests.
+var ErrNoRequestSigningKeys = errors.New("no secp256k1 keys, requests can only be signed with secp256k1 keys")
+
 const (
 	// DefaultRequestGracePeriodSecs is the default grace period in which to
 	// allow requests to be valid after the timestamp of the signature.
@@ -28,7 +34,7 @@ const (
 // VerifyEcdsaRequestSignatureWithDid checks the did document for keys and
 // verifies the signatures using the dids ECDSA public keys
 // Expects a signature with no 0x prefix.
-func VerifyEcdsaRequestSignatureWithDid(ds *did.Service, keyType linkeddata.SuiteType,
+func VerifyEcdsaRequestSignatureWithDid(ctx context.Context, ds *did.Service, keyType linkeddata.SuiteType,
 	signature string, ts int, didStr string, gracePeriod int) error {
 	if !linkeddata.IsEcdsaKeySuiteType(keyType) {
 		return errors.New("supports ecdsa only")
@@ -42,7 +48,7 @@ func VerifyEcdsaRequestSignatureWithDid(ds *did.Service, keyType linkeddata.Suit
 	// Use the did method and id to retrieve the correct doc
 	didMethodID := did.MethodIDOnly(d)
 
-	doc, err := ds.GetDocument(didMethodID)
+	doc, err := ds.GetDocument(ctx, didMethodID)
 	if err != nil {
 		return errors.Wrapf(err, "did not found for %v", didStr)
 	}
@@ -51,25 +57,36 @@ func VerifyEcdsaRequestSignatureWithDid(ds *did.Service, keyType linkeddata.Suit
 	}
 
 	// If there is a fragment, then likely a specific key is used from that DID.
-	// Use that key directly.
+	// Use that key directly, it must be an authentication of the DID.
 	if d.Fragment != "" {
-		pubKey, err := doc.GetPublicKeyFromFragment(d.Fragment)
+		pubKey, err := doc.GetRelationshipKeyFromFragment(did.RelationshipAuthentication, d.Fragment)
 		if err != nil {
 			return errors.Wrap(err, "error getting public key from fragment")
 		}
 
-		pubKeyStr, err := did.KeyFromType(pubKey)
-		if err != nil {
-			return errors.Wrap(err, "error getting public key from type")
-		}
+		var valid bool
+		if isAddressKey(pubKey) {
+			valid, err = VerifyEcdsaRequestSignatureWithAddress(
+				*pubKey.EthereumAddress,
+				signature,
+				didStr,
+				ts,
+				gracePeriod,
+			)
+		} else {
+			pubKeyStr, err := did.KeyFromType(pubKey)
+			if err != nil {
+				return errors.Wrap(err, "error getting public key from type")
+			}
 
-		valid, err := VerifyEcdsaRequestSignature(
-			*pubKeyStr,
-			signature,
-			didStr,
-			ts,
-			gracePeriod,
-		)
+			valid, err = VerifyEcdsaRequestSignature(
+				*pubKeyStr,
+				signature,
+				didStr,
+				ts,
+				gracePeriod,
+			)
+		}
 		if err != nil {
 			return errors.Wrap(err, "error validating signature")
 		}
@@ -81,9 +98,9 @@ func VerifyEcdsaRequestSignatureWithDid(ds *did.Service, keyType linkeddata.Suit
 		return nil
 	}
 
-	// No fragment, so check against each public key of the same type
+	// No fragment, so check against each authentication key of the same type
 	return VerifyEcdsaRequestSignatureWithPks(
-		doc.PublicKeys,
+		doc.RelationshipKeys(did.RelationshipAuthentication),
 		keyType,
 		signature,
 		ts,
@@ -111,10 +128,26 @@ func VerifyEcdsaRequestSignatureWithPks(pks []did.DocPublicKey, keyType linkedda
 	var pubKey *string
 	var valid bool
 	verified := false
+	found := false
 
 KeyLoop:
 	for _, key := range pks {
-		if key.Type == keyType {
+		if isAddressKey(&key) {
+			found = true
+			valid, err = VerifyEcdsaRequestSignatureWithAddress(*key.EthereumAddress, signature,
+				didStr, ts, gracePeriod)
+			if err != nil {
+				log.Errorf("Error verifying signature: err: %v", err)
+				retErr = err
+			}
+
+			if valid {
+				verified = true
+				break KeyLoop
+			}
+
+		} else if key.Type == keyType {
+			found = true
 			pubKey, err = did.KeyFromType(&key)
 			if err != nil {
 				log.Errorf("Error getting key from type: err: %v", err)
@@ -135,6 +168,10 @@ KeyLoop:
 		}
 	}
 
+	if !found {
+		return ErrNoRequestSigningKeys
+	}
+
 	if retErr != nil {
 		return errors.Wrap(retErr, "error when verifying signature")
 	}
@@ -162,9 +199,6 @@ func VerifyEcdsaRequestSignature(pubKey string, signature string,
 		}
 	}
 
-	// Signed message should be did and timestamp related
-	msg := RequestMessage(did, reqTs)
-
 	pubKeyBys, err := hex.DecodeString(pubKey)
 	if err != nil {
 		return false, errors.Wrap(err, "error decoding pubkey hex")
@@ -174,8 +208,29 @@ func VerifyEcdsaRequestSignature(pubKey string, signature string,
 		return false, errors.Wrap(err, "error unmarshalling to public key")
 	}
 
+	return VerifyEcdsaRequestSignatureWithAddress(crypto.PubkeyToAddress(*pk).Hex(), signature,
+		did, reqTs, gracePeriodSecs)
+}
+
+// VerifyEcdsaRequestSignatureWithAddress determines if a signature is valid
+// given the ethereum address of the secp256k1 key that signed it, which is
+// recovered from the signature. Used for keys that only have an address, like
+// the owner and delegate keys of did:ethr documents. Otherwise the same as
+// VerifyEcdsaRequestSignature.
+func VerifyEcdsaRequestSignatureWithAddress(address string, signature string,
+	did string, reqTs int, gracePeriodSecs int) (bool, error) {
+	if did != "" {
+		_, err := didlib.Parse(did)
+		if err != nil {
+			return false, errors.Wrap(err, "error parsing did for signature")
+		}
+	}
+
+	// Signed message should be did and timestamp related
+	msg := RequestMessage(did, reqTs)
+
 	// Verify that the signature is correct
-	verified, err := ceth.VerifyEthSignatureWithPubkey(*pk, msg, signature)
+	verified, err := ceth.VerifyEthSignature(address, msg, signature)
 	if err != nil {
 		return false, errors.Wrap(err, "error verifying signature")
 	}
@@ -200,6 +255,16 @@ func VerifyEcdsaRequestSignature(pubKey string, signature string,
 	return true, nil
 }
 
+// isAddressKey returns true if the key is a secp256k1 key that only has an
+// ethereum address
+func isAddressKey(pk *did.DocPublicKey) bool {
+	if pk.EthereumAddress == nil || *pk.EthereumAddress == "" || pk.PublicKeyHex != nil {
+		return false
+	}
+	return linkeddata.IsEcdsaKeySuiteType(pk.Type) ||
+		pk.Type == linkeddata.SuiteTypeSecp256k1SignatureAuth2018
+}
+
 // SignEcdsaRequestMessage is a convenience function to sign a message used for
 // API requests. Returns a signature with no 0x prefix.
 func SignEcdsaRequestMessage(privKey *ecdsa.PrivateKey, did string, reqTs int) (string, error) {
@@ -130,14 +130,14 @@ func TestCredentialsAndGqlClaimSave(t *testing.T) {
 	if err != nil {
 		t.Fatalf("error making the did doc: %v", err)
 	}
-	err = didService.SaveDocument(didDoc)
+	err = didService.SaveDocument(didDoc, 0)
 	if err != nil {
 		t.Fatalf("error saving the did doc: %v", err)
 	}
 	t.Logf("saved did document")
 
 	// New claims tree for the DID
-	err = claimService.CreateTreeForDID(&didDoc.ID, []*ecdsa.PublicKey{&pubKey})
+	err = claimService.CreateTreeForDID(context.Background(), &didDoc.ID, []*ecdsa.PublicKey{&pubKey})
 	if err != nil {
 		t.Fatalf("problem creating did tree: %v", err)
 	}
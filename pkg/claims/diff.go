@@ -0,0 +1,257 @@
+package claims
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	icore "github.com/iden3/go-iden3-core/core"
+	"github.com/iden3/go-iden3-core/merkletree"
+	"github.com/jinzhu/gorm"
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/claimsstore"
+	"github.com/joincivil/id-hub/pkg/claimtypes"
+)
+
+var (
+	// ErrTreeDiffRootNotAncestor is returned when the to root of a diff is missing
+	// claims that are in the from root, so it is not a later version of the tree
+	ErrTreeDiffRootNotAncestor = errors.New("the from root is not an earlier version of the to root")
+)
+
+// TreeDiffChangeType is the kind of claim added between two roots of a did tree
+type TreeDiffChangeType string
+
+const (
+	// TreeDiffRegistration is a registered document
+	TreeDiffRegistration TreeDiffChangeType = "registration"
+	// TreeDiffRevocation is a revoked document
+	TreeDiffRevocation TreeDiffChangeType = "revocation"
+	// TreeDiffKey is a signing key
+	TreeDiffKey TreeDiffChangeType = "key"
+	// TreeDiffDocumentVersion is an anchored version of a did document
+	TreeDiffDocumentVersion TreeDiffChangeType = "documentVersion"
+	// TreeDiffUnknown is a claim of a type not expected in a did tree
+	TreeDiffUnknown TreeDiffChangeType = "unknown"
+)
+
+// TreeDiffChange is a claim added between two roots of a did tree, resolved to
+// the credential or jwt it registers where they are stored
+type TreeDiffChange struct {
+	Type  TreeDiffChangeType
+	Entry string
+	// DocType and ContentHash are set for registrations and revocations
+	DocType     uint32
+	ContentHash string
+	// PublicKey is the hex of the compressed public key of a key claim
+	PublicKey string
+	// DocVersion and DocumentHash are set for document versions
+	DocVersion   uint32
+	DocumentHash string
+	// ClaimType is the hex of the claim type of unknown claims
+	ClaimType  string
+	Credential claimtypes.Credential
+	JWT        string
+}
+
+// TreeDiff is the claims added to a did tree between two of its roots
+type TreeDiff struct {
+	DID           string
+	From          *merkletree.Hash
+	To            *merkletree.Hash
+	Registrations []*TreeDiffChange
+	Revocations   []*TreeDiffChange
+	Keys          []*TreeDiffChange
+	// DocumentVersions are the anchored versions of the did document
+	DocumentVersions []*TreeDiffChange
+	// Unknown are the claims of other types, which are not expected in a did tree
+	Unknown []*TreeDiffChange
+}
+
+// ParseTreeRoot parses the hex of a tree root with or without the 0x prefix
+func ParseTreeRoot(root string) (*merkletree.Hash, error) {
+	rootb, err := hex.DecodeString(strings.TrimPrefix(root, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "parsetreeroot.decodestring")
+	}
+	if len(rootb) != len(merkletree.HashZero) {
+		return nil, errors.New("root hash should be 32 bytes")
+	}
+	hash := &merkletree.Hash{}
+	copy(hash[:], rootb)
+	return hash, nil
+}
+
+// DIDRootAtCommit returns the root of the did tree claimed in the root tree
+// committed with commitRoot, or the empty root if the did had no tree then
+func (s *Service) DIDRootAtCommit(userDid *didlib.DID, commitRoot string) (*merkletree.Hash, error) {
+	if s.rootService == nil {
+		return nil, errors.New("Unable to find the commit, no root service initialized")
+	}
+	commit, err := s.rootService.GetCommit(commitRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "didrootatcommit.getcommit")
+	}
+	rootSnapshot, err := s.getRootSnapshot(commit)
+	if err != nil {
+		return nil, errors.Wrap(err, "didrootatcommit.getrootsnapshot")
+	}
+	rootClaim, err := s.rootClaimIndex.GetLatestRootClaimInSnapshot(userDid, rootSnapshot)
+	if err == claimsstore.ErrNoRootCommitForDID {
+		return &merkletree.HashZero, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "didrootatcommit.getlatestrootclaiminsnapshot")
+	}
+	return &rootClaim.RootKey, nil
+}
+
+// DiffDIDRoots returns the registrations, revocations, keys, document versions
+// and unknown claims added to the tree of a did between the from and to roots. The empty root diffs from the
+// start of the tree and a nil to root diffs to the current root. Credentials are
+// resolved from the signed claim store, jwts are not resolved.
+func (s *Service) DiffDIDRoots(userDid *didlib.DID, from *merkletree.Hash,
+	to *merkletree.Hash) (*TreeDiff, error) {
+	didMt, err := s.BuildDIDMt(userDid)
+	if err != nil {
+		return nil, errors.Wrap(err, "diffdidroots.builddidmt")
+	}
+	if from == nil {
+		from = &merkletree.HashZero
+	}
+	if to == nil {
+		to = didMt.RootKey()
+	}
+
+	fromEntries, err := didMt.DumpClaims(from)
+	if err != nil {
+		return nil, errors.Wrap(err, "diffdidroots.dumpclaims from")
+	}
+	toEntries, err := didMt.DumpClaims(to)
+	if err != nil {
+		return nil, errors.Wrap(err, "diffdidroots.dumpclaims to")
+	}
+
+	inFrom := make(map[string]bool, len(fromEntries))
+	for _, entry := range fromEntries {
+		inFrom[entry] = true
+	}
+	diff := &TreeDiff{
+		DID:              userDid.String(),
+		From:             from,
+		To:               to,
+		Registrations:    []*TreeDiffChange{},
+		Revocations:      []*TreeDiffChange{},
+		Keys:             []*TreeDiffChange{},
+		DocumentVersions: []*TreeDiffChange{},
+		Unknown:          []*TreeDiffChange{},
+	}
+	for _, entry := range toEntries {
+		if !inFrom[entry] {
+			err = s.addTreeDiffChange(diff, entry)
+			if err != nil {
+				return nil, err
+			}
+		}
+		delete(inFrom, entry)
+	}
+	if len(inFrom) > 0 {
+		return nil, ErrTreeDiffRootNotAncestor
+	}
+	return diff, nil
+}
+
+func (s *Service) addTreeDiffChange(diff *TreeDiff, entryHex string) error {
+	entry, err := entryFromHex(entryHex)
+	if err != nil {
+		return errors.Wrap(err, "addtreediffchange.entryfromhex")
+	}
+	change := &TreeDiffChange{Entry: entryHex}
+	claim, err := claimtypes.NewClaimFromEntry(entry)
+	if err == icore.ErrInvalidClaimType {
+		addTreeDiffUnknown(diff, change, entry)
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "addtreediffchange.newclaimfromentry")
+	}
+
+	switch c := claim.(type) {
+	case *claimtypes.ClaimRegisteredDocument:
+		change.DocType = c.DocType
+		change.ContentHash = hex.EncodeToString(c.ContentHash[:])
+		if c.DocType == claimtypes.ContentCredentialDocType || c.DocType == claimtypes.LicenseCredentialDocType {
+			err = s.resolveTreeDiffCredential(change)
+			if err != nil {
+				return err
+			}
+		}
+		if c.Version > 0 {
+			change.Type = TreeDiffRevocation
+			diff.Revocations = append(diff.Revocations, change)
+		} else {
+			change.Type = TreeDiffRegistration
+			diff.Registrations = append(diff.Registrations, change)
+		}
+	case *icore.ClaimAuthorizeKSignSecp256k1:
+		change.Type = TreeDiffKey
+		change.PublicKey = hex.EncodeToString(crypto.CompressPubkey(c.PubKey))
+		diff.Keys = append(diff.Keys, change)
+	case *claimtypes.ClaimDIDDocumentVersion:
+		change.Type = TreeDiffDocumentVersion
+		change.DocVersion = c.DocVersion
+		change.DocumentHash = hex.EncodeToString(c.DocumentHash[:])
+		diff.DocumentVersions = append(diff.DocumentVersions, change)
+	default:
+		addTreeDiffUnknown(diff, change, entry)
+	}
+	return nil
+}
+
+// addTreeDiffUnknown records a claim of a type not expected in a did tree, so
+// it is reported instead of dropped
+func addTreeDiffUnknown(diff *TreeDiff, change *TreeDiffChange, entry *merkletree.Entry) {
+	claimType, _ := icore.GetClaimTypeVersion(entry)
+	change.Type = TreeDiffUnknown
+	change.ClaimType = hex.EncodeToString(claimType[:])
+	diff.Unknown = append(diff.Unknown, change)
+}
+
+func (s *Service) resolveTreeDiffCredential(change *TreeDiffChange) error {
+	if s.signedClaimStore == nil {
+		return nil
+	}
+	cred, err := s.signedClaimStore.GetCredentialByMultihash(change.ContentHash)
+	if err == gorm.ErrRecordNotFound {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "resolvetreediffcredential.getcredentialbymultihash")
+	}
+	change.Credential = cred
+	return nil
+}
+
+// DiffDIDRoots returns the claims added to the tree of a did between two roots
+// like Service.DiffDIDRoots and also resolves the registered jwts
+func (s *JWTService) DiffDIDRoots(userDid *didlib.DID, from *merkletree.Hash,
+	to *merkletree.Hash) (*TreeDiff, error) {
+	diff, err := s.claimService.DiffDIDRoots(userDid, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, changes := range [][]*TreeDiffChange{diff.Registrations, diff.Revocations} {
+		for _, change := range changes {
+			if change.DocType != claimtypes.JWTDocType {
+				continue
+			}
+			jwtClaim, err := s.jwtPersister.GetJWTClaimByMultihash(change.ContentHash)
+			if err == gorm.ErrRecordNotFound {
+				continue
+			} else if err != nil {
+				return nil, errors.Wrap(err, "diffdidroots.getjwtclaimbymultihash")
+			}
+			change.JWT = jwtClaim.JWT
+		}
+	}
+	return diff, nil
+}
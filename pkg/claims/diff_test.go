@@ -0,0 +1,125 @@
+package claims_test
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/joincivil/go-common/pkg/lock"
+	didlib "github.com/ockam-network/did"
+
+	"github.com/joincivil/id-hub/pkg/claims"
+	"github.com/joincivil/id-hub/pkg/claimsstore"
+	"github.com/joincivil/id-hub/pkg/claimtypes"
+)
+
+func TestDiffDIDRoots(t *testing.T) {
+	dir, err := ioutil.TempDir("", "difftest")
+	if err != nil {
+		t.Fatalf("Should have created a temp dir: err: %v", err)
+	}
+	defer os.RemoveAll(dir) // nolint: errcheck
+	store, err := claimsstore.NewLevelDBStore(dir)
+	if err != nil {
+		t.Fatalf("Should have opened the store: err: %v", err)
+	}
+	defer store.Close()
+
+	claimService, err := claims.NewService(store, store, nil, nil, nil, nil, lock.NewLocalDLock())
+	if err != nil {
+		t.Fatalf("Should have created the service: err: %v", err)
+	}
+
+	userDid, _ := didlib.Parse("did:ethuri:e7ab0c43-d9fe-4a61-87a3-3fa99ce879e1")
+	secKey, _ := crypto.GenerateKey()
+	err = claimService.CreateTreeForDIDWithPks(userDid, []*ecdsa.PublicKey{&secKey.PublicKey})
+	if err != nil {
+		t.Fatalf("Should have added the keys: err: %v", err)
+	}
+	keysRoot, err := claimService.GetDIDRoot(userDid)
+	if err != nil {
+		t.Fatalf("Should have got the root: err: %v", err)
+	}
+
+	hash := "1b20b1e2a9f0d8c7e6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d9c8b7a6f5e4d3c2b1"
+	didMt, err := claimService.BuildDIDMt(userDid)
+	if err != nil {
+		t.Fatalf("Should have built the did tree: err: %v", err)
+	}
+	claim := testRegisteredDocument(t, hash, userDid, claimtypes.JWTDocType)
+	err = claimService.AddToDIDTree(didMt, userDid, claim.Entry())
+	if err != nil {
+		t.Fatalf("Should have added the document: err: %v", err)
+	}
+	revoked := testRegisteredDocument(t, hash, userDid, claimtypes.JWTDocType)
+	revoked.Version = 1
+	err = claimService.AddToDIDTree(didMt, userDid, revoked.Entry())
+	if err != nil {
+		t.Fatalf("Should have revoked the document: err: %v", err)
+	}
+
+	docVersion, err := claimtypes.NewClaimDIDDocumentVersionIndex(userDid, 1)
+	if err != nil {
+		t.Fatalf("Should have made the document version: err: %v", err)
+	}
+	docVersion.DocumentHash[0] = 0xab
+	err = claimService.AddToDIDTree(didMt, userDid, docVersion.Entry())
+	if err != nil {
+		t.Fatalf("Should have added the document version: err: %v", err)
+	}
+	// A root claim is not expected in a did tree
+	unknown, err := claimtypes.NewClaimSetRootKeyDID(userDid, keysRoot)
+	if err != nil {
+		t.Fatalf("Should have made the root claim: err: %v", err)
+	}
+	err = claimService.AddToDIDTree(didMt, userDid, unknown.Entry())
+	if err != nil {
+		t.Fatalf("Should have added the root claim: err: %v", err)
+	}
+
+	diff, err := claimService.DiffDIDRoots(userDid, nil, keysRoot)
+	if err != nil {
+		t.Fatalf("Should have diffed the roots: err: %v", err)
+	}
+	if len(diff.Keys) != 1 || len(diff.Registrations) != 0 || len(diff.Revocations) != 0 {
+		t.Errorf("Should have found only the key: %+v", diff)
+	} else if diff.Keys[0].PublicKey != hex.EncodeToString(crypto.CompressPubkey(&secKey.PublicKey)) {
+		t.Errorf("Should have found the public key: %v", diff.Keys[0].PublicKey)
+	}
+
+	diff, err = claimService.DiffDIDRoots(userDid, keysRoot, nil)
+	if err != nil {
+		t.Fatalf("Should have diffed the roots: err: %v", err)
+	}
+	if len(diff.Keys) != 0 || len(diff.Registrations) != 1 || len(diff.Revocations) != 1 {
+		t.Fatalf("Should have found the registration and revocation: %+v", diff)
+	}
+	if diff.Registrations[0].ContentHash != hash || diff.Registrations[0].DocType != claimtypes.JWTDocType ||
+		diff.Revocations[0].ContentHash != hash {
+		t.Errorf("Should have found the document: %+v %+v", diff.Registrations[0], diff.Revocations[0])
+	}
+	if len(diff.DocumentVersions) != 1 || diff.DocumentVersions[0].DocVersion != 1 ||
+		diff.DocumentVersions[0].DocumentHash != hex.EncodeToString(docVersion.DocumentHash[:]) {
+		t.Errorf("Should have found the document version: %+v", diff.DocumentVersions)
+	}
+	if len(diff.Unknown) != 1 || diff.Unknown[0].Type != claims.TreeDiffUnknown ||
+		diff.Unknown[0].ClaimType != hex.EncodeToString(claimtypes.ClaimTypeSetRootKeyDID[:]) {
+		t.Errorf("Should have found the unknown claim: %+v", diff.Unknown)
+	}
+
+	currentRoot, err := claimService.GetDIDRoot(userDid)
+	if err != nil {
+		t.Fatalf("Should have got the root: err: %v", err)
+	}
+	parsedRoot, err := claims.ParseTreeRoot(currentRoot.Hex())
+	if err != nil || *parsedRoot != *currentRoot {
+		t.Errorf("Should have parsed the root: %v, err: %v", parsedRoot, err)
+	}
+	_, err = claimService.DiffDIDRoots(userDid, currentRoot, keysRoot)
+	if err != claims.ErrTreeDiffRootNotAncestor {
+		t.Errorf("Should not have diffed to an earlier root: err: %v", err)
+	}
+}
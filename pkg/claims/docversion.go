@@ -0,0 +1,205 @@
+package claims
+
+import (
+	"encoding/hex"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iden3/go-iden3-core/merkletree"
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/claimsstore"
+	"github.com/joincivil/id-hub/pkg/claimtypes"
+	"github.com/joincivil/id-hub/pkg/did"
+)
+
+var (
+	// ErrDocumentVersionNotFound is returned when a version of a did document is
+	// not registered in the did tree at a root
+	ErrDocumentVersionNotFound = errors.New("the document version is not in the did tree at the root")
+	// ErrDocumentVersionNotCurrent is returned when a later version of a did
+	// document is registered in the did tree at a root
+	ErrDocumentVersionNotCurrent = errors.New("the document version is not the current version at the root")
+)
+
+// DocumentVersionProof is a proof that a version of a did document was the
+// current version registered in the tree of the did at a committed root of the
+// root tree
+type DocumentVersionProof struct {
+	DID                  string          `json:"did"`
+	DocVersion           uint32          `json:"docVersion"`
+	DocumentHash         string          `json:"documentHash"`             // HEX
+	ExistsInDIDMTProof   string          `json:"versionExistsInDIDTree"`   // HEX
+	NextNotInDIDMTProof  string          `json:"nextVersionNotInDIDTree"`  // HEX
+	DIDRootExistsProof   string          `json:"didRootExistsInRelayTree"` // HEX
+	DIDRootExistsVersion uint32          `json:"didRootVersion"`           // The version of the root claim in the tree, this is needed to verify the proof
+	BlockNumber          int64           `json:"blockNumber"`
+	ContractAddress      common.Address  `json:"contractAddress"`
+	TXHash               common.Hash     `json:"txHash"`
+	Root                 merkletree.Hash `json:"relayTreeRoot"`
+	DIDRoot              merkletree.Hash `json:"didTreeRoot"`
+	CommitterAddress     common.Address  `json:"relayAddress"`
+}
+
+// AnchorDocumentVersion registers a version of a did document in the tree of
+// its did and adds a new root claim, so the document history is provable with
+// the root commits. Anchoring a version again only adds the root claim if the
+// last root claim of the did is not at the current root of its tree, so a
+// partially anchored version can be retried.
+func (s *Service) AnchorDocumentVersion(doc *did.Document, version uint) error {
+	claim, err := claimtypes.NewClaimDIDDocumentVersion(doc, uint32(version))
+	if err != nil {
+		return errors.Wrap(err, "anchordocumentversion.newclaimdiddocumentversion")
+	}
+	didMt, err := s.BuildDIDMt(&doc.ID)
+	if err != nil {
+		return errors.Wrap(err, "anchordocumentversion.builddidmt")
+	}
+	err = s.AddToDIDTree(didMt, &doc.ID, claim.Entry())
+	if err == merkletree.ErrEntryIndexAlreadyExists {
+		rootClaim, _, rerr := s.getLastRootClaim(&doc.ID, s.rootMt)
+		if rerr == nil && rootClaim.RootKey.Equals(didMt.RootKey()) {
+			return nil
+		}
+	} else if err != nil {
+		return errors.Wrap(err, "anchordocumentversion.add")
+	}
+	err = s.AddNewRootClaim(&doc.ID)
+	if err != nil {
+		return errors.Wrap(err, "anchordocumentversion.addnewrootclaim")
+	}
+	return nil
+}
+
+// GenerateProofDocumentVersion returns a proof that version docVersion of the
+// did document of userDid was the current version at a committed root, the hex
+// of the root with 0x prefix, or at the latest commit if root is empty
+func (s *Service) GenerateProofDocumentVersion(userDid *didlib.DID, docVersion uint32,
+	root string) (*DocumentVersionProof, error) {
+	if s.rootService == nil {
+		return nil, errors.New("Unable to generate proof, no root service initialized")
+	}
+
+	var commit *claimsstore.RootCommit
+	var err error
+	if root == "" {
+		commit, err = s.rootService.GetLatest()
+	} else {
+		commit, err = s.rootService.GetCommit(root)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "generateproofdocumentversion.getcommit")
+	}
+	rootSnapshot, err := s.getRootSnapshot(commit)
+	if err != nil {
+		return nil, errors.Wrap(err, "generateproofdocumentversion.getrootsnapshot")
+	}
+	rootClaim, didSnapshot, err := s.getLastRootClaim(userDid, rootSnapshot)
+	if err != nil {
+		return nil, errors.Wrap(err, "generateproofdocumentversion.getlastrootclaim")
+	}
+
+	index, err := claimtypes.NewClaimDIDDocumentVersionIndex(userDid, docVersion)
+	if err != nil {
+		return nil, errors.Wrap(err, "generateproofdocumentversion.newclaimdiddocumentversionindex")
+	}
+	hIndex := index.Entry().HIndex()
+	data, err := didSnapshot.GetDataByIndex(hIndex)
+	if err != nil {
+		return nil, ErrDocumentVersionNotFound
+	}
+	claim := claimtypes.NewClaimDIDDocumentVersionFromEntry(&merkletree.Entry{Data: *data})
+	existsProof, err := didSnapshot.GenerateProof(hIndex, didSnapshot.RootKey())
+	if err != nil {
+		return nil, errors.Wrap(err, "generateproofdocumentversion.generateproof")
+	}
+
+	next, err := claimtypes.NewClaimDIDDocumentVersionIndex(userDid, docVersion+1)
+	if err != nil {
+		return nil, errors.Wrap(err, "generateproofdocumentversion.newclaimdiddocumentversionindex")
+	}
+	nextProof, err := didSnapshot.GenerateProof(next.Entry().HIndex(), didSnapshot.RootKey())
+	if err != nil {
+		return nil, errors.Wrap(err, "generateproofdocumentversion.generateproof next")
+	}
+	if nextProof.Existence {
+		return nil, ErrDocumentVersionNotCurrent
+	}
+
+	didRootExistsProof, err := rootSnapshot.GenerateProof(rootClaim.Entry().HIndex(), rootSnapshot.RootKey())
+	if err != nil {
+		return nil, errors.Wrap(err, "generateproofdocumentversion.rootsnapshot.generateproof")
+	}
+
+	return &DocumentVersionProof{
+		DID:                  did.MethodIDOnly(userDid),
+		DocVersion:           docVersion,
+		DocumentHash:         hex.EncodeToString(claim.DocumentHash[:]),
+		ExistsInDIDMTProof:   hex.EncodeToString(existsProof.Bytes()),
+		NextNotInDIDMTProof:  hex.EncodeToString(nextProof.Bytes()),
+		DIDRootExistsProof:   hex.EncodeToString(didRootExistsProof.Bytes()),
+		DIDRootExistsVersion: rootClaim.Version,
+		BlockNumber:          commit.BlockNumber,
+		ContractAddress:      common.HexToAddress(commit.ContractAddress),
+		TXHash:               common.HexToHash(commit.TransactionHash),
+		Root:                 *rootSnapshot.RootKey(),
+		DIDRoot:              *didSnapshot.RootKey(),
+		CommitterAddress:     common.HexToAddress(commit.CommitterAddress),
+	}, nil
+}
+
+// VerifyDocumentVersionProof checks the proof shows doc was the current version
+// of the did document at the root of the proof
+func VerifyDocumentVersionProof(proof *DocumentVersionProof, doc *did.Document) (bool, error) {
+	if proof.DID != did.MethodIDOnly(&doc.ID) {
+		return false, nil
+	}
+	claim, err := claimtypes.NewClaimDIDDocumentVersion(doc, proof.DocVersion)
+	if err != nil {
+		return false, errors.Wrap(err, "verifydocumentversionproof.newclaimdiddocumentversion")
+	}
+	next, err := claimtypes.NewClaimDIDDocumentVersionIndex(&doc.ID, proof.DocVersion+1)
+	if err != nil {
+		return false, errors.Wrap(err, "verifydocumentversionproof.newclaimdiddocumentversionindex")
+	}
+	rootClaim, err := claimtypes.NewClaimSetRootKeyDID(&doc.ID, &proof.DIDRoot)
+	if err != nil {
+		return false, errors.Wrap(err, "verifydocumentversionproof.newclaimsetrootkeydid")
+	}
+	rootClaim.Version = proof.DIDRootExistsVersion
+
+	existsProof, err := proofFromHex(proof.ExistsInDIDMTProof)
+	if err != nil {
+		return false, err
+	}
+	nextProof, err := proofFromHex(proof.NextNotInDIDMTProof)
+	if err != nil {
+		return false, err
+	}
+	didRootProof, err := proofFromHex(proof.DIDRootExistsProof)
+	if err != nil {
+		return false, err
+	}
+
+	entry := claim.Entry()
+	nextEntry := next.Entry()
+	rootEntry := rootClaim.Entry()
+	return existsProof.Existence &&
+		merkletree.VerifyProof(&proof.DIDRoot, existsProof, entry.HIndex(), entry.HValue()) &&
+		!nextProof.Existence &&
+		merkletree.VerifyProof(&proof.DIDRoot, nextProof, nextEntry.HIndex(), nextEntry.HValue()) &&
+		didRootProof.Existence &&
+		merkletree.VerifyProof(&proof.Root, didRootProof, rootEntry.HIndex(), rootEntry.HValue()), nil
+}
+
+func proofFromHex(proofHex string) (*merkletree.Proof, error) {
+	bys, err := hex.DecodeString(proofHex)
+	if err != nil {
+		return nil, errors.Wrap(err, "prooffromhex.decodestring")
+	}
+	proof, err := merkletree.NewProofFromBytes(bys)
+	if err != nil {
+		return nil, errors.Wrap(err, "prooffromhex.newprooffrombytes")
+	}
+	return proof, nil
+}
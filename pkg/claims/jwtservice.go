@@ -1,6 +1,7 @@
 package claims
 
 import (
+	"context"
 	"encoding/hex"
 
 	"github.com/dgrijalva/jwt-go"
@@ -36,8 +37,23 @@ func NewJWTService(didJWTService *didjwt.Service,
 }
 
 // AddJWTClaim adds a new jwt claim to it's issuers tree
-func (s *JWTService) AddJWTClaim(tokenString string, senderDID *didlib.DID) (*jwt.Token, error) {
-	token, hash, err := s.jwtPersister.AddJWT(tokenString, senderDID)
+func (s *JWTService) AddJWTClaim(ctx context.Context, tokenString string, senderDID *didlib.DID) (*jwt.Token, error) {
+	// Check the issuer is not deactivated before the token is stored, the token
+	// is verified when it is added
+	unverified, _, err := new(jwt.Parser).ParseUnverified(tokenString, &didjwt.VCClaimsJWT{})
+	if err != nil {
+		return nil, errors.Wrap(err, "AddJWTClaim error parsing token")
+	}
+	unverifiedIssuer, err := GetIssuerDIDfromToken(unverified)
+	if err != nil {
+		return nil, errors.Wrap(err, "AddJWTClaim error parsing issuer did")
+	}
+	err = s.claimService.checkDIDNotDeactivated(ctx, unverifiedIssuer)
+	if err != nil {
+		return nil, errors.Wrap(err, "AddJWTClaim issuer is deactivated")
+	}
+
+	token, hash, err := s.jwtPersister.AddJWT(ctx, tokenString, senderDID)
 	if err != nil {
 		return nil, errors.Wrap(err, "AddJWTClaim error adding JWT to db")
 	}
@@ -47,7 +63,8 @@ func (s *JWTService) AddJWTClaim(tokenString string, senderDID *didlib.DID) (*jw
 		return nil, errors.Wrap(err, "AddJWTClaim error parsing issuer did")
 	}
 
-	didMT, err := s.claimService.BuildDIDMt(issuer)
+	claimService := s.claimService.WithSender(senderDID.String())
+	didMT, err := claimService.BuildDIDMt(issuer)
 	if err != nil {
 		return nil, errors.Wrap(err, "AddJWTClaim error building didmt")
 	}
@@ -67,12 +84,12 @@ func (s *JWTService) AddJWTClaim(tokenString string, senderDID *didlib.DID) (*jw
 		return nil, errors.Wrap(err, "AddJWTClaim error creating registered document claim")
 	}
 
-	err = didMT.Add(claim.Entry())
+	err = claimService.AddToDIDTree(didMT, issuer, claim.Entry())
 	if err != nil {
 		return nil, errors.Wrap(err, "AddJWTClaim add claim to did mt")
 	}
 
-	err = s.claimService.AddNewRootClaim(issuer)
+	err = claimService.AddNewRootClaim(issuer)
 	if err != nil {
 		return nil, errors.Wrap(err, "AddJWTClaim.addnewrootclaim")
 	}
@@ -96,9 +113,10 @@ func (s *JWTService) makeRegisteredDocClaimFromJWT(token string,
 	return claimtypes.NewClaimRegisteredDocument(hash34, issuer, claimtypes.JWTDocType)
 }
 
-// RevokeJWTClaim takes a token and revokes it in the merkle tree
-func (s *JWTService) RevokeJWTClaim(tokenString string) error {
-	token, err := s.didJWTService.ParseJWT(tokenString)
+// RevokeJWTClaim takes a token and revokes it in the merkle tree, the revocation
+// is recorded as made by senderDID
+func (s *JWTService) RevokeJWTClaim(ctx context.Context, tokenString string, senderDID *didlib.DID) error {
+	token, err := s.didJWTService.ParseJWT(ctx, tokenString)
 	if err != nil {
 		return errors.Wrap(err, "RevokeJWTClaim couldn't parse token")
 	}
@@ -108,26 +126,15 @@ func (s *JWTService) RevokeJWTClaim(tokenString string) error {
 		return errors.Wrap(err, "RevokeJWTClaim error parsing issuer did")
 	}
 
-	didMt, err := s.claimService.BuildDIDMt(issuer)
-	if err != nil {
-		return errors.Wrap(err, "RevokeJWTClaim.builddidMt")
-	}
-
 	regDocClaim, err := s.makeRegisteredDocClaimFromJWT(tokenString, issuer)
 	if err != nil {
 		return errors.Wrap(err, "RevokeJWTClaim couldn't make reg doc claim")
 	}
 
-	regDocClaim.Version = 1
-
-	err = didMt.Add(regDocClaim.Entry())
-	if err != nil {
-		return errors.Wrap(err, "RevokeJWTClaim.add")
-	}
-
-	err = s.claimService.AddNewRootClaim(issuer)
+	claimService := s.claimService.WithSender(senderDID.String())
+	err = claimService.RevokeRegisteredDocument(regDocClaim, issuer)
 	if err != nil {
-		return errors.Wrap(err, "RevokeJWTClaim.addnewrootclaim")
+		return errors.Wrap(err, "RevokeJWTClaim.revokeregistereddocument")
 	}
 
 	err = s.natsService.PublishRevoke(token)
@@ -144,7 +151,7 @@ func (s *JWTService) GetJWTSforSubjectsOrIssuers(issuers []string, subjects []st
 }
 
 // GetJWTSforDID returns all jwt claims for a DID
-func (s *JWTService) GetJWTSforDID(userDID *didlib.DID) ([]*jwt.Token, error) {
+func (s *JWTService) GetJWTSforDID(ctx context.Context, userDID *didlib.DID) ([]*jwt.Token, error) {
 	claims, err := s.claimService.GetMerkleTreeClaimsForDid(userDID)
 	if err != nil {
 		return nil, err
@@ -164,14 +171,14 @@ func (s *JWTService) GetJWTSforDID(userDID *didlib.DID) ([]*jwt.Token, error) {
 			if regDoc.DocType == claimtypes.JWTDocType {
 				claimHash := hex.EncodeToString(regDoc.ContentHash[:])
 
-				token, err := s.jwtPersister.GetJWTByMultihash(claimHash)
+				token, err := s.jwtPersister.GetJWTByMultihash(ctx, claimHash)
 				if err != nil {
 					return nil, errors.Wrap(err, "GetJWTSforDID error fetching token from db")
 				}
 				tokens = append(tokens, token)
 			}
-		case *icore.ClaimAuthorizeKSignSecp256k1:
-			// Known claim type to ignore here
+		case *icore.ClaimAuthorizeKSignSecp256k1, *claimtypes.ClaimDIDDocumentVersion:
+			// Known claim types to ignore here
 		default:
 			log.Errorf("Unknown claim type, is %T", v)
 		}
@@ -180,8 +187,8 @@ func (s *JWTService) GetJWTSforDID(userDID *didlib.DID) ([]*jwt.Token, error) {
 }
 
 // GenerateProof creates a proof from a jwt
-func (s *JWTService) GenerateProof(tokenString string) (*MTProof, error) {
-	token, err := s.didJWTService.ParseJWT(tokenString)
+func (s *JWTService) GenerateProof(ctx context.Context, tokenString string) (*MTProof, error) {
+	token, err := s.didJWTService.ParseJWT(ctx, tokenString)
 	if err != nil {
 		return nil, errors.Wrap(err, "GenerateProof couldn't parse token")
 	}
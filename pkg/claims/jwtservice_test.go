@@ -1,6 +1,7 @@
 package claims_test
 
 import (
+	"context"
 	"encoding/hex"
 	"testing"
 
@@ -67,13 +68,13 @@ func TestJWTService(t *testing.T) {
 		t.Errorf("unable to create jwt string: %v", err)
 	}
 
-	_, err = jwtService.AddJWTClaim(tokenS, senderDID)
+	_, err = jwtService.AddJWTClaim(context.Background(), tokenS, senderDID)
 
 	if err != nil {
 		t.Errorf("failed to add jwt: %v", err)
 	}
 
-	usersTokens, err := jwtService.GetJWTSforDID(userDID)
+	usersTokens, err := jwtService.GetJWTSforDID(context.Background(), userDID)
 
 	if err != nil {
 		t.Errorf("failed to fetch tokens: %v", err)
@@ -83,7 +84,7 @@ func TestJWTService(t *testing.T) {
 		t.Errorf("wrong number of tokens returned got: %v, expected: %v", len(usersTokens), 1)
 	}
 
-	proofBeforeCommit, err := jwtService.GenerateProof(tokenS)
+	proofBeforeCommit, err := jwtService.GenerateProof(context.Background(), tokenS)
 	if err != nil {
 		t.Errorf("error generating proof: %v", err)
 	}
@@ -97,7 +98,7 @@ func TestJWTService(t *testing.T) {
 		t.Errorf("error committing root: %v", err)
 	}
 
-	proof, err := jwtService.GenerateProof(tokenS)
+	proof, err := jwtService.GenerateProof(context.Background(), tokenS)
 	if err != nil {
 		t.Errorf("error generating proof: %v", err)
 	}
@@ -162,12 +163,19 @@ func TestJWTService(t *testing.T) {
 		t.Errorf("couldn't verify root tree proof")
 	}
 
-	err = jwtService.RevokeJWTClaim(tokenS)
+	err = jwtService.RevokeJWTClaim(context.Background(), tokenS, senderDID)
 	if err != nil {
 		t.Errorf("couldn't revoke claim")
 	}
+	changes, total, err := claimService.GetTreeChanges(userDID, 0, 0)
+	if err != nil {
+		t.Errorf("couldn't get the tree changes: %v", err)
+	} else if changes[total-2].Operation != claimsstore.TreeChangeRevoke ||
+		changes[total-2].Sender != senderDIDs {
+		t.Errorf("should have recorded the sender of the revocation: %+v", changes[total-2])
+	}
 
-	_, err = jwtService.GenerateProof(tokenS)
+	_, err = jwtService.GenerateProof(context.Background(), tokenS)
 	if err == nil {
 		t.Errorf("it should error if the claim is revoked")
 	}
@@ -0,0 +1,133 @@
+package claims
+
+import (
+	"encoding/hex"
+
+	icore "github.com/iden3/go-iden3-core/core"
+	"github.com/iden3/go-iden3-core/merkletree"
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/claimsstore"
+	"github.com/joincivil/id-hub/pkg/claimtypes"
+	"github.com/joincivil/id-hub/pkg/did"
+)
+
+const (
+	// DefaultTreeChangesLimit is the number of changes returned when no limit is given
+	DefaultTreeChangesLimit = 50
+	// MaxTreeChangesLimit is the most changes returned at a time
+	MaxTreeChangesLimit = 500
+)
+
+// WithSender returns a copy of the service that records sender as the
+// authenticated sender of the changes it makes to the trees
+func (s *Service) WithSender(sender string) *Service {
+	senderService := *s
+	senderService.sender = sender
+	return &senderService
+}
+
+// WithClaimedSender returns a copy of the service that records sender as the
+// claimed sender of the changes it makes to the trees, for requests that are not
+// authenticated so the sender is not verified
+func (s *Service) WithClaimedSender(sender string) *Service {
+	senderService := *s
+	senderService.claimedSender = sender
+	return &senderService
+}
+
+// AddToDIDTree adds an entry to didMt, the tree of userDid, and records the
+// change in the ledger
+func (s *Service) AddToDIDTree(didMt *merkletree.MerkleTree, userDid *didlib.DID,
+	entry *merkletree.Entry) error {
+	return s.addToTree(didMt, []byte(did.MethodIDOnly(userDid)), userDid, entry)
+}
+
+// addToTree adds an entry to the tree with prefix. If the tree storage is a
+// TreeChangeRecorder the change is written to the ledger in the same transaction
+// as the nodes.
+func (s *Service) addToTree(tree *merkletree.MerkleTree, prefix []byte, userDid *didlib.DID,
+	entry *merkletree.Entry) error {
+	recorder, ok := s.treeStore.(claimsstore.TreeChangeRecorder)
+	if !ok {
+		return tree.Add(entry)
+	}
+
+	recorded := recorder.RecordChange(prefix, entry, newTreeChange(userDid, prefix, entry, s.sender,
+		s.claimedSender))
+	err := tree.Add(entry)
+	recordErr := recorded()
+	if err != nil {
+		return err
+	}
+	if recordErr != nil {
+		return errors.Wrap(recordErr, "addtotree.recordchange")
+	}
+	return nil
+}
+
+// GetTreeChanges returns a page of the changes to the trees for a did, oldest
+// first, and the total number of changes
+func (s *Service) GetTreeChanges(userDid *didlib.DID, offset int,
+	limit int) ([]*claimsstore.TreeChange, int, error) {
+	ledger, ok := s.treeStore.(claimsstore.TreeChangeLedger)
+	if !ok {
+		return nil, 0, claimsstore.ErrTreeChangeLedgerNotSupported
+	}
+	if limit <= 0 {
+		limit = DefaultTreeChangesLimit
+	} else if limit > MaxTreeChangesLimit {
+		limit = MaxTreeChangesLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	didString := did.MethodIDOnly(userDid)
+	total, err := ledger.CountTreeChanges(didString)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "gettreechanges.counttreechanges")
+	}
+	changes, err := ledger.GetTreeChanges(didString, offset, limit)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "gettreechanges.gettreechanges")
+	}
+	return changes, total, nil
+}
+
+func newTreeChange(userDid *didlib.DID, prefix []byte, entry *merkletree.Entry,
+	sender string, claimedSender string) *claimsstore.TreeChange {
+	claimType, version := icore.GetClaimTypeVersion(entry)
+	change := &claimsstore.TreeChange{
+		DID:           did.MethodIDOnly(userDid),
+		Tree:          string(prefix),
+		ClaimType:     hex.EncodeToString(claimType[:]),
+		ClaimVersion:  version,
+		Operation:     claimsstore.TreeChangeAdd,
+		Sender:        sender,
+		ClaimedSender: claimedSender,
+	}
+
+	claim, err := claimtypes.NewClaimFromEntry(entry)
+	if err != nil {
+		return change
+	}
+	switch c := claim.(type) {
+	case *claimtypes.ClaimRegisteredDocument:
+		change.DocType = c.DocType
+		change.ContentHash = hex.EncodeToString(c.ContentHash[:])
+		change.Operation = claimsstore.TreeChangeRegister
+		if c.Version > 0 {
+			change.Operation = claimsstore.TreeChangeRevoke
+		}
+	case *claimtypes.ClaimDIDDocumentVersion:
+		change.Operation = claimsstore.TreeChangeDocumentVersion
+	case *claimtypes.ClaimSetRootKeyDID:
+		change.Operation = claimsstore.TreeChangeSetRoot
+		change.DIDRoot = c.RootKey.Hex()
+	case *icore.ClaimAuthorizeKSignSecp256k1:
+		change.Operation = claimsstore.TreeChangeAddKey
+	}
+	return change
+}
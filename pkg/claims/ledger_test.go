@@ -0,0 +1,175 @@
+package claims_test
+
+import (
+	"crypto/ecdsa"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/joincivil/go-common/pkg/lock"
+	didlib "github.com/ockam-network/did"
+
+	"github.com/joincivil/id-hub/pkg/claims"
+	"github.com/joincivil/id-hub/pkg/claimsstore"
+	"github.com/joincivil/id-hub/pkg/claimtypes"
+)
+
+func TestTreeChanges(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ledgertest")
+	if err != nil {
+		t.Fatalf("Should have created a temp dir: err: %v", err)
+	}
+	defer os.RemoveAll(dir) // nolint: errcheck
+	store, err := claimsstore.NewLevelDBStore(dir)
+	if err != nil {
+		t.Fatalf("Should have opened the store: err: %v", err)
+	}
+	defer store.Close()
+
+	claimService, err := claims.NewService(store, store, nil, nil, nil, nil, lock.NewLocalDLock())
+	if err != nil {
+		t.Fatalf("Should have created the service: err: %v", err)
+	}
+
+	userDid, _ := didlib.Parse("did:ethuri:e7ab0c43-d9fe-4a61-87a3-3fa99ce879e1#keys-1")
+	secKey, _ := crypto.GenerateKey()
+	err = claimService.CreateTreeForDIDWithPks(userDid, []*ecdsa.PublicKey{&secKey.PublicKey})
+	if err != nil {
+		t.Fatalf("Should have added the keys: err: %v", err)
+	}
+
+	senderService := claimService.WithSender("did:ethuri:0c2b5a8e-3f5a-4a4c-9c2a-8f4f0e7f7a11")
+	didMt, err := senderService.BuildDIDMt(userDid)
+	if err != nil {
+		t.Fatalf("Should have built the did tree: err: %v", err)
+	}
+	hash := "1b20b1e2a9f0d8c7e6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d9c8b7a6f5e4d3c2b1"
+	claim := testRegisteredDocument(t, hash, userDid, claimtypes.JWTDocType)
+	err = senderService.AddToDIDTree(didMt, userDid, claim.Entry())
+	if err != nil {
+		t.Fatalf("Should have added the document: err: %v", err)
+	}
+	err = senderService.AddNewRootClaim(userDid)
+	if err != nil {
+		t.Fatalf("Should have added the root claim: err: %v", err)
+	}
+
+	err = senderService.AddToDIDTree(didMt, userDid, claim.Entry())
+	if err == nil {
+		t.Errorf("Should not have added the document twice")
+	}
+	claimedService := claimService.WithClaimedSender("did:ethuri:sender-1")
+	revoked := testRegisteredDocument(t, hash, userDid, claimtypes.JWTDocType)
+	revoked.Version = 1
+	err = claimedService.AddToDIDTree(didMt, userDid, revoked.Entry())
+	if err != nil {
+		t.Fatalf("Should have revoked the document: err: %v", err)
+	}
+
+	changes, total, err := claimService.GetTreeChanges(userDid, 0, 0)
+	if err != nil {
+		t.Fatalf("Should have got the changes: err: %v", err)
+	}
+	if total != 5 || len(changes) != 5 {
+		t.Fatalf("Should have recorded 5 changes, got %v: %v", total, len(changes))
+	}
+	expected := []claimsstore.TreeChangeOperation{
+		claimsstore.TreeChangeAddKey,
+		claimsstore.TreeChangeSetRoot,
+		claimsstore.TreeChangeRegister,
+		claimsstore.TreeChangeSetRoot,
+		claimsstore.TreeChangeRevoke,
+	}
+	for i, change := range changes {
+		if change.Operation != expected[i] {
+			t.Errorf("Should have recorded %v as change %v, got %v", expected[i], i, change.Operation)
+		}
+		if change.DID != "did:ethuri:e7ab0c43-d9fe-4a61-87a3-3fa99ce879e1" {
+			t.Errorf("Should have recorded the change for the did without the fragment: %v", change.DID)
+		}
+		if change.DIDRoot == "" || change.CreatedAt.IsZero() {
+			t.Errorf("Should have recorded the did root and time of change %v", i)
+		}
+	}
+	if changes[0].Sender != "" || changes[2].Sender != "did:ethuri:0c2b5a8e-3f5a-4a4c-9c2a-8f4f0e7f7a11" {
+		t.Errorf("Should have recorded the senders: %v, %v", changes[0].Sender, changes[2].Sender)
+	}
+	if changes[4].Sender != "" || changes[4].ClaimedSender != "did:ethuri:sender-1" {
+		t.Errorf("Should have recorded the claimed sender apart: %+v", changes[4])
+	}
+	if changes[2].ContentHash != hash || changes[2].DocType != claimtypes.JWTDocType {
+		t.Errorf("Should have recorded the document: %+v", changes[2])
+	}
+	if changes[2].DIDRoot != changes[3].DIDRoot {
+		t.Errorf("Should have recorded the did root claimed by the root claim")
+	}
+
+	changes, total, err = claimService.GetTreeChanges(userDid, 3, 10)
+	if err != nil {
+		t.Fatalf("Should have got the changes: err: %v", err)
+	}
+	if total != 5 || len(changes) != 2 || changes[0].Operation != claimsstore.TreeChangeSetRoot {
+		t.Errorf("Should have got the last changes: %v %v", total, changes)
+	}
+}
+
+func TestTreeChangesSameLeaf(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ledgertest")
+	if err != nil {
+		t.Fatalf("Should have created a temp dir: err: %v", err)
+	}
+	defer os.RemoveAll(dir) // nolint: errcheck
+	store, err := claimsstore.NewLevelDBStore(dir)
+	if err != nil {
+		t.Fatalf("Should have opened the store: err: %v", err)
+	}
+	defer store.Close()
+
+	claimService, err := claims.NewService(store, store, nil, nil, nil, nil, lock.NewLocalDLock())
+	if err != nil {
+		t.Fatalf("Should have created the service: err: %v", err)
+	}
+	userDid, _ := didlib.Parse("did:ethuri:e7ab0c43-d9fe-4a61-87a3-3fa99ce879e1")
+	didMt, err := claimService.BuildDIDMt(userDid)
+	if err != nil {
+		t.Fatalf("Should have built the did tree: err: %v", err)
+	}
+	hash := "1b20b1e2a9f0d8c7e6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d9c8b7a6f5e4d3c2b1"
+	claim := testRegisteredDocument(t, hash, userDid, claimtypes.JWTDocType)
+
+	// The same document is added by two senders at once, the change recorded is
+	// of the sender whose add wrote the leaf
+	senders := []string{"did:ethuri:sender-1", "did:ethuri:sender-2"}
+	errs := make([]error, len(senders))
+	var wg sync.WaitGroup
+	for i, sender := range senders {
+		wg.Add(1)
+		go func(i int, sender string) {
+			defer wg.Done()
+			errs[i] = claimService.WithSender(sender).AddToDIDTree(didMt, userDid, claim.Entry())
+		}(i, sender)
+	}
+	wg.Wait()
+
+	added := -1
+	for i, err := range errs {
+		if err == nil {
+			added = i
+		} else if err == claimsstore.ErrTreeChangeNotRecorded {
+			t.Errorf("Should have recorded the change of every leaf written")
+		}
+	}
+	if added == -1 || errs[1-added] == nil {
+		t.Fatalf("Should have added the document once: %v", errs)
+	}
+
+	changes, total, err := claimService.GetTreeChanges(userDid, 0, 0)
+	if err != nil {
+		t.Fatalf("Should have got the changes: err: %v", err)
+	}
+	if total != 1 || changes[0].Sender != senders[added] {
+		t.Errorf("Should have recorded the change of %v: %v", senders[added], changes)
+	}
+}
@@ -0,0 +1,555 @@
+package claims
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	log "github.com/golang/glog"
+	icore "github.com/iden3/go-iden3-core/core"
+	"github.com/iden3/go-iden3-core/db"
+	"github.com/iden3/go-iden3-core/merkletree"
+	isrv "github.com/iden3/go-iden3-core/services/claimsrv"
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/claimsstore"
+	"github.com/joincivil/id-hub/pkg/claimtypes"
+	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/did/ethuri"
+)
+
+var (
+	// ErrRebuildTargetNotEmpty is returned when the storage the trees are rebuilt
+	// into already has a root tree
+	ErrRebuildTargetNotEmpty = errors.New("the rebuild target already has a root tree")
+)
+
+// RebuildSource provides the documents, revocations, document versions and
+// signing keys the merkle trees are rebuilt from
+type RebuildSource interface {
+	GetAllCredentials() ([]*claimsstore.SignedClaimPostgres, error)
+	GetAllJWTs() ([]*claimsstore.JWTClaimPostgres, error)
+	GetAllRevocations() ([]*claimsstore.Revocation, error)
+	GetAllDocumentVersions() ([]*ethuri.DocumentVersion, error)
+	GetSigningKeys(did *didlib.DID) ([]*ecdsa.PublicKey, error)
+}
+
+// PGRebuildSource is a RebuildSource that reads the credential tables, the
+// revocation ledger and the ethuri document versions in postgres and the keys
+// in the did documents
+type PGRebuildSource struct {
+	signedClaimStore     *claimsstore.SignedClaimPGPersister
+	jwtClaimStore        *claimsstore.JWTClaimPGPersister
+	revocationStore      *claimsstore.RevocationPGPersister
+	documentVersionStore ethuri.Persister
+	didService           *did.Service
+}
+
+// NewPGRebuildSource returns a new PGRebuildSource
+func NewPGRebuildSource(signedClaimStore *claimsstore.SignedClaimPGPersister,
+	jwtClaimStore *claimsstore.JWTClaimPGPersister,
+	revocationStore *claimsstore.RevocationPGPersister,
+	documentVersionStore ethuri.Persister,
+	didService *did.Service) *PGRebuildSource {
+	return &PGRebuildSource{
+		signedClaimStore:     signedClaimStore,
+		jwtClaimStore:        jwtClaimStore,
+		revocationStore:      revocationStore,
+		documentVersionStore: documentVersionStore,
+		didService:           didService,
+	}
+}
+
+// GetAllCredentials returns all the signed claims
+func (s *PGRebuildSource) GetAllCredentials() ([]*claimsstore.SignedClaimPostgres, error) {
+	return s.signedClaimStore.GetAllCredentials()
+}
+
+// GetAllJWTs returns all the jwt claims
+func (s *PGRebuildSource) GetAllJWTs() ([]*claimsstore.JWTClaimPostgres, error) {
+	return s.jwtClaimStore.GetAllJWTs()
+}
+
+// GetAllRevocations returns all the revocations in the ledger
+func (s *PGRebuildSource) GetAllRevocations() ([]*claimsstore.Revocation, error) {
+	return s.revocationStore.GetAll()
+}
+
+// GetAllDocumentVersions returns all the ethuri document versions
+func (s *PGRebuildSource) GetAllDocumentVersions() ([]*ethuri.DocumentVersion, error) {
+	return s.documentVersionStore.GetAllDocumentVersions()
+}
+
+// GetSigningKeys returns the public keys in the document of the did
+func (s *PGRebuildSource) GetSigningKeys(userDid *didlib.DID) ([]*ecdsa.PublicKey, error) {
+	doc, err := s.didService.GetDocumentFromDID(context.Background(), userDid)
+	if err != nil {
+		return nil, errors.Wrap(err, "getsigningkeys.getdocumentfromdid")
+	}
+	if doc == nil {
+		return nil, errors.New("no doc found for did")
+	}
+	return did.DocPublicKeyToEcdsaKeys(doc.PublicKeys), nil
+}
+
+// TreeRebuildReport is the result of RebuildTrees
+type TreeRebuildReport struct {
+	DIDCount        int
+	KeyCount        int
+	DocumentCount   int
+	RevocationCount int
+	// DocumentVersionCount is the number of anchored ethuri document versions
+	DocumentVersionCount int
+	RootClaimCount       int
+	// Skipped describes the documents and keys that could not be replayed
+	Skipped []string
+	// Root is the root of the rebuilt root tree
+	Root *merkletree.Hash
+	// DIDRoots are the roots of the rebuilt did trees by did
+	DIDRoots map[string]*merkletree.Hash
+
+	// CommitRoot is the root of the commit compared with, set by CompareWithCommit
+	CommitRoot string
+	// Faithful is true if the rebuilt root is the committed root
+	Faithful bool
+	// Mismatches describes the did trees that differ from the committed ones
+	Mismatches []string
+
+	rootClaims map[string]*claimtypes.ClaimSetRootKeyDID
+}
+
+const (
+	rebuildStepKeys = iota
+	rebuildStepDocument
+	rebuildStepRevocation
+	rebuildStepDocumentVersion
+)
+
+// rebuildStep is a change to a did tree that was followed by a new root claim
+type rebuildStep struct {
+	kind         int
+	time         time.Time
+	keys         []*ecdsa.PublicKey
+	claim        *claimtypes.ClaimRegisteredDocument
+	versionClaim *claimtypes.ClaimDIDDocumentVersion
+}
+
+type rebuildTree struct {
+	did   *didlib.DID
+	steps []*rebuildStep
+	// needsKeys is set for trees with signed claims, the hub claims the keys of
+	// the signer before registering the first one
+	needsKeys bool
+}
+
+type treeRebuilder struct {
+	source    RebuildSource
+	trees     map[string]*rebuildTree
+	documents map[string]*rebuildStep
+	report    *TreeRebuildReport
+}
+
+// RebuildTrees rebuilds every did tree and the root tree into target, which is
+// expected to be a fresh prefix or database. The changes to each did tree are
+// replayed in the order they were most likely made, each followed by a root
+// claim, as the claims service does: the keys in the did document before the
+// first signed claim, then the signed claims, jwts, revocations and anchored
+// ethuri document versions by the time they were issued, made or saved.
+//
+// The rebuilt trees only match the originals if that order is the order the
+// changes were made in. Trees that had keys added after their first signed claim,
+// license credentials, whose claimer is not stored, and raw data entries, which
+// are not stored at all, can't be rebuilt faithfully.
+func RebuildTrees(source RebuildSource, target db.Storage) (*TreeRebuildReport, error) {
+	rootMt, err := merkletree.NewMerkleTree(target.WithPrefix(claimsstore.PrefixRootMerkleTree), 150)
+	if err != nil {
+		return nil, errors.Wrap(err, "rebuildtrees.newmerkletree")
+	}
+	if !rootMt.RootKey().Equals(&merkletree.HashZero) {
+		return nil, ErrRebuildTargetNotEmpty
+	}
+
+	r := &treeRebuilder{
+		source:    source,
+		trees:     map[string]*rebuildTree{},
+		documents: map[string]*rebuildStep{},
+		report: &TreeRebuildReport{
+			DIDRoots:   map[string]*merkletree.Hash{},
+			rootClaims: map[string]*claimtypes.ClaimSetRootKeyDID{},
+		},
+	}
+	err = r.loadCredentials()
+	if err != nil {
+		return nil, err
+	}
+	err = r.loadJWTs()
+	if err != nil {
+		return nil, err
+	}
+	err = r.loadRevocations()
+	if err != nil {
+		return nil, err
+	}
+	err = r.loadDocumentVersions()
+	if err != nil {
+		return nil, err
+	}
+	r.loadKeys()
+
+	dids := make([]string, 0, len(r.trees))
+	for d := range r.trees {
+		dids = append(dids, d)
+	}
+	sort.Strings(dids)
+	for _, d := range dids {
+		err = r.replay(target, rootMt, d, r.trees[d])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	r.report.DIDCount = len(dids)
+	r.report.Root = rootMt.RootKey()
+	return r.report, nil
+}
+
+func (r *treeRebuilder) skip(format string, args ...interface{}) {
+	r.report.Skipped = append(r.report.Skipped, fmt.Sprintf(format, args...))
+}
+
+func (r *treeRebuilder) addStep(userDid *didlib.DID, step *rebuildStep) *rebuildTree {
+	key := did.MethodIDOnly(userDid)
+	tree, ok := r.trees[key]
+	if !ok {
+		tree = &rebuildTree{did: &didlib.DID{Method: userDid.Method, ID: userDid.ID}}
+		r.trees[key] = tree
+	}
+	tree.steps = append(tree.steps, step)
+	if step.kind == rebuildStepDocument {
+		r.documents[documentKey(key, step.claim)] = step
+	}
+	return tree
+}
+
+func (r *treeRebuilder) loadCredentials() error {
+	creds, err := r.source.GetAllCredentials()
+	if err != nil {
+		return errors.Wrap(err, "rebuildtrees.getallcredentials")
+	}
+	for _, c := range creds {
+		if c.Type != claimtypes.ContentCredentialType {
+			r.skip("credential %v: the claimer of %v credentials is not stored", c.Hash, c.Type)
+			continue
+		}
+		cred, err := c.ToCredential()
+		if err != nil {
+			r.skip("credential %v: %v", c.Hash, err)
+			continue
+		}
+		linkedDataProof, err := cred.FindLinkedDataProof()
+		if err != nil {
+			r.skip("credential %v: %v", c.Hash, err)
+			continue
+		}
+		signerDid, err := didlib.Parse(linkedDataProof.Creator)
+		if err != nil {
+			r.skip("credential %v: invalid proof creator: %v", c.Hash, err)
+			continue
+		}
+		claim, err := registeredDocumentClaim(c.Hash, signerDid, claimtypes.ContentCredentialDocType)
+		if err != nil {
+			r.skip("credential %v: %v", c.Hash, err)
+			continue
+		}
+		tree := r.addStep(signerDid, &rebuildStep{
+			kind:  rebuildStepDocument,
+			time:  c.IssuanceDate,
+			claim: claim,
+		})
+		tree.needsKeys = true
+	}
+	return nil
+}
+
+func (r *treeRebuilder) loadJWTs() error {
+	jwts, err := r.source.GetAllJWTs()
+	if err != nil {
+		return errors.Wrap(err, "rebuildtrees.getalljwts")
+	}
+	for _, j := range jwts {
+		issuer, err := didlib.Parse(j.Issuer)
+		if err != nil {
+			r.skip("jwt %v: invalid issuer: %v", j.Hash, err)
+			continue
+		}
+		claim, err := registeredDocumentClaim(j.Hash, issuer, claimtypes.JWTDocType)
+		if err != nil {
+			r.skip("jwt %v: %v", j.Hash, err)
+			continue
+		}
+		r.addStep(issuer, &rebuildStep{
+			kind:  rebuildStepDocument,
+			time:  time.Unix(j.IssuedAt, 0),
+			claim: claim,
+		})
+	}
+	return nil
+}
+
+func (r *treeRebuilder) loadRevocations() error {
+	revocations, err := r.source.GetAllRevocations()
+	if err != nil {
+		return errors.Wrap(err, "rebuildtrees.getallrevocations")
+	}
+	for _, rev := range revocations {
+		claimer, err := didlib.Parse(rev.DID)
+		if err != nil {
+			r.skip("revocation %v: invalid did: %v", rev.Hash, err)
+			continue
+		}
+		claim, err := registeredDocumentClaim(rev.Hash, claimer, rev.DocType)
+		if err != nil {
+			r.skip("revocation %v: %v", rev.Hash, err)
+			continue
+		}
+		claim.Version = 1
+
+		// a document can only be revoked after it was registered, the issuance
+		// dates are set by the issuers and may be later than the revocation
+		revokedAt := rev.CreatedAt
+		doc, ok := r.documents[documentKey(did.MethodIDOnly(claimer), claim)]
+		if ok && doc.time.After(revokedAt) {
+			revokedAt = doc.time
+		}
+		r.addStep(claimer, &rebuildStep{
+			kind:  rebuildStepRevocation,
+			time:  revokedAt,
+			claim: claim,
+		})
+	}
+	return nil
+}
+
+// loadDocumentVersions adds the anchored ethuri document versions, versions
+// that are not anchored are not in the trees
+func (r *treeRebuilder) loadDocumentVersions() error {
+	versions, err := r.source.GetAllDocumentVersions()
+	if err != nil {
+		return errors.Wrap(err, "rebuildtrees.getalldocumentversions")
+	}
+	for _, v := range versions {
+		if !v.Anchored {
+			continue
+		}
+		claim, err := claimtypes.NewClaimDIDDocumentVersion(v.Document, uint32(v.Version))
+		if err != nil {
+			r.skip("document %v version %v: %v", v.Document.ID.String(), v.Version, err)
+			continue
+		}
+		r.addStep(&v.Document.ID, &rebuildStep{
+			kind:         rebuildStepDocumentVersion,
+			time:         v.CreatedAt,
+			versionClaim: claim,
+		})
+	}
+	return nil
+}
+
+func (r *treeRebuilder) loadKeys() {
+	for _, tree := range r.trees {
+		if !tree.needsKeys {
+			continue
+		}
+		keys, err := r.source.GetSigningKeys(tree.did)
+		if err != nil {
+			r.skip("keys for %v: %v", tree.did, err)
+			continue
+		}
+		tree.steps = append(tree.steps, &rebuildStep{kind: rebuildStepKeys, keys: keys})
+	}
+}
+
+// replay adds the steps of a did tree to a new tree in target, adding a root
+// claim to rootMt after each step that changed it
+func (r *treeRebuilder) replay(target db.Storage, rootMt *merkletree.MerkleTree,
+	key string, tree *rebuildTree) error {
+	sort.SliceStable(tree.steps, func(i, j int) bool {
+		si, sj := tree.steps[i], tree.steps[j]
+		if si.kind == rebuildStepKeys || sj.kind == rebuildStepKeys {
+			return si.kind < sj.kind
+		}
+		if !si.time.Equal(sj.time) {
+			return si.time.Before(sj.time)
+		}
+		return si.kind < sj.kind
+	})
+
+	didMt, err := merkletree.NewMerkleTree(target.WithPrefix([]byte(key)), 150)
+	if err != nil {
+		return errors.Wrapf(err, "rebuildtrees.newmerkletree: did: %v", key)
+	}
+
+	var version uint32
+	var rootClaim *claimtypes.ClaimSetRootKeyDID
+	for _, step := range tree.steps {
+		changed, err := r.apply(didMt, key, step)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			continue
+		}
+
+		rootClaim, err = claimtypes.NewClaimSetRootKeyDID(tree.did, didMt.RootKey())
+		if err != nil {
+			return errors.Wrap(err, "rebuildtrees.newclaimsetrootkeydid")
+		}
+		rootClaim.Version = version
+		version++
+		err = rootMt.Add(rootClaim.Entry())
+		if err != nil {
+			return errors.Wrapf(err, "rebuildtrees.rootmt.add: did: %v", key)
+		}
+		r.report.RootClaimCount++
+	}
+
+	r.report.DIDRoots[key] = didMt.RootKey()
+	if rootClaim != nil {
+		r.report.rootClaims[key] = rootClaim
+	}
+	log.Infof("Rebuilt %v with %v root claims", key, version)
+	return nil
+}
+
+func (r *treeRebuilder) apply(didMt *merkletree.MerkleTree, key string,
+	step *rebuildStep) (bool, error) {
+	if step.kind == rebuildStepKeys {
+		changed := false
+		for _, k := range step.keys {
+			if isrv.CheckKSignInIddb(didMt, k) {
+				continue
+			}
+			err := didMt.Add(icore.NewClaimAuthorizeKSignSecp256k1(k).Entry())
+			if err != nil {
+				return false, errors.Wrapf(err, "rebuildtrees.add key: did: %v", key)
+			}
+			r.report.KeyCount++
+			changed = true
+		}
+		return changed, nil
+	}
+
+	if step.kind == rebuildStepDocumentVersion {
+		err := didMt.Add(step.versionClaim.Entry())
+		if err == merkletree.ErrEntryIndexAlreadyExists {
+			r.skip("%v: document version %v is already in the tree", key, step.versionClaim.DocVersion)
+			return false, nil
+		} else if err != nil {
+			return false, errors.Wrapf(err, "rebuildtrees.add document version: did: %v", key)
+		}
+		r.report.DocumentVersionCount++
+		return true, nil
+	}
+
+	err := didMt.Add(step.claim.Entry())
+	if err == merkletree.ErrEntryIndexAlreadyExists {
+		r.skip("%v: document %v version %v is already in the tree", key,
+			hex.EncodeToString(step.claim.ContentHash[:]), step.claim.Version)
+		return false, nil
+	} else if err != nil {
+		return false, errors.Wrapf(err, "rebuildtrees.add document: did: %v", key)
+	}
+	if step.kind == rebuildStepRevocation {
+		r.report.RevocationCount++
+	} else {
+		r.report.DocumentCount++
+	}
+	return true, nil
+}
+
+// CompareWithCommit compares the rebuilt trees with a root commit. The rebuild
+// is faithful if the rebuilt root is the committed root. If committed, the
+// storage the commit was made from, is given the latest rebuilt root claim of
+// each did is looked up in the committed root tree to find the did trees that
+// differ, which fails if the committed root tree is damaged.
+func (r *TreeRebuildReport) CompareWithCommit(commit *claimsstore.RootCommit,
+	committed db.Storage) error {
+	r.CommitRoot = commit.Root
+	r.Faithful = r.Root != nil && r.Root.Hex() == commit.Root
+	r.Mismatches = nil
+	if committed == nil {
+		return nil
+	}
+
+	commitRoot := merkletree.Hash{}
+	err := commitRoot.UnmarshalText([]byte(commit.Root))
+	if err != nil {
+		return errors.Wrapf(err, "comparewithcommit.unmarshaltext: root: %v", commit.Root)
+	}
+	rootMt, err := merkletree.NewMerkleTree(committed.WithPrefix(claimsstore.PrefixRootMerkleTree), 150)
+	if err != nil {
+		return errors.Wrap(err, "comparewithcommit.newmerkletree")
+	}
+	snapshot, err := rootMt.Snapshot(&commitRoot)
+	if err != nil {
+		return errors.Wrap(err, "comparewithcommit.snapshot")
+	}
+
+	dids := make([]string, 0, len(r.rootClaims))
+	for d := range r.rootClaims {
+		dids = append(dids, d)
+	}
+	sort.Strings(dids)
+	for _, d := range dids {
+		rebuilt := r.rootClaims[d]
+		data, err := snapshot.GetDataByIndex(rebuilt.Entry().HIndex())
+		if err == merkletree.ErrEntryIndexNotFound {
+			r.Mismatches = append(r.Mismatches, fmt.Sprintf(
+				"%v: root claim version %v is not in the committed root tree", d, rebuilt.Version))
+			continue
+		} else if err != nil {
+			return errors.Wrapf(err, "comparewithcommit.getdatabyindex: did: %v", d)
+		}
+		claim := claimtypes.NewClaimSetRootKeyDIDFromEntry(&merkletree.Entry{Data: *data})
+		if !claim.RootKey.Equals(&rebuilt.RootKey) {
+			r.Mismatches = append(r.Mismatches, fmt.Sprintf(
+				"%v: root claim version %v has root %v, rebuilt %v", d, rebuilt.Version,
+				claim.RootKey.Hex(), rebuilt.RootKey.Hex()))
+			continue
+		}
+
+		// the committed tree may have had later changes that weren't rebuilt
+		next := *rebuilt
+		next.Version++
+		_, err = snapshot.GetDataByIndex(next.Entry().HIndex())
+		if err == nil {
+			r.Mismatches = append(r.Mismatches, fmt.Sprintf(
+				"%v: the committed root tree has root claims after version %v", d, rebuilt.Version))
+		} else if err != merkletree.ErrEntryIndexNotFound {
+			return errors.Wrapf(err, "comparewithcommit.getdatabyindex: did: %v", d)
+		}
+	}
+	return nil
+}
+
+func documentKey(didKey string, claim *claimtypes.ClaimRegisteredDocument) string {
+	return fmt.Sprintf("%v/%v/%x", didKey, claim.DocType, claim.ContentHash)
+}
+
+func registeredDocumentClaim(mHash string, userDid *didlib.DID,
+	docType uint32) (*claimtypes.ClaimRegisteredDocument, error) {
+	hashb, err := hex.DecodeString(mHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "registereddocumentclaim.decodestring")
+	}
+	if len(hashb) > 34 {
+		return nil, errors.New("hash hex string is the wrong size")
+	}
+	hashb34 := [34]byte{}
+	copy(hashb34[:], hashb)
+	return claimtypes.NewClaimRegisteredDocument(hashb34, userDid, docType)
+}
@@ -0,0 +1,227 @@
+package claims_test
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iden3/go-iden3-core/merkletree"
+	"github.com/joincivil/go-common/pkg/lock"
+	didlib "github.com/ockam-network/did"
+
+	"github.com/joincivil/id-hub/pkg/claims"
+	"github.com/joincivil/id-hub/pkg/claimsstore"
+	"github.com/joincivil/id-hub/pkg/claimtypes"
+	"github.com/joincivil/id-hub/pkg/did/ethuri"
+	"github.com/joincivil/id-hub/pkg/linkeddata"
+	"github.com/joincivil/id-hub/pkg/testutils"
+)
+
+type testRebuildSource struct {
+	creds       []*claimsstore.SignedClaimPostgres
+	jwts        []*claimsstore.JWTClaimPostgres
+	revocations []*claimsstore.Revocation
+	versions    []*ethuri.DocumentVersion
+	keys        map[string][]*ecdsa.PublicKey
+}
+
+func (s *testRebuildSource) GetAllCredentials() ([]*claimsstore.SignedClaimPostgres, error) {
+	return s.creds, nil
+}
+
+func (s *testRebuildSource) GetAllJWTs() ([]*claimsstore.JWTClaimPostgres, error) {
+	return s.jwts, nil
+}
+
+func (s *testRebuildSource) GetAllRevocations() ([]*claimsstore.Revocation, error) {
+	return s.revocations, nil
+}
+
+func (s *testRebuildSource) GetAllDocumentVersions() ([]*ethuri.DocumentVersion, error) {
+	return s.versions, nil
+}
+
+func (s *testRebuildSource) GetSigningKeys(did *didlib.DID) ([]*ecdsa.PublicKey, error) {
+	return s.keys[did.String()], nil
+}
+
+// addRebuildTestTrees adds a did with keys, two content credentials and a
+// revocation, a did with two jwts and a did with an anchored document version
+// to the trees the same way the services do, and returns the source they can
+// be rebuilt from
+func addRebuildTestTrees(t *testing.T, claimService *claims.Service) *testRebuildSource {
+	source := &testRebuildSource{keys: map[string][]*ecdsa.PublicKey{}}
+
+	signerDid, _ := didlib.Parse("did:ethuri:e7ab0c43-d9fe-4a61-87a3-3fa99ce879e1")
+	secKey, _ := crypto.GenerateKey()
+	source.keys[signerDid.String()] = []*ecdsa.PublicKey{&secKey.PublicKey}
+	err := claimService.CreateTreeForDIDWithPks(signerDid, source.keys[signerDid.String()])
+	if err != nil {
+		t.Fatalf("Should have added the keys: err: %v", err)
+	}
+	signerMt, err := claimService.BuildDIDMt(signerDid)
+	if err != nil {
+		t.Fatalf("Should have built the did tree: err: %v", err)
+	}
+
+	for i, title := range []string{"first", "second"} {
+		cred := makeContentCredential(signerDid)
+		cred.CredentialSubject.Metadata.Title = title
+		cred.IssuanceDate = time.Date(2019, 2, 1+i, 12, 30, 0, 0, time.UTC)
+		cred.Proof = []interface{}{linkeddata.Proof{Creator: signerDid.String() + "#keys-1"}}
+		row := &claimsstore.SignedClaimPostgres{}
+		err = row.FromContentCredential(cred)
+		if err != nil {
+			t.Fatalf("Should have made the signed claim: err: %v", err)
+		}
+		source.creds = append(source.creds, row)
+
+		claim := testRegisteredDocument(t, row.Hash, signerDid, claimtypes.ContentCredentialDocType)
+		err = signerMt.Add(claim.Entry())
+		if err != nil {
+			t.Fatalf("Should have added the credential: err: %v", err)
+		}
+		err = claimService.AddNewRootClaim(signerDid)
+		if err != nil {
+			t.Fatalf("Should have added the root claim: err: %v", err)
+		}
+	}
+
+	revoked := testRegisteredDocument(t, source.creds[0].Hash, signerDid, claimtypes.ContentCredentialDocType)
+	revoked.Version = 1
+	err = signerMt.Add(revoked.Entry())
+	if err != nil {
+		t.Fatalf("Should have added the revocation: err: %v", err)
+	}
+	err = claimService.AddNewRootClaim(signerDid)
+	if err != nil {
+		t.Fatalf("Should have added the root claim: err: %v", err)
+	}
+	revocation := &claimsstore.Revocation{
+		DID:     signerDid.String(),
+		DocType: claimtypes.ContentCredentialDocType,
+		Hash:    source.creds[0].Hash,
+	}
+	revocation.CreatedAt = time.Date(2019, 3, 1, 12, 30, 0, 0, time.UTC)
+	source.revocations = append(source.revocations, revocation)
+
+	issuerDid, _ := didlib.Parse("did:ethuri:0c2b5a8e-3f5a-4a4c-9c2a-8f4f0e7f7a11")
+	issuerMt, err := claimService.BuildDIDMt(issuerDid)
+	if err != nil {
+		t.Fatalf("Should have built the did tree: err: %v", err)
+	}
+	// added out of order to check they are replayed by the time they were issued
+	for i, hash := range []string{
+		"1b20b1e2a9f0d8c7e6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d9c8b7a6f5e4d3c2b1",
+		"1b20b1e2a9f000c7e6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d9c8b7a6f5e4d3c2b1",
+	} {
+		claim := testRegisteredDocument(t, hash, issuerDid, claimtypes.JWTDocType)
+		err = issuerMt.Add(claim.Entry())
+		if err != nil {
+			t.Fatalf("Should have added the jwt: err: %v", err)
+		}
+		err = claimService.AddNewRootClaim(issuerDid)
+		if err != nil {
+			t.Fatalf("Should have added the root claim: err: %v", err)
+		}
+		source.jwts = append([]*claimsstore.JWTClaimPostgres{{
+			Issuer:   issuerDid.String(),
+			Hash:     hash,
+			IssuedAt: int64(1500000000 + i),
+		}}, source.jwts...)
+	}
+
+	// the second version failed to anchor, so it is not in the tree
+	doc := testutils.BuildTestDocument()
+	err = claimService.AnchorDocumentVersion(doc, 1)
+	if err != nil {
+		t.Fatalf("Should have anchored the document version: err: %v", err)
+	}
+	source.versions = []*ethuri.DocumentVersion{
+		{Version: 1, Document: doc, CreatedAt: time.Date(2019, 4, 1, 12, 30, 0, 0, time.UTC), Anchored: true},
+		{Version: 2, Document: doc, CreatedAt: time.Date(2019, 4, 2, 12, 30, 0, 0, time.UTC)},
+	}
+	return source
+}
+
+func testRegisteredDocument(t *testing.T, mHash string, did *didlib.DID,
+	docType uint32) *claimtypes.ClaimRegisteredDocument {
+	hashb, err := hex.DecodeString(mHash)
+	if err != nil {
+		t.Fatalf("Should have decoded the hash: err: %v", err)
+	}
+	hash34 := [34]byte{}
+	copy(hash34[:], hashb)
+	claim, err := claimtypes.NewClaimRegisteredDocument(hash34, did, docType)
+	if err != nil {
+		t.Fatalf("Should have made the claim: err: %v", err)
+	}
+	return claim
+}
+
+func TestRebuildTrees(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rebuildtest")
+	if err != nil {
+		t.Fatalf("Should have created a temp dir: err: %v", err)
+	}
+	defer os.RemoveAll(dir) // nolint: errcheck
+	store, err := claimsstore.NewLevelDBStore(dir)
+	if err != nil {
+		t.Fatalf("Should have opened the store: err: %v", err)
+	}
+	defer store.Close()
+
+	claimService, err := claims.NewService(store, store, nil, nil, nil, nil, lock.NewLocalDLock())
+	if err != nil {
+		t.Fatalf("Should have created the service: err: %v", err)
+	}
+	source := addRebuildTestTrees(t, claimService)
+	rootMt, err := merkletree.NewMerkleTree(store.WithPrefix(claimsstore.PrefixRootMerkleTree), 150)
+	if err != nil {
+		t.Fatalf("Should have opened the root tree: err: %v", err)
+	}
+	commit := &claimsstore.RootCommit{Root: rootMt.RootKey().Hex()}
+
+	report, err := claims.RebuildTrees(source, store.WithPrefix([]byte("rebuild/")))
+	if err != nil {
+		t.Fatalf("Should have rebuilt the trees: err: %v", err)
+	}
+	if report.DIDCount != 3 || report.KeyCount != 1 || report.DocumentCount != 4 ||
+		report.RevocationCount != 1 || report.DocumentVersionCount != 1 || report.RootClaimCount != 7 {
+		t.Errorf("Should have replayed 3 dids, 1 key, 4 documents, 1 revocation, 1 document version "+
+			"and 7 root claims: %+v", report)
+	}
+	if len(report.Skipped) != 0 {
+		t.Errorf("Should not have skipped anything: %v", report.Skipped)
+	}
+	err = report.CompareWithCommit(commit, store)
+	if err != nil {
+		t.Fatalf("Should have compared the trees: err: %v", err)
+	}
+	if !report.Faithful || len(report.Mismatches) != 0 {
+		t.Errorf("Should have rebuilt the committed root %v, got %v: %v",
+			commit.Root, report.Root.Hex(), report.Mismatches)
+	}
+
+	_, err = claims.RebuildTrees(source, store.WithPrefix([]byte("rebuild/")))
+	if err != claims.ErrRebuildTargetNotEmpty {
+		t.Errorf("Should not have rebuilt into a target with trees: err: %v", err)
+	}
+
+	source.jwts = source.jwts[1:]
+	report, err = claims.RebuildTrees(source, store.WithPrefix([]byte("rebuild2/")))
+	if err != nil {
+		t.Fatalf("Should have rebuilt the trees: err: %v", err)
+	}
+	err = report.CompareWithCommit(commit, store)
+	if err != nil {
+		t.Fatalf("Should have compared the trees: err: %v", err)
+	}
+	if report.Faithful || len(report.Mismatches) != 1 {
+		t.Errorf("Should have found the tree with the missing jwt: %v", report.Mismatches)
+	}
+}
@@ -1,8 +1,14 @@
 package claims
 
 import (
+	"strings"
+	"time"
+
 	"github.com/iden3/go-iden3-core/db"
 	"github.com/iden3/go-iden3-core/merkletree"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+
 	"github.com/joincivil/id-hub/pkg/claimsstore"
 )
 
@@ -11,19 +17,26 @@ type RootService struct {
 	treeStore db.Storage
 	committer RootCommitterInterface
 	persister *claimsstore.RootCommitsPGPersister
+	signer    *TreeHeadSigner
 }
 
-// NewRootService constructs a new root service
-func NewRootService(treeStore db.Storage, committer RootCommitterInterface, persister *claimsstore.RootCommitsPGPersister) (*RootService, error) {
+// NewRootService constructs a new root service. The tree head of every root
+// committed is signed with signer, roots can only be committed with a signer.
+func NewRootService(treeStore db.Storage, committer RootCommitterInterface,
+	persister *claimsstore.RootCommitsPGPersister, signer *TreeHeadSigner) (*RootService, error) {
 	return &RootService{
 		treeStore: treeStore,
 		committer: committer,
 		persister: persister,
+		signer:    signer,
 	}, nil
 }
 
 // CommitRoot commits the current root of the root tree to the contract and saves the blocknumber and transaction in pg
 func (s *RootService) CommitRoot() error {
+	if s.signer == nil {
+		return ErrTreeHeadSignerRequired
+	}
 	rootStore := s.treeStore.WithPrefix(claimsstore.PrefixRootMerkleTree)
 
 	rootMt, err := merkletree.NewMerkleTree(rootStore, 150)
@@ -55,6 +68,11 @@ func (s *RootService) CommitRoot() error {
 		CommitterAddress: s.committer.GetAccount().Hex(),
 	}
 
+	err = s.signTreeHead(rootMt, rootSlice, rootCommit)
+	if err != nil {
+		return err
+	}
+
 	return s.persister.Save(rootCommit)
 }
 
@@ -74,3 +92,106 @@ func (s *RootService) GetCurrent() (string, error) {
 func (s *RootService) GetLatest() (*claimsstore.RootCommit, error) {
 	return s.persister.GetLatest()
 }
+
+// GetCommit returns the commit of a root of the root tree
+func (s *RootService) GetCommit(root string) (*claimsstore.RootCommit, error) {
+	return s.persister.Get(root)
+}
+
+// GetCommitterAddresses returns the address roots are committed with and the
+// addresses of the past commits
+func (s *RootService) GetCommitterAddresses() ([]string, error) {
+	addresses, err := s.persister.GetCommitterAddresses()
+	if err != nil {
+		return nil, errors.Wrap(err, "getcommitteraddresses.getcommitteraddresses")
+	}
+	if s.committer == nil {
+		return addresses, nil
+	}
+	current := s.committer.GetAccount().Hex()
+	for _, address := range addresses {
+		if strings.EqualFold(address, current) {
+			return addresses, nil
+		}
+	}
+	return append([]string{current}, addresses...), nil
+}
+
+func (s *RootService) signTreeHead(rootMt *merkletree.MerkleTree, root *merkletree.Hash,
+	rootCommit *claimsstore.RootCommit) error {
+	size, err := CountTreeLeaves(rootMt, root)
+	if err != nil {
+		return errors.Wrap(err, "signtreehead.counttreeleaves")
+	}
+	treeHead, err := s.signer.Sign(root, size, time.Now().Unix())
+	if err != nil {
+		return errors.Wrap(err, "signtreehead.sign")
+	}
+	rootCommit.TreeSize = treeHead.Size
+	rootCommit.TreeHeadTimestamp = treeHead.Timestamp
+	rootCommit.TreeHeadSigner = treeHead.Signer
+	rootCommit.TreeHeadSignature = treeHead.Signature
+	return nil
+}
+
+// GetSignedTreeHead returns the signed tree head of a committed root
+func (s *RootService) GetSignedTreeHead(root string) (*SignedTreeHead, error) {
+	commit, err := s.persister.Get(root)
+	if err != nil {
+		return nil, errors.Wrap(err, "getsignedtreehead.get")
+	}
+	return SignedTreeHeadFromCommit(commit)
+}
+
+// GetLatestSignedTreeHead returns the signed tree head of the latest committed root
+func (s *RootService) GetLatestSignedTreeHead() (*SignedTreeHead, error) {
+	commit, err := s.persister.GetLatest()
+	if err != nil {
+		return nil, errors.Wrap(err, "getlatestsignedtreehead.getlatest")
+	}
+	return SignedTreeHeadFromCommit(commit)
+}
+
+// ConsistencyProof returns a proof that the second committed root contains every
+// root claim in the first, with the signed tree heads of both commits. Returns
+// ErrInvalidTreeRoot if a root can not be parsed and ErrRootCommitNotFound if it
+// has not been committed.
+func (s *RootService) ConsistencyProof(first string, second string) (*ConsistencyProof, error) {
+	roots := make([]*merkletree.Hash, 2)
+	for i, root := range []string{first, second} {
+		var err error
+		roots[i], err = ParseTreeRoot(root)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidTreeRoot, err.Error())
+		}
+	}
+	treeHeads := make([]*SignedTreeHead, 2)
+	for i, root := range roots {
+		commit, err := s.persister.Get(root.Hex())
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, ErrRootCommitNotFound
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "consistencyproof.get")
+		}
+		treeHeads[i], err = SignedTreeHeadFromCommit(commit)
+		if err != nil {
+			return nil, errors.Wrap(err, "consistencyproof.signedtreeheadfromcommit")
+		}
+	}
+
+	rootMt, err := merkletree.NewMerkleTree(s.treeStore.WithPrefix(claimsstore.PrefixRootMerkleTree), 150)
+	if err != nil {
+		return nil, errors.Wrap(err, "consistencyproof.newmerkletree")
+	}
+	proof, err := GenerateConsistencyProof(rootMt, roots[0], roots[1])
+	if err != nil {
+		return nil, err
+	}
+	if proof.FirstSize != treeHeads[0].Size || proof.SecondSize != treeHeads[1].Size {
+		return nil, errors.New("consistencyproof: the tree sizes do not match the signed tree heads")
+	}
+	proof.FirstTreeHead = treeHeads[0]
+	proof.SecondTreeHead = treeHeads[1]
+	return proof, nil
+}
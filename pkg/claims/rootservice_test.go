@@ -4,6 +4,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	iden3db "github.com/iden3/go-iden3-core/db"
 	"github.com/iden3/go-iden3-core/merkletree"
 	isrv "github.com/iden3/go-iden3-core/services/claimsrv"
@@ -20,7 +21,8 @@ func makeRootService(db *gorm.DB) (*claims.RootService, *claimsstore.RootCommits
 	rootpersister := claimsstore.NewRootCommitsPGPersister(db)
 	treeStore := claimsstore.NewPGStore(nodepersister)
 	committer := &claims.FakeRootCommitter{CurrentBlockNumber: big.NewInt(1)}
-	rootService, err := claims.NewRootService(treeStore, committer, rootpersister)
+	signerKey, _ := crypto.GenerateKey()
+	rootService, err := claims.NewRootService(treeStore, committer, rootpersister, claims.NewTreeHeadSigner(signerKey))
 	return rootService, rootpersister, treeStore, err
 }
 
@@ -2,10 +2,13 @@ package claims
 
 import (
 	"bytes"
+	"context"
 	"crypto/ecdsa"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jinzhu/gorm"
@@ -16,6 +19,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 
 	icore "github.com/iden3/go-iden3-core/core"
+	"github.com/iden3/go-iden3-core/db"
 	"github.com/iden3/go-iden3-core/merkletree"
 	isrv "github.com/iden3/go-iden3-core/services/claimsrv"
 
@@ -38,16 +42,25 @@ var (
 // Service is a service for creating and reading claims
 type Service struct {
 	rootMt           *merkletree.MerkleTree
-	treeStore        *claimsstore.PGStore
+	treeStore        db.Storage
+	rootClaimIndex   claimsstore.RootClaimIndex
 	signedClaimStore *claimsstore.SignedClaimPGPersister
+	revocationStore  *claimsstore.RevocationPGPersister
 	didService       *did.Service
 	rootService      *RootService
 	dlock            lock.DLock
+	sender           string
+	claimedSender    string
 }
 
-// NewService returns a new service
-func NewService(treeStore *claimsstore.PGStore, signedClaimStore *claimsstore.SignedClaimPGPersister,
-	didService *did.Service, rootService *RootService, dlock lock.DLock) (*Service, error) {
+// NewService returns a new service. treeStore is the storage for all the merkle
+// trees, usually a PGStore or a CachedStore wrapping one, and rootClaimIndex is
+// used to look up the root claims stored in it. Revocations are recorded in
+// revocationStore so the trees can be rebuilt.
+func NewService(treeStore db.Storage, rootClaimIndex claimsstore.RootClaimIndex,
+	signedClaimStore *claimsstore.SignedClaimPGPersister,
+	revocationStore *claimsstore.RevocationPGPersister, didService *did.Service,
+	rootService *RootService, dlock lock.DLock) (*Service, error) {
 	rootStore := treeStore.WithPrefix(claimsstore.PrefixRootMerkleTree)
 
 	rootMt, err := merkletree.NewMerkleTree(rootStore, 150)
@@ -58,7 +71,9 @@ func NewService(treeStore *claimsstore.PGStore, signedClaimStore *claimsstore.Si
 	return &Service{
 		rootMt:           rootMt,
 		treeStore:        treeStore,
+		rootClaimIndex:   rootClaimIndex,
 		signedClaimStore: signedClaimStore,
+		revocationStore:  revocationStore,
 		didService:       didService,
 		rootService:      rootService,
 		dlock:            dlock,
@@ -94,7 +109,7 @@ func (s *Service) generateProofAndNonRevokeFromEntry(entry *merkletree.Entry,
 func (s *Service) getLastRootClaim(signerDID *didlib.DID,
 	rootSnapShotTree *merkletree.MerkleTree) (*claimtypes.ClaimSetRootKeyDID, *merkletree.MerkleTree, error) {
 
-	lastRootClaimForDID, err := s.treeStore.NodePersister.GetLatestRootClaimInSnapshot(signerDID, rootSnapShotTree)
+	lastRootClaimForDID, err := s.rootClaimIndex.GetLatestRootClaimInSnapshot(signerDID, rootSnapShotTree)
 	if err != nil {
 		return nil, nil, errors.Wrap(err,
 			"getLastRootClaim NodePersister.GetLatestRootClaimInSnapshot failed to get last root for did")
@@ -275,15 +290,15 @@ func (s *Service) AddNewRootClaim(userDid *didlib.DID) error {
 	}
 
 	// get next version of the claim
-	version, err := s.treeStore.NodePersister.GetNextRootClaimVersion(userDid)
+	version, err := s.rootClaimIndex.GetNextRootClaimVersion(userDid)
 	if gorm.IsRecordNotFoundError(err) {
 		version = 0
 	} else if err != nil {
-		return errors.Wrap(err, "addNewRootClaim.NodePersister.GetNextRootClaimVersion")
+		return errors.Wrap(err, "addNewRootClaim.RootClaimIndex.GetNextRootClaimVersion")
 	}
 
 	claimSetRootKey.Version = version
-	err = s.rootMt.Add(claimSetRootKey.Entry())
+	err = s.addToTree(s.rootMt, claimsstore.PrefixRootMerkleTree, userDid, claimSetRootKey.Entry())
 	lerr = s.dlock.Unlock(userDid.String())
 	if lerr != nil {
 		log.Infof("addNewRootClaim.Unlock: err: %v, did: %v", lerr, userDid.String())
@@ -318,7 +333,7 @@ func (s *Service) CreateTreeForDIDWithPks(userDid *didlib.DID, signPks []*ecdsa.
 		}
 
 		claimKey = icore.NewClaimAuthorizeKSignSecp256k1(k)
-		err = didMt.Add(claimKey.Entry())
+		err = s.AddToDIDTree(didMt, userDid, claimKey.Entry())
 		if err != nil {
 			return errors.Wrap(err, "unable to add signing key claim")
 		}
@@ -333,8 +348,8 @@ func (s *Service) CreateTreeForDIDWithPks(userDid *didlib.DID, signPks []*ecdsa.
 }
 
 // CreateTreeForDID creates a new tree for a user DID if it does not exist already.
-func (s *Service) CreateTreeForDID(userDid *didlib.DID) error {
-	doc, err := s.didService.GetDocumentFromDID(userDid)
+func (s *Service) CreateTreeForDID(ctx context.Context, userDid *didlib.DID) error {
+	doc, err := s.didService.GetDocumentFromDID(ctx, userDid)
 	if err != nil {
 		return errors.Wrap(err, "unable to retrieve document for did")
 	}
@@ -348,7 +363,10 @@ func (s *Service) CreateTreeForDID(userDid *didlib.DID) error {
 	)
 }
 
-func (s *Service) verifyCredential(cred claimtypes.Credential) (bool, error) {
+// verifyCredential verifies the proof of a credential received by the hub at
+// receivedAt, the signer controlled created time of the proof is bounded by it
+func (s *Service) verifyCredential(ctx context.Context, cred claimtypes.Credential,
+	receivedAt time.Time) (bool, error) {
 	linkedDataProof, err := cred.FindLinkedDataProof()
 	if err != nil {
 		return false, errors.Wrap(err, "verifyCredential.FindLinkedDataProof")
@@ -360,7 +378,10 @@ func (s *Service) verifyCredential(cred claimtypes.Credential) (bool, error) {
 	if err != nil {
 		return false, errors.Wrap(err, "verifyCredential parse creator did")
 	}
-	pubkey, err := s.didService.GetKeyFromDIDDocument(signerDid)
+	// Verify against the document as it was when the credential was signed,
+	// bounded by when it was received so keys removed before can not be used
+	pubkey, err := s.didService.GetAssertionMethodAtTime(ctx, signerDid,
+		linkedDataProof.Created, receivedAt)
 	if err != nil {
 		return false, err
 	}
@@ -396,7 +417,7 @@ func (s *Service) verifyCredential(cred claimtypes.Credential) (bool, error) {
 
 // ClaimContent takes a content credential and saves it to the signed credential table
 // and then registers it in the tree
-func (s *Service) ClaimContent(cred *claimtypes.ContentCredential) error {
+func (s *Service) ClaimContent(ctx context.Context, cred *claimtypes.ContentCredential) error {
 	signerDid, err := s.getSignerDID(cred)
 	if err != nil {
 		return errors.Wrap(err, "ClaimContent.getSignerDID")
@@ -406,12 +427,17 @@ func (s *Service) ClaimContent(cred *claimtypes.ContentCredential) error {
 		return errors.New("claimcontent expecting fragment on did for proof creator")
 	}
 
+	err = s.checkDIDNotDeactivated(ctx, signerDid)
+	if err != nil {
+		return errors.Wrap(err, "claimcontent.checkdidnotdeactivated")
+	}
+
 	// for a content claim the signer should also be the issuer and holder
 	didMt, err := s.BuildDIDMt(signerDid)
 	if err != nil {
 		return errors.Wrap(err, "claimcontent.builddidMt")
 	}
-	verified, err := s.verifyCredential(cred)
+	verified, err := s.verifyCredential(ctx, cred, time.Now())
 	if err != nil {
 		return errors.Wrap(err, "claimcontent.verifycredential")
 	}
@@ -436,7 +462,7 @@ func (s *Service) ClaimContent(cred *claimtypes.ContentCredential) error {
 	if err != nil {
 		return errors.Wrap(err, "claimcontent.newclaimregistereddocument")
 	}
-	err = didMt.Add(claim.Entry())
+	err = s.AddToDIDTree(didMt, signerDid, claim.Entry())
 	if err != nil {
 		return errors.Wrap(err, "claimcontent.add")
 	}
@@ -450,39 +476,143 @@ func (s *Service) ClaimContent(cred *claimtypes.ContentCredential) error {
 
 // RevokeClaim adds a revocation to the registered doc associated with a credential
 func (s *Service) RevokeClaim(cred claimtypes.Credential, claimer *didlib.DID) error {
-	didMt, err := s.BuildDIDMt(claimer)
+	rdClaim, err := s.makeContentClaimFromCred(cred, claimer)
 	if err != nil {
-		return errors.Wrap(err, "revokeclaim.builddidMt")
+		return errors.Wrap(err, "RevokeClaim.makeContentClaimFromCred")
 	}
 
-	rdClaim, err := s.makeContentClaimFromCred(cred, claimer)
+	err = s.RevokeRegisteredDocument(rdClaim, claimer)
 	if err != nil {
-		return errors.Wrap(err, "RevokeClaim.makeContentClaimFromCred")
+		return errors.Wrap(err, "RevokeClaim.revokeregistereddocument")
+	}
+
+	return nil
+}
+
+// RevokeRegisteredDocument adds a revocation of the registered doc to the
+// claimers tree and records it in the revocation ledger
+func (s *Service) RevokeRegisteredDocument(rdClaim *claimtypes.ClaimRegisteredDocument,
+	claimer *didlib.DID) error {
+	didMt, err := s.BuildDIDMt(claimer)
+	if err != nil {
+		return errors.Wrap(err, "revokeregistereddocument.builddidMt")
 	}
 
 	rdClaim.Version = 1 // 1 signifies revokation for all registered document claims
 
-	err = didMt.Add(rdClaim.Entry())
+	err = s.AddToDIDTree(didMt, claimer, rdClaim.Entry())
+	if err != nil {
+		return errors.Wrap(err, "revokeregistereddocument.add")
+	}
+
+	err = s.revocationStore.AddRevocation(claimer, rdClaim.DocType, rdClaim.ContentHash)
 	if err != nil {
-		return errors.Wrap(err, "RevokeClaim.add")
+		return errors.Wrap(err, "revokeregistereddocument.addrevocation")
 	}
 
 	err = s.AddNewRootClaim(claimer)
 	if err != nil {
-		return errors.Wrap(err, "RevokeClaim.addnewrootclaim")
+		return errors.Wrap(err, "revokeregistereddocument.addnewrootclaim")
 	}
 
 	return nil
 }
 
+// RevokeAllRegisteredDocuments revokes every registered document in the
+// claimers tree that is not revoked yet, records them in the revocation ledger
+// and adds a single new root claim for the changes. Returns the number of
+// revoked documents.
+func (s *Service) RevokeAllRegisteredDocuments(claimer *didlib.DID) (int, error) {
+	didMt, err := s.BuildDIDMt(claimer)
+	if err != nil {
+		return 0, errors.Wrap(err, "revokeallregistereddocuments.builddidMt")
+	}
+	clms, err := getClaimsForTree(didMt)
+	if err != nil {
+		return 0, errors.Wrap(err, "revokeallregistereddocuments.getclaimsfortree")
+	}
+
+	registered := []claimtypes.ClaimRegisteredDocument{}
+	revoked := map[string]bool{}
+	for _, v := range clms {
+		regDoc, ok := toRegisteredDocument(v)
+		if !ok {
+			continue
+		}
+		if regDoc.Version == 1 {
+			revoked[registeredDocumentKey(regDoc)] = true
+		} else {
+			registered = append(registered, regDoc)
+		}
+	}
+
+	count := 0
+	for _, regDoc := range registered {
+		if revoked[registeredDocumentKey(regDoc)] {
+			continue
+		}
+		regDoc.Version = 1 // 1 signifies revokation for all registered document claims
+		err = s.AddToDIDTree(didMt, claimer, regDoc.Entry())
+		if err != nil {
+			return count, errors.Wrap(err, "revokeallregistereddocuments.add")
+		}
+		err = s.revocationStore.AddRevocation(claimer, regDoc.DocType, regDoc.ContentHash)
+		if err != nil {
+			return count, errors.Wrap(err, "revokeallregistereddocuments.addrevocation")
+		}
+		count++
+	}
+
+	if count == 0 {
+		return 0, nil
+	}
+	err = s.AddNewRootClaim(claimer)
+	if err != nil {
+		return count, errors.Wrap(err, "revokeallregistereddocuments.addnewrootclaim")
+	}
+	return count, nil
+}
+
+func toRegisteredDocument(clm merkletree.Claim) (claimtypes.ClaimRegisteredDocument, bool) {
+	switch tv := clm.(type) {
+	case *claimtypes.ClaimRegisteredDocument:
+		return *tv, true
+	case claimtypes.ClaimRegisteredDocument:
+		return tv, true
+	}
+	return claimtypes.ClaimRegisteredDocument{}, false
+}
+
+func registeredDocumentKey(regDoc claimtypes.ClaimRegisteredDocument) string {
+	return fmt.Sprintf("%v:%x", regDoc.DocType, regDoc.ContentHash)
+}
+
+// checkDIDNotDeactivated returns the did.DeactivatedError if userDid is
+// deactivated, no new claims are added to the trees of deactivated DIDs
+func (s *Service) checkDIDNotDeactivated(ctx context.Context, userDid *didlib.DID) error {
+	d := did.CopyDID(userDid)
+	d.Fragment = ""
+	_, err := s.didService.GetDocumentFromDID(ctx, d)
+	if derr, ok := did.IsDeactivated(err); ok {
+		return derr
+	}
+	return nil
+}
+
 // ClaimLicense adds a license claim to the claimers claim tree
-func (s *Service) ClaimLicense(cred *claimtypes.LicenseCredential, claimer *didlib.DID) error {
+func (s *Service) ClaimLicense(ctx context.Context, cred *claimtypes.LicenseCredential,
+	claimer *didlib.DID) error {
+	err := s.checkDIDNotDeactivated(ctx, claimer)
+	if err != nil {
+		return errors.Wrap(err, "claimlicense.checkdidnotdeactivated")
+	}
+
 	didMt, err := s.BuildDIDMt(claimer)
 	if err != nil {
 		return errors.Wrap(err, "claimlicense.builddidMt")
 	}
 
-	verified, err := s.verifyCredential(cred)
+	verified, err := s.verifyCredential(ctx, cred, time.Now())
 	if err != nil {
 		return errors.Wrap(err, "ClaimLicense.verifycredential")
 	}
@@ -509,7 +639,7 @@ func (s *Service) ClaimLicense(cred *claimtypes.LicenseCredential, claimer *didl
 	if err != nil {
 		return errors.Wrap(err, "ClaimLicense.newclaimregistereddocument")
 	}
-	err = didMt.Add(claim.Entry())
+	err = s.AddToDIDTree(didMt, claimer, claim.Entry())
 	if err != nil {
 		return errors.Wrap(err, "ClaimLicense.add")
 	}
@@ -580,8 +710,8 @@ func (s *Service) ClaimsToContentCredentials(clms []merkletree.Claim) (
 				}
 			}
 
-		case *icore.ClaimAuthorizeKSignSecp256k1:
-			// Known claim type to ignore here
+		case *icore.ClaimAuthorizeKSignSecp256k1, *claimtypes.ClaimDIDDocumentVersion:
+			// Known claim types to ignore here
 
 		default:
 			log.Errorf("Unknown claim type, is %T", v)
@@ -613,3 +743,32 @@ func (s *Service) GetDIDRoot(did *didlib.DID) (*merkletree.Hash, error) {
 	}
 	return didMt.RootKey(), nil
 }
+
+// GetDIDRootClaim returns a version of the root claim of a did in the root tree
+// at root, or in the current root tree if root is nil. Returns
+// merkletree.ErrEntryIndexNotFound if the version is not in the tree.
+func (s *Service) GetDIDRootClaim(userDid *didlib.DID, version uint32,
+	root *merkletree.Hash) (*claimtypes.ClaimSetRootKeyDID, error) {
+	// the root tree is reopened as it may have been changed by another process
+	rootMt, err := merkletree.NewMerkleTree(s.treeStore.WithPrefix(claimsstore.PrefixRootMerkleTree), 150)
+	if err != nil {
+		return nil, errors.Wrap(err, "getdidrootclaim.newmerkletree")
+	}
+	if root != nil {
+		rootMt, err = rootMt.Snapshot(root)
+		if err != nil {
+			return nil, errors.Wrap(err, "getdidrootclaim.snapshot")
+		}
+	}
+
+	claim, err := claimtypes.NewClaimSetRootKeyDID(userDid, &merkletree.HashZero)
+	if err != nil {
+		return nil, errors.Wrap(err, "getdidrootclaim.newclaimsetrootkeydid")
+	}
+	claim.Version = version
+	data, err := rootMt.GetDataByIndex(claim.Entry().HIndex())
+	if err != nil {
+		return nil, err
+	}
+	return claimtypes.NewClaimSetRootKeyDIDFromEntry(&merkletree.Entry{Data: *data}), nil
+}
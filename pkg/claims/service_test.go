@@ -1,6 +1,7 @@
 package claims_test
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"encoding/hex"
 	"encoding/json"
@@ -21,6 +22,7 @@ import (
 	"github.com/joincivil/id-hub/pkg/testutils"
 	"github.com/multiformats/go-multihash"
 	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
 )
 
 func setupConnection() (*gorm.DB, error) {
@@ -28,8 +30,9 @@ func setupConnection() (*gorm.DB, error) {
 	if err != nil {
 		return nil, err
 	}
-	db.DropTable(&ethuri.PostgresDocument{}, &claimsstore.RootCommit{}, &claimsstore.Node{})
-	err = db.AutoMigrate(&ethuri.PostgresDocument{}, &claimsstore.SignedClaimPostgres{}, &claimsstore.Node{}, &claimsstore.RootCommit{}, &claimsstore.JWTClaimPostgres{}).Error
+	db.DropTable(&ethuri.PostgresDocument{}, &ethuri.PostgresDocumentVersion{}, &ethuri.PostgresDocumentIndex{}, &claimsstore.RootCommit{}, &claimsstore.Node{}, &claimsstore.Revocation{}, &claimsstore.TreeChange{})
+	err = db.AutoMigrate(&ethuri.PostgresDocument{}, &ethuri.PostgresDocumentVersion{}, &ethuri.PostgresDocumentIndex{}, &claimsstore.SignedClaimPostgres{}, &claimsstore.Node{}, &claimsstore.RootCommit{}, &claimsstore.JWTClaimPostgres{},
+		&claimsstore.Revocation{}, &claimsstore.TreeChange{}).Error
 	if err != nil {
 		return nil, err
 	}
@@ -187,7 +190,7 @@ func TestCreateTreeForDID(t *testing.T) {
 	}
 
 	// Return error since the user DID doesn't exist
-	err = claimService.CreateTreeForDID(userDID)
+	err = claimService.CreateTreeForDID(context.Background(), userDID)
 	if err == nil {
 		t.Errorf("should have returned an error since did does not exist")
 	}
@@ -210,12 +213,12 @@ func TestCreateTreeForDID(t *testing.T) {
 	if err != nil {
 		t.Errorf("error making the did doc: %v", err)
 	}
-	if err := ethURI.SaveDocument(didDoc); err != nil {
+	if err := ethURI.SaveDocument(didDoc, 0); err != nil {
 		t.Errorf("error saving the did doc: %v", err)
 	}
 
 	// Return no error since the user DID exists
-	err = claimService.CreateTreeForDID(userDID)
+	err = claimService.CreateTreeForDID(context.Background(), userDID)
 	if err != nil {
 		t.Errorf("should not have returned error: err: %v", err)
 	}
@@ -255,7 +258,7 @@ func TestClaimContent(t *testing.T) {
 	if err != nil {
 		t.Errorf("error making the did doc: %v", err)
 	}
-	if err := ethURI.SaveDocument(didDoc); err != nil {
+	if err := ethURI.SaveDocument(didDoc, 0); err != nil {
 		t.Errorf("error saving the did doc: %v", err)
 	}
 
@@ -265,7 +268,7 @@ func TestClaimContent(t *testing.T) {
 		t.Errorf("error adding proof: %v", err)
 	}
 
-	err = claimService.ClaimContent(cred)
+	err = claimService.ClaimContent(context.Background(), cred)
 	if err == nil {
 		t.Errorf("should have errored because couldn't resolv the key")
 	}
@@ -274,11 +277,11 @@ func TestClaimContent(t *testing.T) {
 	if err != nil {
 		t.Errorf("problem creating did tree: %v", err)
 	}
-	err = claimService.ClaimContent(cred)
+	err = claimService.ClaimContent(context.Background(), cred)
 	if err != nil {
 		t.Errorf("problem creating content claim: %v", err)
 	}
-	err = claimService.ClaimContent(cred)
+	err = claimService.ClaimContent(context.Background(), cred)
 	if err == nil {
 		t.Errorf("should err for duplicate claim")
 	}
@@ -293,7 +296,7 @@ func TestClaimContent(t *testing.T) {
 
 	linkedDataProof.ProofValue = "04e9627daa1419d73a7a3bdd9e907a9bf0ae4344149521d4b5d07377b589658265e705971b26da6d51bbea4ef7ecf5267f10437126add370f752a1b2f0af65c32f"
 	proofs[0] = linkedDataProof
-	err = claimService.ClaimContent(cred)
+	err = claimService.ClaimContent(context.Background(), cred)
 	if err == nil {
 		t.Errorf("should have errored for the bad signature")
 	}
@@ -370,7 +373,7 @@ func TestClaimsToContentCredentials(t *testing.T) {
 	if err != nil {
 		t.Errorf("error making the did doc: %v", err)
 	}
-	if err := ethURI.SaveDocument(didDoc); err != nil {
+	if err := ethURI.SaveDocument(didDoc, 0); err != nil {
 		t.Errorf("error saving the did doc: %v", err)
 	}
 
@@ -385,7 +388,7 @@ func TestClaimsToContentCredentials(t *testing.T) {
 	// Claim content 1
 	cred := makeContentCredential(&didDoc.ID)
 	_ = claims.AddProof(cred, didDoc.PublicKeys[0].ID, key)
-	err = claimService.ClaimContent(cred)
+	err = claimService.ClaimContent(context.Background(), cred)
 	if err != nil {
 		t.Errorf("problem creating content claim: %v", err)
 	}
@@ -442,7 +445,7 @@ func TestGenerateProof(t *testing.T) {
 	if err != nil {
 		t.Errorf("error making the did doc: %v", err)
 	}
-	if err := ethURI.SaveDocument(didDoc); err != nil {
+	if err := ethURI.SaveDocument(didDoc, 0); err != nil {
 		t.Errorf("error saving the did doc: %v", err)
 	}
 
@@ -463,7 +466,7 @@ func TestGenerateProof(t *testing.T) {
 	// Claim content 1
 	cred := makeContentCredential(&didDoc.ID)
 	_ = claims.AddProof(cred, didDoc.PublicKeys[0].ID, key)
-	err = claimService.ClaimContent(cred)
+	err = claimService.ClaimContent(context.Background(), cred)
 	if err != nil {
 		t.Errorf("problem creating content claim: %v", err)
 	}
@@ -594,7 +597,7 @@ func TestClaimLicense(t *testing.T) {
 	if err != nil {
 		t.Errorf("error making the did doc: %v", err)
 	}
-	if err := ethURI.SaveDocument(didDoc); err != nil {
+	if err := ethURI.SaveDocument(didDoc, 0); err != nil {
 		t.Errorf("error saving the did doc: %v", err)
 	}
 	subjDid, _ := ethuri.GenerateEthURIDID()
@@ -604,7 +607,7 @@ func TestClaimLicense(t *testing.T) {
 	if err != nil {
 		t.Errorf("error adding proof: %v", err)
 	}
-	err = claimService.ClaimLicense(license, signerDid)
+	err = claimService.ClaimLicense(context.Background(), license, signerDid)
 	if err == nil {
 		t.Errorf("should have errored because couldn't resolv the key")
 	}
@@ -613,12 +616,223 @@ func TestClaimLicense(t *testing.T) {
 	if err != nil {
 		t.Errorf("problem creating did tree: %v", err)
 	}
-	err = claimService.ClaimLicense(license, signerDid)
+	err = claimService.ClaimLicense(context.Background(), license, signerDid)
 	if err != nil {
 		t.Errorf("problem creating content claim: %v", err)
 	}
-	err = claimService.ClaimLicense(license, signerDid)
+	err = claimService.ClaimLicense(context.Background(), license, signerDid)
 	if err == nil {
 		t.Errorf("should err for duplicate claim")
 	}
 }
+
+func TestDeactivatedDID(t *testing.T) {
+	db, err := setupConnection()
+	if err != nil {
+		t.Fatalf("error setting up the db: %v", err)
+	}
+
+	cleaner := testutils.DeleteCreatedEntities(db)
+	defer cleaner()
+
+	// Setup
+	didService, ethURI := testinits.InitDIDService(db)
+	signedClaimStore := claimsstore.NewSignedClaimPGPersister(db)
+	claimService, _, err := testinits.MakeService(db, didService, signedClaimStore)
+	if err != nil {
+		t.Errorf("error setting up service: %v", err)
+	}
+
+	// Create a DID identity
+	key, err := crypto.HexToECDSA("79156abe7fe2fd433dc9df969286b96666489bac508612d0e16593e944c4f69f")
+	if err != nil {
+		t.Fatalf("should be able to make a key")
+	}
+	pubBytes := crypto.FromECDSAPub(&key.PublicKey)
+	pub := hex.EncodeToString(pubBytes)
+	docPubKey := &did.DocPublicKey{
+		Type:         linkeddata.SuiteTypeSecp256k1Verification,
+		PublicKeyHex: &pub,
+	}
+	signerDid, err := didlib.Parse("did:ethuri:e7ab0c43-d9fe-4a61-87a3-3fa99ce879e1")
+	if err != nil {
+		t.Errorf("error creating did: %v", err)
+	}
+	docPubKey.ID = signerDid
+	docPubKey.Controller = did.CopyDID(signerDid)
+	didDoc, err := ethuri.InitializeNewDocument(signerDid, docPubKey, true, true)
+	if err != nil {
+		t.Errorf("error making the did doc: %v", err)
+	}
+	if err := ethURI.SaveDocument(didDoc, 0); err != nil {
+		t.Errorf("error saving the did doc: %v", err)
+	}
+	err = claimService.CreateTreeForDIDWithPks(&didDoc.ID,
+		[]*ecdsa.PublicKey{&key.PublicKey})
+	if err != nil {
+		t.Errorf("problem creating did tree: %v", err)
+	}
+
+	cred := makeContentCredential(&didDoc.ID)
+	_ = claims.AddProof(cred, didDoc.PublicKeys[0].ID, key)
+	err = claimService.ClaimContent(context.Background(), cred)
+	if err != nil {
+		t.Fatalf("problem creating content claim: %v", err)
+	}
+
+	// Revoke every registered document
+	rootBefore, _ := claimService.GetDIDRoot(&didDoc.ID)
+	revoked, err := claimService.RevokeAllRegisteredDocuments(&didDoc.ID)
+	if err != nil {
+		t.Fatalf("should have revoked the documents: err: %v", err)
+	}
+	if revoked != 1 {
+		t.Errorf("should have revoked 1 document: %v", revoked)
+	}
+	rootAfter, _ := claimService.GetDIDRoot(&didDoc.ID)
+	if rootBefore.String() == rootAfter.String() {
+		t.Errorf("should have changed the did root")
+	}
+	_, err = claimService.GenerateProof(cred)
+	if err == nil {
+		t.Errorf("it should error if the claim is revoked")
+	}
+	revoked, err = claimService.RevokeAllRegisteredDocuments(&didDoc.ID)
+	if err != nil || revoked != 0 {
+		t.Errorf("should not have revoked documents again: err: %v", err)
+	}
+
+	// No new claims for a deactivated DID
+	err = ethuri.NewPostgresPersister(db).DeactivateDocument(&didDoc.ID)
+	if err != nil {
+		t.Fatalf("should have deactivated the did: err: %v", err)
+	}
+	cred = makeContentCredential(&didDoc.ID)
+	cred.CredentialSubject.ID = "https://ap.com/article/2"
+	_ = claims.AddProof(cred, didDoc.PublicKeys[0].ID, key)
+	err = claimService.ClaimContent(context.Background(), cred)
+	if errors.Cause(err) != did.ErrResolverDIDDeactivated {
+		t.Errorf("should not have claimed content for a deactivated did: err: %v", err)
+	}
+}
+
+func TestDocumentVersionProof(t *testing.T) {
+	db, err := setupConnection()
+	if err != nil {
+		t.Fatalf("error setting up the db: %v", err)
+	}
+
+	cleaner := testutils.DeleteCreatedEntities(db)
+	defer cleaner()
+
+	// Setup
+	didService, ethURI := testinits.InitDIDService(db)
+	signedClaimStore := claimsstore.NewSignedClaimPGPersister(db)
+	claimService, rootService, err := testinits.MakeService(db, didService, signedClaimStore)
+	if err != nil {
+		t.Fatalf("error setting up service: %v", err)
+	}
+	ethURI.SetDocumentAnchor(claimService)
+
+	// Create the did, which anchors the first version
+	key, err := crypto.HexToECDSA("79156abe7fe2fd433dc9df969286b96666489bac508612d0e16593e944c4f69f")
+	if err != nil {
+		t.Fatalf("should be able to make a key")
+	}
+	pub := hex.EncodeToString(crypto.FromECDSAPub(&key.PublicKey))
+	docPubKey := &did.DocPublicKey{
+		Type:         linkeddata.SuiteTypeSecp256k1Verification,
+		PublicKeyHex: &pub,
+	}
+	signerDid, err := didlib.Parse("did:ethuri:e7ab0c43-d9fe-4a61-87a3-3fa99ce879e1")
+	if err != nil {
+		t.Fatalf("error creating did: %v", err)
+	}
+	docPubKey.ID = signerDid
+	docPubKey.Controller = did.CopyDID(signerDid)
+	didDoc, err := ethuri.InitializeNewDocument(signerDid, docPubKey, true, true)
+	if err != nil {
+		t.Fatalf("error making the did doc: %v", err)
+	}
+	if err := ethURI.SaveDocument(didDoc, 0); err != nil {
+		t.Fatalf("error saving the did doc: %v", err)
+	}
+	first, err := ethURI.GetDocumentVersion(&didDoc.ID, 1)
+	if err != nil {
+		t.Fatalf("error getting the first version: %v", err)
+	}
+	err = rootService.CommitRoot()
+	if err != nil {
+		t.Fatalf("error committing root: %v", err)
+	}
+	firstCommit, err := rootService.GetLatest()
+	if err != nil {
+		t.Fatalf("error getting the commit: %v", err)
+	}
+
+	// Update the document, which anchors the second version
+	didDoc.Services = append(didDoc.Services, did.DocService{
+		ID:              *did.CopyDID(signerDid),
+		Type:            "TestService",
+		ServiceEndpoint: "https://example.com",
+	})
+	if err := ethURI.SaveDocument(didDoc, 1); err != nil {
+		t.Fatalf("error saving the did doc: %v", err)
+	}
+	second, err := ethURI.GetDocumentVersion(&didDoc.ID, 2)
+	if err != nil {
+		t.Fatalf("error getting the second version: %v", err)
+	}
+	err = rootService.CommitRoot()
+	if err != nil {
+		t.Fatalf("error committing root: %v", err)
+	}
+
+	proof, err := claimService.GenerateProofDocumentVersion(&didDoc.ID, 2, "")
+	if err != nil {
+		t.Fatalf("error generating proof: %v", err)
+	}
+	ok, err := claims.VerifyDocumentVersionProof(proof, second.Document)
+	if err != nil || !ok {
+		t.Errorf("the proof of the second version should verify: %v", err)
+	}
+	ok, err = claims.VerifyDocumentVersionProof(proof, first.Document)
+	if err != nil || ok {
+		t.Errorf("the proof should not verify for the first version: %v", err)
+	}
+
+	_, err = claimService.GenerateProofDocumentVersion(&didDoc.ID, 1, "")
+	if err != claims.ErrDocumentVersionNotCurrent {
+		t.Errorf("the first version should not be current at the latest root: %v", err)
+	}
+	_, err = claimService.GenerateProofDocumentVersion(&didDoc.ID, 3, "")
+	if err != claims.ErrDocumentVersionNotFound {
+		t.Errorf("the third version should not be found: %v", err)
+	}
+
+	proof, err = claimService.GenerateProofDocumentVersion(&didDoc.ID, 1, firstCommit.Root)
+	if err != nil {
+		t.Fatalf("error generating proof at the first root: %v", err)
+	}
+	ok, err = claims.VerifyDocumentVersionProof(proof, first.Document)
+	if err != nil || !ok {
+		t.Errorf("the proof of the first version at the first root should verify: %v", err)
+	}
+
+	// Retrying an anchored version does not add another root claim
+	rootClaims, err := claimService.GetRootMerkleTreeClaims()
+	if err != nil {
+		t.Fatalf("error getting the root claims: %v", err)
+	}
+	err = claimService.AnchorDocumentVersion(second.Document, 2)
+	if err != nil {
+		t.Errorf("retrying an anchored version should not fail: %v", err)
+	}
+	retriedClaims, err := claimService.GetRootMerkleTreeClaims()
+	if err != nil {
+		t.Fatalf("error getting the root claims: %v", err)
+	}
+	if len(retriedClaims) != len(rootClaims) {
+		t.Errorf("retrying an anchored version should not have added a root claim")
+	}
+}
@@ -0,0 +1,272 @@
+package claims
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iden3/go-iden3-core/db"
+	"github.com/iden3/go-iden3-core/merkletree"
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/claimsstore"
+)
+
+var (
+	// ErrTreeHeadNotSigned is returned when a root commit has no signed tree head
+	ErrTreeHeadNotSigned = errors.New("the root commit has no signed tree head")
+	// ErrTreeHeadBadSignature is returned when a tree head is not signed by the signer
+	ErrTreeHeadBadSignature = errors.New("the tree head signature does not match the signer")
+	// ErrConsistencyProofFailed is returned when a consistency proof does not
+	// prove the second root contains every root claim of the first
+	ErrConsistencyProofFailed = errors.New("the consistency proof does not verify")
+	// ErrTreeHeadSignerRequired is returned when a root is committed without a
+	// tree head signer
+	ErrTreeHeadSignerRequired = errors.New("a tree head signer is required to commit roots")
+	// ErrInvalidTreeRoot is returned when a root is not a 32 byte hex hash
+	ErrInvalidTreeRoot = errors.New("the root is not a valid hash")
+	// ErrRootCommitNotFound is returned when a root has not been committed
+	ErrRootCommitNotFound = errors.New("the root has not been committed")
+
+	treeHeadDomain = []byte("idhub-tree-head")
+)
+
+// SignedTreeHead is the root of the root tree, the number of root claims in it
+// and the time it was committed, signed by the hub
+type SignedTreeHead struct {
+	Root      string `json:"root"`
+	Size      uint64 `json:"size"`
+	Timestamp int64  `json:"timestamp"`
+	Signer    string `json:"signer"`
+	Signature string `json:"signature"`
+}
+
+// SignedTreeHeadFromCommit returns the signed tree head of a root commit
+func SignedTreeHeadFromCommit(commit *claimsstore.RootCommit) (*SignedTreeHead, error) {
+	if commit.TreeHeadSignature == "" {
+		return nil, ErrTreeHeadNotSigned
+	}
+	return &SignedTreeHead{
+		Root:      commit.Root,
+		Size:      commit.TreeSize,
+		Timestamp: commit.TreeHeadTimestamp,
+		Signer:    commit.TreeHeadSigner,
+		Signature: commit.TreeHeadSignature,
+	}, nil
+}
+
+// Verify checks the tree head is signed by its signer
+func (h *SignedTreeHead) Verify() error {
+	root, err := ParseTreeRoot(h.Root)
+	if err != nil {
+		return errors.Wrap(err, "verify.parsetreeroot")
+	}
+	sig, err := hex.DecodeString(strings.TrimPrefix(h.Signature, "0x"))
+	if err != nil {
+		return errors.Wrap(err, "verify.decodestring")
+	}
+	pubKey, err := crypto.SigToPub(treeHeadHash(root, h.Size, h.Timestamp), sig)
+	if err != nil {
+		return ErrTreeHeadBadSignature
+	}
+	if crypto.PubkeyToAddress(*pubKey) != common.HexToAddress(h.Signer) {
+		return ErrTreeHeadBadSignature
+	}
+	return nil
+}
+
+// TreeHeadSigner signs the tree heads of the root tree with the key of the hub
+type TreeHeadSigner struct {
+	privKey *ecdsa.PrivateKey
+}
+
+// NewTreeHeadSigner returns a new TreeHeadSigner
+func NewTreeHeadSigner(privKey *ecdsa.PrivateKey) *TreeHeadSigner {
+	return &TreeHeadSigner{privKey: privKey}
+}
+
+// Address returns the address of the signing key
+func (s *TreeHeadSigner) Address() common.Address {
+	return crypto.PubkeyToAddress(s.privKey.PublicKey)
+}
+
+// Sign returns the signed tree head for a root with size root claims at timestamp
+func (s *TreeHeadSigner) Sign(root *merkletree.Hash, size uint64, timestamp int64) (*SignedTreeHead, error) {
+	sig, err := crypto.Sign(treeHeadHash(root, size, timestamp), s.privKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "sign.sign")
+	}
+	return &SignedTreeHead{
+		Root:      root.Hex(),
+		Size:      size,
+		Timestamp: timestamp,
+		Signer:    s.Address().Hex(),
+		Signature: hex.EncodeToString(sig),
+	}, nil
+}
+
+// treeHeadHash is the keccak256 of the domain, the root and the big endian size
+// and timestamp
+func treeHeadHash(root *merkletree.Hash, size uint64, timestamp int64) []byte {
+	msg := make([]byte, 16)
+	binary.BigEndian.PutUint64(msg[:8], size)
+	binary.BigEndian.PutUint64(msg[8:], uint64(timestamp))
+	return crypto.Keccak256(treeHeadDomain, root[:], msg)
+}
+
+// ConsistencyProof proves the second root of the root tree contains every root
+// claim in the first. The sparse merkle tree has no compact consistency proof, so
+// it lists the root claims of the first root, which rebuild it, with a proof
+// each is in the second. The roots and sizes are bound to the signed tree heads
+// of their commits.
+type ConsistencyProof struct {
+	FirstRoot      string          `json:"firstRoot"`
+	FirstSize      uint64          `json:"firstSize"`
+	SecondRoot     string          `json:"secondRoot"`
+	SecondSize     uint64          `json:"secondSize"`
+	Entries        []string        `json:"entries"`
+	Proofs         []string        `json:"proofs"`
+	FirstTreeHead  *SignedTreeHead `json:"firstTreeHead"`
+	SecondTreeHead *SignedTreeHead `json:"secondTreeHead"`
+}
+
+// CountTreeLeaves returns the number of claims in a tree at root
+func CountTreeLeaves(tree *merkletree.MerkleTree, root *merkletree.Hash) (uint64, error) {
+	var count uint64
+	err := tree.Walk(root, func(n *merkletree.Node) {
+		if n.Type == merkletree.NodeTypeLeaf {
+			count++
+		}
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "counttreeleaves.walk")
+	}
+	return count, nil
+}
+
+// GenerateConsistencyProof returns a proof that the second root of the root tree
+// contains every root claim of the first
+func GenerateConsistencyProof(rootMt *merkletree.MerkleTree, first *merkletree.Hash,
+	second *merkletree.Hash) (*ConsistencyProof, error) {
+	entries, err := rootMt.DumpClaims(first)
+	if err != nil {
+		return nil, errors.Wrap(err, "generateconsistencyproof.dumpclaims")
+	}
+	secondSize, err := CountTreeLeaves(rootMt, second)
+	if err != nil {
+		return nil, errors.Wrap(err, "generateconsistencyproof.counttreeleaves")
+	}
+
+	proof := &ConsistencyProof{
+		FirstRoot:  first.Hex(),
+		FirstSize:  uint64(len(entries)),
+		SecondRoot: second.Hex(),
+		SecondSize: secondSize,
+		Entries:    make([]string, len(entries)),
+		Proofs:     make([]string, len(entries)),
+	}
+	for i, entryHex := range entries {
+		entry, err := entryFromHex(entryHex)
+		if err != nil {
+			return nil, errors.Wrap(err, "generateconsistencyproof.entryfromhex")
+		}
+		existsProof, err := rootMt.GenerateProof(entry.HIndex(), second)
+		if err != nil {
+			return nil, errors.Wrap(err, "generateconsistencyproof.generateproof")
+		}
+		if !existsProof.Existence {
+			return nil, errors.Errorf("root claim %v of the first root is not in the second", entryHex)
+		}
+		proof.Entries[i] = hex.EncodeToString(entry.Bytes())
+		proof.Proofs[i] = hex.EncodeToString(existsProof.Bytes())
+	}
+	return proof, nil
+}
+
+// VerifyConsistencyProof checks the tree heads of the proof are signed by signer,
+// the address of the hub tree head signer, and match the roots and sizes of the
+// proof, that the entries of the proof rebuild the first root and that each of
+// them is in the second root
+func VerifyConsistencyProof(proof *ConsistencyProof, signer string) error {
+	err := verifyProofTreeHead(proof.FirstTreeHead, proof.FirstRoot, proof.FirstSize, signer)
+	if err != nil {
+		return err
+	}
+	err = verifyProofTreeHead(proof.SecondTreeHead, proof.SecondRoot, proof.SecondSize, signer)
+	if err != nil {
+		return err
+	}
+
+	first, err := ParseTreeRoot(proof.FirstRoot)
+	if err != nil {
+		return errors.Wrap(err, "verifyconsistencyproof.parsetreeroot first")
+	}
+	second, err := ParseTreeRoot(proof.SecondRoot)
+	if err != nil {
+		return errors.Wrap(err, "verifyconsistencyproof.parsetreeroot second")
+	}
+	if uint64(len(proof.Entries)) != proof.FirstSize || len(proof.Proofs) != len(proof.Entries) ||
+		proof.SecondSize < proof.FirstSize {
+		return ErrConsistencyProofFailed
+	}
+
+	firstMt, err := merkletree.NewMerkleTree(db.NewMemoryStorage(), 150)
+	if err != nil {
+		return errors.Wrap(err, "verifyconsistencyproof.newmerkletree")
+	}
+	for i, entryHex := range proof.Entries {
+		entry, err := entryFromHex(entryHex)
+		if err != nil {
+			return errors.Wrap(err, "verifyconsistencyproof.entryfromhex")
+		}
+		err = firstMt.Add(entry)
+		if err != nil {
+			return ErrConsistencyProofFailed
+		}
+
+		proofb, err := hex.DecodeString(proof.Proofs[i])
+		if err != nil {
+			return errors.Wrap(err, "verifyconsistencyproof.decodestring")
+		}
+		existsProof, err := merkletree.NewProofFromBytes(proofb)
+		if err != nil {
+			return errors.Wrap(err, "verifyconsistencyproof.newprooffrombytes")
+		}
+		if !existsProof.Existence || !merkletree.VerifyProof(second, existsProof, entry.HIndex(), entry.HValue()) {
+			return ErrConsistencyProofFailed
+		}
+	}
+	if !bytes.Equal(firstMt.RootKey()[:], first[:]) {
+		return ErrConsistencyProofFailed
+	}
+	return nil
+}
+
+func verifyProofTreeHead(treeHead *SignedTreeHead, root string, size uint64, signer string) error {
+	if treeHead == nil {
+		return ErrTreeHeadNotSigned
+	}
+	if common.HexToAddress(treeHead.Signer) != common.HexToAddress(signer) {
+		return ErrTreeHeadBadSignature
+	}
+	err := treeHead.Verify()
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(treeHead.Root, root) || treeHead.Size != size {
+		return ErrConsistencyProofFailed
+	}
+	return nil
+}
+
+func entryFromHex(entryHex string) (*merkletree.Entry, error) {
+	entryb, err := hex.DecodeString(strings.TrimPrefix(entryHex, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	return merkletree.NewEntryFromBytes(entryb)
+}
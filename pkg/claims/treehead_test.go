@@ -0,0 +1,156 @@
+package claims_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iden3/go-iden3-core/db"
+	"github.com/iden3/go-iden3-core/merkletree"
+	"github.com/joincivil/go-common/pkg/lock"
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/claims"
+	"github.com/joincivil/id-hub/pkg/claimsstore"
+)
+
+func TestSignedTreeHead(t *testing.T) {
+	privKey, _ := crypto.GenerateKey()
+	signer := claims.NewTreeHeadSigner(privKey)
+	root := &merkletree.Hash{0x0b, 0x0c}
+
+	treeHead, err := signer.Sign(root, 3, 1570000000)
+	if err != nil {
+		t.Fatalf("Should have signed the tree head: err: %v", err)
+	}
+	if treeHead.Signer != crypto.PubkeyToAddress(privKey.PublicKey).Hex() {
+		t.Errorf("Should have set the signer address: %v", treeHead.Signer)
+	}
+	err = treeHead.Verify()
+	if err != nil {
+		t.Errorf("Should have verified the tree head: err: %v", err)
+	}
+
+	treeHead.Size = 4
+	err = treeHead.Verify()
+	if err != claims.ErrTreeHeadBadSignature {
+		t.Errorf("Should not have verified a changed tree head: err: %v", err)
+	}
+
+	_, err = claims.SignedTreeHeadFromCommit(&claimsstore.RootCommit{Root: root.Hex()})
+	if err != claims.ErrTreeHeadNotSigned {
+		t.Errorf("Should not have returned a tree head for an unsigned commit: err: %v", err)
+	}
+}
+
+func TestConsistencyProof(t *testing.T) {
+	dir, err := ioutil.TempDir("", "consistencytest")
+	if err != nil {
+		t.Fatalf("Should have created a temp dir: err: %v", err)
+	}
+	defer os.RemoveAll(dir) // nolint: errcheck
+	store, err := claimsstore.NewLevelDBStore(dir)
+	if err != nil {
+		t.Fatalf("Should have opened the store: err: %v", err)
+	}
+	defer store.Close()
+
+	claimService, err := claims.NewService(store, store, nil, nil, nil, nil, lock.NewLocalDLock())
+	if err != nil {
+		t.Fatalf("Should have created the service: err: %v", err)
+	}
+	var rootMt *merkletree.MerkleTree
+	roots := []*merkletree.Hash{}
+	for _, d := range []string{
+		"did:ethuri:e7ab0c43-d9fe-4a61-87a3-3fa99ce879e1",
+		"did:ethuri:0c2b5a8e-3f5a-4a4c-9c2a-8f4f0e7f7a11",
+		"did:ethuri:e7ab0c43-d9fe-4a61-87a3-3fa99ce879e1",
+	} {
+		userDid, _ := didlib.Parse(d)
+		err = claimService.AddNewRootClaim(userDid)
+		if err != nil {
+			t.Fatalf("Should have added the root claim: err: %v", err)
+		}
+		rootMt, err = merkletree.NewMerkleTree(store.WithPrefix(claimsstore.PrefixRootMerkleTree), 150)
+		if err != nil {
+			t.Fatalf("Should have opened the root tree: err: %v", err)
+		}
+		roots = append(roots, rootMt.RootKey())
+	}
+
+	size, err := claims.CountTreeLeaves(rootMt, roots[2])
+	if err != nil || size != 3 {
+		t.Errorf("Should have counted 3 root claims: %v, err: %v", size, err)
+	}
+
+	privKey, _ := crypto.GenerateKey()
+	signer := claims.NewTreeHeadSigner(privKey)
+	signedProof := func(first int, second int) *claims.ConsistencyProof {
+		proof, err := claims.GenerateConsistencyProof(rootMt, roots[first], roots[second])
+		if err != nil {
+			t.Fatalf("Should have generated the proof: err: %v", err)
+		}
+		proof.FirstTreeHead, _ = signer.Sign(roots[first], proof.FirstSize, 1570000000)
+		proof.SecondTreeHead, _ = signer.Sign(roots[second], proof.SecondSize, 1570000001)
+		return proof
+	}
+
+	proof := signedProof(0, 2)
+	if proof.FirstSize != 1 || proof.SecondSize != 3 {
+		t.Errorf("Should have set the sizes: %v, %v", proof.FirstSize, proof.SecondSize)
+	}
+	err = claims.VerifyConsistencyProof(proof, signer.Address().Hex())
+	if err != nil {
+		t.Errorf("Should have verified the proof: err: %v", err)
+	}
+
+	otherKey, _ := crypto.GenerateKey()
+	err = claims.VerifyConsistencyProof(proof, crypto.PubkeyToAddress(otherKey.PublicKey).Hex())
+	if err != claims.ErrTreeHeadBadSignature {
+		t.Errorf("Should not have verified tree heads of another signer: err: %v", err)
+	}
+
+	proof.SecondSize = 4
+	err = claims.VerifyConsistencyProof(proof, signer.Address().Hex())
+	if err != claims.ErrConsistencyProofFailed {
+		t.Errorf("Should not have verified a size not in the signed tree head: err: %v", err)
+	}
+
+	proof.SecondSize = 3
+	proof.SecondTreeHead = nil
+	err = claims.VerifyConsistencyProof(proof, signer.Address().Hex())
+	if err != claims.ErrTreeHeadNotSigned {
+		t.Errorf("Should not have verified a proof without tree heads: err: %v", err)
+	}
+
+	proof = signedProof(1, 2)
+	proof.Entries = proof.Entries[1:]
+	proof.Proofs = proof.Proofs[1:]
+	proof.FirstSize--
+	proof.FirstTreeHead, _ = signer.Sign(roots[1], proof.FirstSize, 1570000000)
+	err = claims.VerifyConsistencyProof(proof, signer.Address().Hex())
+	if err != claims.ErrConsistencyProofFailed {
+		t.Errorf("Should not have verified a proof missing a root claim: err: %v", err)
+	}
+
+	_, err = claims.GenerateConsistencyProof(rootMt, roots[2], roots[0])
+	if err == nil {
+		t.Errorf("Should not have generated a proof to an earlier root")
+	}
+}
+
+func TestConsistencyProofInvalidRoot(t *testing.T) {
+	rootService, _ := claims.NewRootService(db.NewMemoryStorage(), nil, nil, nil)
+	root := &merkletree.Hash{0x0b, 0x0c}
+
+	_, err := rootService.ConsistencyProof("notaroot", root.Hex())
+	if errors.Cause(err) != claims.ErrInvalidTreeRoot {
+		t.Errorf("Should have returned the invalid root error: err: %v", err)
+	}
+	_, err = rootService.ConsistencyProof(root.Hex(), "0x0b0c")
+	if errors.Cause(err) != claims.ErrInvalidTreeRoot {
+		t.Errorf("Should have returned the invalid root error for a short root: err: %v", err)
+	}
+}
@@ -0,0 +1,296 @@
+package claimsstore
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"sync/atomic"
+
+	log "github.com/golang/glog"
+	"github.com/iden3/go-iden3-core/db"
+	"github.com/iden3/go-iden3-core/merkletree"
+)
+
+const (
+	// NodeCacheMetricsName is the name the node cache stats are published with
+	// in expvar
+	NodeCacheMetricsName = "tree_node_cache"
+)
+
+// CacheStats are the hit and miss counts for a CachedStore
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+type cacheCounters struct {
+	hits   uint64
+	misses uint64
+}
+
+// CachedStore is an implementation of the iden3 storage interface that wraps
+// another storage with a read-through node cache. Only middle and leaf nodes are
+// cached, they are keyed by their hash so never change once written. The current
+// root entry of a tree is always read from the wrapped storage.
+type CachedStore struct {
+	storage  db.Storage
+	local    NodeCache
+	shared   NodeCache
+	prefix   []byte
+	counters *cacheCounters
+}
+
+// NewCachedStore returns a new cached store wrapping storage. local is checked
+// first, then shared if it is not nil. Usually local is an in process cache and
+// shared is a cache used by all instances of the hub.
+func NewCachedStore(storage db.Storage, local NodeCache, shared NodeCache) *CachedStore {
+	return &CachedStore{
+		storage:  storage,
+		local:    local,
+		shared:   shared,
+		counters: &cacheCounters{},
+	}
+}
+
+// NewTx creates a new transaction
+func (s *CachedStore) NewTx() (db.Tx, error) {
+	tx, err := s.storage.NewTx()
+	if err != nil {
+		return nil, err
+	}
+	return &CachedTx{Tx: tx, store: s}, nil
+}
+
+// WithPrefix returns a new instance of the cached store using the passed in prefix
+func (s *CachedStore) WithPrefix(prefix []byte) db.Storage {
+	return &CachedStore{
+		storage:  s.storage.WithPrefix(prefix),
+		local:    s.local,
+		shared:   s.shared,
+		prefix:   Concat(s.prefix, prefix),
+		counters: s.counters,
+	}
+}
+
+// Get gets the data from a node with the given key from the cache or the wrapped storage
+func (s *CachedStore) Get(b []byte) ([]byte, error) {
+	if value, ok := s.getCached(b); ok {
+		return value, nil
+	}
+
+	value, err := s.storage.Get(b)
+	if err != nil {
+		return nil, err
+	}
+	s.setCached(b, value)
+	return value, nil
+}
+
+// List lists all nodes for prefix/did
+func (s *CachedStore) List(limit int) ([]db.KV, error) {
+	return s.storage.List(limit)
+}
+
+// Close closes the wrapped storage
+func (s *CachedStore) Close() {
+	s.storage.Close()
+}
+
+// Info prints general information about all trees in the db and the cache stats
+func (s *CachedStore) Info() string {
+	stats, _ := json.MarshalIndent(s.Stats(), "", "  ")
+	return fmt.Sprintf("%v\n%v", s.storage.Info(), string(stats))
+}
+
+// Iterate performs a function on all nodes in all trees
+func (s *CachedStore) Iterate(f func([]byte, []byte) (bool, error)) error {
+	return s.storage.Iterate(f)
+}
+
+// IterateTrees performs a function on all nodes in all trees if the wrapped
+// storage is a TreeIterator
+func (s *CachedStore) IterateTrees(f func([]byte, []byte, []byte) (bool, error)) error {
+	iterator, ok := s.storage.(TreeIterator)
+	if !ok {
+		return ErrTreeIteratorNotSupported
+	}
+	return iterator.IterateTrees(f)
+}
+
+// DeleteTreeNodes deletes nodes from the wrapped storage if it is a
+// TreeNodeDeleter. Deleted nodes are left in the caches until they expire, they
+// are unreachable so are never read.
+func (s *CachedStore) DeleteTreeNodes(prefix []byte, keys [][]byte) error {
+	deleter, ok := s.storage.(TreeNodeDeleter)
+	if !ok {
+		return ErrTreeNodeDeleterNotSupported
+	}
+	return deleter.DeleteTreeNodes(prefix, keys)
+}
+
+// RecordChange records a change with the wrapped storage if it is a TreeChangeRecorder
+func (s *CachedStore) RecordChange(prefix []byte, entry *merkletree.Entry, change *TreeChange) func() error {
+	recorder, ok := s.storage.(TreeChangeRecorder)
+	if !ok {
+		return func() error { return ErrTreeChangeLedgerNotSupported }
+	}
+	return recorder.RecordChange(prefix, entry, change)
+}
+
+// GetTreeChanges returns the changes for a did from the wrapped storage if it
+// is a TreeChangeLedger
+func (s *CachedStore) GetTreeChanges(did string, offset int, limit int) ([]*TreeChange, error) {
+	ledger, ok := s.storage.(TreeChangeLedger)
+	if !ok {
+		return nil, ErrTreeChangeLedgerNotSupported
+	}
+	return ledger.GetTreeChanges(did, offset, limit)
+}
+
+// CountTreeChanges returns the number of changes for a did from the wrapped
+// storage if it is a TreeChangeLedger
+func (s *CachedStore) CountTreeChanges(did string) (int, error) {
+	ledger, ok := s.storage.(TreeChangeLedger)
+	if !ok {
+		return 0, ErrTreeChangeLedgerNotSupported
+	}
+	return ledger.CountTreeChanges(did)
+}
+
+// Stats returns the hit and miss counts of the cache, shared by all
+// prefixed instances of the store
+func (s *CachedStore) Stats() CacheStats {
+	return CacheStats{
+		Hits:   atomic.LoadUint64(&s.counters.hits),
+		Misses: atomic.LoadUint64(&s.counters.misses),
+	}
+}
+
+// PublishStats publishes the stats of the cache in expvar with name. Only the
+// first store published with a name is kept.
+func (s *CachedStore) PublishStats(name string) {
+	if expvar.Get(name) != nil {
+		log.Errorf("Node cache stats already published as %v", name)
+		return
+	}
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return s.Stats()
+	}))
+}
+
+func (s *CachedStore) getCached(b []byte) ([]byte, bool) {
+	key := Concat(s.prefix, b)
+
+	value, err := s.local.Get(key)
+	if err == nil {
+		atomic.AddUint64(&s.counters.hits, 1)
+		return value, true
+	}
+	if err != ErrNodeCacheMiss {
+		log.Errorf("Error getting node from local cache: err: %v", err)
+	}
+
+	if s.shared != nil {
+		value, err = s.shared.Get(key)
+		if err == nil {
+			atomic.AddUint64(&s.counters.hits, 1)
+			s.setLocal(key, value)
+			return value, true
+		}
+		if err != ErrNodeCacheMiss {
+			log.Errorf("Error getting node from shared cache: err: %v", err)
+		}
+	}
+
+	atomic.AddUint64(&s.counters.misses, 1)
+	return nil, false
+}
+
+func (s *CachedStore) setCached(b []byte, value []byte) {
+	s.setCachedKey(Concat(s.prefix, b), value)
+}
+
+// setCachedKey sets the value in the caches for the full key including the prefix
+func (s *CachedStore) setCachedKey(key []byte, value []byte) {
+	if !cacheableNode(value) {
+		return
+	}
+
+	s.setLocal(key, value)
+	if s.shared != nil {
+		if err := s.shared.Set(key, value); err != nil {
+			log.Errorf("Error setting node in shared cache: err: %v", err)
+		}
+	}
+}
+
+func (s *CachedStore) setLocal(key []byte, value []byte) {
+	if err := s.local.Set(key, value); err != nil {
+		log.Errorf("Error setting node in local cache: err: %v", err)
+	}
+}
+
+// cacheableNode returns true if the value is a middle or leaf node. Any other
+// value, like the current root of a tree, can change and is not cached.
+func cacheableNode(value []byte) bool {
+	if len(value) == 0 {
+		return false
+	}
+	nodeType := merkletree.NodeType(value[0])
+	return nodeType == merkletree.NodeTypeMiddle || nodeType == merkletree.NodeTypeLeaf
+}
+
+// CachedTx implements the iden3 transaction interface for a CachedStore. Nodes
+// written in the transaction are added to the cache once it is committed.
+type CachedTx struct {
+	db.Tx
+	store *CachedStore
+	puts  []db.KV
+}
+
+// Get returns the data from a node that is either in the cache, the transaction
+// or the wrapped storage
+func (t *CachedTx) Get(b []byte) ([]byte, error) {
+	if value, ok := t.store.getCached(b); ok {
+		return value, nil
+	}
+	return t.Tx.Get(b)
+}
+
+// Put adds a new node to the transaction
+func (t *CachedTx) Put(k, v []byte) {
+	t.Tx.Put(k, v)
+	t.puts = append(t.puts, db.KV{K: Concat(t.store.prefix, k), V: v})
+}
+
+// Add copies all nodes from one transaction to this one
+func (t *CachedTx) Add(atx db.Tx) {
+	ctx, ok := atx.(*CachedTx)
+	if !ok {
+		t.Tx.Add(atx)
+		return
+	}
+	t.Tx.Add(ctx.Tx)
+	t.puts = append(t.puts, ctx.puts...)
+}
+
+// Commit writes all nodes in the transaction to the wrapped storage and then
+// adds them to the cache
+func (t *CachedTx) Commit() error {
+	err := t.Tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	for _, kv := range t.puts {
+		t.store.setCachedKey(kv.K, kv.V)
+	}
+	t.puts = nil
+	return nil
+}
+
+// Close closes the transaction
+func (t *CachedTx) Close() {
+	t.Tx.Close()
+	t.puts = nil
+}
@@ -0,0 +1,105 @@
+package claimsstore_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+
+	"github.com/allegro/bigcache"
+	"github.com/iden3/go-iden3-core/db"
+	"github.com/iden3/go-iden3-core/merkletree"
+	"github.com/joincivil/id-hub/pkg/claimsstore"
+	"github.com/joincivil/id-hub/pkg/claimtypes"
+	didlib "github.com/ockam-network/did"
+	"github.com/stretchr/testify/assert"
+)
+
+func cachedStore(t *testing.T) (*claimsstore.CachedStore, *db.MemoryStorage) {
+	bcache, err := bigcache.NewBigCache(claimsstore.DefaultNodeBigCacheConfig)
+	if err != nil {
+		t.Fatalf("Should have created the bigcache: err: %v", err)
+	}
+	storage := db.NewMemoryStorage()
+	return claimsstore.NewCachedStore(storage, claimsstore.NewBigCacheNodeCache(bcache), nil), storage
+}
+
+func TestCachedStore(t *testing.T) {
+	store, _ := cachedStore(t)
+
+	testReturnKnownErrIfNotExists(t, store)
+	testStorageInsertGet(t, store)
+	testStorageWithPrefix(t, store)
+	testConcatTx(t, store)
+}
+
+func TestCachedStoreMerkleTree(t *testing.T) {
+	store, _ := cachedStore(t)
+	didStore := store.WithPrefix([]byte("did:ethuri:123456"))
+
+	mt, err := merkletree.NewMerkleTree(didStore, 150)
+	if err != nil {
+		t.Fatalf("Should have created the merkle tree: err: %v", err)
+	}
+
+	userDID, _ := didlib.Parse("did:ethuri:123456")
+	for i := 0; i < 4; i++ {
+		hash := [34]byte{1, 2, 3, 4, 5, byte(i)}
+		claim, err := claimtypes.NewClaimRegisteredDocument(hash, userDID, claimtypes.ContentCredentialDocType)
+		if err != nil {
+			t.Fatalf("Should have created the claim: err: %v", err)
+		}
+		err = mt.Add(claim.Entry())
+		if err != nil {
+			t.Fatalf("Should have added the claim: err: %v", err)
+		}
+	}
+
+	_, err = mt.DumpClaims(mt.RootKey())
+	if err != nil {
+		t.Fatalf("Should have dumped the claims: err: %v", err)
+	}
+	stats := store.Stats()
+	if stats.Hits == 0 {
+		t.Errorf("Should have had cache hits for nodes added in committed transactions")
+	}
+
+	misses := stats.Misses
+	_, err = mt.DumpClaims(mt.RootKey())
+	if err != nil {
+		t.Fatalf("Should have dumped the claims: err: %v", err)
+	}
+	if store.Stats().Misses != misses {
+		t.Errorf("Should have not had any misses walking the tree a second time")
+	}
+
+	// The current root can change, so it should always be read from the storage
+	reloaded, err := merkletree.NewMerkleTree(didStore, 150)
+	if err != nil {
+		t.Fatalf("Should have loaded the merkle tree: err: %v", err)
+	}
+	assert.Equal(t, mt.RootKey(), reloaded.RootKey())
+	if store.Stats().Misses != misses+1 {
+		t.Errorf("Should have missed the cache for the current root")
+	}
+}
+
+func TestCachedStorePublishStats(t *testing.T) {
+	store, _ := cachedStore(t)
+	store.PublishStats("test_tree_node_cache")
+	testStorageInsertGet(t, store)
+
+	published := expvar.Get("test_tree_node_cache")
+	if published == nil {
+		t.Fatalf("Should have published the stats")
+	}
+	stats := &claimsstore.CacheStats{}
+	err := json.Unmarshal([]byte(published.String()), stats)
+	if err != nil {
+		t.Fatalf("Should have unmarshalled the stats: err: %v", err)
+	}
+	assert.Equal(t, store.Stats(), *stats)
+
+	// Publishing another store with the name keeps the first one
+	other, _ := cachedStore(t)
+	other.PublishStats("test_tree_node_cache")
+}
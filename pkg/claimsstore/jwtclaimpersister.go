@@ -1,6 +1,7 @@
 package claimsstore
 
 import (
+	"context"
 	"encoding/hex"
 
 	"github.com/dgrijalva/jwt-go"
@@ -77,8 +78,8 @@ func NewJWTClaimPGPersister(db *gorm.DB, didJWTService *didjwt.Service) *JWTClai
 }
 
 // AddJWT adds a new jwt claim to the db
-func (p *JWTClaimPGPersister) AddJWT(tokenString string, senderDID *didlib.DID) (*jwt.Token, string, error) {
-	token, err := p.didJWTService.ParseJWT(tokenString)
+func (p *JWTClaimPGPersister) AddJWT(ctx context.Context, tokenString string, senderDID *didlib.DID) (*jwt.Token, string, error) {
+	token, err := p.didJWTService.ParseJWT(ctx, tokenString)
 	if err != nil {
 		return nil, "", errors.Wrap(err, "addJWT failed to parse token")
 	}
@@ -99,7 +100,7 @@ func (p *JWTClaimPGPersister) AddJWT(tokenString string, senderDID *didlib.DID)
 }
 
 // GetJWTByHash returns a jwt from it's hash
-func (p *JWTClaimPGPersister) GetJWTByHash(hash string) (*jwt.Token, error) {
+func (p *JWTClaimPGPersister) GetJWTByHash(ctx context.Context, hash string) (*jwt.Token, error) {
 	bytes, err := hex.DecodeString(hash)
 	if err != nil {
 		return nil, errors.Wrap(err, "GetJWTByHash failed to decode hash")
@@ -111,16 +112,44 @@ func (p *JWTClaimPGPersister) GetJWTByHash(hash string) (*jwt.Token, error) {
 	}
 
 	mHashString := hex.EncodeToString(mHash)
-	return p.GetJWTByMultihash(mHashString)
+	return p.GetJWTByMultihash(ctx, mHashString)
 }
 
 // GetJWTByMultihash returns a jwt from it's multihash
-func (p *JWTClaimPGPersister) GetJWTByMultihash(mHash string) (*jwt.Token, error) {
+func (p *JWTClaimPGPersister) GetJWTByMultihash(ctx context.Context, mHash string) (*jwt.Token, error) {
 	jwtClaim := &JWTClaimPostgres{}
 	if err := p.db.Where(&JWTClaimPostgres{Hash: mHash}).First(jwtClaim).Error; err != nil {
 		return nil, errors.Wrap(err, "GetJWTByMultihash failed to find claim")
 	}
-	return p.didJWTService.ParseJWT(jwtClaim.JWT)
+	return p.didJWTService.ParseJWT(ctx, jwtClaim.JWT)
+}
+
+// GetJWTClaimByMultihash returns the stored jwt claim for a multihash without
+// parsing the jwt
+func (p *JWTClaimPGPersister) GetJWTClaimByMultihash(mHash string) (*JWTClaimPostgres, error) {
+	jwtClaim := &JWTClaimPostgres{}
+	if err := p.db.Where(&JWTClaimPostgres{Hash: mHash}).First(jwtClaim).Error; err != nil {
+		return nil, err
+	}
+	return jwtClaim, nil
+}
+
+// JWTExists returns true if a jwt with the multihash is stored
+func (p *JWTClaimPGPersister) JWTExists(mHash string) (bool, error) {
+	var count int
+	if err := p.db.Model(&JWTClaimPostgres{}).Where(&JWTClaimPostgres{Hash: mHash}).Count(&count).Error; err != nil {
+		return false, errors.Wrap(err, "JWTExists failed to count claims")
+	}
+	return count > 0, nil
+}
+
+// GetAllJWTs returns every jwt claim ordered by the time it was issued
+func (p *JWTClaimPGPersister) GetAllJWTs() ([]*JWTClaimPostgres, error) {
+	claims := []*JWTClaimPostgres{}
+	if err := p.db.Order("issued_at asc").Find(&claims).Error; err != nil {
+		return nil, errors.Wrap(err, "GetAllJWTs failed to find claims")
+	}
+	return claims, nil
 }
 
 // GetJWTBySubjectsOrIssuers takes a list of subjects
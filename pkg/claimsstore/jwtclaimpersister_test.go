@@ -1,6 +1,7 @@
 package claimsstore_test
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"encoding/hex"
 	"testing"
@@ -31,7 +32,7 @@ func makeDID(id *didlib.DID, key *ecdsa.PublicKey, ethURIRes *ethuri.Service) er
 	if err != nil {
 		return err
 	}
-	if err := ethURIRes.SaveDocument(didDoc); err != nil {
+	if err := ethURIRes.SaveDocument(didDoc, 0); err != nil {
 		return err
 	}
 	return nil
@@ -128,7 +129,7 @@ func TestAddJWT(t *testing.T) {
 		t.Errorf("error creating token string: %v", err)
 	}
 
-	_, hash, err := jwtClaimPersister.AddJWT(tokenS, senderDID)
+	_, hash, err := jwtClaimPersister.AddJWT(context.Background(), tokenS, senderDID)
 	if err != nil {
 		t.Errorf("error adding the jwt: %v", err)
 	}
@@ -141,7 +142,7 @@ func TestAddJWT(t *testing.T) {
 		t.Errorf("error creating token string: %v", err)
 	}
 
-	_, _, err = jwtClaimPersister.AddJWT(tokenS, senderDID)
+	_, _, err = jwtClaimPersister.AddJWT(context.Background(), tokenS, senderDID)
 	if err != nil {
 		t.Errorf("error adding the jwt: %v", err)
 	}
@@ -154,7 +155,7 @@ func TestAddJWT(t *testing.T) {
 		t.Errorf("error creating token string: %v", err)
 	}
 
-	_, _, err = jwtClaimPersister.AddJWT(tokenS, senderDID)
+	_, _, err = jwtClaimPersister.AddJWT(context.Background(), tokenS, senderDID)
 	if err != nil {
 		t.Errorf("error adding the jwt: %v", err)
 	}
@@ -167,12 +168,12 @@ func TestAddJWT(t *testing.T) {
 		t.Errorf("error creating token string: %v", err)
 	}
 
-	_, _, err = jwtClaimPersister.AddJWT(tokenS, senderDID)
+	_, _, err = jwtClaimPersister.AddJWT(context.Background(), tokenS, senderDID)
 	if err != nil {
 		t.Errorf("error adding the jwt: %v", err)
 	}
 
-	retrievedToken, err := jwtClaimPersister.GetJWTByMultihash(hash)
+	retrievedToken, err := jwtClaimPersister.GetJWTByMultihash(context.Background(), hash)
 	if err != nil {
 		t.Errorf("error retrieving jwt by hash: %v", err)
 	}
@@ -0,0 +1,427 @@
+package claimsstore
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	log "github.com/golang/glog"
+	"github.com/iden3/go-iden3-core/db"
+	"github.com/iden3/go-iden3-core/merkletree"
+	"github.com/joincivil/id-hub/pkg/claimtypes"
+	didlib "github.com/ockam-network/did"
+	"github.com/syndtr/goleveldb/leveldb"
+	lerrors "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+var (
+	// levelDBNodeNamespace is prepended to the keys of all the nodes
+	levelDBNodeNamespace = []byte("n/")
+	// levelDBIndexNamespace is prepended to the keys of the leaf index entries
+	levelDBIndexNamespace = []byte("i/")
+	// levelDBIndexSeparator separates the tree prefix from the rest of an index key
+	levelDBIndexSeparator = []byte{0}
+	// levelDBChangeNamespace is prepended to the keys of the change ledger entries
+	levelDBChangeNamespace = []byte("c/")
+	// levelDBChangeSeqKey is the key of the id of the last change ledger entry
+	levelDBChangeSeqKey = []byte("s/changes")
+)
+
+// LevelDBStore is an implementation of the iden3 storage interface that uses an
+// embedded leveldb as its backend. Leaf nodes with a DID are indexed by tree
+// prefix, claim type, DID and version, the same columns the postgres store
+// queries to find root claims. The change ledger is kept in the same db, keyed by
+// did and id.
+type LevelDBStore struct {
+	ldb     *leveldb.DB
+	prefix  []byte
+	changes *pendingChanges
+	ledger  *levelDBLedger
+}
+
+// levelDBLedger holds the id of the last change ledger entry
+type levelDBLedger struct {
+	sync.Mutex
+	seq uint64
+}
+
+// NewLevelDBStore opens or creates a leveldb at path and returns a new store
+func NewLevelDBStore(path string) (*LevelDBStore, error) {
+	ldb, err := leveldb.OpenFile(path, &opt.Options{})
+	if err != nil {
+		return nil, err
+	}
+	ledger := &levelDBLedger{}
+	seq, err := ldb.Get(levelDBChangeSeqKey, nil)
+	if err == nil {
+		ledger.seq = binary.BigEndian.Uint64(seq)
+	} else if err != lerrors.ErrNotFound {
+		return nil, err
+	}
+	return &LevelDBStore{ldb: ldb, changes: newPendingChanges(), ledger: ledger}, nil
+}
+
+// NewTx creates a new transaction
+func (s *LevelDBStore) NewTx() (db.Tx, error) {
+	return &LevelDBTX{s, newKvMap()}, nil
+}
+
+// WithPrefix returns a new instance of the store using the passed in prefix
+func (s *LevelDBStore) WithPrefix(prefix []byte) db.Storage {
+	return &LevelDBStore{
+		ldb:     s.ldb,
+		prefix:  Concat(s.prefix, prefix),
+		changes: s.changes,
+		ledger:  s.ledger,
+	}
+}
+
+// Get gets the data from a node with the given key from the db
+func (s *LevelDBStore) Get(b []byte) ([]byte, error) {
+	value, err := s.ldb.Get(levelDBNodeKey(Concat(s.prefix, b)), nil)
+	if err == lerrors.ErrNotFound {
+		return nil, db.ErrNotFound
+	}
+	return value, err
+}
+
+// List lists all nodes for prefix/did
+func (s *LevelDBStore) List(limit int) ([]db.KV, error) {
+	kvs := []db.KV{}
+	err := s.iteratePrefix(s.prefix, func(k []byte, v []byte) (bool, error) {
+		kvs = append(kvs, db.KV{K: k, V: v})
+		return len(kvs) != limit, nil
+	})
+	return kvs, err
+}
+
+// Close closes the db
+func (s *LevelDBStore) Close() {
+	err := s.ldb.Close()
+	if err != nil {
+		log.Errorf("Error closing leveldb: err: %v", err)
+	}
+}
+
+// Info prints general information about all trees in the db
+func (s *LevelDBStore) Info() string {
+	info := storageInfo{}
+	err := s.iteratePrefix([]byte{}, func(k []byte, v []byte) (bool, error) {
+		info.KeyCount++
+		if len(v) > 0 && merkletree.NodeType(v[0]) == merkletree.NodeTypeLeaf {
+			info.ClaimCount++
+		}
+		return true, nil
+	})
+	if err != nil {
+		return err.Error()
+	}
+	json, _ := json.MarshalIndent(info, "", "  ")
+	return string(json)
+}
+
+// Iterate performs a function on all nodes in all trees
+func (s *LevelDBStore) Iterate(f func([]byte, []byte) (bool, error)) error {
+	// WARNING iterate doesn't use the prefix, same as the postgres store
+	return s.iteratePrefix([]byte{}, f)
+}
+
+// iteratePrefix calls f with the key, minus the prefix, and value of every node
+// with keys starting with prefix
+func (s *LevelDBStore) iteratePrefix(prefix []byte, f func([]byte, []byte) (bool, error)) error {
+	iter := s.ldb.NewIterator(util.BytesPrefix(levelDBNodeKey(prefix)), nil)
+	defer iter.Release()
+
+	keyStart := len(levelDBNodeNamespace) + len(prefix)
+	for iter.Next() {
+		k := append([]byte{}, iter.Key()[keyStart:]...)
+		v := append([]byte{}, iter.Value()...)
+		if cont, err := f(k, v); err != nil {
+			return err
+		} else if !cont {
+			break
+		}
+	}
+	return iter.Error()
+}
+
+// IterateTrees performs a function on all nodes in all trees, passing the
+// prefix of the tree each node is in. The prefix is split from the key using the
+// length of the merkle tree keys, all nodes have 32 byte keys apart from the
+// current root of each tree. Only the root tree and did trees are iterated.
+func (s *LevelDBStore) IterateTrees(f func([]byte, []byte, []byte) (bool, error)) error {
+	return s.iteratePrefix([]byte{}, func(k []byte, v []byte) (bool, error) {
+		keyLen := merkletree.ElemBytesLen
+		if len(v) > 0 && merkletree.NodeType(v[0]) == merkletree.DBEntryTypeRoot &&
+			bytes.HasSuffix(k, currentRootKey) {
+			keyLen = len(currentRootKey)
+		}
+		if len(k) < keyLen {
+			keyLen = len(k)
+		}
+		prefix := k[:len(k)-keyLen]
+		if !isTreePrefix(prefix) {
+			return true, nil
+		}
+		return f(prefix, k[len(k)-keyLen:], v)
+	})
+}
+
+// GetNextRootClaimVersion gets the next root claim version for a did. Returns 0
+// if there are no root claims for the did.
+func (s *LevelDBStore) GetNextRootClaimVersion(did *didlib.DID) (uint32, error) {
+	nodes, err := s.GetLatestRootClaimNodes(did)
+	if err != nil {
+		return 0, err
+	}
+	if len(*nodes) == 0 {
+		return 0, nil
+	}
+	return (*nodes)[0].ClaimVersion + 1, nil
+}
+
+// GetLatestRootClaimNodes returns the rootclaims nodes for a did sorted by their version number
+func (s *LevelDBStore) GetLatestRootClaimNodes(did *didlib.DID) (*[]Node, error) {
+	didbytes, err := claimtypes.HashDID(did)
+	if err != nil {
+		return nil, err
+	}
+	indexPrefix := levelDBIndexKeyPrefix(
+		PrefixRootMerkleTree,
+		hex.EncodeToString(claimtypes.ClaimTypeSetRootKeyDID[:]),
+		hex.EncodeToString(didbytes),
+	)
+
+	nodes := []Node{}
+	iter := s.ldb.NewIterator(util.BytesPrefix(indexPrefix), nil)
+	defer iter.Release()
+
+	// Index keys end with the big endian version, so the last is the latest
+	for ok := iter.Last(); ok; ok = iter.Prev() {
+		fullKey := iter.Value()
+		value, err := s.ldb.Get(levelDBNodeKey(fullKey), nil)
+		if err != nil {
+			return nil, err
+		}
+		node := Node{NodeKey: hex.EncodeToString(fullKey)}
+		kv := db.KV{K: fullKey[len(PrefixRootMerkleTree):], V: value}
+		err = node.UpdateKVAndPrefix(kv, PrefixRootMerkleTree)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	return &nodes, nil
+}
+
+// GetLatestRootClaimInSnapshot returns the root claim in the snapshot with the highest version number
+func (s *LevelDBStore) GetLatestRootClaimInSnapshot(did *didlib.DID,
+	tree *merkletree.MerkleTree) (*claimtypes.ClaimSetRootKeyDID, error) {
+	nodes, err := s.GetLatestRootClaimNodes(did)
+	if err != nil {
+		return nil, err
+	}
+	return latestRootClaimInSnapshot(*nodes, tree)
+}
+
+// DeleteTreeNodes deletes the nodes with the keys from the tree with the prefix
+// along with their index entries
+func (s *LevelDBStore) DeleteTreeNodes(prefix []byte, keys [][]byte) error {
+	treePrefix := Concat(s.prefix, prefix)
+	batch := new(leveldb.Batch)
+	for _, key := range keys {
+		fullKey := Concat(treePrefix, key)
+		value, err := s.ldb.Get(levelDBNodeKey(fullKey), nil)
+		if err == lerrors.ErrNotFound {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		node := Node{}
+		err = node.UpdateKVAndPrefix(db.KV{K: key, V: value}, treePrefix)
+		if err != nil {
+			return err
+		}
+		if node.NodeType == LeafNode && node.DID != "" {
+			batch.Delete(levelDBIndexKey(treePrefix, node.ClaimType, node.DID, node.ClaimVersion))
+		}
+		batch.Delete(levelDBNodeKey(fullKey))
+	}
+	return s.ldb.Write(batch, nil)
+}
+
+// RecordChange records change in the ledger in the same batch as the nodes of
+// entry when it is added to the tree with prefix
+func (s *LevelDBStore) RecordChange(prefix []byte, entry *merkletree.Entry, change *TreeChange) func() error {
+	return s.changes.add(Concat(s.prefix, prefix), entry, change)
+}
+
+// GetTreeChanges returns the changes for a did, oldest first
+func (s *LevelDBStore) GetTreeChanges(did string, offset int, limit int) ([]*TreeChange, error) {
+	changes := []*TreeChange{}
+	iter := s.ldb.NewIterator(util.BytesPrefix(levelDBChangeKeyPrefix(did)), nil)
+	defer iter.Release()
+
+	for skipped := 0; iter.Next(); {
+		if skipped < offset {
+			skipped++
+			continue
+		}
+		change := &TreeChange{}
+		err := json.Unmarshal(iter.Value(), change)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+		if len(changes) == limit {
+			break
+		}
+	}
+	return changes, iter.Error()
+}
+
+// CountTreeChanges returns the number of changes for a did
+func (s *LevelDBStore) CountTreeChanges(did string) (int, error) {
+	iter := s.ldb.NewIterator(util.BytesPrefix(levelDBChangeKeyPrefix(did)), nil)
+	defer iter.Release()
+
+	count := 0
+	for iter.Next() {
+		count++
+	}
+	return count, iter.Error()
+}
+
+// LevelDBTX implements the iden3 transaction interface to use a leveldb store
+type LevelDBTX struct {
+	*LevelDBStore
+	cache *kvMap
+}
+
+// Get returns the data from a node that is either in the cache or in the db
+func (t *LevelDBTX) Get(b []byte) ([]byte, error) {
+	if value, ok := t.cache.Get(Concat(t.prefix, b)); ok {
+		return value, nil
+	}
+	return t.LevelDBStore.Get(b)
+}
+
+// Put adds a new node to the cache
+func (t *LevelDBTX) Put(k, v []byte) {
+	t.cache.Put(Concat(t.prefix, k[:]), v)
+}
+
+// Add copies all nodes from one transaction to this one
+func (t *LevelDBTX) Add(atx db.Tx) {
+	ltx := atx.(*LevelDBTX)
+	var v db.KV
+	for _, key := range ltx.cache.order {
+		v = ltx.cache.kv[key]
+		t.cache.Put(v.K, v.V)
+	}
+}
+
+// Commit writes all nodes in the cache, their index entries and the changes
+// recorded for them to the db in one batch
+func (t *LevelDBTX) Commit() error {
+	taken := t.changes.take(t.cache)
+	err := t.commit(taken)
+	t.changes.finish(taken, err)
+	return err
+}
+
+func (t *LevelDBTX) commit(taken []*pendingChange) error {
+	batch := new(leveldb.Batch)
+	var v db.KV
+	var node Node
+	for _, key := range t.cache.order {
+		v = t.cache.kv[key]
+		batch.Put(levelDBNodeKey(v.K), v.V)
+
+		node = Node{}
+		err := node.UpdateKVAndPrefix(db.KV{K: v.K[len(t.prefix):], V: v.V}, t.prefix)
+		if err != nil {
+			return err
+		}
+		if node.NodeType == LeafNode && node.DID != "" {
+			batch.Put(levelDBIndexKey(t.prefix, node.ClaimType, node.DID, node.ClaimVersion), v.K)
+		}
+	}
+
+	var seq uint64
+	if len(taken) > 0 {
+		// the ids are assigned in the order the batches are written
+		t.ledger.Lock()
+		defer t.ledger.Unlock()
+		seq = t.ledger.seq
+	}
+	for _, pending := range taken {
+		seq++
+		pending.change.ID = seq
+		value, err := json.Marshal(pending.change)
+		if err != nil {
+			return err
+		}
+		batch.Put(levelDBChangeKey(pending.change.DID, seq), value)
+	}
+	if len(taken) > 0 {
+		var seqb [8]byte
+		binary.BigEndian.PutUint64(seqb[:], seq)
+		batch.Put(levelDBChangeSeqKey, seqb[:])
+	}
+
+	err := t.ldb.Write(batch, nil)
+	if err != nil {
+		return err
+	}
+	if len(taken) > 0 {
+		t.ledger.seq = seq
+	}
+	t.cache.kv = nil
+	t.cache.order = nil
+	return nil
+}
+
+// Close deletes the cache of the transaction
+func (t *LevelDBTX) Close() {
+	t.cache.kv = nil
+	t.cache.order = nil
+}
+
+func levelDBNodeKey(fullKey []byte) []byte {
+	return Concat(levelDBNodeNamespace, fullKey)
+}
+
+func levelDBIndexKeyPrefix(prefix []byte, claimType string, did string) []byte {
+	return Concat(
+		levelDBIndexNamespace,
+		prefix,
+		levelDBIndexSeparator,
+		[]byte(claimType),
+		[]byte(did),
+	)
+}
+
+func levelDBIndexKey(prefix []byte, claimType string, did string, version uint32) []byte {
+	var versionb [4]byte
+	binary.BigEndian.PutUint32(versionb[:], version)
+	return Concat(levelDBIndexKeyPrefix(prefix, claimType, did), versionb[:])
+}
+
+func levelDBChangeKeyPrefix(did string) []byte {
+	return Concat(levelDBChangeNamespace, []byte(did), levelDBIndexSeparator)
+}
+
+func levelDBChangeKey(did string, id uint64) []byte {
+	var idb [8]byte
+	binary.BigEndian.PutUint64(idb[:], id)
+	return Concat(levelDBChangeKeyPrefix(did), idb[:])
+}
@@ -0,0 +1,55 @@
+package claimsstore_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/joincivil/id-hub/pkg/claimsstore"
+	didlib "github.com/ockam-network/did"
+)
+
+func levelDBStore(t *testing.T) (*claimsstore.LevelDBStore, func()) {
+	dir, err := ioutil.TempDir("", "idhub-leveldb")
+	if err != nil {
+		t.Fatalf("Should have created the temp dir: err: %v", err)
+	}
+	store, err := claimsstore.NewLevelDBStore(dir)
+	if err != nil {
+		t.Fatalf("Should have opened the leveldb: err: %v", err)
+	}
+	return store, func() {
+		store.Close()
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func TestLevelDBStore(t *testing.T) {
+	store, cleaner := levelDBStore(t)
+	defer cleaner()
+
+	testReturnKnownErrIfNotExists(t, store)
+	testStorageInsertGet(t, store)
+	testStorageWithPrefix(t, store)
+	testConcatTx(t, store)
+	testList(t, store)
+}
+
+func TestLevelDBStoreVersionAPI(t *testing.T) {
+	store, cleaner := levelDBStore(t)
+	defer cleaner()
+
+	did, err := didlib.Parse("did:ethuri:did1")
+	if err != nil {
+		t.Fatalf("couldn't parse did: %v", err)
+	}
+	version, err := store.GetNextRootClaimVersion(did)
+	if err != nil {
+		t.Errorf("couldn't get next root claim version: %v", err)
+	}
+	if version != 0 {
+		t.Errorf("unexpected version number: expected 0, got %v", version)
+	}
+
+	testVersionAPI(t, store, store)
+}
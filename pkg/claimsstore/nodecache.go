@@ -0,0 +1,20 @@
+package claimsstore
+
+import (
+	"errors"
+)
+
+var (
+	// ErrNodeCacheMiss error indicating that the node was not found in the cache
+	ErrNodeCacheMiss = errors.New("node not found in cache")
+)
+
+// NodeCache interface defines a cache for merkle tree nodes keyed by their
+// full storage key (prefix + node key)
+type NodeCache interface {
+	// Get returns the node value for the key. Expects ErrNodeCacheMiss as error
+	// when the key is not in the cache.
+	Get(key []byte) ([]byte, error)
+	// Set sets the node value for the key
+	Set(key []byte, value []byte) error
+}
@@ -0,0 +1,58 @@
+package claimsstore
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/allegro/bigcache"
+	"github.com/pkg/errors"
+)
+
+var (
+	// DefaultNodeBigCacheConfig is a default cache config to use for the node cache.
+	// Nodes never change once written, so the life window only bounds how long
+	// unused nodes take up space.
+	DefaultNodeBigCacheConfig = bigcache.Config{
+		Shards:             1024,
+		LifeWindow:         60 * time.Minute,
+		CleanWindow:        5 * time.Minute,
+		MaxEntriesInWindow: 1000 * 10 * 60,
+		MaxEntrySize:       256,
+		Verbose:            false,
+		HardMaxCacheSize:   256,
+	}
+)
+
+// NewBigCacheNodeCache is a convenience function to init and return a new
+// BigCacheNodeCache
+func NewBigCacheNodeCache(cache *bigcache.BigCache) *BigCacheNodeCache {
+	return &BigCacheNodeCache{
+		cache: cache,
+	}
+}
+
+// BigCacheNodeCache implements a NodeCache using allegro/bigcache
+type BigCacheNodeCache struct {
+	cache *bigcache.BigCache
+}
+
+// Get retrieves the node value out of the cache
+func (c *BigCacheNodeCache) Get(key []byte) ([]byte, error) {
+	value, err := c.cache.Get(hex.EncodeToString(key))
+	if err != nil {
+		if err == bigcache.ErrEntryNotFound {
+			return nil, ErrNodeCacheMiss
+		}
+		return nil, errors.Wrap(err, "nodecache.get")
+	}
+	return value, nil
+}
+
+// Set sets the node value in the cache given the key
+func (c *BigCacheNodeCache) Set(key []byte, value []byte) error {
+	err := c.cache.Set(hex.EncodeToString(key), value)
+	if err != nil {
+		return errors.Wrap(err, "nodecache.set")
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package claimsstore
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/pkg/errors"
+)
+
+const (
+	redisNodeCacheKeyFmt = "%v:node:%v"
+
+	// DefaultRedisNodeCacheExpirySecs is the default expiry for nodes stored in redis
+	DefaultRedisNodeCacheExpirySecs = 60 * 60 * 24
+)
+
+// RedisPool is a pool of redis connections
+type RedisPool interface {
+	Get() redis.Conn
+}
+
+// NewRedisNodeCache is a convenience function to init and return a new
+// RedisNodeCache. Nodes are stored under the given namespace and expire after
+// expirySecs.
+func NewRedisNodeCache(pool RedisPool, namespace string, expirySecs int) *RedisNodeCache {
+	return &RedisNodeCache{
+		pool:       pool,
+		namespace:  namespace,
+		expirySecs: expirySecs,
+	}
+}
+
+// RedisNodeCache implements a NodeCache backed by redis, so the cache can be
+// shared between instances of the hub
+type RedisNodeCache struct {
+	pool       RedisPool
+	namespace  string
+	expirySecs int
+}
+
+// Get retrieves the node value out of redis
+func (c *RedisNodeCache) Get(key []byte) ([]byte, error) {
+	conn := c.pool.Get()
+	defer conn.Close() // nolint: errcheck
+
+	value, err := redis.Bytes(conn.Do("GET", c.cacheKey(key)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return nil, ErrNodeCacheMiss
+		}
+		return nil, errors.Wrap(err, "redisnodecache.get")
+	}
+	return value, nil
+}
+
+// Set sets the node value in redis given the key
+func (c *RedisNodeCache) Set(key []byte, value []byte) error {
+	conn := c.pool.Get()
+	defer conn.Close() // nolint: errcheck
+
+	_, err := conn.Do("SET", c.cacheKey(key), value, "EX", c.expirySecs)
+	if err != nil {
+		return errors.Wrap(err, "redisnodecache.set")
+	}
+	return nil
+}
+
+func (c *RedisNodeCache) cacheKey(key []byte) string {
+	return fmt.Sprintf(redisNodeCacheKeyFmt, c.namespace, hex.EncodeToString(key))
+}
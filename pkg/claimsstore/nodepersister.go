@@ -3,7 +3,6 @@ package claimsstore
 import (
 	"encoding/hex"
 	"encoding/json"
-	"fmt"
 
 	"github.com/iden3/go-iden3-core/core"
 	"github.com/iden3/go-iden3-core/db"
@@ -11,7 +10,6 @@ import (
 	"github.com/jinzhu/gorm"
 	"github.com/joincivil/id-hub/pkg/claimtypes"
 	didlib "github.com/ockam-network/did"
-	"github.com/pkg/errors"
 )
 
 const (
@@ -21,11 +19,6 @@ const (
 	MiddleNode = "middlenode"
 )
 
-var (
-	// ErrNoRootCommitForDID is an error for when no root claims are found
-	ErrNoRootCommitForDID = errors.New("no rootclaims were in the snapshot")
-)
-
 type storageInfo struct {
 	KeyCount   int
 	ClaimCount int
@@ -79,7 +72,8 @@ func (c *Node) UpdateKVAndPrefix(kv db.KV, prefix []byte) error {
 		claimType, version := core.GetClaimTypeVersion(entry)
 
 		if claimType == *claimtypes.ClaimTypeRegisteredDocument ||
-			claimType == *claimtypes.ClaimTypeSetRootKeyDID {
+			claimType == *claimtypes.ClaimTypeSetRootKeyDID ||
+			claimType == *claimtypes.ClaimTypeDIDDocumentVersion {
 			c.DID = hex.EncodeToString(entry.Data[2][:])
 		}
 		c.ClaimType = hex.EncodeToString(claimType[:])
@@ -123,8 +117,9 @@ func (c *NodePGPersister) Get(key []byte) (*Node, error) {
 }
 
 // Batch updates many nodes at once from the cached kv values
-// used to update all the middle nodes when a leaf is added or changed
-func (c *NodePGPersister) Batch(cache *kvMap, prefix []byte) error {
+// used to update all the middle nodes when a leaf is added or changed.
+// The changes are added to the ledger in the same transaction.
+func (c *NodePGPersister) Batch(cache *kvMap, prefix []byte, changes ...*TreeChange) error {
 	tx := c.DB.Begin()
 
 	defer func() {
@@ -150,10 +145,35 @@ func (c *NodePGPersister) Batch(cache *kvMap, prefix []byte) error {
 			return err
 		}
 	}
+	for _, change := range changes {
+		if err := tx.Create(change).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
 
 	return tx.Commit().Error
 }
 
+// GetTreeChanges returns the changes in the ledger for a did, oldest first
+func (c *NodePGPersister) GetTreeChanges(did string, offset int, limit int) ([]*TreeChange, error) {
+	changes := []*TreeChange{}
+	if err := c.DB.Where(&TreeChange{DID: did}).Order("id asc").Offset(offset).Limit(limit).
+		Find(&changes).Error; err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// CountTreeChanges returns the number of changes in the ledger for a did
+func (c *NodePGPersister) CountTreeChanges(did string) (int, error) {
+	var count int
+	if err := c.DB.Model(&TreeChange{}).Where(&TreeChange{DID: did}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 // Info returns basic info about the table
 func (c *NodePGPersister) Info() string {
 	var totalCount int
@@ -202,12 +222,42 @@ func convertNodesToKVs(nodes []Node) ([]db.KV, error) {
 
 // GetAll returns all the nodes in all trees
 func (c *NodePGPersister) GetAll() ([]db.KV, error) {
+	nodes, err := c.GetAllNodes()
+	if err != nil {
+		return []db.KV{}, err
+	}
+	return convertNodesToKVs(nodes)
+}
+
+// GetAllNodes returns all the nodes in all trees with their prefixes
+func (c *NodePGPersister) GetAllNodes() ([]Node, error) {
 	var nodes []Node
-	var kvs []db.KV
 	if err := c.DB.Find(&nodes).Error; err != nil {
-		return kvs, err
+		return nodes, err
 	}
-	return convertNodesToKVs(nodes)
+	return nodes, nil
+}
+
+// GetTreeNodesPage returns up to limit nodes of the root tree and the did trees
+// with an id after afterID, ordered by id
+func (c *NodePGPersister) GetTreeNodesPage(afterID uint, limit int) ([]Node, error) {
+	var nodes []Node
+	err := c.DB.Where("id > ? AND (prefix = ? OR prefix LIKE ?)", afterID,
+		string(PrefixRootMerkleTree), string(didTreePrefix)+"%").
+		Order("id asc").Limit(limit).Find(&nodes).Error
+	if err != nil {
+		return nodes, err
+	}
+	return nodes, nil
+}
+
+// DeleteNodes permanently deletes the nodes with the given keys, including their prefixes
+func (c *NodePGPersister) DeleteNodes(keys [][]byte) error {
+	strKeys := make([]string, len(keys))
+	for i, key := range keys {
+		strKeys[i] = hex.EncodeToString(key)
+	}
+	return c.DB.Unscoped().Where("node_key IN (?)", strKeys).Delete(&Node{}).Error
 }
 
 //GetNextRootClaimVersion gets the next root claim version for a did
@@ -252,26 +302,5 @@ func (c *NodePGPersister) GetLatestRootClaimInSnapshot(did *didlib.DID,
 	if err != nil {
 		return nil, err
 	}
-	for index, node := range *nodes {
-		dataBytes, err := hex.DecodeString(node.NodeData)
-		// If you get a bad node, then just error
-		if err != nil {
-			return nil, errors.Wrap(err,
-				fmt.Sprintf("GetLatestRootClaimsInSnapshot node at position %v failed to decode from hex", index))
-		}
-		entry, err := merkletree.NewEntryFromBytes(dataBytes[1:])
-		if err != nil {
-			return nil, errors.Wrap(err,
-				fmt.Sprintf("GetLatestRootClaimsInSnapshot node at position %v failed to create Entry", index))
-		}
-		_, err = tree.GetDataByIndex(entry.HIndex())
-		if err == merkletree.ErrEntryIndexNotFound {
-			continue
-		} else if err != nil {
-			return nil, errors.Wrap(err,
-				fmt.Sprintf("GetLatestRootClaimsInSnapshot node at position %v unexpected error retrieving data", index))
-		}
-		return claimtypes.NewClaimSetRootKeyDIDFromEntry(entry), nil
-	}
-	return nil, ErrNoRootCommitForDID
+	return latestRootClaimInSnapshot(*nodes, tree)
 }
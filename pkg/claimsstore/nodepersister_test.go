@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/iden3/go-iden3-core/db"
 	"github.com/iden3/go-iden3-core/merkletree"
 	"github.com/joincivil/id-hub/pkg/claimsstore"
 	"github.com/joincivil/id-hub/pkg/claimtypes"
@@ -15,6 +16,10 @@ func TestVersionAPI(t *testing.T) {
 	store, persister := pgStore(t)
 	cleaner := testutils.DeleteCreatedEntities(persister.DB)
 	defer cleaner()
+	testVersionAPI(t, store, persister)
+}
+
+func testVersionAPI(t *testing.T, store db.Storage, persister claimsstore.RootClaimIndex) {
 	rootStore := store.WithPrefix(claimsstore.PrefixRootMerkleTree)
 
 	rootMt, err := merkletree.NewMerkleTree(rootStore, 150)
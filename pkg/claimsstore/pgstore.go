@@ -2,19 +2,27 @@ package claimsstore
 
 import (
 	"github.com/iden3/go-iden3-core/db"
+	"github.com/iden3/go-iden3-core/merkletree"
 	"github.com/jinzhu/gorm"
 )
 
+const (
+	// iterateTreesPageSize is the number of nodes loaded at a time by IterateTrees
+	iterateTreesPageSize = 1000
+)
+
 // PGStore is an implementation of the iden3 storage interface that uses postgres as its backend
 type PGStore struct {
 	NodePersister *NodePGPersister
 	prefix        []byte
+	changes       *pendingChanges
 }
 
 // NewPGStore returns a new postgress store
 func NewPGStore(nodePersister *NodePGPersister) *PGStore {
 	return &PGStore{
 		NodePersister: nodePersister,
+		changes:       newPendingChanges(),
 	}
 }
 
@@ -26,7 +34,7 @@ func (s *PGStore) NewTx() (db.Tx, error) {
 
 // WithPrefix returns a new instance of the pgstore using the passed in prefix
 func (s *PGStore) WithPrefix(prefix []byte) db.Storage {
-	return &PGStore{NodePersister: s.NodePersister, prefix: Concat(s.prefix, prefix)}
+	return &PGStore{NodePersister: s.NodePersister, prefix: Concat(s.prefix, prefix), changes: s.changes}
 }
 
 // Get gets the data from a node with the given key from the db
@@ -80,3 +88,56 @@ func (s *PGStore) Iterate(f func([]byte, []byte) (bool, error)) error {
 	}
 	return nil
 }
+
+// IterateTrees performs a function on all nodes in all trees, passing the
+// prefix of the tree each node is in. Only the root tree and did trees are
+// iterated, the nodes are loaded a page at a time.
+func (s *PGStore) IterateTrees(f func([]byte, []byte, []byte) (bool, error)) error {
+	var afterID uint
+	for {
+		nodes, err := s.NodePersister.GetTreeNodesPage(afterID, iterateTreesPageSize)
+		if err != nil {
+			return err
+		}
+		for _, node := range nodes {
+			kv, err := node.ToKV()
+			if err != nil {
+				return err
+			}
+			if cont, err := f([]byte(node.Prefix), kv.K, kv.V); err != nil {
+				return err
+			} else if !cont {
+				return nil
+			}
+		}
+		if len(nodes) < iterateTreesPageSize {
+			return nil
+		}
+		afterID = nodes[len(nodes)-1].ID
+	}
+}
+
+// DeleteTreeNodes deletes the nodes with the keys from the tree with the prefix
+func (s *PGStore) DeleteTreeNodes(prefix []byte, keys [][]byte) error {
+	fullKeys := make([][]byte, len(keys))
+	for i, key := range keys {
+		fullKeys[i] = Concat(s.prefix, prefix, key)
+	}
+	return s.NodePersister.DeleteNodes(fullKeys)
+}
+
+// RecordChange records change in the tree_changes table in the same transaction
+// as the nodes of entry when it is added to the tree with prefix
+func (s *PGStore) RecordChange(prefix []byte, entry *merkletree.Entry, change *TreeChange) func() error {
+	return s.changes.add(Concat(s.prefix, prefix), entry, change)
+}
+
+// GetTreeChanges returns the changes for a did, oldest first
+func (s *PGStore) GetTreeChanges(did string, offset int, limit int) ([]*TreeChange, error) {
+	return s.NodePersister.GetTreeChanges(did, offset, limit)
+}
+
+// CountTreeChanges returns the number of changes for a did
+func (s *PGStore) CountTreeChanges(did string) (int, error) {
+	return s.NodePersister.CountTreeChanges(did)
+}
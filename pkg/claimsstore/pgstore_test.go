@@ -6,6 +6,7 @@ import (
 	"github.com/iden3/go-iden3-core/db"
 	"github.com/joincivil/id-hub/pkg/claimsstore"
 	"github.com/joincivil/id-hub/pkg/testutils"
+	didlib "github.com/ockam-network/did"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -166,3 +167,24 @@ func TestPGStore(t *testing.T) {
 	testConcatTx(t, store)
 	testList(t, store)
 }
+
+func TestPGStoreIterateTrees(t *testing.T) {
+	store, persister := pgStore(t)
+	cleaner := testutils.DeleteCreatedEntities(persister.DB)
+	defer cleaner()
+
+	did, _ := didlib.Parse("did:ethuri:123456")
+	addTestDocuments(t, store, persister, did, 2, true)
+	addTestDocuments(t, store.WithPrefix([]byte("rebuild/")), persister, did, 2, false)
+
+	prefixes := map[string]bool{}
+	err := store.(claimsstore.TreeIterator).IterateTrees(func(prefix []byte, k []byte, v []byte) (bool, error) {
+		prefixes[string(prefix)] = true
+		return true, nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]bool{
+		string(claimsstore.PrefixRootMerkleTree): true,
+		did.String():                             true,
+	}, prefixes)
+}
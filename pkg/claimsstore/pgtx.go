@@ -69,9 +69,11 @@ func (t *PGTX) Add(atx db.Tx) {
 	}
 }
 
-// Commit writes all nodes in the cache to the db
+// Commit writes all nodes in the cache and the changes recorded for them to the db
 func (t *PGTX) Commit() error {
-	err := t.NodePersister.Batch(t.cache, t.prefix)
+	taken := t.changes.take(t.cache)
+	err := t.NodePersister.Batch(t.cache, t.prefix, pendingTreeChanges(taken)...)
+	t.changes.finish(taken, err)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,61 @@
+package claimsstore
+
+import (
+	"encoding/hex"
+
+	"github.com/jinzhu/gorm"
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+)
+
+// Revocation is an entry in the ledger of registered documents revoked in the
+// did trees. Revocations have no source document of their own, the ledger is what
+// lets them be replayed when the trees are rebuilt.
+type Revocation struct {
+	gorm.Model
+	DID     string `gorm:"column:did;not null;index:revocationdid"`
+	DocType uint32
+	Hash    string `gorm:"not null"`
+}
+
+// TableName sets the table name for revocations
+func (Revocation) TableName() string {
+	return "claim_revocations"
+}
+
+// RevocationPGPersister persister model for the revocation ledger
+type RevocationPGPersister struct {
+	db *gorm.DB
+}
+
+// NewRevocationPGPersister returns a new RevocationPGPersister
+func NewRevocationPGPersister(db *gorm.DB) *RevocationPGPersister {
+	return &RevocationPGPersister{
+		db: db,
+	}
+}
+
+// AddRevocation records the revocation of the document with the content hash in
+// the tree of the did
+func (p *RevocationPGPersister) AddRevocation(did *didlib.DID, docType uint32,
+	contentHash [34]byte) error {
+	revocation := &Revocation{
+		DID:     did.String(),
+		DocType: docType,
+		Hash:    hex.EncodeToString(contentHash[:]),
+	}
+	if err := p.db.Create(revocation).Error; err != nil {
+		return errors.Wrapf(err, "addrevocation.dbcreate: did: %v, hash: %v",
+			revocation.DID, revocation.Hash)
+	}
+	return nil
+}
+
+// GetAll returns every revocation in the order they were made
+func (p *RevocationPGPersister) GetAll() ([]*Revocation, error) {
+	revocations := []*Revocation{}
+	if err := p.db.Order("id asc").Find(&revocations).Error; err != nil {
+		return nil, errors.Wrap(err, "getall.find")
+	}
+	return revocations, nil
+}
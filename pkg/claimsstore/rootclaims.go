@@ -0,0 +1,51 @@
+package claimsstore
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/iden3/go-iden3-core/merkletree"
+	"github.com/joincivil/id-hub/pkg/claimtypes"
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrNoRootCommitForDID is an error for when no root claims are found
+	ErrNoRootCommitForDID = errors.New("no rootclaims were in the snapshot")
+)
+
+// RootClaimIndex looks up the root claims of a did in the root merkle tree by
+// their version, it is implemented by each tree storage backend
+type RootClaimIndex interface {
+	GetNextRootClaimVersion(did *didlib.DID) (uint32, error)
+	GetLatestRootClaimNodes(did *didlib.DID) (*[]Node, error)
+	GetLatestRootClaimInSnapshot(did *didlib.DID, tree *merkletree.MerkleTree) (*claimtypes.ClaimSetRootKeyDID, error)
+}
+
+// latestRootClaimInSnapshot returns the first root claim in nodes, sorted by
+// version number descending, that is in the tree
+func latestRootClaimInSnapshot(nodes []Node, tree *merkletree.MerkleTree) (*claimtypes.ClaimSetRootKeyDID, error) {
+	for index, node := range nodes {
+		dataBytes, err := hex.DecodeString(node.NodeData)
+		// If you get a bad node, then just error
+		if err != nil {
+			return nil, errors.Wrap(err,
+				fmt.Sprintf("GetLatestRootClaimsInSnapshot node at position %v failed to decode from hex", index))
+		}
+		entry, err := merkletree.NewEntryFromBytes(dataBytes[1:])
+		if err != nil {
+			return nil, errors.Wrap(err,
+				fmt.Sprintf("GetLatestRootClaimsInSnapshot node at position %v failed to create Entry", index))
+		}
+		_, err = tree.GetDataByIndex(entry.HIndex())
+		if err == merkletree.ErrEntryIndexNotFound {
+			continue
+		} else if err != nil {
+			return nil, errors.Wrap(err,
+				fmt.Sprintf("GetLatestRootClaimsInSnapshot node at position %v unexpected error retrieving data", index))
+		}
+		return claimtypes.NewClaimSetRootKeyDIDFromEntry(entry), nil
+	}
+	return nil, ErrNoRootCommitForDID
+}
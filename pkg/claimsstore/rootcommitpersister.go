@@ -13,6 +13,13 @@ type RootCommit struct {
 	TransactionHash  string
 	ContractAddress  string
 	CommitterAddress string
+	// TreeSize is the number of root claims in the root tree
+	TreeSize uint64
+	// TreeHeadTimestamp, TreeHeadSigner and TreeHeadSignature are the signed tree
+	// head of the root, they are empty if the hub has no tree head key
+	TreeHeadTimestamp int64
+	TreeHeadSigner    string
+	TreeHeadSignature string
 }
 
 // TableName sets the table name for signed claims
@@ -58,3 +65,27 @@ func (p *RootCommitsPGPersister) GetLatest() (*RootCommit, error) {
 	}
 	return rootCommit, nil
 }
+
+// GetLatestCommits returns the most recent root commits, latest first. Returns
+// all the commits if count is 0.
+func (p *RootCommitsPGPersister) GetLatestCommits(count int) ([]*RootCommit, error) {
+	rootCommits := []*RootCommit{}
+	stmt := p.db.Order("block_number desc")
+	if count > 0 {
+		stmt = stmt.Limit(count)
+	}
+	if err := stmt.Find(&rootCommits).Error; err != nil {
+		return rootCommits, err
+	}
+	return rootCommits, nil
+}
+
+// GetCommitterAddresses returns the distinct addresses that committed roots
+func (p *RootCommitsPGPersister) GetCommitterAddresses() ([]string, error) {
+	addresses := []string{}
+	stmt := p.db.Model(&RootCommit{}).Where("committer_address <> ''").Order("committer_address")
+	if err := stmt.Pluck("DISTINCT committer_address", &addresses).Error; err != nil {
+		return addresses, err
+	}
+	return addresses, nil
+}
@@ -220,3 +220,21 @@ func (p *SignedClaimPGPersister) GetCredentialByMultihash(mHash string) (claimty
 	}
 	return signedClaim.ToCredential()
 }
+
+// CredentialExists returns true if a credential with the multihash is stored
+func (p *SignedClaimPGPersister) CredentialExists(mHash string) (bool, error) {
+	var count int
+	if err := p.db.Model(&SignedClaimPostgres{}).Where(&SignedClaimPostgres{Hash: mHash}).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetAllCredentials returns every signed claim ordered by issuance date
+func (p *SignedClaimPGPersister) GetAllCredentials() ([]*SignedClaimPostgres, error) {
+	claims := []*SignedClaimPostgres{}
+	if err := p.db.Order("issuance_date asc").Find(&claims).Error; err != nil {
+		return nil, errors.Wrap(err, "getallcredentials.find")
+	}
+	return claims, nil
+}
@@ -22,6 +22,8 @@ func setupConnection() (*gorm.DB, error) {
 	}
 	err = db.AutoMigrate(
 		&ethuri.PostgresDocument{},
+		&ethuri.PostgresDocumentVersion{},
+		&ethuri.PostgresDocumentIndex{},
 		&claimsstore.SignedClaimPostgres{},
 		&claimsstore.Node{},
 		&claimsstore.RootCommit{},
@@ -0,0 +1,185 @@
+package claimsstore
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/iden3/go-iden3-core/merkletree"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrTreeChangeNotRecorded is returned when no transaction wrote the leaf of a
+	// recorded change
+	ErrTreeChangeNotRecorded = errors.New("the tree change was not written to the ledger")
+	// ErrTreeChangeLedgerNotSupported is returned when the storage has no change ledger
+	ErrTreeChangeLedgerNotSupported = errors.New("storage does not support the tree change ledger")
+)
+
+// TreeChangeOperation is the kind of change made to a tree
+type TreeChangeOperation string
+
+const (
+	// TreeChangeAddKey is a signing key claimed in a did tree
+	TreeChangeAddKey TreeChangeOperation = "add_key"
+	// TreeChangeRegister is a document registered in a did tree
+	TreeChangeRegister TreeChangeOperation = "register"
+	// TreeChangeRevoke is a registered document revoked in a did tree
+	TreeChangeRevoke TreeChangeOperation = "revoke"
+	// TreeChangeDocumentVersion is a version of the did document registered in
+	// a did tree
+	TreeChangeDocumentVersion TreeChangeOperation = "document_version"
+	// TreeChangeSetRoot is a new root of a did tree claimed in the root tree
+	TreeChangeSetRoot TreeChangeOperation = "set_root"
+	// TreeChangeAdd is any other claim added to a tree
+	TreeChangeAdd TreeChangeOperation = "add"
+)
+
+// TreeChange is an entry in the append-only ledger of changes to the trees. It
+// is written in the same transaction as the nodes of the claim added.
+type TreeChange struct {
+	ID           uint64 `gorm:"primary_key"`
+	CreatedAt    time.Time
+	DID          string `gorm:"column:did;not null;index:treechangedid"`
+	Tree         string `gorm:"not null"`
+	ClaimType    string
+	ClaimVersion uint32
+	DocType      uint32
+	ContentHash  string
+	Operation    TreeChangeOperation
+	// Sender is the authenticated did that made the change
+	Sender string
+	// ClaimedSender is the did an unauthenticated request claimed to be sent
+	// by, it is not verified
+	ClaimedSender string
+	DIDRoot       string `gorm:"column:did_root"`
+}
+
+// TableName sets the table name for tree changes
+func (TreeChange) TableName() string {
+	return "tree_changes"
+}
+
+// TreeChangeRecorder is a storage that writes changes to the ledger in the same
+// transaction as the nodes of the claims that make them
+type TreeChangeRecorder interface {
+	// RecordChange records change with the transaction that writes the leaf of
+	// entry to the tree with prefix. The returned function must be called once the
+	// entry has been added, it returns the error writing the change or
+	// ErrTreeChangeNotRecorded if no transaction wrote the leaf.
+	RecordChange(prefix []byte, entry *merkletree.Entry, change *TreeChange) func() error
+}
+
+// TreeChangeLedger reads the change ledger of the trees
+type TreeChangeLedger interface {
+	// GetTreeChanges returns the changes for a did, oldest first
+	GetTreeChanges(did string, offset int, limit int) ([]*TreeChange, error)
+	CountTreeChanges(did string) (int, error)
+}
+
+type pendingChange struct {
+	change *TreeChange
+	taken  bool
+	done   bool
+	err    error
+}
+
+// pendingChanges are the changes waiting for the transaction that writes their
+// leaf, keyed by the full key of the leaf. There is one change per leaf at a
+// time, a change for a leaf that already has one waits until the transaction of
+// the first is done, so a transaction only takes the change of the caller that
+// added its leaf.
+type pendingChanges struct {
+	sync.Mutex
+	changes  map[string]*pendingChange
+	released *sync.Cond
+}
+
+func newPendingChanges() *pendingChanges {
+	p := &pendingChanges{changes: map[string]*pendingChange{}}
+	p.released = sync.NewCond(&p.Mutex)
+	return p
+}
+
+func (p *pendingChanges) add(prefix []byte, entry *merkletree.Entry, change *TreeChange) func() error {
+	key := string(Concat(prefix, merkletree.NewNodeLeaf(entry).Key()[:]))
+	pending := &pendingChange{change: change}
+
+	p.Lock()
+	for p.changes[key] != nil {
+		p.released.Wait()
+	}
+	p.changes[key] = pending
+	p.Unlock()
+
+	return func() error {
+		p.Lock()
+		defer p.Unlock()
+		if p.changes[key] == pending {
+			delete(p.changes, key)
+			p.released.Broadcast()
+		}
+		if !pending.done {
+			return ErrTreeChangeNotRecorded
+		}
+		return pending.err
+	}
+}
+
+// take returns the pending changes for the leaves in cache, setting their did
+// root to the new root of the tree if it is not set
+func (p *pendingChanges) take(cache *kvMap) []*pendingChange {
+	p.Lock()
+	defer p.Unlock()
+	if len(p.changes) == 0 {
+		return nil
+	}
+
+	var root *merkletree.Hash
+	taken := []*pendingChange{}
+	for _, key := range cache.order {
+		kv := cache.kv[key]
+		if len(kv.V) > merkletree.ElemBytesLen && merkletree.NodeType(kv.V[0]) == merkletree.DBEntryTypeRoot &&
+			bytes.HasSuffix(kv.K, currentRootKey) {
+			root = &merkletree.Hash{}
+			copy(root[:], kv.V[1:])
+			continue
+		}
+		pending, ok := p.changes[string(kv.K)]
+		if !ok || pending.taken {
+			continue
+		}
+		pending.taken = true
+		taken = append(taken, pending)
+	}
+
+	now := time.Now()
+	for _, pending := range taken {
+		if pending.change.DIDRoot == "" && root != nil {
+			pending.change.DIDRoot = root.Hex()
+		}
+		if pending.change.CreatedAt.IsZero() {
+			pending.change.CreatedAt = now
+		}
+	}
+	return taken
+}
+
+// finish marks the taken changes as written or failed with err
+func (p *pendingChanges) finish(taken []*pendingChange, err error) {
+	p.Lock()
+	defer p.Unlock()
+	for _, pending := range taken {
+		pending.done = true
+		pending.err = err
+	}
+}
+
+func pendingTreeChanges(taken []*pendingChange) []*TreeChange {
+	changes := make([]*TreeChange, len(taken))
+	for i, pending := range taken {
+		changes[i] = pending.change
+	}
+	return changes
+}
@@ -0,0 +1,415 @@
+package claimsstore
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/iden3/go-iden3-core/core"
+	"github.com/iden3/go-iden3-core/merkletree"
+	"github.com/joincivil/id-hub/pkg/claimtypes"
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+)
+
+// TreeIssueType is the kind of problem found by CheckTrees
+type TreeIssueType string
+
+const (
+	// TreeIssueCorrupt is a node that can't be parsed or whose hash doesn't
+	// match its key
+	TreeIssueCorrupt TreeIssueType = "corrupt"
+	// TreeIssueDangling is a reference to a node, tree or document that doesn't exist
+	TreeIssueDangling TreeIssueType = "dangling"
+	// TreeIssueOrphaned is a node or did tree root that isn't reachable from any
+	// root claim
+	TreeIssueOrphaned TreeIssueType = "orphaned"
+)
+
+var (
+	// ErrTreeIteratorNotSupported is returned when the storage can't iterate by tree
+	ErrTreeIteratorNotSupported = errors.New("storage does not support iterating by tree")
+
+	// currentRootKey is the key iden3 stores the current root of a tree under
+	currentRootKey = []byte("currentroot")
+)
+
+// TreeIterator iterates over the nodes of all trees in a storage
+type TreeIterator interface {
+	IterateTrees(f func(prefix []byte, key []byte, value []byte) (bool, error)) error
+}
+
+// DocumentIndex reports if the document registered by a ClaimRegisteredDocument
+// is stored
+type DocumentIndex interface {
+	DocumentExists(docType uint32, mHash string) (bool, error)
+}
+
+// NewPGDocumentIndex returns a DocumentIndex that looks up credentials in
+// signed_claims and jwts in jwt_claims
+func NewPGDocumentIndex(signedClaims *SignedClaimPGPersister,
+	jwtClaims *JWTClaimPGPersister) *PGDocumentIndex {
+	return &PGDocumentIndex{signedClaims: signedClaims, jwtClaims: jwtClaims}
+}
+
+// PGDocumentIndex is a DocumentIndex for the documents stored in postgres
+type PGDocumentIndex struct {
+	signedClaims *SignedClaimPGPersister
+	jwtClaims    *JWTClaimPGPersister
+}
+
+// DocumentExists returns true if the document with the multihash is stored for the doc type
+func (i *PGDocumentIndex) DocumentExists(docType uint32, mHash string) (bool, error) {
+	switch docType {
+	case claimtypes.ContentCredentialDocType, claimtypes.LicenseCredentialDocType:
+		return i.signedClaims.CredentialExists(mHash)
+	case claimtypes.JWTDocType:
+		return i.jwtClaims.JWTExists(mHash)
+	case claimtypes.RawDataDocType:
+		// raw data is only registered in the tree, it is never stored
+		return true, nil
+	}
+	return false, nil
+}
+
+// TreeIssue is a problem found by CheckTrees
+type TreeIssue struct {
+	Type   TreeIssueType
+	Prefix string
+	Key    string
+	Detail string
+}
+
+// String returns a readable description of the issue
+func (i TreeIssue) String() string {
+	return fmt.Sprintf("%v: prefix: %v, key: %v: %v", i.Type, i.Prefix, i.Key, i.Detail)
+}
+
+// TreeCheckReport is the result of CheckTrees
+type TreeCheckReport struct {
+	TreeCount      int
+	NodeCount      int
+	RootClaimCount int
+	DocumentCount  int
+	Issues         []TreeIssue
+}
+
+type checkedTree struct {
+	prefix    string
+	root      *merkletree.Hash
+	nodes     map[merkletree.Hash]*merkletree.Node
+	sizes     map[merkletree.Hash]int
+	corrupt   map[merkletree.Hash]bool
+	reachable map[merkletree.Hash]bool
+	anchored  map[merkletree.Hash]bool
+}
+
+type treeChecker struct {
+	docs   DocumentIndex
+	trees  map[string]*checkedTree
+	report *TreeCheckReport
+}
+
+// CheckTrees checks the integrity of the root tree and every did tree in the
+// storage. Every node is rehashed, the root tree and did trees are walked from
+// their current roots and from every ClaimSetRootKeyDID, and every
+// ClaimRegisteredDocument is looked up in docs. docs can be nil to skip checking
+// the documents. Nodes of the root tree that are not reachable from its current
+// root are not reported, they are left by every update to the root tree.
+func CheckTrees(storage TreeIterator, docs DocumentIndex) (*TreeCheckReport, error) {
+	c := &treeChecker{
+		docs:   docs,
+		trees:  map[string]*checkedTree{},
+		report: &TreeCheckReport{},
+	}
+	err := storage.IterateTrees(c.addNode)
+	if err != nil {
+		return nil, errors.Wrap(err, "checktrees.iteratetrees")
+	}
+	c.report.TreeCount = len(c.trees)
+
+	rootTree, ok := c.trees[string(PrefixRootMerkleTree)]
+	if !ok {
+		return c.report, nil
+	}
+	rootClaims, err := c.walkRootTree(rootTree, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	didTrees := c.didTrees()
+	for _, claim := range rootClaims {
+		err = c.checkRootClaim(didTrees, claim)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, prefix := range c.sortedPrefixes() {
+		tree := c.trees[prefix]
+		if tree == rootTree {
+			continue
+		}
+		err = c.checkDIDTree(tree)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.report, nil
+}
+
+func (c *treeChecker) tree(prefix []byte) *checkedTree {
+	tree, ok := c.trees[string(prefix)]
+	if !ok {
+		tree = &checkedTree{
+			prefix:    string(prefix),
+			nodes:     map[merkletree.Hash]*merkletree.Node{},
+			sizes:     map[merkletree.Hash]int{},
+			corrupt:   map[merkletree.Hash]bool{},
+			reachable: map[merkletree.Hash]bool{},
+			anchored:  map[merkletree.Hash]bool{},
+		}
+		c.trees[string(prefix)] = tree
+	}
+	return tree
+}
+
+func (c *treeChecker) addIssue(issueType TreeIssueType, tree *checkedTree, key []byte,
+	detail string, args ...interface{}) {
+	c.report.Issues = append(c.report.Issues, TreeIssue{
+		Type:   issueType,
+		Prefix: tree.prefix,
+		Key:    hex.EncodeToString(key),
+		Detail: fmt.Sprintf(detail, args...),
+	})
+}
+
+// addNode parses and rehashes a stored node and adds it to its tree
+func (c *treeChecker) addNode(prefix []byte, k []byte, v []byte) (bool, error) {
+	tree := c.tree(prefix)
+	if len(v) > 0 && merkletree.NodeType(v[0]) == merkletree.DBEntryTypeRoot &&
+		bytes.Equal(k, currentRootKey) {
+		if len(v) != 1+merkletree.ElemBytesLen {
+			c.addIssue(TreeIssueCorrupt, tree, k, "current root has the wrong length")
+			return true, nil
+		}
+		tree.root = &merkletree.Hash{}
+		copy(tree.root[:], v[1:])
+		return true, nil
+	}
+
+	c.report.NodeCount++
+	if len(k) != merkletree.ElemBytesLen {
+		c.addIssue(TreeIssueCorrupt, tree, k, "node key has the wrong length")
+		return true, nil
+	}
+	key := merkletree.Hash{}
+	copy(key[:], k)
+
+	node, err := merkletree.NewNodeFromBytes(v)
+	if err != nil {
+		tree.corrupt[key] = true
+		c.addIssue(TreeIssueCorrupt, tree, k, "node could not be parsed: %v", err)
+		return true, nil
+	}
+	if !bytes.Equal(node.Key()[:], key[:]) {
+		tree.corrupt[key] = true
+		c.addIssue(TreeIssueCorrupt, tree, k, "node hashes to %v", hex.EncodeToString(node.Key()[:]))
+		return true, nil
+	}
+	tree.nodes[key] = node
+	tree.sizes[key] = len(prefix) + len(k) + len(v)
+	return true, nil
+}
+
+// walk marks every node reachable from key and calls f with each leaf
+func (c *treeChecker) walk(tree *checkedTree, key merkletree.Hash, from string,
+	f func(*merkletree.Node) error) error {
+	if key == merkletree.HashZero || tree.reachable[key] {
+		return nil
+	}
+	tree.reachable[key] = true
+	if tree.corrupt[key] {
+		return nil
+	}
+
+	node, ok := tree.nodes[key]
+	if !ok {
+		c.addIssue(TreeIssueDangling, tree, key[:], "node referenced by %v does not exist", from)
+		return nil
+	}
+	switch node.Type {
+	case merkletree.NodeTypeMiddle:
+		from = fmt.Sprintf("node %v", hex.EncodeToString(key[:]))
+		if err := c.walk(tree, *node.ChildL, from, f); err != nil {
+			return err
+		}
+		return c.walk(tree, *node.ChildR, from, f)
+	case merkletree.NodeTypeLeaf:
+		return f(node)
+	}
+	return nil
+}
+
+// walkRootTree walks the root tree from its current root and the retained roots
+// and returns all the root claims found
+func (c *treeChecker) walkRootTree(rootTree *checkedTree,
+	retained []merkletree.Hash) ([]*claimtypes.ClaimSetRootKeyDID, error) {
+	rootClaims := []*claimtypes.ClaimSetRootKeyDID{}
+	collectRootClaims := func(node *merkletree.Node) error {
+		claimType, _ := core.GetClaimTypeVersion(node.Entry)
+		if claimType == *claimtypes.ClaimTypeSetRootKeyDID {
+			rootClaims = append(rootClaims, claimtypes.NewClaimSetRootKeyDIDFromEntry(node.Entry))
+		}
+		return nil
+	}
+
+	if rootTree.root == nil {
+		c.addIssue(TreeIssueDangling, rootTree, currentRootKey, "root tree has no current root")
+	} else {
+		err := c.walk(rootTree, *rootTree.root, "the current root", collectRootClaims)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, root := range retained {
+		err := c.walk(rootTree, root, fmt.Sprintf("retained root %v", root.Hex()), collectRootClaims)
+		if err != nil {
+			return nil, err
+		}
+	}
+	c.report.RootClaimCount = len(rootClaims)
+	return rootClaims, nil
+}
+
+// didTrees maps the hash of the did of each did tree to the tree
+func (c *treeChecker) didTrees() map[[32]byte]*checkedTree {
+	didTrees := map[[32]byte]*checkedTree{}
+	for _, prefix := range c.sortedPrefixes() {
+		tree := c.trees[prefix]
+		if prefix == string(PrefixRootMerkleTree) {
+			continue
+		}
+		did, err := didlib.Parse(prefix)
+		if err != nil {
+			c.addIssue(TreeIssueOrphaned, tree, []byte{}, "tree prefix is not a did")
+			continue
+		}
+		didbytes, err := claimtypes.HashDID(did)
+		if err != nil {
+			c.addIssue(TreeIssueOrphaned, tree, []byte{}, "tree prefix could not be hashed")
+			continue
+		}
+		didbytes32 := [32]byte{}
+		copy(didbytes32[:], didbytes)
+		didTrees[didbytes32] = tree
+	}
+	return didTrees
+}
+
+// checkRootClaim checks the did tree root the root claim points at exists and
+// walks the did tree from it
+func (c *treeChecker) checkRootClaim(didTrees map[[32]byte]*checkedTree,
+	claim *claimtypes.ClaimSetRootKeyDID) error {
+	if claim.RootKey == merkletree.HashZero {
+		return nil
+	}
+	tree, ok := didTrees[claim.DID]
+	if !ok {
+		rootTree := c.trees[string(PrefixRootMerkleTree)]
+		c.addIssue(TreeIssueDangling, rootTree, claim.DID[:],
+			"root claim version %v points at a did without a tree", claim.Version)
+		return nil
+	}
+	tree.anchored[claim.RootKey] = true
+	from := fmt.Sprintf("root claim version %v", claim.Version)
+	return c.walk(tree, claim.RootKey, from, c.checkDocument(tree))
+}
+
+// checkDIDTree walks the did tree from its current root and reports the nodes
+// not reachable from it or any root claim. Operations that add several entries
+// anchor only the last root with a root claim, so the nodes of the intermediate
+// roots are not reported if every leaf under them is reachable.
+func (c *treeChecker) checkDIDTree(tree *checkedTree) error {
+	if tree.root != nil && *tree.root != merkletree.HashZero {
+		if !tree.anchored[*tree.root] {
+			c.addIssue(TreeIssueOrphaned, tree, tree.root[:],
+				"current root is not anchored by a root claim")
+		}
+		err := c.walk(tree, *tree.root, "the current root", c.checkDocument(tree))
+		if err != nil {
+			return err
+		}
+	}
+
+	superseded := map[merkletree.Hash]bool{}
+	keys := make([]string, 0, len(tree.nodes))
+	for key := range tree.nodes {
+		if !tree.reachable[key] && !c.superseded(tree, key, superseded) {
+			keys = append(keys, string(key[:]))
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		c.addIssue(TreeIssueOrphaned, tree, []byte(key),
+			"node is not reachable from the current root or any root claim")
+	}
+	return nil
+}
+
+// superseded returns true if every leaf under the node at key is reachable, the
+// node is then part of an intermediate root of the tree that a later anchored
+// root contains
+func (c *treeChecker) superseded(tree *checkedTree, key merkletree.Hash,
+	checked map[merkletree.Hash]bool) bool {
+	if key == merkletree.HashZero || tree.reachable[key] {
+		return true
+	}
+	if result, ok := checked[key]; ok {
+		return result
+	}
+	result := false
+	node, ok := tree.nodes[key]
+	if ok && node.Type == merkletree.NodeTypeMiddle {
+		result = c.superseded(tree, *node.ChildL, checked) && c.superseded(tree, *node.ChildR, checked)
+	}
+	checked[key] = result
+	return result
+}
+
+// checkDocument returns a function that checks the document registered by a
+// leaf of the tree is stored
+func (c *treeChecker) checkDocument(tree *checkedTree) func(*merkletree.Node) error {
+	return func(node *merkletree.Node) error {
+		claimType, _ := core.GetClaimTypeVersion(node.Entry)
+		if claimType != *claimtypes.ClaimTypeRegisteredDocument {
+			return nil
+		}
+		c.report.DocumentCount++
+		if c.docs == nil {
+			return nil
+		}
+
+		claim := claimtypes.NewClaimRegisteredDocumentFromEntry(node.Entry)
+		mHash := hex.EncodeToString(claim.ContentHash[:])
+		exists, err := c.docs.DocumentExists(claim.DocType, mHash)
+		if err != nil {
+			return errors.Wrap(err, "checktrees.documentexists")
+		}
+		if !exists {
+			c.addIssue(TreeIssueDangling, tree, node.Key()[:],
+				"document %v with type %v is not stored", mHash, claim.DocType)
+		}
+		return nil
+	}
+}
+
+func (c *treeChecker) sortedPrefixes() []string {
+	prefixes := make([]string, 0, len(c.trees))
+	for prefix := range c.trees {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	return prefixes
+}
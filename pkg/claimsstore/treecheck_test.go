@@ -0,0 +1,308 @@
+package claimsstore_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/iden3/go-iden3-core/db"
+	"github.com/iden3/go-iden3-core/merkletree"
+	"github.com/jinzhu/gorm"
+	"github.com/joincivil/id-hub/pkg/claimsstore"
+	"github.com/joincivil/id-hub/pkg/claimtypes"
+	didlib "github.com/ockam-network/did"
+)
+
+type testDocumentIndex struct {
+	missing map[string]bool
+}
+
+func (i *testDocumentIndex) DocumentExists(docType uint32, mHash string) (bool, error) {
+	return !i.missing[mHash], nil
+}
+
+// treeNodes is a copy of all the trees in a storage that can be altered
+type treeNodes struct {
+	prefixes []string
+	keys     []string
+	values   map[string][]byte
+}
+
+func copyTrees(t *testing.T, storage claimsstore.TreeIterator) *treeNodes {
+	nodes := &treeNodes{values: map[string][]byte{}}
+	err := storage.IterateTrees(func(prefix []byte, k []byte, v []byte) (bool, error) {
+		nodes.prefixes = append(nodes.prefixes, string(prefix))
+		nodes.keys = append(nodes.keys, string(k))
+		nodes.values[string(prefix)+string(k)] = v
+		return true, nil
+	})
+	if err != nil {
+		t.Fatalf("Should have iterated the trees: err: %v", err)
+	}
+	return nodes
+}
+
+func (n *treeNodes) IterateTrees(f func([]byte, []byte, []byte) (bool, error)) error {
+	for i, prefix := range n.prefixes {
+		v, ok := n.values[prefix+n.keys[i]]
+		if !ok {
+			continue
+		}
+		if cont, err := f([]byte(prefix), []byte(n.keys[i]), v); err != nil || !cont {
+			return err
+		}
+	}
+	return nil
+}
+
+func addTestDocuments(t *testing.T, store db.Storage, index claimsstore.RootClaimIndex,
+	did *didlib.DID, count int, anchor bool) []*claimtypes.ClaimRegisteredDocument {
+	rootMt, err := merkletree.NewMerkleTree(store.WithPrefix(claimsstore.PrefixRootMerkleTree), 150)
+	if err != nil {
+		t.Fatalf("Should have created the root tree: err: %v", err)
+	}
+	didMt, err := merkletree.NewMerkleTree(store.WithPrefix([]byte(did.String())), 150)
+	if err != nil {
+		t.Fatalf("Should have created the did tree: err: %v", err)
+	}
+	nextVersion, err := index.GetNextRootClaimVersion(did)
+	if gorm.IsRecordNotFoundError(err) {
+		nextVersion = 0
+	} else if err != nil {
+		t.Fatalf("Should have gotten the next root claim version: err: %v", err)
+	}
+
+	claims := []*claimtypes.ClaimRegisteredDocument{}
+	for i := 0; i < count; i++ {
+		hash := [34]byte{0x1b, 0x20, 3, 4, 5, byte(nextVersion), byte(i)}
+		claim, err := claimtypes.NewClaimRegisteredDocument(hash, did, claimtypes.JWTDocType)
+		if err != nil {
+			t.Fatalf("Should have created the claim: err: %v", err)
+		}
+		err = didMt.Add(claim.Entry())
+		if err != nil {
+			t.Fatalf("Should have added the claim: err: %v", err)
+		}
+		claims = append(claims, claim)
+		if !anchor {
+			continue
+		}
+
+		rootClaim, err := claimtypes.NewClaimSetRootKeyDID(did, didMt.RootKey())
+		if err != nil {
+			t.Fatalf("Should have created the root claim: err: %v", err)
+		}
+		rootClaim.Version = nextVersion
+		nextVersion++
+		err = rootMt.Add(rootClaim.Entry())
+		if err != nil {
+			t.Fatalf("Should have added the root claim: err: %v", err)
+		}
+	}
+	return claims
+}
+
+func issueCount(report *claimsstore.TreeCheckReport, issueType claimsstore.TreeIssueType) int {
+	count := 0
+	for _, issue := range report.Issues {
+		if issue.Type == issueType {
+			count++
+		}
+	}
+	return count
+}
+
+func TestCheckTrees(t *testing.T) {
+	store, cleaner := levelDBStore(t)
+	defer cleaner()
+
+	did1, _ := didlib.Parse("did:ethuri:123456")
+	did2, _ := didlib.Parse("did:ethuri:654321")
+	addTestDocuments(t, store, store, did1, 3, true)
+	addTestDocuments(t, store, store, did2, 2, true)
+
+	report, err := claimsstore.CheckTrees(store, &testDocumentIndex{})
+	if err != nil {
+		t.Fatalf("Should have checked the trees: err: %v", err)
+	}
+	if len(report.Issues) != 0 {
+		t.Errorf("Should have found no issues: %v", report.Issues)
+	}
+	if report.TreeCount != 3 {
+		t.Errorf("Should have checked 3 trees, got %v", report.TreeCount)
+	}
+	if report.RootClaimCount != 5 {
+		t.Errorf("Should have found 5 root claims, got %v", report.RootClaimCount)
+	}
+	if report.DocumentCount != 5 {
+		t.Errorf("Should have found 5 documents, got %v", report.DocumentCount)
+	}
+}
+
+func TestCheckTreesSkipsOtherPrefixes(t *testing.T) {
+	store, cleaner := levelDBStore(t)
+	defer cleaner()
+
+	did, _ := didlib.Parse("did:ethuri:123456")
+	addTestDocuments(t, store, store, did, 2, true)
+	addTestDocuments(t, store.WithPrefix([]byte("rebuild/")), store, did, 2, false)
+
+	report, err := claimsstore.CheckTrees(store, &testDocumentIndex{})
+	if err != nil {
+		t.Fatalf("Should have checked the trees: err: %v", err)
+	}
+	if len(report.Issues) != 0 {
+		t.Errorf("Should have skipped the rebuild trees: %v", report.Issues)
+	}
+	if report.TreeCount != 2 {
+		t.Errorf("Should have checked 2 trees, got %v", report.TreeCount)
+	}
+}
+
+func TestCheckTreesMissingDocument(t *testing.T) {
+	store, cleaner := levelDBStore(t)
+	defer cleaner()
+
+	did, _ := didlib.Parse("did:ethuri:123456")
+	claims := addTestDocuments(t, store, store, did, 2, true)
+
+	docs := &testDocumentIndex{missing: map[string]bool{
+		hex.EncodeToString(claims[1].ContentHash[:]): true,
+	}}
+	report, err := claimsstore.CheckTrees(store, docs)
+	if err != nil {
+		t.Fatalf("Should have checked the trees: err: %v", err)
+	}
+	if len(report.Issues) != 1 || report.Issues[0].Type != claimsstore.TreeIssueDangling {
+		t.Errorf("Should have found one dangling document: %v", report.Issues)
+	}
+
+	report, err = claimsstore.CheckTrees(store, nil)
+	if err != nil {
+		t.Fatalf("Should have checked the trees: err: %v", err)
+	}
+	if len(report.Issues) != 0 {
+		t.Errorf("Should have skipped checking documents: %v", report.Issues)
+	}
+}
+
+func TestCheckTreesUnanchoredRoot(t *testing.T) {
+	store, cleaner := levelDBStore(t)
+	defer cleaner()
+
+	did, _ := didlib.Parse("did:ethuri:123456")
+	addTestDocuments(t, store, store, did, 2, true)
+	addTestDocuments(t, store, store, did, 1, false)
+
+	report, err := claimsstore.CheckTrees(store, &testDocumentIndex{})
+	if err != nil {
+		t.Fatalf("Should have checked the trees: err: %v", err)
+	}
+	if issueCount(report, claimsstore.TreeIssueOrphaned) != 1 || len(report.Issues) != 1 {
+		t.Errorf("Should have found the unanchored root: %v", report.Issues)
+	}
+}
+
+func TestCheckTreesDanglingAndCorrupt(t *testing.T) {
+	store, cleaner := levelDBStore(t)
+	defer cleaner()
+
+	did, _ := didlib.Parse("did:ethuri:123456")
+	addTestDocuments(t, store, store, did, 4, true)
+
+	nodes := copyTrees(t, store)
+	var middleKey, leafKey string
+	for i, prefix := range nodes.prefixes {
+		if prefix != did.String() {
+			continue
+		}
+		v := nodes.values[prefix+nodes.keys[i]]
+		switch merkletree.NodeType(v[0]) {
+		case merkletree.NodeTypeMiddle:
+			middleKey = prefix + nodes.keys[i]
+		case merkletree.NodeTypeLeaf:
+			leafKey = prefix + nodes.keys[i]
+		}
+	}
+	if middleKey == "" || leafKey == "" {
+		t.Fatalf("Should have found a middle and a leaf node in the did tree")
+	}
+	delete(nodes.values, middleKey)
+	corrupted := append([]byte{}, nodes.values[leafKey]...)
+	corrupted[len(corrupted)-1]++
+	nodes.values[leafKey] = corrupted
+
+	report, err := claimsstore.CheckTrees(nodes, &testDocumentIndex{})
+	if err != nil {
+		t.Fatalf("Should have checked the trees: err: %v", err)
+	}
+	if issueCount(report, claimsstore.TreeIssueDangling) == 0 {
+		t.Errorf("Should have found the dangling reference to the deleted node: %v", report.Issues)
+	}
+	if issueCount(report, claimsstore.TreeIssueCorrupt) != 1 {
+		t.Errorf("Should have found the corrupt leaf: %v", report.Issues)
+	}
+}
+
+func TestCheckTreesMultipleEntriesOneRootClaim(t *testing.T) {
+	store, cleaner := levelDBStore(t)
+	defer cleaner()
+
+	// Like adding several keys when the tree is created, the entries are added
+	// and then one root claim anchors the last root
+	did, _ := didlib.Parse("did:ethuri:123456")
+	addTestDocuments(t, store, store, did, 1, true)
+	addTestDocuments(t, store, store, did, 3, false)
+	rootMt, err := merkletree.NewMerkleTree(store.WithPrefix(claimsstore.PrefixRootMerkleTree), 150)
+	if err != nil {
+		t.Fatalf("Should have created the root tree: err: %v", err)
+	}
+	didMt, err := merkletree.NewMerkleTree(store.WithPrefix([]byte(did.String())), 150)
+	if err != nil {
+		t.Fatalf("Should have created the did tree: err: %v", err)
+	}
+	rootClaim, err := claimtypes.NewClaimSetRootKeyDID(did, didMt.RootKey())
+	if err != nil {
+		t.Fatalf("Should have created the root claim: err: %v", err)
+	}
+	rootClaim.Version = 1
+	err = rootMt.Add(rootClaim.Entry())
+	if err != nil {
+		t.Fatalf("Should have added the root claim: err: %v", err)
+	}
+
+	report, err := claimsstore.CheckTrees(store, &testDocumentIndex{})
+	if err != nil {
+		t.Fatalf("Should have checked the trees: err: %v", err)
+	}
+	if len(report.Issues) != 0 {
+		t.Errorf("Should not have reported the intermediate roots: %v", report.Issues)
+	}
+
+	// A leaf that is not in any anchored root is still orphaned
+	other, _ := didlib.Parse("did:ethuri:654321")
+	addTestDocuments(t, store, store, other, 1, false)
+	nodes := copyTrees(t, store)
+	for i, prefix := range nodes.prefixes {
+		v := nodes.values[prefix+nodes.keys[i]]
+		if prefix == other.String() && merkletree.NodeType(v[0]) == merkletree.NodeTypeLeaf {
+			nodes.prefixes = append(nodes.prefixes, did.String())
+			nodes.keys = append(nodes.keys, nodes.keys[i])
+			nodes.values[did.String()+nodes.keys[i]] = v
+			break
+		}
+	}
+
+	report, err = claimsstore.CheckTrees(nodes, &testDocumentIndex{})
+	if err != nil {
+		t.Fatalf("Should have checked the trees: err: %v", err)
+	}
+	orphaned := []claimsstore.TreeIssue{}
+	for _, issue := range report.Issues {
+		if issue.Type == claimsstore.TreeIssueOrphaned && issue.Prefix == did.String() {
+			orphaned = append(orphaned, issue)
+		}
+	}
+	if len(orphaned) != 1 {
+		t.Errorf("Should have reported the leaf that is in no anchored root: %v", report.Issues)
+	}
+}
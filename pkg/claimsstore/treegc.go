@@ -0,0 +1,146 @@
+package claimsstore
+
+import (
+	"sort"
+
+	log "github.com/golang/glog"
+	"github.com/iden3/go-iden3-core/merkletree"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrTreeNodeDeleterNotSupported is returned when the storage can't delete nodes
+	ErrTreeNodeDeleterNotSupported = errors.New("storage does not support deleting nodes")
+	// ErrTreeGCDanglingNodes is returned when nodes are not deleted because some
+	// of the retained nodes are missing
+	ErrTreeGCDanglingNodes = errors.New("retained roots reference missing nodes, run tree-check")
+)
+
+const (
+	// DefaultTreeGCBatchSize is the default number of nodes deleted at a time
+	DefaultTreeGCBatchSize = 500
+)
+
+// TreeNodeDeleter deletes nodes from the trees in a storage
+type TreeNodeDeleter interface {
+	DeleteTreeNodes(prefix []byte, keys [][]byte) error
+}
+
+// TreeGCOptions are the options for CollectTreeGarbage
+type TreeGCOptions struct {
+	// RetainedRoots are the roots of the root tree to keep in addition to its
+	// current root, usually the roots in root_commits
+	RetainedRoots []merkletree.Hash
+	// DryRun only reports the nodes that would be deleted
+	DryRun bool
+	// BatchSize is the number of nodes deleted at a time
+	BatchSize int
+}
+
+// TreeGCReport is the result of CollectTreeGarbage
+type TreeGCReport struct {
+	TreeCount        int
+	NodeCount        int
+	ReachableCount   int
+	ReclaimableCount int
+	ReclaimableBytes int
+	DeletedCount     int
+	Issues           []TreeIssue
+}
+
+// CollectTreeGarbage deletes the nodes of the root tree and did trees that are
+// not reachable from a retained root. The retained roots are the current root of
+// the root tree, the roots in opts.RetainedRoots, every did tree root claimed in
+// one of those and the current root of every did tree. Trees with prefixes that
+// are not a did are never collected. Nodes written after the trees are loaded are
+// not deleted, so the hub can keep writing while it runs. Nothing is deleted if a
+// retained root references a missing node, as the nodes below it would be
+// collected.
+func CollectTreeGarbage(storage TreeIterator, deleter TreeNodeDeleter,
+	opts TreeGCOptions) (*TreeGCReport, error) {
+	if !opts.DryRun && deleter == nil {
+		return nil, errors.New("collecttreegarbage: a deleter is required unless it is a dry run")
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultTreeGCBatchSize
+	}
+
+	c := &treeChecker{
+		trees:  map[string]*checkedTree{},
+		report: &TreeCheckReport{},
+	}
+	err := storage.IterateTrees(c.addNode)
+	if err != nil {
+		return nil, errors.Wrap(err, "collecttreegarbage.iteratetrees")
+	}
+	report := &TreeGCReport{TreeCount: len(c.trees), NodeCount: c.report.NodeCount}
+
+	rootTree, ok := c.trees[string(PrefixRootMerkleTree)]
+	if !ok {
+		report.Issues = c.report.Issues
+		return report, nil
+	}
+	rootClaims, err := c.walkRootTree(rootTree, opts.RetainedRoots)
+	if err != nil {
+		return nil, err
+	}
+
+	collected := []*checkedTree{rootTree}
+	didTrees := c.didTrees()
+	for _, claim := range rootClaims {
+		err = c.checkRootClaim(didTrees, claim)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, tree := range didTrees {
+		if tree.root != nil {
+			err = c.walk(tree, *tree.root, "the current root", c.checkDocument(tree))
+			if err != nil {
+				return nil, err
+			}
+		}
+		collected = append(collected, tree)
+	}
+	report.Issues = c.report.Issues
+
+	// the unreachable corrupt nodes are left for tree-check to report
+	garbage := map[*checkedTree][][]byte{}
+	for _, tree := range collected {
+		for key := range tree.nodes {
+			if tree.reachable[key] {
+				report.ReachableCount++
+				continue
+			}
+			report.ReclaimableCount++
+			report.ReclaimableBytes += tree.sizes[key]
+			garbage[tree] = append(garbage[tree], append([]byte{}, key[:]...))
+		}
+	}
+	if opts.DryRun {
+		return report, nil
+	}
+	for _, issue := range report.Issues {
+		if issue.Type == TreeIssueDangling {
+			return report, ErrTreeGCDanglingNodes
+		}
+	}
+
+	sort.Slice(collected, func(i, j int) bool { return collected[i].prefix < collected[j].prefix })
+	for _, tree := range collected {
+		keys := garbage[tree]
+		for start := 0; start < len(keys); start += opts.BatchSize {
+			end := start + opts.BatchSize
+			if end > len(keys) {
+				end = len(keys)
+			}
+			err = deleter.DeleteTreeNodes([]byte(tree.prefix), keys[start:end])
+			if err != nil {
+				return report, errors.Wrap(err, "collecttreegarbage.deletetreenodes")
+			}
+			report.DeletedCount += end - start
+			log.Infof("Deleted %v of %v unreachable nodes from %v", end, len(keys), tree.prefix)
+		}
+	}
+	return report, nil
+}
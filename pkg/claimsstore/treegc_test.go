@@ -0,0 +1,108 @@
+package claimsstore_test
+
+import (
+	"testing"
+
+	"github.com/iden3/go-iden3-core/db"
+	"github.com/iden3/go-iden3-core/merkletree"
+	"github.com/joincivil/id-hub/pkg/claimsstore"
+	"github.com/joincivil/id-hub/pkg/testutils"
+	didlib "github.com/ockam-network/did"
+)
+
+type gcStorage interface {
+	db.Storage
+	claimsstore.TreeIterator
+	claimsstore.TreeNodeDeleter
+}
+
+func TestCollectTreeGarbage(t *testing.T) {
+	store, cleaner := levelDBStore(t)
+	defer cleaner()
+
+	testCollectTreeGarbage(t, store, store)
+}
+
+func TestCollectTreeGarbagePG(t *testing.T) {
+	store, persister := pgStore(t)
+	cleaner := testutils.DeleteCreatedEntities(persister.DB)
+	defer cleaner()
+
+	testCollectTreeGarbage(t, store.(*claimsstore.PGStore), persister)
+}
+
+func testCollectTreeGarbage(t *testing.T, store gcStorage, index claimsstore.RootClaimIndex) {
+	did1, _ := didlib.Parse("did:ethuri:123456")
+	did2, _ := didlib.Parse("did:ethuri:654321")
+	addTestDocuments(t, store, index, did1, 3, true)
+
+	rootMt, err := merkletree.NewMerkleTree(store.WithPrefix(claimsstore.PrefixRootMerkleTree), 150)
+	if err != nil {
+		t.Fatalf("Should have loaded the root tree: err: %v", err)
+	}
+	committedRoot := *rootMt.RootKey()
+
+	addTestDocuments(t, store, index, did1, 2, true)
+	addTestDocuments(t, store, index, did2, 3, true)
+	addTestDocuments(t, store, index, did2, 1, false)
+
+	opts := claimsstore.TreeGCOptions{
+		RetainedRoots: []merkletree.Hash{committedRoot},
+		DryRun:        true,
+		BatchSize:     2,
+	}
+	report, err := claimsstore.CollectTreeGarbage(store, store, opts)
+	if err != nil {
+		t.Fatalf("Should have collected the garbage: err: %v", err)
+	}
+	if report.ReclaimableCount == 0 || report.ReclaimableBytes == 0 {
+		t.Errorf("Should have found reclaimable nodes in the root tree")
+	}
+	if report.DeletedCount != 0 {
+		t.Errorf("Should have not deleted any nodes in a dry run")
+	}
+	reclaimable := report.ReclaimableCount
+
+	opts.DryRun = false
+	report, err = claimsstore.CollectTreeGarbage(store, store, opts)
+	if err != nil {
+		t.Fatalf("Should have collected the garbage: err: %v", err)
+	}
+	if report.DeletedCount != reclaimable {
+		t.Errorf("Should have deleted %v nodes, deleted %v", reclaimable, report.DeletedCount)
+	}
+
+	check, err := claimsstore.CheckTrees(store, nil)
+	if err != nil {
+		t.Fatalf("Should have checked the trees: err: %v", err)
+	}
+	// the unanchored update of the did2 tree is kept
+	if len(check.Issues) != 1 || check.Issues[0].Type != claimsstore.TreeIssueOrphaned {
+		t.Errorf("Should have only found the unanchored did root: %v", check.Issues)
+	}
+
+	snapshot, err := rootMt.Snapshot(&committedRoot)
+	if err != nil {
+		t.Fatalf("Should have kept the retained root: err: %v", err)
+	}
+	claim, err := index.GetLatestRootClaimInSnapshot(did1, snapshot)
+	if err != nil {
+		t.Fatalf("Should have found the root claim in the retained root: err: %v", err)
+	}
+	if claim.Version != 2 {
+		t.Errorf("Should have found root claim version 2, got %v", claim.Version)
+	}
+
+	opts.RetainedRoots = nil
+	report, err = claimsstore.CollectTreeGarbage(store, store, opts)
+	if err != nil {
+		t.Fatalf("Should have collected the garbage: err: %v", err)
+	}
+	if report.DeletedCount == 0 {
+		t.Errorf("Should have deleted the nodes of the root that is no longer retained")
+	}
+	_, err = rootMt.Snapshot(&committedRoot)
+	if err == nil {
+		t.Errorf("Should have deleted the root that is no longer retained")
+	}
+}
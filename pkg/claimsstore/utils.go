@@ -8,12 +8,19 @@ import (
 var (
 	// PrefixRootMerkleTree prefix value for root tree
 	PrefixRootMerkleTree = []byte("root_merkletree")
+	didTreePrefix        = []byte("did:")
 	// ErrTooLongDIDMethod did to binary is a bit fragile for now, fails when method is more than 15 bytes
 	ErrTooLongDIDMethod = errors.New("method string is too long to fit in merkletree elembytes")
 	// ErrWrongSizByteSliceDID if the slice is the wrong size can't convert it back to a did
 	ErrWrongSizByteSliceDID = errors.New("binaryToDID expects a byte slice of length 32")
 )
 
+// isTreePrefix returns true if the prefix is of the root tree or a did tree,
+// the trees of other prefixes, like a tree-rebuild --prefix, are not iterated
+func isTreePrefix(prefix []byte) bool {
+	return bytes.Equal(prefix, PrefixRootMerkleTree) || bytes.HasPrefix(prefix, didTreePrefix)
+}
+
 // Concat is  a internal method from iden3 db that seemed necessary to implement the interface
 func Concat(vs ...[]byte) []byte {
 	var b bytes.Buffer
@@ -0,0 +1,107 @@
+package claimtypes
+
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iden3/go-iden3-core/core"
+	"github.com/iden3/go-iden3-core/merkletree"
+	didlib "github.com/ockam-network/did"
+
+	didhub "github.com/joincivil/id-hub/pkg/did"
+)
+
+// ClaimTypeDIDDocumentVersion is the type indicator for the did document version claim
+var ClaimTypeDIDDocumentVersion = core.NewClaimTypeNum(12)
+
+// ClaimDIDDocumentVersion is a claim type for registering a version of the did
+// document of a did in its merkle tree. The index is the did and the document
+// version, so there is one claim for each version.
+type ClaimDIDDocumentVersion struct {
+	// Version is the claim version.
+	Version uint32
+	// DocVersion is the version of the did document, starting at 1
+	DocVersion uint32
+	// DID is the DID of the document represented in bytes
+	DID [32]byte
+	// DocumentHash is the keccak256 of the canonical json of the document
+	DocumentHash [32]byte
+}
+
+// HashDIDDocument returns the keccak256 of the canonical json of a did document
+func HashDIDDocument(doc *didhub.Document) ([32]byte, error) {
+	hash := [32]byte{}
+	js, err := json.Marshal(doc)
+	if err != nil {
+		return hash, err
+	}
+	copy(hash[:], crypto.Keccak256(js))
+	return hash, nil
+}
+
+// NewClaimDIDDocumentVersion creates a new ClaimDIDDocumentVersion for a
+// version of a did document
+func NewClaimDIDDocumentVersion(doc *didhub.Document, docVersion uint32) (*ClaimDIDDocumentVersion, error) {
+	docHash, err := HashDIDDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	c, err := NewClaimDIDDocumentVersionIndex(&doc.ID, docVersion)
+	if err != nil {
+		return nil, err
+	}
+	c.DocumentHash = docHash
+	return c, nil
+}
+
+// NewClaimDIDDocumentVersionIndex creates a ClaimDIDDocumentVersion without
+// the document hash, it has the index of the claim for the version to look it
+// up in a tree
+func NewClaimDIDDocumentVersionIndex(did *didlib.DID, docVersion uint32) (*ClaimDIDDocumentVersion, error) {
+	didbytes, err := HashDID(did)
+	if err != nil {
+		return nil, err
+	}
+	didbytes32 := [32]byte{}
+	copy(didbytes32[:], didbytes[:])
+	return &ClaimDIDDocumentVersion{
+		Version:    0,
+		DocVersion: docVersion,
+		DID:        didbytes32,
+	}, nil
+}
+
+// NewClaimDIDDocumentVersionFromEntry turns a merkletree Entry into a ClaimDIDDocumentVersion
+func NewClaimDIDDocumentVersionFromEntry(e *merkletree.Entry) *ClaimDIDDocumentVersion {
+	c := &ClaimDIDDocumentVersion{}
+	_, c.Version = core.GetClaimTypeVersionFromData(&e.Data)
+
+	var docVersion [4]byte
+	copyFromElemBytes(docVersion[:], core.ClaimTypeVersionLen, &e.Data[3])
+	c.DocVersion = binary.BigEndian.Uint32(docVersion[:])
+
+	copyFromElemBytes(c.DID[:], 0, &e.Data[2])
+
+	copyFromElemBytes(c.DocumentHash[1:], 0, &e.Data[1])
+	copyFromElemBytes(c.DocumentHash[0:1], 0, &e.Data[0])
+	return c
+}
+
+// Entry converts the ClaimDIDDocumentVersion into a merkletree entry
+func (c ClaimDIDDocumentVersion) Entry() *merkletree.Entry {
+	e := &merkletree.Entry{}
+	core.SetClaimTypeVersion(e, c.Type(), c.Version)
+	var docVersion [4]byte
+	binary.BigEndian.PutUint32(docVersion[:], c.DocVersion)
+	copyToElemBytes(&e.Data[3], core.ClaimTypeVersionLen, docVersion[:])
+	copyToElemBytes(&e.Data[2], 0, c.DID[:])
+	copyToElemBytes(&e.Data[1], 0, c.DocumentHash[1:])
+	copyToElemBytes(&e.Data[0], 0, c.DocumentHash[0:1])
+	return e
+}
+
+// Type returns the type of the claim
+func (c *ClaimDIDDocumentVersion) Type() core.ClaimType {
+	return *ClaimTypeDIDDocumentVersion
+}
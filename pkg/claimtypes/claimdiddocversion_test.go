@@ -0,0 +1,55 @@
+package claimtypes_test
+
+import (
+	"testing"
+
+	"github.com/joincivil/id-hub/pkg/claimtypes"
+	"github.com/joincivil/id-hub/pkg/testutils"
+)
+
+func TestClaimDIDDocumentVersion(t *testing.T) {
+	doc := testutils.BuildTestDocument()
+	claim, err := claimtypes.NewClaimDIDDocumentVersion(doc, 3)
+	if err != nil {
+		t.Fatalf("error making claim: %v", err)
+	}
+	hash, err := claimtypes.HashDIDDocument(doc)
+	if err != nil {
+		t.Fatalf("error hashing document: %v", err)
+	}
+	if claim.DocumentHash != hash || claim.DocVersion != 3 {
+		t.Errorf("wrong claim values")
+	}
+
+	entry := claim.Entry()
+	recovered, err := claimtypes.NewClaimFromEntry(entry)
+	if err != nil {
+		t.Fatalf("error recovering claim: %v", err)
+	}
+	claim2, ok := recovered.(*claimtypes.ClaimDIDDocumentVersion)
+	if !ok {
+		t.Fatalf("should have recovered a document version claim: %T", recovered)
+	}
+	if *claim2 != *claim {
+		t.Errorf("couldn't successfully recover claim from entry")
+	}
+
+	// The index does not depend on the document
+	index, err := claimtypes.NewClaimDIDDocumentVersionIndex(&doc.ID, 3)
+	if err != nil {
+		t.Fatalf("error making claim index: %v", err)
+	}
+	if index.Entry().HIndex().String() != entry.HIndex().String() {
+		t.Errorf("index should match the claim")
+	}
+	next, _ := claimtypes.NewClaimDIDDocumentVersionIndex(&doc.ID, 4)
+	if next.Entry().HIndex().String() == entry.HIndex().String() {
+		t.Errorf("index of other versions should differ")
+	}
+
+	doc.Services = nil
+	changed, _ := claimtypes.NewClaimDIDDocumentVersion(doc, 3)
+	if changed.DocumentHash == claim.DocumentHash {
+		t.Errorf("document hash should change with the document")
+	}
+}
@@ -18,6 +18,10 @@ func NewClaimFromEntry(entry *merkletree.Entry) (merkletree.Claim, error) {
 			claim := NewClaimRegisteredDocumentFromEntry(entry)
 			return claim, nil
 		}
+		if claimType == *ClaimTypeDIDDocumentVersion {
+			claim := NewClaimDIDDocumentVersionFromEntry(entry)
+			return claim, nil
+		}
 		return nil, core.ErrInvalidClaimType
 	}
 
@@ -0,0 +1,146 @@
+package did
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// ServiceParam is the DID URL parameter selecting a service of the document
+	// by its id fragment
+	ServiceParam = "service"
+	// RelativeRefParam is the DID URL parameter with a relative reference that is
+	// resolved against the endpoint of the selected service
+	RelativeRefParam = "relativeRef"
+)
+
+var (
+	// ErrDereferenceNotFound is returned when the fragment or service of a DID URL
+	// is not found in the DID document
+	ErrDereferenceNotFound = errors.New("did url resource not found")
+	// ErrDereferenceUnsupported is returned for DID URLs with paths, or services
+	// without an endpoint URL to resolve a relative reference against
+	ErrDereferenceUnsupported = errors.New("did url dereferencing not supported")
+	// ErrDereferenceInvalidRelativeRef is returned for relative references with
+	// a scheme or host, which would replace the service endpoint
+	ErrDereferenceInvalidRelativeRef = errors.New("relative reference must not have a scheme or host")
+)
+
+// DereferenceResult is the resource a DID URL dereferences to, only one of its
+// fields is set
+type DereferenceResult struct {
+	// Document is set for a DID URL without a fragment or service
+	Document *Document
+	// PublicKey is set for a fragment of a verification method, or of a key
+	// embedded in a verification relationship
+	PublicKey *DocPublicKey
+	// Service is set for a fragment of a service, or a service parameter
+	// without an endpoint URL
+	Service *DocService
+	// ServiceEndpoint is set for a service parameter, it is the endpoint URL of the
+	// service with the relative reference resolved against it
+	ServiceEndpoint *string
+}
+
+// Dereference dereferences a DID URL. The DID document is resolved, or the
+// version in the versionId or versionTime parameters, then a service parameter
+// selects a service and resolves its endpoint URL with relativeRef, as a
+// RFC 3986 relative reference, and the fragment, otherwise the fragment selects
+// a key or service of the document.
+func (s *Service) Dereference(ctx context.Context, didURL string) (*DereferenceResult, error) {
+	d, params, err := ParseDIDURL(didURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "dereference.parsedidurl")
+	}
+	if d.Path != "" || len(d.PathSegments) > 0 {
+		return nil, ErrDereferenceUnsupported
+	}
+	fragment := d.Fragment
+
+	docDID := CopyDID(d)
+	docDID.Fragment = ""
+	versionID, versionTime, err := VersionFromParams(params)
+	if err != nil {
+		return nil, errors.Wrap(err, "dereference.versionfromparams")
+	}
+	var doc *Document
+	if versionID != "" || versionTime != nil {
+		doc, err = s.GetDocumentVersion(ctx, docDID, versionID, versionTime)
+	} else {
+		doc, err = s.GetDocumentFromDID(ctx, docDID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, ErrResolverDIDNotFound
+	}
+
+	if serviceID := params.Get(ServiceParam); serviceID != "" {
+		return dereferenceService(doc, serviceID, params.Get(RelativeRefParam), fragment)
+	}
+	if fragment == "" {
+		return &DereferenceResult{Document: doc}, nil
+	}
+	return dereferenceFragment(doc, fragment)
+}
+
+func dereferenceService(doc *Document, serviceID string, relativeRef string,
+	fragment string) (*DereferenceResult, error) {
+	srv, err := doc.GetServiceFromFragment(serviceID)
+	if err != nil {
+		return nil, ErrDereferenceNotFound
+	}
+	endpoint := srv.ServiceEndpointURI
+	if str, ok := srv.ServiceEndpoint.(string); endpoint == nil && ok {
+		endpoint = &str
+	}
+	if endpoint == nil {
+		if relativeRef != "" {
+			return nil, ErrDereferenceUnsupported
+		}
+		return &DereferenceResult{Service: srv}, nil
+	}
+
+	base, err := url.Parse(*endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "dereferenceservice.parse endpoint")
+	}
+	if relativeRef != "" {
+		ref, err := url.Parse(relativeRef)
+		if err != nil {
+			return nil, errors.Wrap(err, "dereferenceservice.parse relativeref")
+		}
+		if ref.IsAbs() || ref.Host != "" {
+			return nil, ErrDereferenceInvalidRelativeRef
+		}
+		base = base.ResolveReference(ref)
+	}
+	// The fragment of the DID URL applies unless the reference has its own
+	if fragment != "" && base.Fragment == "" {
+		base.Fragment = fragment
+	}
+	result := base.String()
+	return &DereferenceResult{ServiceEndpoint: &result}, nil
+}
+
+func dereferenceFragment(doc *Document, fragment string) (*DereferenceResult, error) {
+	key, err := doc.GetPublicKeyFromFragment(fragment)
+	if err == nil {
+		return &DereferenceResult{PublicKey: key}, nil
+	}
+	for _, rel := range doc.relationships() {
+		for _, v := range *rel {
+			if !v.IDOnly && v.ID != nil && v.ID.Fragment == fragment {
+				key := v.DocPublicKey
+				return &DereferenceResult{PublicKey: &key}, nil
+			}
+		}
+	}
+	if srv, err := doc.GetServiceFromFragment(fragment); err == nil {
+		return &DereferenceResult{Service: srv}, nil
+	}
+	return nil, ErrDereferenceNotFound
+}
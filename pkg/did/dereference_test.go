@@ -0,0 +1,132 @@
+package did_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/joincivil/id-hub/pkg/did"
+)
+
+const testDIDDocServices = `
+{
+	"@context": "https://www.w3.org/ns/did/v1",
+	"id": "did:example:123456789abcdefghi",
+	"verificationMethod": [
+		{
+		"id": "#keys-1",
+		"type": "EcdsaSecp256k1VerificationKey2019",
+		"controller": "did:example:123456789abcdefghi",
+		"publicKeyHex": "04f3df3cea421eac2a7f5dbd8e8d505470d42150334f512bd6383c7dc91bf8fa4d5458d498b4dcd05574c902fb4c233005b3f5f3ff3904b41be186ddbda600580b"
+		}
+	],
+	"keyAgreement": [
+		{
+		"id": "#keys-2",
+		"type": "X25519KeyAgreementKey2019",
+		"controller": "did:example:123456789abcdefghi",
+		"publicKeyBase58": "JhNWeSVLMYccCk7iopQW4guaSJTojqpMEELgSLhKwRr"
+		}
+	],
+	"service": [
+		{
+		"id": "#hub",
+		"type": "IdentityHub",
+		"serviceEndpoint": "https://hub.example.com/base/"
+		},
+		{
+		"id": "#agent",
+		"type": "AgentService",
+		"serviceEndpoint": {"@context": "https://example.com/agent", "uri": "https://agent.example.com"}
+		}
+	]
+}
+`
+
+func newDereferenceService(t *testing.T) *did.Service {
+	doc := &did.Document{}
+	err := json.Unmarshal([]byte(testDIDDocServices), doc)
+	if err != nil {
+		t.Fatalf("Should have unmarshalled document from json: err: %v", err)
+	}
+	return did.NewService([]did.Resolver{&StaticResolver{doc: doc}})
+}
+
+func TestDereference(t *testing.T) {
+	serv := newDereferenceService(t)
+	didStr := "did:example:123456789abcdefghi"
+
+	result, err := serv.Dereference(context.Background(), didStr)
+	if err != nil || result.Document == nil {
+		t.Errorf("Should have dereferenced the document: err: %v", err)
+	}
+
+	result, err = serv.Dereference(context.Background(), didStr+"#keys-1")
+	if err != nil || result.PublicKey == nil || result.PublicKey.PublicKeyHex == nil {
+		t.Errorf("Should have dereferenced the verification method: err: %v", err)
+	}
+	result, err = serv.Dereference(context.Background(), didStr+"#keys-2")
+	if err != nil || result.PublicKey == nil || result.PublicKey.PublicKeyBase58 == nil {
+		t.Errorf("Should have dereferenced the embedded key agreement key: err: %v", err)
+	}
+	result, err = serv.Dereference(context.Background(), didStr+"#agent")
+	if err != nil || result.Service == nil || result.Service.Type != "AgentService" {
+		t.Errorf("Should have dereferenced the service: err: %v", err)
+	}
+	_, err = serv.Dereference(context.Background(), didStr+"#keys-9")
+	if err != did.ErrDereferenceNotFound {
+		t.Errorf("Should have not found the fragment: err: %v", err)
+	}
+	_, err = serv.Dereference(context.Background(), "did:example:notfound#keys-1")
+	if err != did.ErrResolverDIDNotFound {
+		t.Errorf("Should have not found the did: err: %v", err)
+	}
+	_, err = serv.Dereference(context.Background(), didStr+"/path")
+	if err != did.ErrDereferenceUnsupported {
+		t.Errorf("Should not have dereferenced a path: err: %v", err)
+	}
+}
+
+func TestDereferenceService(t *testing.T) {
+	serv := newDereferenceService(t)
+	didStr := "did:example:123456789abcdefghi"
+
+	tests := []struct {
+		didURL   string
+		endpoint string
+	}{
+		{didStr + "?service=hub", "https://hub.example.com/base/"},
+		{didStr + "?service=hub&relativeRef=v1/query", "https://hub.example.com/base/v1/query"},
+		{didStr + "?service=hub&relativeRef=%2Fv1%2Fquery%3Fa%3Db", "https://hub.example.com/v1/query?a=b"},
+		{didStr + "?service=hub&relativeRef=v1#frag", "https://hub.example.com/base/v1#frag"},
+	}
+	for _, test := range tests {
+		result, err := serv.Dereference(context.Background(), test.didURL)
+		if err != nil {
+			t.Errorf("Should have dereferenced %v: err: %v", test.didURL, err)
+			continue
+		}
+		if result.ServiceEndpoint == nil || *result.ServiceEndpoint != test.endpoint {
+			t.Errorf("Should have resolved %v to %v: %v", test.didURL, test.endpoint, result.ServiceEndpoint)
+		}
+	}
+
+	result, err := serv.Dereference(context.Background(), didStr+"?service=agent")
+	if err != nil || result.Service == nil {
+		t.Errorf("Should have returned the service without an endpoint url: err: %v", err)
+	}
+	_, err = serv.Dereference(context.Background(), didStr+"?service=agent&relativeRef=/path")
+	if err != did.ErrDereferenceUnsupported {
+		t.Errorf("Should not have resolved a reference without an endpoint url: err: %v", err)
+	}
+	for _, ref := range []string{"https://evil.example.com/x", "%2F%2Fevil.example.com%2Fx", "javascript:alert(1)"} {
+		_, err = serv.Dereference(context.Background(), didStr+"?service=hub&relativeRef="+ref)
+		if err != did.ErrDereferenceInvalidRelativeRef {
+			t.Errorf("Should not have resolved the reference %v: err: %v", ref, err)
+		}
+	}
+	_, err = serv.Dereference(context.Background(), didStr+"?service=none")
+	if err != did.ErrDereferenceNotFound {
+		t.Errorf("Should have not found the service: err: %v", err)
+	}
+}
@@ -0,0 +1,520 @@
+package ethr
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/mr-tron/base58"
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/linkeddata"
+)
+
+// Implements the did.Resolver interface, so can be passed into did.Service
+
+const (
+	// Method is the did method of did:ethr
+	Method = "ethr"
+
+	// RegistryABI is the ABI of the events and the changed function of the
+	// ERC-1056 EthereumDIDRegistry
+	RegistryABI = `[
+	{"constant":true,"name":"changed","type":"function","stateMutability":"view",
+		"inputs":[{"name":"","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"anonymous":false,"name":"DIDOwnerChanged","type":"event","inputs":[
+		{"indexed":true,"name":"identity","type":"address"},
+		{"indexed":false,"name":"owner","type":"address"},
+		{"indexed":false,"name":"previousChange","type":"uint256"}]},
+	{"anonymous":false,"name":"DIDDelegateChanged","type":"event","inputs":[
+		{"indexed":true,"name":"identity","type":"address"},
+		{"indexed":false,"name":"delegateType","type":"bytes32"},
+		{"indexed":false,"name":"delegate","type":"address"},
+		{"indexed":false,"name":"validTo","type":"uint256"},
+		{"indexed":false,"name":"previousChange","type":"uint256"}]},
+	{"anonymous":false,"name":"DIDAttributeChanged","type":"event","inputs":[
+		{"indexed":true,"name":"identity","type":"address"},
+		{"indexed":false,"name":"name","type":"bytes32"},
+		{"indexed":false,"name":"value","type":"bytes"},
+		{"indexed":false,"name":"validTo","type":"uint256"},
+		{"indexed":false,"name":"previousChange","type":"uint256"}]}
+	]`
+
+	// MethodChanged is the registry function returning the block of the last
+	// change of an identity
+	MethodChanged = "changed"
+
+	// EventOwnerChanged is the event emitted when the owner of an identity changes
+	EventOwnerChanged = "DIDOwnerChanged"
+	// EventDelegateChanged is the event emitted when a delegate is added or revoked
+	EventDelegateChanged = "DIDDelegateChanged"
+	// EventAttributeChanged is the event emitted when an attribute is set or revoked
+	EventAttributeChanged = "DIDAttributeChanged"
+
+	// DelegateTypeVeriKey is the delegate type of keys that verify signatures
+	DelegateTypeVeriKey = "veriKey"
+	// DelegateTypeSigAuth is the delegate type of keys that also authenticate
+	DelegateTypeSigAuth = "sigAuth"
+
+	ownerFragment    = "owner"
+	delegateFragment = "delegate"
+	serviceFragment  = "service"
+
+	// attribute names are did/pub/<algorithm>/<purpose>/<encoding> for public
+	// keys and did/svc/<type> for services
+	attributePubPrefix = "did/pub/"
+	attributeSvcPrefix = "did/svc/"
+	// attributePurposeEnc is the purpose of encryption keys
+	attributePurposeEnc = "enc"
+)
+
+type ownerChanged struct {
+	Owner          common.Address
+	PreviousChange *big.Int
+}
+
+type delegateChanged struct {
+	DelegateType   [32]byte
+	Delegate       common.Address
+	ValidTo        *big.Int
+	PreviousChange *big.Int
+}
+
+type attributeChanged struct {
+	Name           [32]byte
+	Value          []byte
+	ValidTo        *big.Int
+	PreviousChange *big.Int
+}
+
+// Backend is the ethereum client the registry is read with
+type Backend interface {
+	ethereum.ContractCaller
+	ethereum.LogFilterer
+}
+
+// Resolver builds the did documents of did:ethr dids from the events of an
+// ERC-1056 registry. The events of an identity are read block by block from
+// the block of its last change, following the previousChange of the events,
+// and later events override earlier ones.
+//
+// Owner and delegate keys only have an ethereumAddress. Request signatures are
+// verified with them by recovering the address, but JWTs and credentials can
+// only be verified with the public keys set as attributes.
+type Resolver struct {
+	registry    common.Address
+	backend     Backend
+	network     string
+	cache       did.ResolverCache
+	registryABI abi.ABI
+	now         func() time.Time
+}
+
+// NewResolver returns a new did:ethr Resolver that reads the registry at
+// registry with backend. dids with a network, like
+// did:ethr:rinkeby:0x..., only resolve if it is network, dids without one
+// resolve against the registry. Documents are not cached if cache is nil.
+func NewResolver(registry common.Address, backend Backend, network string,
+	cache did.ResolverCache) (*Resolver, error) {
+	registryABI, err := abi.JSON(strings.NewReader(RegistryABI))
+	if err != nil {
+		return nil, errors.Wrap(err, "newresolver.json")
+	}
+	return &Resolver{
+		registry:    registry,
+		backend:     backend,
+		network:     network,
+		cache:       cache,
+		registryABI: registryABI,
+		now:         time.Now,
+	}, nil
+}
+
+// Resolve implements the did.Resolver interface and returns the did document of
+// a did:ethr did
+func (r *Resolver) Resolve(ctx context.Context, d *didlib.DID) (*did.Document, error) {
+	if d.Method != Method {
+		return nil, did.ErrResolverDIDNotFound
+	}
+	identity, err := r.identityAddress(d)
+	if err != nil {
+		return nil, err
+	}
+	docDID, err := didlib.Parse(did.MethodIDOnly(d))
+	if err != nil {
+		return nil, errors.Wrap(err, "resolve.parse")
+	}
+
+	if r.cache != nil {
+		doc, err := r.cache.Get(docDID)
+		if err != nil && errors.Cause(err) != did.ErrResolverCacheDIDNotFound {
+			return nil, errors.Wrap(err, "resolve.cache.get")
+		}
+		if doc != nil {
+			return doc, nil
+		}
+	}
+
+	logs, err := r.changeLogs(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := r.buildDocument(docDID, identity, logs)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.cache != nil {
+		err = r.cache.Set(docDID, doc)
+		if err != nil {
+			return nil, errors.Wrap(err, "resolve.cache.set")
+		}
+	}
+	return doc, nil
+}
+
+// changeLogs returns the events of identity, reading the logs of the block of
+// its last change and then of the previousChange blocks of the events until
+// the first change
+func (r *Resolver) changeLogs(ctx context.Context, identity common.Address) ([]types.Log, error) {
+	data, err := r.registryABI.Pack(MethodChanged, identity)
+	if err != nil {
+		return nil, errors.Wrap(err, "changelogs.pack")
+	}
+	result, err := r.backend.CallContract(ctx, ethereum.CallMsg{To: &r.registry, Data: data}, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "changelogs.callcontract")
+	}
+	block := new(big.Int)
+	err = r.registryABI.Unpack(&block, MethodChanged, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "changelogs.unpack")
+	}
+
+	allLogs := []types.Log{}
+	for block.Sign() > 0 {
+		logs, err := r.backend.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: block,
+			ToBlock:   block,
+			Addresses: []common.Address{r.registry},
+			Topics:    [][]common.Hash{nil, {common.BytesToHash(identity.Bytes())}},
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "changelogs.filterlogs")
+		}
+
+		// The first change in a block points to the previous block, the
+		// changes after it to the block itself
+		previous := big.NewInt(0)
+		for _, l := range logs {
+			if l.Removed {
+				continue
+			}
+			change, err := r.previousChange(l)
+			if err != nil {
+				return nil, err
+			}
+			if change != nil && change.Cmp(block) < 0 && change.Cmp(previous) > 0 {
+				previous = change
+			}
+			allLogs = append(allLogs, l)
+		}
+		block = previous
+	}
+	return allLogs, nil
+}
+
+// previousChange returns the previousChange of a registry event, or nil if the
+// log is not a registry event
+func (r *Resolver) previousChange(l types.Log) (*big.Int, error) {
+	if len(l.Topics) == 0 {
+		return nil, nil
+	}
+	var err error
+	var change *big.Int
+	switch l.Topics[0] {
+	case r.registryABI.Events[EventOwnerChanged].ID():
+		event := &ownerChanged{}
+		err = r.registryABI.Unpack(event, EventOwnerChanged, l.Data)
+		change = event.PreviousChange
+	case r.registryABI.Events[EventDelegateChanged].ID():
+		event := &delegateChanged{}
+		err = r.registryABI.Unpack(event, EventDelegateChanged, l.Data)
+		change = event.PreviousChange
+	case r.registryABI.Events[EventAttributeChanged].ID():
+		event := &attributeChanged{}
+		err = r.registryABI.Unpack(event, EventAttributeChanged, l.Data)
+		change = event.PreviousChange
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "previouschange.unpack")
+	}
+	return change, nil
+}
+
+// identityAddress returns the address in a did:ethr did
+func (r *Resolver) identityAddress(d *didlib.DID) (common.Address, error) {
+	ids := d.IDStrings
+	if len(ids) == 2 {
+		if ids[0] != r.network {
+			return common.Address{}, did.ErrResolverDIDNotFound
+		}
+		ids = ids[1:]
+	}
+	if len(ids) != 1 || !strings.HasPrefix(ids[0], "0x") || !common.IsHexAddress(ids[0]) {
+		return common.Address{}, errors.Errorf("not a did:ethr did: %v", d.String())
+	}
+	return common.HexToAddress(ids[0]), nil
+}
+
+// docEntry is a delegate or attribute of the identity, the latest event for it
+// sets its validity
+type docEntry struct {
+	delegate  *delegateChanged
+	attribute *attributeChanged
+	validTo   *big.Int
+}
+
+func (r *Resolver) buildDocument(docDID *didlib.DID, identity common.Address,
+	logs []types.Log) (*did.Document, error) {
+	sort.SliceStable(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber < logs[j].BlockNumber
+		}
+		return logs[i].Index < logs[j].Index
+	})
+
+	owner := identity
+	entries := map[string]*docEntry{}
+	order := []string{}
+	setEntry := func(key string, entry *docEntry) {
+		if _, ok := entries[key]; !ok {
+			order = append(order, key)
+		}
+		entries[key] = entry
+	}
+
+	for _, l := range logs {
+		if len(l.Topics) == 0 || l.Removed {
+			continue
+		}
+		switch l.Topics[0] {
+		case r.registryABI.Events[EventOwnerChanged].ID():
+			event := &ownerChanged{}
+			err := r.registryABI.Unpack(event, EventOwnerChanged, l.Data)
+			if err != nil {
+				return nil, errors.Wrap(err, "builddocument.unpack owner")
+			}
+			owner = event.Owner
+
+		case r.registryABI.Events[EventDelegateChanged].ID():
+			event := &delegateChanged{}
+			err := r.registryABI.Unpack(event, EventDelegateChanged, l.Data)
+			if err != nil {
+				return nil, errors.Wrap(err, "builddocument.unpack delegate")
+			}
+			key := fmt.Sprintf("delegate-%x-%v", event.DelegateType, event.Delegate.Hex())
+			setEntry(key, &docEntry{delegate: event, validTo: event.ValidTo})
+
+		case r.registryABI.Events[EventAttributeChanged].ID():
+			event := &attributeChanged{}
+			err := r.registryABI.Unpack(event, EventAttributeChanged, l.Data)
+			if err != nil {
+				return nil, errors.Wrap(err, "builddocument.unpack attribute")
+			}
+			key := fmt.Sprintf("attribute-%x-%x", event.Name, event.Value)
+			setEntry(key, &docEntry{attribute: event, validTo: event.ValidTo})
+		}
+	}
+
+	doc := &did.Document{
+		Context:    did.DefaultDIDContextV1,
+		ID:         *docDID,
+		PublicKeys: []did.DocPublicKey{},
+		Services:   []did.DocService{},
+	}
+	ownerAddress := owner.Hex()
+	ownerKey := &did.DocPublicKey{
+		ID:              did.CopyDID(docDID),
+		Type:            linkeddata.SuiteTypeSecp256k1Verification2018,
+		Owner:           did.CopyDID(docDID),
+		EthereumAddress: &ownerAddress,
+	}
+	ownerKey.ID.Fragment = ownerFragment
+	err := doc.AddPublicKey(ownerKey, true, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "builddocument.addpublickey owner")
+	}
+	err = doc.AddKeyToRelationship(ownerKey.ID, did.RelationshipAssertionMethod)
+	if err != nil {
+		return nil, errors.Wrap(err, "builddocument.addkeytorelationship owner")
+	}
+
+	now := big.NewInt(r.now().Unix())
+	keyCount := 0
+	serviceCount := 0
+	for _, key := range order {
+		entry := entries[key]
+		if entry.validTo == nil || entry.validTo.Cmp(now) <= 0 {
+			continue
+		}
+		if entry.delegate != nil {
+			keyCount++
+			err = addDelegateKey(doc, entry.delegate, keyCount)
+		} else if strings.HasPrefix(bytes32String(entry.attribute.Name), attributeSvcPrefix) {
+			serviceCount++
+			err = addAttributeService(doc, entry.attribute, serviceCount)
+		} else {
+			keyCount++
+			err = addAttributeKey(doc, entry.attribute, keyCount)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The document is derived from the registry, it has no update time of its own
+	doc.Updated = nil
+	return doc, nil
+}
+
+func addDelegateKey(doc *did.Document, event *delegateChanged, n int) error {
+	var suiteType linkeddata.SuiteType
+	auth := false
+	switch bytes32String(event.DelegateType) {
+	case DelegateTypeVeriKey:
+		suiteType = linkeddata.SuiteTypeSecp256k1Verification2018
+	case DelegateTypeSigAuth:
+		suiteType = linkeddata.SuiteTypeSecp256k1SignatureAuth2018
+		auth = true
+	default:
+		return nil
+	}
+	address := event.Delegate.Hex()
+	key := &did.DocPublicKey{
+		ID:              did.CopyDID(&doc.ID),
+		Type:            suiteType,
+		Owner:           did.CopyDID(&doc.ID),
+		EthereumAddress: &address,
+	}
+	key.ID.Fragment = fmt.Sprintf("%v-%v", delegateFragment, n)
+	err := doc.AddPublicKey(key, auth, false)
+	if err != nil {
+		return errors.Wrap(err, "adddelegatekey.addpublickey")
+	}
+	err = doc.AddKeyToRelationship(key.ID, did.RelationshipAssertionMethod)
+	if err != nil {
+		return errors.Wrap(err, "adddelegatekey.addkeytorelationship")
+	}
+	return nil
+}
+
+// addAttributeKey adds a public key set with a did/pub/<algorithm>/<purpose>/<encoding>
+// attribute, unsupported algorithms and encodings are ignored
+func addAttributeKey(doc *did.Document, event *attributeChanged, n int) error {
+	parts := strings.Split(strings.TrimPrefix(bytes32String(event.Name), attributePubPrefix), "/")
+	if !strings.HasPrefix(bytes32String(event.Name), attributePubPrefix) || len(parts) < 2 {
+		return nil
+	}
+	algorithm, purpose := parts[0], parts[1]
+	encoding := "hex"
+	if len(parts) > 2 {
+		encoding = parts[2]
+	}
+
+	var suiteType linkeddata.SuiteType
+	auth := false
+	switch algorithm {
+	case "Secp256k1":
+		suiteType = linkeddata.SuiteTypeSecp256k1Verification2018
+		if purpose == DelegateTypeSigAuth {
+			suiteType = linkeddata.SuiteTypeSecp256k1SignatureAuth2018
+			auth = true
+		}
+	case "Ed25519":
+		suiteType = linkeddata.SuiteTypeEd25519Verification
+	case "Rsa":
+		suiteType = linkeddata.SuiteTypeRsaVerification
+	default:
+		return nil
+	}
+
+	key := &did.DocPublicKey{
+		ID:    did.CopyDID(&doc.ID),
+		Type:  suiteType,
+		Owner: did.CopyDID(&doc.ID),
+	}
+	key.ID.Fragment = fmt.Sprintf("%v-%v", delegateFragment, n)
+	switch encoding {
+	case "hex":
+		value := hex.EncodeToString(event.Value)
+		key.PublicKeyHex = &value
+	case "base64":
+		value := base64.StdEncoding.EncodeToString(event.Value)
+		key.PublicKeyBase64 = &value
+	case "base58":
+		value := base58.Encode(event.Value)
+		key.PublicKeyBase58 = &value
+	default:
+		return nil
+	}
+	err := doc.AddPublicKey(key, auth, false)
+	if err != nil {
+		return errors.Wrap(err, "addattributekey.addpublickey")
+	}
+	// Encryption keys are for key agreement, the others sign
+	rel := did.RelationshipAssertionMethod
+	if purpose == attributePurposeEnc {
+		rel = did.RelationshipKeyAgreement
+	}
+	err = doc.AddKeyToRelationship(key.ID, rel)
+	if err != nil {
+		return errors.Wrap(err, "addattributekey.addkeytorelationship")
+	}
+	return nil
+}
+
+// addAttributeService adds a service set with a did/svc/<type> attribute, the
+// value is the endpoint
+func addAttributeService(doc *did.Document, event *attributeChanged, n int) error {
+	endpoint := string(event.Value)
+	srv := &did.DocService{
+		ID:                 *did.CopyDID(&doc.ID),
+		Type:               strings.TrimPrefix(bytes32String(event.Name), attributeSvcPrefix),
+		ServiceEndpoint:    endpoint,
+		ServiceEndpointURI: &endpoint,
+	}
+	srv.ID.Fragment = fmt.Sprintf("%v-%v", serviceFragment, n)
+	err := doc.AddService(srv)
+	if err != nil {
+		return errors.Wrap(err, "addattributeservice.addservice")
+	}
+	return nil
+}
+
+// bytes32String returns the string in a right zero padded bytes32
+func bytes32String(b [32]byte) string {
+	return string(bytes.TrimRight(b[:], "\x00"))
+}
+
+// StringToBytes32 returns a string as a right zero padded bytes32, it is
+// truncated if longer than 32 bytes
+func StringToBytes32(s string) [32]byte {
+	b := [32]byte{}
+	copy(b[:], s)
+	return b
+}
@@ -0,0 +1,316 @@
+package ethr_test
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/joincivil/go-common/pkg/eth"
+	didlib "github.com/ockam-network/did"
+
+	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/did/ethr"
+	"github.com/joincivil/id-hub/pkg/linkeddata"
+)
+
+// registryCode deploys a contract that stands in for the registry in tests.
+// Called with a changed(address) calldata it returns the block stored for the
+// address. Otherwise it copies the calldata to memory, emits LOG2 with the
+// first two words as the topics and the rest as the data, and stores the block
+// number for the second word, the identity:
+//
+//	PUSH1 0x24 CALLDATASIZE EQ PUSH1 0x20 JUMPI
+//	CALLDATASIZE PUSH1 0 PUSH1 0 CALLDATACOPY
+//	PUSH1 0x20 MLOAD PUSH1 0 MLOAD
+//	PUSH1 0x40 CALLDATASIZE SUB PUSH1 0x40 LOG2
+//	NUMBER PUSH1 0x20 MLOAD SSTORE STOP
+//	JUMPDEST PUSH1 4 CALLDATALOAD SLOAD PUSH1 0 MSTORE PUSH1 0x20 PUSH1 0 RETURN
+//
+// after init code that returns the 45 byte runtime code.
+const registryCode = "602d600c600039602d6000f3" +
+	"60243614602057366000600037602051600051604036036040a2" +
+	"4360205155005b6004355460005260206000f3"
+
+type testRegistry struct {
+	t           *testing.T
+	helper      *eth.Helper
+	blockchain  *backends.SimulatedBackend
+	address     common.Address
+	registryABI abi.ABI
+	changed     map[common.Address]*big.Int
+}
+
+func newTestRegistry(t *testing.T) *testRegistry {
+	helper, err := eth.NewSimulatedBackendHelper()
+	if err != nil {
+		t.Fatalf("error constructing blockchain helper: err: %v", err)
+	}
+	blockchain := helper.Blockchain.(*backends.SimulatedBackend)
+	address, _, _, err := bind.DeployContract(helper.Auth, abi.ABI{},
+		common.FromHex(registryCode), blockchain)
+	if err != nil {
+		t.Fatalf("error deploying registry: err: %v", err)
+	}
+	blockchain.Commit()
+
+	registryABI, err := abi.JSON(strings.NewReader(ethr.RegistryABI))
+	if err != nil {
+		t.Fatalf("error parsing registry abi: err: %v", err)
+	}
+	return &testRegistry{
+		t:           t,
+		helper:      helper,
+		blockchain:  blockchain,
+		address:     address,
+		registryABI: registryABI,
+		changed:     map[common.Address]*big.Int{},
+	}
+}
+
+// emit emits a registry event for identity with the block of its last change
+// as previousChange and mines it
+func (r *testRegistry) emit(identity common.Address, name string, args ...interface{}) {
+	previousChange, ok := r.changed[identity]
+	if !ok {
+		previousChange = big.NewInt(0)
+	}
+	r.emitWithPreviousChange(identity, previousChange, name, args...)
+}
+
+// emitWithPreviousChange emits a registry event for identity with
+// previousChange and mines it
+func (r *testRegistry) emitWithPreviousChange(identity common.Address, previousChange *big.Int,
+	name string, args ...interface{}) {
+	event := r.registryABI.Events[name]
+	data, err := event.Inputs.NonIndexed().Pack(append(args, previousChange)...)
+	if err != nil {
+		r.t.Fatalf("error packing event: err: %v", err)
+	}
+	calldata := append(event.ID().Bytes(), common.BytesToHash(identity.Bytes()).Bytes()...)
+	calldata = append(calldata, data...)
+
+	ctx := context.Background()
+	nonce, err := r.blockchain.PendingNonceAt(ctx, r.helper.Auth.From)
+	if err != nil {
+		r.t.Fatalf("error getting nonce: err: %v", err)
+	}
+	tx := types.NewTransaction(nonce, r.address, big.NewInt(0), 200000, big.NewInt(1), calldata)
+	signed, err := r.helper.Auth.Signer(types.HomesteadSigner{}, r.helper.Auth.From, tx)
+	if err != nil {
+		r.t.Fatalf("error signing tx: err: %v", err)
+	}
+	err = r.blockchain.SendTransaction(ctx, signed)
+	if err != nil {
+		r.t.Fatalf("error sending tx: err: %v", err)
+	}
+	r.blockchain.Commit()
+
+	receipt, err := r.blockchain.TransactionReceipt(ctx, signed.Hash())
+	if err != nil || receipt.Status != types.ReceiptStatusSuccessful || len(receipt.Logs) != 1 {
+		r.t.Fatalf("event was not emitted: err: %v", err)
+	}
+	r.changed[identity] = receipt.BlockNumber
+}
+
+func newAddress(t *testing.T) common.Address {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("error generating key: err: %v", err)
+	}
+	return crypto.PubkeyToAddress(key.PublicKey)
+}
+
+func validTo(d time.Duration) *big.Int {
+	return big.NewInt(time.Now().Add(d).Unix())
+}
+
+func TestResolveNoEvents(t *testing.T) {
+	registry := newTestRegistry(t)
+	resolver, err := ethr.NewResolver(registry.address, registry.blockchain, "", nil)
+	if err != nil {
+		t.Fatalf("error creating resolver: err: %v", err)
+	}
+
+	identity := newAddress(t)
+	d, _ := didlib.Parse("did:ethr:" + identity.Hex())
+	doc, err := resolver.Resolve(context.Background(), d)
+	if err != nil {
+		t.Fatalf("error resolving: err: %v", err)
+	}
+	if doc.ID.String() != d.String() {
+		t.Errorf("wrong doc id: %v", doc.ID.String())
+	}
+	if len(doc.PublicKeys) != 1 || len(doc.Authentications) != 1 {
+		t.Fatalf("should have only the owner key: %v", doc.String())
+	}
+	ownerKey := doc.PublicKeys[0]
+	if ownerKey.ID.Fragment != "owner" || ownerKey.Type != linkeddata.SuiteTypeSecp256k1Verification2018 {
+		t.Errorf("wrong owner key: %v", ownerKey.ID.String())
+	}
+	if *ownerKey.EthereumAddress != identity.Hex() {
+		t.Errorf("the identity should own itself: %v", *ownerKey.EthereumAddress)
+	}
+	if doc.Authentications[0].ID.String() != ownerKey.ID.String() {
+		t.Errorf("owner should authenticate")
+	}
+}
+
+func TestResolveEvents(t *testing.T) {
+	registry := newTestRegistry(t)
+	resolver, err := ethr.NewResolver(registry.address, registry.blockchain, "", nil)
+	if err != nil {
+		t.Fatalf("error creating resolver: err: %v", err)
+	}
+
+	identity := newAddress(t)
+	other := newAddress(t)
+	owner := newAddress(t)
+	veriKey := newAddress(t)
+	sigAuth := newAddress(t)
+	revoked := newAddress(t)
+	zero := big.NewInt(0)
+
+	registry.emit(identity, ethr.EventOwnerChanged, owner)
+	registry.emit(identity, ethr.EventDelegateChanged,
+		ethr.StringToBytes32(ethr.DelegateTypeVeriKey), veriKey, validTo(time.Hour))
+	registry.emit(identity, ethr.EventDelegateChanged,
+		ethr.StringToBytes32(ethr.DelegateTypeSigAuth), sigAuth, validTo(time.Hour))
+	registry.emit(identity, ethr.EventDelegateChanged,
+		ethr.StringToBytes32(ethr.DelegateTypeVeriKey), revoked, validTo(time.Hour))
+	registry.emit(identity, ethr.EventDelegateChanged,
+		ethr.StringToBytes32(ethr.DelegateTypeVeriKey), revoked, zero)
+	registry.emit(identity, ethr.EventAttributeChanged,
+		ethr.StringToBytes32("did/pub/Secp256k1/veriKey/hex"), []byte{0x02, 0xab, 0xcd},
+		validTo(time.Hour))
+	registry.emit(identity, ethr.EventAttributeChanged,
+		ethr.StringToBytes32("did/pub/Ed25519/veriKey/base64"), []byte{0x01, 0x02},
+		validTo(-time.Hour))
+	registry.emit(identity, ethr.EventAttributeChanged,
+		ethr.StringToBytes32("did/svc/HubService"), []byte("https://hub.example.com"),
+		validTo(time.Hour))
+	registry.emit(other, ethr.EventOwnerChanged, newAddress(t))
+
+	d, _ := didlib.Parse("did:ethr:" + identity.Hex())
+	doc, err := resolver.Resolve(context.Background(), d)
+	if err != nil {
+		t.Fatalf("error resolving: err: %v", err)
+	}
+
+	if len(doc.PublicKeys) != 4 {
+		t.Fatalf("should have 4 keys: %v", doc.String())
+	}
+	expected := []struct {
+		fragment  string
+		suiteType linkeddata.SuiteType
+		address   string
+		hex       string
+	}{
+		{"owner", linkeddata.SuiteTypeSecp256k1Verification2018, owner.Hex(), ""},
+		{"delegate-1", linkeddata.SuiteTypeSecp256k1Verification2018, veriKey.Hex(), ""},
+		{"delegate-2", linkeddata.SuiteTypeSecp256k1SignatureAuth2018, sigAuth.Hex(), ""},
+		{"delegate-3", linkeddata.SuiteTypeSecp256k1Verification2018, "", "02abcd"},
+	}
+	for i, e := range expected {
+		key := doc.PublicKeys[i]
+		if key.ID.Fragment != e.fragment || key.Type != e.suiteType {
+			t.Errorf("wrong key %v: %v %v", i, key.ID.String(), key.Type)
+		}
+		if e.address != "" && (key.EthereumAddress == nil || *key.EthereumAddress != e.address) {
+			t.Errorf("wrong address for key %v", i)
+		}
+		if e.hex != "" && (key.PublicKeyHex == nil || *key.PublicKeyHex != e.hex) {
+			t.Errorf("wrong public key hex for key %v", i)
+		}
+	}
+
+	if len(doc.Authentications) != 2 || doc.Authentications[1].ID.Fragment != "delegate-2" {
+		t.Errorf("owner and sigAuth delegate should authenticate")
+	}
+	if len(doc.AssertionMethods) != 4 {
+		t.Errorf("all keys should be assertion methods")
+	}
+	if len(doc.Services) != 1 {
+		t.Fatalf("should have 1 service")
+	}
+	srv := doc.Services[0]
+	if srv.Type != "HubService" || srv.ServiceEndpoint.(string) != "https://hub.example.com" ||
+		srv.ID.Fragment != "service-1" {
+		t.Errorf("wrong service: %+v", srv)
+	}
+}
+
+func TestResolveFollowsPreviousChange(t *testing.T) {
+	registry := newTestRegistry(t)
+	resolver, err := ethr.NewResolver(registry.address, registry.blockchain, "", nil)
+	if err != nil {
+		t.Fatalf("error creating resolver: err: %v", err)
+	}
+
+	identity := newAddress(t)
+	unlinked := newAddress(t)
+	linked := newAddress(t)
+	registry.emit(identity, ethr.EventDelegateChanged,
+		ethr.StringToBytes32(ethr.DelegateTypeVeriKey), unlinked, validTo(time.Hour))
+	// Starts a new chain of changes, the earlier event is not part of it
+	registry.emitWithPreviousChange(identity, big.NewInt(0), ethr.EventDelegateChanged,
+		ethr.StringToBytes32(ethr.DelegateTypeVeriKey), linked, validTo(time.Hour))
+	registry.emit(identity, ethr.EventAttributeChanged,
+		ethr.StringToBytes32("did/svc/HubService"), []byte("https://hub.example.com"),
+		validTo(time.Hour))
+
+	d, _ := didlib.Parse("did:ethr:" + identity.Hex())
+	doc, err := resolver.Resolve(context.Background(), d)
+	if err != nil {
+		t.Fatalf("error resolving: err: %v", err)
+	}
+	if len(doc.PublicKeys) != 2 || *doc.PublicKeys[1].EthereumAddress != linked.Hex() {
+		t.Errorf("should have only the owner and the linked delegate: %v", doc.String())
+	}
+	if len(doc.Services) != 1 {
+		t.Errorf("should have the service")
+	}
+}
+
+func TestResolveNetwork(t *testing.T) {
+	registry := newTestRegistry(t)
+	resolver, err := ethr.NewResolver(registry.address, registry.blockchain, "rinkeby", nil)
+	if err != nil {
+		t.Fatalf("error creating resolver: err: %v", err)
+	}
+	identity := newAddress(t)
+
+	d, _ := didlib.Parse("did:ethr:rinkeby:" + identity.Hex())
+	doc, err := resolver.Resolve(context.Background(), d)
+	if err != nil {
+		t.Fatalf("error resolving: err: %v", err)
+	}
+	if doc.ID.String() != d.String() {
+		t.Errorf("wrong doc id: %v", doc.ID.String())
+	}
+
+	d, _ = didlib.Parse("did:ethr:mainnet:" + identity.Hex())
+	_, err = resolver.Resolve(context.Background(), d)
+	if err != did.ErrResolverDIDNotFound {
+		t.Errorf("should not resolve dids of other networks: err: %v", err)
+	}
+
+	d, _ = didlib.Parse("did:web:example.com")
+	_, err = resolver.Resolve(context.Background(), d)
+	if err != did.ErrResolverDIDNotFound {
+		t.Errorf("should not resolve other methods: err: %v", err)
+	}
+
+	d, _ = didlib.Parse("did:ethr:notanaddress")
+	_, err = resolver.Resolve(context.Background(), d)
+	if err == nil {
+		t.Errorf("should have failed with an invalid address")
+	}
+}
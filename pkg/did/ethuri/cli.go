@@ -7,13 +7,20 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/pkg/errors"
 
 	"github.com/joincivil/id-hub/pkg/did"
 	"github.com/joincivil/id-hub/pkg/linkeddata"
 )
 
-// GenerateDIDCli is the logic to handle the generatedid command for CLI
+// ErrPublicKeyAlreadyBound is returned by GenerateDIDCli when the public key is
+// already a key of a stored DID document
+var ErrPublicKeyAlreadyBound = errors.New("public key is already bound to a did")
+
+// GenerateDIDCli is the logic to handle the generatedid command for CLI. If
+// a persister is given and the key is already bound to stored DIDs, prints
+// them and returns ErrPublicKeyAlreadyBound instead of generating a new DID.
 func GenerateDIDCli(pubKeyType linkeddata.SuiteType, pubKeyFile string, didPersister Persister) (*did.Document, error) {
 	pubKeyValue, err := pubKeyFromFile(pubKeyFile)
 	if err != nil {
@@ -25,6 +32,20 @@ func GenerateDIDCli(pubKeyType linkeddata.SuiteType, pubKeyFile string, didPersi
 		PublicKeyHex: &pubKeyValue,
 	}
 
+	if didPersister != nil {
+		bound, err := BoundDocuments(firstPK, didPersister)
+		if err != nil {
+			return nil, errors.Wrap(err, "error searching dids for key")
+		}
+		if len(bound) > 0 {
+			fmt.Printf("-- Key already bound to DID --\n")
+			for _, doc := range bound {
+				fmt.Printf("%v\n", doc.ID.String())
+			}
+			return nil, ErrPublicKeyAlreadyBound
+		}
+	}
+
 	doc, err := GenerateNewDocument(firstPK, true, true)
 	if err != nil {
 		return nil, errors.Wrap(err, "error initializing new did document")
@@ -39,7 +60,7 @@ func GenerateDIDCli(pubKeyType linkeddata.SuiteType, pubKeyFile string, didPersi
 	fmt.Printf("%v\n", string(bys))
 
 	if didPersister != nil {
-		err = didPersister.SaveDocument(doc)
+		err = didPersister.SaveDocument(doc, 0)
 		if err != nil {
 			return nil, errors.Wrap(err, "error storing new did to persister")
 		}
@@ -48,6 +69,43 @@ func GenerateDIDCli(pubKeyType linkeddata.SuiteType, pubKeyFile string, didPersi
 	return doc, nil
 }
 
+// BoundDocuments returns the stored DID documents a public key is bound to, by
+// its hex or, for secp256k1 keys, its ethereum address
+func BoundDocuments(pk *did.DocPublicKey, didPersister Persister) ([]*did.Document, error) {
+	docs := []*did.Document{}
+	if pk.PublicKeyHex != nil {
+		found, err := didPersister.GetDocumentsByPublicKey(*pk.PublicKeyHex)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, found...)
+	}
+
+	pubKey, err := pk.AsEcdsaPubKey()
+	if err != nil || pubKey.Curve != crypto.S256() {
+		return docs, nil
+	}
+	found, err := didPersister.GetDocumentsByEthereumAddress(crypto.PubkeyToAddress(*pubKey).Hex())
+	if err != nil {
+		return nil, err
+	}
+	for _, doc := range found {
+		if !documentInSlice(doc, docs) {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+func documentInSlice(doc *did.Document, docs []*did.Document) bool {
+	for _, d := range docs {
+		if d.ID.String() == doc.ID.String() {
+			return true
+		}
+	}
+	return false
+}
+
 func pubKeyFromFile(filename string) (string, error) {
 	bys, err := ioutil.ReadFile(filepath.Clean(filename))
 	if err != nil {
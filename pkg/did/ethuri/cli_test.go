@@ -27,7 +27,7 @@ func persister(t *testing.T) *PostgresPersister {
 	if err != nil {
 		t.Fatal("Should have gotten test gorm")
 	}
-	db.AutoMigrate(&PostgresDocument{})
+	db.AutoMigrate(&PostgresDocument{}, &PostgresDocumentVersion{}, &PostgresDocumentIndex{})
 	p := NewPostgresPersister(db)
 	return p
 }
@@ -87,3 +87,30 @@ func TestGenerateDIDCli(t *testing.T) {
 		t.Errorf("Should have matched DIDs")
 	}
 }
+
+func TestGenerateDIDCliKeyBound(t *testing.T) {
+	err := writeTestKeyFile()
+	if err != nil {
+		t.Fatalf("Should have written the test key file")
+	}
+	defer deleteTestKeyFile() // nolint: errcheck
+
+	p := &InMemoryPersister{}
+	doc, err := GenerateDIDCli(linkeddata.SuiteTypeSecp256k1Verification, testKeyFilename, p)
+	if err != nil {
+		t.Fatalf("Should have not gotten error for did cli gen: %v", err)
+	}
+
+	_, err = GenerateDIDCli(linkeddata.SuiteTypeSecp256k1Verification, testKeyFilename, p)
+	if err != ErrPublicKeyAlreadyBound {
+		t.Errorf("Should have detected the key is bound: err: %v", err)
+	}
+
+	bound, err := BoundDocuments(&doc.PublicKeys[0], p)
+	if err != nil {
+		t.Fatalf("Should have searched the bound documents: err: %v", err)
+	}
+	if len(bound) != 1 || bound[0].ID.String() != doc.ID.String() {
+		t.Errorf("Should have found the generated did: %v", bound)
+	}
+}
@@ -0,0 +1,89 @@
+package ethuri
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/linkeddata"
+)
+
+// DeactivateParams are input params for DeactivateDocument
+type DeactivateParams struct {
+	Did          string
+	RevokeClaims bool
+	Proof        *linkeddata.Proof
+}
+
+// signedDeactivate is the content of a deactivation that is signed in its proof
+type signedDeactivate struct {
+	DID          string `json:"did"`
+	Deactivate   bool   `json:"deactivate"`
+	RevokeClaims bool   `json:"revokeClaims"`
+	Created      string `json:"created"`
+	Domain       string `json:"domain,omitempty"`
+	Nonce        string `json:"nonce,omitempty"`
+}
+
+// DeactivateHash returns the hash signed by the proof of the deactivation in p.
+// It is the keccak256 of the json of the did and whether to revoke its claims
+// with the created time, domain and nonce of the proof.
+func DeactivateHash(p *DeactivateParams) ([]byte, error) {
+	if p.Proof == nil {
+		return nil, ErrUpdateProofRequired
+	}
+	deactivate := signedDeactivate{
+		DID:          p.Did,
+		Deactivate:   true,
+		RevokeClaims: p.RevokeClaims,
+		Created:      p.Proof.Created.UTC().Format(time.RFC3339),
+	}
+	if p.Proof.Domain != nil {
+		deactivate.Domain = *p.Proof.Domain
+	}
+	if p.Proof.Nonce != nil {
+		deactivate.Nonce = *p.Proof.Nonce
+	}
+
+	js, err := json.Marshal(deactivate)
+	if err != nil {
+		return nil, errors.Wrap(err, "deactivatehash.marshal")
+	}
+	return crypto.Keccak256(js), nil
+}
+
+// SignDeactivate sets the proof of the deactivation in p, signed with the
+// secp256k1 key privKey with the key id keyID
+func SignDeactivate(p *DeactivateParams, keyID string, privKey *ecdsa.PrivateKey) error {
+	p.Proof = &linkeddata.Proof{
+		Type:    string(linkeddata.SuiteTypeSecp256k1Signature),
+		Creator: keyID,
+		Created: time.Now().UTC().Truncate(time.Second),
+	}
+	hash, err := DeactivateHash(p)
+	if err != nil {
+		return errors.Wrap(err, "signdeactivate.deactivatehash")
+	}
+	sig, err := crypto.Sign(hash, privKey)
+	if err != nil {
+		return errors.Wrap(err, "signdeactivate.sign")
+	}
+	p.Proof.ProofValue = hex.EncodeToString(sig)
+	return nil
+}
+
+// VerifyDeactivateProof checks the deactivation in p is signed by the
+// authentication key of doc that is the creator of the proof, with the same
+// rules as updates
+func VerifyDeactivateProof(doc *did.Document, p *DeactivateParams) error {
+	hash, err := DeactivateHash(p)
+	if err != nil {
+		return err
+	}
+	return verifyAuthenticationProof(doc, p.Did, p.Proof, hash)
+}
@@ -0,0 +1,97 @@
+package ethuri_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/did/ethuri"
+	"github.com/joincivil/id-hub/pkg/utils"
+)
+
+func TestDeactivateDocument(t *testing.T) {
+	service := ethuri.NewService(&ethuri.InMemoryPersister{})
+	didService := did.NewService([]did.Resolver{service})
+	doc, privKey := createSignedDocument(t, service)
+	keyID := doc.Authentications[0].ID.String()
+
+	params := &ethuri.DeactivateParams{Did: doc.ID.String()}
+
+	// No proof
+	_, err := service.DeactivateDocument(params)
+	if errors.Cause(err) != ethuri.ErrUpdateProofRequired {
+		t.Errorf("should have required a proof: err: %v", err)
+	}
+
+	// Proof by a key that is not in the document
+	otherKey, _ := newSecp256k1DocKey(t)
+	err = ethuri.SignDeactivate(params, keyID, otherKey)
+	if err != nil {
+		t.Fatalf("error signing deactivate: err: %v", err)
+	}
+	_, err = service.DeactivateDocument(params)
+	if errors.Cause(err) != ethuri.ErrUpdateProofInvalid {
+		t.Errorf("should have rejected a proof by another key: err: %v", err)
+	}
+
+	// Proof signed for revoking the claims too
+	err = ethuri.SignDeactivate(params, keyID, privKey)
+	if err != nil {
+		t.Fatalf("error signing deactivate: err: %v", err)
+	}
+	tampered := *params
+	tampered.RevokeClaims = true
+	_, err = service.DeactivateDocument(&tampered)
+	if errors.Cause(err) != ethuri.ErrUpdateProofInvalid {
+		t.Errorf("should have rejected a proof for other content: err: %v", err)
+	}
+
+	metadata, err := service.DeactivateDocument(params)
+	if err != nil {
+		t.Fatalf("should have deactivated the document: err: %v", err)
+	}
+	if !metadata.Deactivated || metadata.Updated == nil {
+		t.Errorf("should have returned the deactivated metadata")
+	}
+
+	_, err = service.Resolve(context.Background(), &doc.ID)
+	derr, ok := did.IsDeactivated(err)
+	if !ok {
+		t.Fatalf("should have returned a deactivated error: err: %v", err)
+	}
+	if derr.DID != doc.ID.String() || !derr.Metadata.Deactivated {
+		t.Errorf("wrong deactivated metadata: %+v", derr)
+	}
+	_, err = didService.GetDocument(context.Background(), doc.ID.String())
+	if errors.Cause(err) != did.ErrResolverDIDDeactivated {
+		t.Errorf("should have resolved the did as deactivated: err: %v", err)
+	}
+
+	// Earlier versions are still resolved
+	_, err = didService.GetDocument(context.Background(), doc.ID.String()+"?versionId=1")
+	if err != nil {
+		t.Errorf("should have resolved the first version: err: %v", err)
+	}
+
+	// It cannot be updated or deactivated again
+	_, newKey := newSecp256k1DocKey(t)
+	update := &ethuri.CreateOrUpdateParams{
+		Did:        utils.StrToPtr(doc.ID.String()),
+		Version:    1,
+		PublicKeys: []did.DocPublicKey{newKey},
+	}
+	err = ethuri.SignUpdate(update, keyID, privKey)
+	if err != nil {
+		t.Fatalf("error signing update: err: %v", err)
+	}
+	_, err = service.CreateOrUpdateDocument(update)
+	if errors.Cause(err) != did.ErrResolverDIDDeactivated {
+		t.Errorf("should not have updated a deactivated document: err: %v", err)
+	}
+	_, err = service.DeactivateDocument(params)
+	if errors.Cause(err) != did.ErrResolverDIDDeactivated {
+		t.Errorf("should not have deactivated the document again: err: %v", err)
+	}
+}
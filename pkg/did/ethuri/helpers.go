@@ -49,7 +49,8 @@ func GenerateEthURIDID() (*didlib.DID, error) {
 }
 
 // InitializeNewDocument generates a simple version of a DID document given
-// the DID and an initial public key.
+// the DID and an initial public key. The key is an assertion method and, if
+// addRefToAuth, an authentication of the document.
 func InitializeNewDocument(d *didlib.DID, firstPK *did.DocPublicKey, addRefToAuth bool,
 	addFragment bool) (*did.Document, error) {
 	if !did.ValidDocPublicKey(firstPK) {
@@ -70,10 +71,25 @@ func InitializeNewDocument(d *didlib.DID, firstPK *did.DocPublicKey, addRefToAut
 		Updated:         &updated,
 	}
 
-	err := doc.AddPublicKey(firstPK, addRefToAuth, addFragment)
+	err := addPublicKey(doc, firstPK, addRefToAuth, addFragment)
 	if err != nil {
 		return nil, err
 	}
 
 	return doc, nil
 }
+
+// addPublicKey adds a public key to an ethuri document as an assertion method,
+// every key of an ethuri document can sign credentials
+func addPublicKey(doc *did.Document, pk *did.DocPublicKey, addRefToAuth bool,
+	addFragment bool) error {
+	err := doc.AddPublicKey(pk, addRefToAuth, addFragment)
+	if err != nil {
+		return err
+	}
+	// Keys already in the document are not added again and keep their relationships
+	if _, err := doc.GetPublicKeyFromFragment(pk.ID.Fragment); err != nil {
+		return nil
+	}
+	return doc.AddKeyToRelationship(pk.ID, did.RelationshipAssertionMethod)
+}
@@ -1,14 +1,22 @@
 package ethuri
 
 import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	cpersist "github.com/joincivil/go-common/pkg/persistence"
 	"github.com/joincivil/id-hub/pkg/did"
 	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
 )
 
 // InMemoryPersister is a persister that stores and get did documents in memory
 // Mainly used for testing.
 type InMemoryPersister struct {
-	store map[string]*did.Document
+	store       map[string]*did.Document
+	versions    map[string][]*DocumentVersion
+	deactivated map[string]time.Time
 }
 
 // GetDocument retrieves a DID document from the given DID
@@ -24,12 +32,166 @@ func (p *InMemoryPersister) GetDocument(d *didlib.DID) (*did.Document, error) {
 	return doc, nil
 }
 
-// SaveDocument saves a DID document
-func (p *InMemoryPersister) SaveDocument(doc *did.Document) error {
+// SaveDocument saves a DID document if lastVersion is its latest version
+func (p *InMemoryPersister) SaveDocument(doc *did.Document, lastVersion uint) error {
 	if p.store == nil {
 		p.store = map[string]*did.Document{}
 	}
+	if p.versions == nil {
+		p.versions = map[string][]*DocumentVersion{}
+	}
 	theDID := did.MethodIDOnly(&doc.ID)
+	if uint(len(p.versions[theDID])) != lastVersion {
+		return ErrUpdateVersionMismatch
+	}
 	p.store[theDID] = doc
+
+	// Store a copy as the version, the stored document is updated in place
+	bys, err := json.Marshal(doc)
+	if err != nil {
+		return errors.Wrap(err, "savedocument.marshal")
+	}
+	versionDoc := &did.Document{}
+	err = json.Unmarshal(bys, versionDoc)
+	if err != nil {
+		return errors.Wrap(err, "savedocument.unmarshal")
+	}
+	p.versions[theDID] = append(p.versions[theDID], &DocumentVersion{
+		Version:   uint(len(p.versions[theDID]) + 1),
+		Document:  versionDoc,
+		CreatedAt: time.Now(),
+	})
 	return nil
 }
+
+// GetDocumentVersion retrieves a version of a DID document
+func (p *InMemoryPersister) GetDocumentVersion(d *didlib.DID, version uint) (*DocumentVersion, error) {
+	versions := p.versions[did.MethodIDOnly(d)]
+	if version == 0 || int(version) > len(versions) {
+		return nil, cpersist.ErrPersisterNoResults
+	}
+	return versions[version-1], nil
+}
+
+// GetLatestDocumentVersion retrieves the last saved version of a DID document
+func (p *InMemoryPersister) GetLatestDocumentVersion(d *didlib.DID) (*DocumentVersion, error) {
+	versions := p.versions[did.MethodIDOnly(d)]
+	if len(versions) == 0 {
+		return nil, cpersist.ErrPersisterNoResults
+	}
+	return versions[len(versions)-1], nil
+}
+
+// GetDocumentVersionAtTime retrieves the version of a DID document that was
+// current at a time
+func (p *InMemoryPersister) GetDocumentVersionAtTime(d *didlib.DID, t time.Time) (*DocumentVersion, error) {
+	versions := p.versions[did.MethodIDOnly(d)]
+	for i := len(versions) - 1; i >= 0; i-- {
+		if !versions[i].CreatedAt.After(t) {
+			return versions[i], nil
+		}
+	}
+	return nil, cpersist.ErrPersisterNoResults
+}
+
+// GetAllDocumentVersions retrieves the versions of every DID document by DID
+// and version
+func (p *InMemoryPersister) GetAllDocumentVersions() ([]*DocumentVersion, error) {
+	return p.documentVersions(func(v *DocumentVersion) bool { return true }), nil
+}
+
+// GetUnanchoredDocumentVersions retrieves the versions of a DID document, or
+// of every DID document if d is nil, that are not anchored by DID and version
+func (p *InMemoryPersister) GetUnanchoredDocumentVersions(d *didlib.DID) ([]*DocumentVersion, error) {
+	return p.documentVersions(func(v *DocumentVersion) bool {
+		return !v.Anchored && (d == nil || did.MethodIDOnly(&v.Document.ID) == did.MethodIDOnly(d))
+	}), nil
+}
+
+func (p *InMemoryPersister) documentVersions(include func(v *DocumentVersion) bool) []*DocumentVersion {
+	dids := make([]string, 0, len(p.versions))
+	for theDID := range p.versions {
+		dids = append(dids, theDID)
+	}
+	sort.Strings(dids)
+	versions := []*DocumentVersion{}
+	for _, theDID := range dids {
+		for _, v := range p.versions[theDID] {
+			if include(v) {
+				versions = append(versions, v)
+			}
+		}
+	}
+	return versions
+}
+
+// SetDocumentVersionAnchored marks a version of a DID document as anchored
+func (p *InMemoryPersister) SetDocumentVersionAnchored(d *didlib.DID, version uint) error {
+	versions := p.versions[did.MethodIDOnly(d)]
+	if version == 0 || int(version) > len(versions) {
+		return cpersist.ErrPersisterNoResults
+	}
+	versions[version-1].Anchored = true
+	return nil
+}
+
+// DeactivateDocument marks a DID document as deactivated
+func (p *InMemoryPersister) DeactivateDocument(d *didlib.DID) error {
+	theDID := did.MethodIDOnly(d)
+	if _, ok := p.store[theDID]; !ok {
+		return cpersist.ErrPersisterNoResults
+	}
+	if p.deactivated == nil {
+		p.deactivated = map[string]time.Time{}
+	}
+	delete(p.store, theDID)
+	p.deactivated[theDID] = time.Now()
+	return nil
+}
+
+// GetDeactivated returns when a DID document was deactivated, or nil if it
+// is not deactivated
+func (p *InMemoryPersister) GetDeactivated(d *didlib.DID) (*time.Time, error) {
+	theDID := did.MethodIDOnly(d)
+	if deactivated, ok := p.deactivated[theDID]; ok {
+		return &deactivated, nil
+	}
+	if _, ok := p.store[theDID]; !ok {
+		return nil, cpersist.ErrPersisterNoResults
+	}
+	return nil, nil
+}
+
+// GetDocumentsByPublicKey retrieves the DID documents with a public key hex
+func (p *InMemoryPersister) GetDocumentsByPublicKey(pubKeyHex string) ([]*did.Document, error) {
+	return p.getDocumentsByIndex(IndexKindPublicKeyHex, pubKeyHex), nil
+}
+
+// GetDocumentsByEthereumAddress retrieves the DID documents with a key for
+// an ethereum address
+func (p *InMemoryPersister) GetDocumentsByEthereumAddress(address string) ([]*did.Document, error) {
+	return p.getDocumentsByIndex(IndexKindEthereumAddress, address), nil
+}
+
+// GetDocumentsByServiceEndpoint retrieves the DID documents with a service
+// endpoint
+func (p *InMemoryPersister) GetDocumentsByServiceEndpoint(endpoint string) ([]*did.Document, error) {
+	return p.getDocumentsByIndex(IndexKindServiceEndpoint, endpoint), nil
+}
+
+func (p *InMemoryPersister) getDocumentsByIndex(kind IndexKind, value string) []*did.Document {
+	search := IndexValue{Kind: kind, Value: NormalizeIndexValue(kind, value)}
+	docs := []*did.Document{}
+	for _, doc := range p.store {
+		for _, v := range DocumentIndexValues(doc) {
+			if v == search {
+				docs = append(docs, doc)
+				break
+			}
+		}
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		return docs[i].ID.String() < docs[j].ID.String()
+	})
+	return docs
+}
@@ -1,15 +1,64 @@
 package ethuri
 
 import (
+	"time"
+
 	"github.com/joincivil/id-hub/pkg/did"
 	didlib "github.com/ockam-network/did"
 )
 
+// DocumentVersion is a version of a DID document, versions are numbered from 1
+// in the order they were saved. Anchored is set once the version is registered
+// with the DocumentAnchor.
+type DocumentVersion struct {
+	Version   uint
+	Document  *did.Document
+	CreatedAt time.Time
+	Anchored  bool
+}
+
 // Persister is the interface of storing and retrieving DID documents
 // for the ID hub. Implement this interface with different backing stores.
 type Persister interface {
 	// GetDocument retrieves a DID document from the given DID
 	GetDocument(d *didlib.DID) (*did.Document, error)
-	// SaveDocument saves a DID document
-	SaveDocument(doc *did.Document) error
+	// SaveDocument saves a DID document as a new version, lastVersion is the
+	// latest version it was built on, 0 for a new document. Returns
+	// ErrUpdateVersionMismatch if lastVersion is not the latest version.
+	SaveDocument(doc *did.Document, lastVersion uint) error
+	// GetDocumentVersion retrieves a version of a DID document
+	GetDocumentVersion(d *didlib.DID, version uint) (*DocumentVersion, error)
+	// GetLatestDocumentVersion retrieves the last saved version of a DID document
+	GetLatestDocumentVersion(d *didlib.DID) (*DocumentVersion, error)
+	// GetDocumentVersionAtTime retrieves the version of a DID document that was
+	// current at a time
+	GetDocumentVersionAtTime(d *didlib.DID, t time.Time) (*DocumentVersion, error)
+	// GetAllDocumentVersions retrieves the versions of every DID document by DID
+	// and version
+	GetAllDocumentVersions() ([]*DocumentVersion, error)
+	// GetUnanchoredDocumentVersions retrieves the versions of a DID document, or
+	// of every DID document if d is nil, that are not anchored by DID and version
+	GetUnanchoredDocumentVersions(d *didlib.DID) ([]*DocumentVersion, error)
+	// SetDocumentVersionAnchored marks a version of a DID document as anchored
+	SetDocumentVersionAnchored(d *didlib.DID, version uint) error
+	// DeactivateDocument marks a DID document as deactivated, it is no longer
+	// returned by GetDocument
+	DeactivateDocument(d *didlib.DID) error
+	// GetDeactivated returns when a DID document was deactivated, or nil if it
+	// is not deactivated
+	GetDeactivated(d *didlib.DID) (*time.Time, error)
+	// GetDocumentsByPublicKey retrieves the DID documents with a public key hex
+	GetDocumentsByPublicKey(pubKeyHex string) ([]*did.Document, error)
+	// GetDocumentsByEthereumAddress retrieves the DID documents with a key for
+	// an ethereum address
+	GetDocumentsByEthereumAddress(address string) ([]*did.Document, error)
+	// GetDocumentsByServiceEndpoint retrieves the DID documents with a service
+	// endpoint
+	GetDocumentsByServiceEndpoint(endpoint string) ([]*did.Document, error)
+}
+
+// DocumentAnchor registers saved versions of DID documents so the document
+// history can be proven, implemented by claims.Service
+type DocumentAnchor interface {
+	AnchorDocumentVersion(doc *did.Document, version uint) error
 }
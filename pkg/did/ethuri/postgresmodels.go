@@ -53,3 +53,49 @@ func (p *PostgresDocument) FromDocument(doc *did.Document) error {
 
 	return nil
 }
+
+// PostgresDocumentVersion is the GORM model for storing every saved version of
+// a DID document
+type PostgresDocumentVersion struct {
+	ID        uint           `gorm:"primary_key"`
+	DID       string         `gorm:"column:did;not null;unique_index:idx_did_version"`
+	Version   uint           `gorm:"not null;unique_index:idx_did_version"`
+	Document  postgres.Jsonb `gorm:"not null"`
+	CreatedAt time.Time      `gorm:"index"`
+	Anchored  bool           `gorm:"not null;default:false;index"`
+}
+
+// TableName sets the tablename for PostgresDocumentVersions
+func (PostgresDocumentVersion) TableName() string {
+	return "did_versions"
+}
+
+// ToDocumentVersion returns the DocumentVersion from this PostgresDocumentVersion
+func (p *PostgresDocumentVersion) ToDocumentVersion() (*DocumentVersion, error) {
+	doc := &did.Document{}
+	err := json.Unmarshal(p.Document.RawMessage, doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling db postgres doc version")
+	}
+
+	return &DocumentVersion{
+		Version:   p.Version,
+		Document:  doc,
+		CreatedAt: p.CreatedAt,
+		Anchored:  p.Anchored,
+	}, nil
+}
+
+// PostgresDocumentIndex is the GORM model for the values DID documents are
+// indexed by for reverse lookups, see DocumentIndexValues
+type PostgresDocumentIndex struct {
+	ID    uint   `gorm:"primary_key"`
+	DID   string `gorm:"column:did;not null;index"`
+	Kind  string `gorm:"not null;index:idx_did_index_value"`
+	Value string `gorm:"not null;index:idx_did_index_value"`
+}
+
+// TableName sets the tablename for PostgresDocumentIndexes
+func (PostgresDocumentIndex) TableName() string {
+	return "did_index"
+}
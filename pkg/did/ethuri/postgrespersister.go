@@ -1,6 +1,8 @@
 package ethuri
 
 import (
+	"time"
+
 	"github.com/pkg/errors"
 
 	"github.com/jinzhu/gorm"
@@ -43,21 +45,303 @@ func (p *PostgresPersister) GetDocument(d *didlib.DID) (*did.Document, error) {
 	return doc.ToDocument()
 }
 
-// SaveDocument saves a DID document with the given DID
-func (p *PostgresPersister) SaveDocument(doc *did.Document) error {
+// SaveDocument saves a DID document with the given DID and adds it to the
+// versions of the document, if lastVersion is the latest version of the
+// document. The did row is locked by the update, so concurrent saves built on
+// the same version fail with ErrUpdateVersionMismatch instead of dropping one.
+func (p *PostgresPersister) SaveDocument(doc *did.Document, lastVersion uint) error {
 	dbdoc := &PostgresDocument{}
 	err := dbdoc.FromDocument(doc)
 	if err != nil {
 		return errors.Wrap(err, "error setting up db doc with document")
 	}
 
+	tx := p.db.Begin()
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "error beginning transaction")
+	}
+	defer tx.Rollback() // nolint: errcheck
+
 	updated := &PostgresDocument{}
-	err = p.db.Where(&PostgresDocument{DID: did.MethodIDOnly(&doc.ID)}).
+	err = tx.Where(&PostgresDocument{DID: dbdoc.DID}).
 		Assign(&PostgresDocument{Document: dbdoc.Document}).
 		FirstOrCreate(updated).Error
 	if err != nil {
 		return errors.Wrap(err, "error saving up db doc")
 	}
 
+	last := &PostgresDocumentVersion{}
+	err = tx.Where(&PostgresDocumentVersion{DID: dbdoc.DID}).Order("version desc").First(last).Error
+	if err != nil && !gorm.IsRecordNotFoundError(err) {
+		return errors.Wrap(err, "error getting last doc version")
+	}
+	if last.Version != lastVersion {
+		return ErrUpdateVersionMismatch
+	}
+	err = tx.Create(&PostgresDocumentVersion{
+		DID:      dbdoc.DID,
+		Version:  last.Version + 1,
+		Document: dbdoc.Document,
+	}).Error
+	if err != nil {
+		return errors.Wrap(err, "error saving doc version")
+	}
+
+	err = indexDocument(tx, doc)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// indexDocument replaces the index values of a DID document
+func indexDocument(tx *gorm.DB, doc *did.Document) error {
+	theDID := did.MethodIDOnly(&doc.ID)
+	err := tx.Where(&PostgresDocumentIndex{DID: theDID}).Delete(&PostgresDocumentIndex{}).Error
+	if err != nil {
+		return errors.Wrap(err, "error deleting doc index")
+	}
+	for _, v := range DocumentIndexValues(doc) {
+		err = tx.Create(&PostgresDocumentIndex{
+			DID:   theDID,
+			Kind:  string(v.Kind),
+			Value: v.Value,
+		}).Error
+		if err != nil {
+			return errors.Wrap(err, "error saving doc index")
+		}
+	}
+	return nil
+}
+
+// ReindexDocuments rebuilds the index values of every DID document, used to
+// index the documents saved before the index existed
+func (p *PostgresPersister) ReindexDocuments() error {
+	dbDocs := []*PostgresDocument{}
+	err := p.db.Find(&dbDocs).Error
+	if err != nil {
+		return errors.Wrap(err, "error getting did documents")
+	}
+
+	tx := p.db.Begin()
+	if tx.Error != nil {
+		return errors.Wrap(tx.Error, "error beginning transaction")
+	}
+	defer tx.Rollback() // nolint: errcheck
+
+	for _, dbDoc := range dbDocs {
+		doc, err := dbDoc.ToDocument()
+		if err != nil {
+			return err
+		}
+		err = indexDocument(tx, doc)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// BackfillDocumentVersions saves the current document of every DID without
+// versions as its version 1, used for the documents saved before versions were
+// kept. The version is created at dids.updated_at, the last update of the
+// document and the time it is known to have been current since, so there is no
+// version at an earlier time and the key lookups at those times fall back to
+// the current document. Processes starting together can backfill the same
+// DIDs, the version of the one that loses the race is skipped.
+func (p *PostgresPersister) BackfillDocumentVersions() error {
+	err := p.db.Exec(`INSERT INTO did_versions (did, version, document, created_at)
+		SELECT dids.did, 1, dids.document, dids.updated_at FROM dids
+		WHERE NOT EXISTS (SELECT 1 FROM did_versions WHERE did_versions.did = dids.did)
+		ON CONFLICT DO NOTHING`).Error
+	if err != nil {
+		return errors.Wrap(err, "error backfilling did document versions")
+	}
+	return nil
+}
+
+// GetDocumentsByPublicKey retrieves the DID documents with a public key hex
+func (p *PostgresPersister) GetDocumentsByPublicKey(pubKeyHex string) ([]*did.Document, error) {
+	return p.getDocumentsByIndex(IndexKindPublicKeyHex, pubKeyHex)
+}
+
+// GetDocumentsByEthereumAddress retrieves the DID documents with a key for
+// an ethereum address
+func (p *PostgresPersister) GetDocumentsByEthereumAddress(address string) ([]*did.Document, error) {
+	return p.getDocumentsByIndex(IndexKindEthereumAddress, address)
+}
+
+// GetDocumentsByServiceEndpoint retrieves the DID documents with a service
+// endpoint
+func (p *PostgresPersister) GetDocumentsByServiceEndpoint(endpoint string) ([]*did.Document, error) {
+	return p.getDocumentsByIndex(IndexKindServiceEndpoint, endpoint)
+}
+
+func (p *PostgresPersister) getDocumentsByIndex(kind IndexKind, value string) ([]*did.Document, error) {
+	dbDocs := []*PostgresDocument{}
+	err := p.db.Joins("JOIN did_index ON did_index.did = dids.did").
+		Where("did_index.kind = ? AND did_index.value = ?", string(kind), NormalizeIndexValue(kind, value)).
+		Order("dids.did").
+		Find(&dbDocs).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "error searching did documents")
+	}
+
+	docs := make([]*did.Document, 0, len(dbDocs))
+	for _, dbDoc := range dbDocs {
+		doc, err := dbDoc.ToDocument()
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// GetDocumentVersion retrieves a version of a DID document
+func (p *PostgresPersister) GetDocumentVersion(d *didlib.DID, version uint) (*DocumentVersion, error) {
+	if d == nil {
+		return nil, errors.New("nil did for get document version")
+	}
+
+	dbVersion := &PostgresDocumentVersion{}
+	err := p.db.Where(&PostgresDocumentVersion{DID: did.MethodIDOnly(d), Version: version}).
+		First(dbVersion).Error
+	if err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, cpersist.ErrPersisterNoResults
+		}
+		return nil, errors.Wrap(err, "error getting did document version")
+	}
+
+	return dbVersion.ToDocumentVersion()
+}
+
+// GetLatestDocumentVersion retrieves the last saved version of a DID document
+func (p *PostgresPersister) GetLatestDocumentVersion(d *didlib.DID) (*DocumentVersion, error) {
+	if d == nil {
+		return nil, errors.New("nil did for get latest document version")
+	}
+
+	dbVersion := &PostgresDocumentVersion{}
+	err := p.db.Where(&PostgresDocumentVersion{DID: did.MethodIDOnly(d)}).
+		Order("version desc").
+		First(dbVersion).Error
+	if err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, cpersist.ErrPersisterNoResults
+		}
+		return nil, errors.Wrap(err, "error getting latest did document version")
+	}
+
+	return dbVersion.ToDocumentVersion()
+}
+
+// GetDocumentVersionAtTime retrieves the version of a DID document that was
+// current at a time
+func (p *PostgresPersister) GetDocumentVersionAtTime(d *didlib.DID, t time.Time) (*DocumentVersion, error) {
+	if d == nil {
+		return nil, errors.New("nil did for get document version")
+	}
+
+	dbVersion := &PostgresDocumentVersion{}
+	err := p.db.Where(&PostgresDocumentVersion{DID: did.MethodIDOnly(d)}).
+		Where("created_at <= ?", t).
+		Order("version desc").
+		First(dbVersion).Error
+	if err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, cpersist.ErrPersisterNoResults
+		}
+		return nil, errors.Wrap(err, "error getting did document version at time")
+	}
+
+	return dbVersion.ToDocumentVersion()
+}
+
+// DeactivateDocument marks a DID document as deactivated by setting its
+// deleted at time
+func (p *PostgresPersister) DeactivateDocument(d *didlib.DID) error {
+	if d == nil {
+		return errors.New("nil did for deactivate document")
+	}
+
+	result := p.db.Where(&PostgresDocument{DID: did.MethodIDOnly(d)}).Delete(&PostgresDocument{})
+	if result.Error != nil {
+		return errors.Wrap(result.Error, "error deactivating did document")
+	}
+	if result.RowsAffected == 0 {
+		return cpersist.ErrPersisterNoResults
+	}
+	return nil
+}
+
+// GetDeactivated returns when a DID document was deactivated, or nil if it
+// is not deactivated
+func (p *PostgresPersister) GetDeactivated(d *didlib.DID) (*time.Time, error) {
+	if d == nil {
+		return nil, errors.New("nil did for get deactivated")
+	}
+
+	doc := &PostgresDocument{}
+	err := p.db.Unscoped().Where(&PostgresDocument{DID: did.MethodIDOnly(d)}).First(doc).Error
+	if err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, cpersist.ErrPersisterNoResults
+		}
+		return nil, errors.Wrap(err, "error getting did document deactivation")
+	}
+
+	return doc.DeletedAt, nil
+}
+
+// GetAllDocumentVersions retrieves the versions of every DID document by DID
+// and version
+func (p *PostgresPersister) GetAllDocumentVersions() ([]*DocumentVersion, error) {
+	return p.getDocumentVersions(p.db)
+}
+
+// GetUnanchoredDocumentVersions retrieves the versions of a DID document, or
+// of every DID document if d is nil, that are not anchored by DID and version
+func (p *PostgresPersister) GetUnanchoredDocumentVersions(d *didlib.DID) ([]*DocumentVersion, error) {
+	query := p.db.Where("anchored = ?", false)
+	if d != nil {
+		query = query.Where(&PostgresDocumentVersion{DID: did.MethodIDOnly(d)})
+	}
+	return p.getDocumentVersions(query)
+}
+
+func (p *PostgresPersister) getDocumentVersions(query *gorm.DB) ([]*DocumentVersion, error) {
+	dbVersions := []*PostgresDocumentVersion{}
+	err := query.Order("did, version").Find(&dbVersions).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting did document versions")
+	}
+
+	versions := make([]*DocumentVersion, 0, len(dbVersions))
+	for _, dbVersion := range dbVersions {
+		version, err := dbVersion.ToDocumentVersion()
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+// SetDocumentVersionAnchored marks a version of a DID document as anchored
+func (p *PostgresPersister) SetDocumentVersionAnchored(d *didlib.DID, version uint) error {
+	if d == nil {
+		return errors.New("nil did for set document version anchored")
+	}
+
+	err := p.db.Model(&PostgresDocumentVersion{}).
+		Where(&PostgresDocumentVersion{DID: did.MethodIDOnly(d), Version: version}).
+		Update("anchored", true).Error
+	if err != nil {
+		return errors.Wrap(err, "error setting did document version anchored")
+	}
 	return nil
 }
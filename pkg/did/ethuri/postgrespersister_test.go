@@ -3,6 +3,7 @@ package ethuri
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	cpersist "github.com/joincivil/go-common/pkg/persistence"
 	"github.com/joincivil/id-hub/pkg/testutils"
@@ -19,7 +20,7 @@ func setupDBConnection() (*PostgresPersister, error) {
 		return nil, err
 	}
 
-	err = db.AutoMigrate(&PostgresDocument{}).Error
+	err = db.AutoMigrate(&PostgresDocument{}, &PostgresDocumentVersion{}, &PostgresDocumentIndex{}).Error
 	if err != nil {
 		return nil, err
 	}
@@ -36,7 +37,7 @@ func setupTestTable() (*PostgresPersister, error) {
 }
 
 func deleteTestTable(persister *PostgresPersister) error {
-	return persister.db.DropTable(&PostgresDocument{}).Error
+	return persister.db.DropTable(&PostgresDocument{}, &PostgresDocumentVersion{}, &PostgresDocumentIndex{}).Error
 }
 
 func TestSaveGetDocument(t *testing.T) {
@@ -48,7 +49,7 @@ func TestSaveGetDocument(t *testing.T) {
 
 	// Save a document
 	testDoc := testutils.BuildTestDocument()
-	err = persister.SaveDocument(testDoc)
+	err = persister.SaveDocument(testDoc, 0)
 	if err != nil {
 		t.Errorf("Should have saved the document: err: %v", err)
 	}
@@ -110,3 +111,183 @@ func TestSaveGetDocumentErr(t *testing.T) {
 		t.Errorf("Should have returned a nil document")
 	}
 }
+
+func TestSaveGetDocumentVersions(t *testing.T) {
+	persister, err := setupTestTable()
+	if err != nil {
+		t.Fatalf("Error connecting to DB: %v", err)
+	}
+	defer deleteTestTable(persister) // nolint: errcheck
+
+	testDoc := testutils.BuildTestDocument()
+	err = persister.SaveDocument(testDoc, 0)
+	if err != nil {
+		t.Fatalf("Should have saved the document: err: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	betweenSaves := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	testDoc.Services = nil
+	err = persister.SaveDocument(testDoc, 1)
+	if err != nil {
+		t.Fatalf("Should have saved the document again: err: %v", err)
+	}
+
+	d, _ := didlib.Parse(testDID)
+	version, err := persister.GetDocumentVersion(d, 2)
+	if err != nil {
+		t.Fatalf("Should have retrieved the second version: err: %v", err)
+	}
+	if version.Version != 2 || len(version.Document.Services) != 0 {
+		t.Errorf("Should have gotten the second version")
+	}
+	version, err = persister.GetLatestDocumentVersion(d)
+	if err != nil {
+		t.Fatalf("Should have retrieved the latest version: err: %v", err)
+	}
+	if version.Version != 2 {
+		t.Errorf("Should have gotten the second version as the latest")
+	}
+	version, err = persister.GetDocumentVersionAtTime(d, betweenSaves)
+	if err != nil {
+		t.Fatalf("Should have retrieved the version at the time: err: %v", err)
+	}
+	if version.Version != 1 || len(version.Document.Services) == 0 {
+		t.Errorf("Should have gotten the first version")
+	}
+	_, err = persister.GetDocumentVersion(d, 3)
+	if err != cpersist.ErrPersisterNoResults {
+		t.Errorf("Should have gotten no results for a missing version: err: %v", err)
+	}
+}
+
+func TestDeactivateDocument(t *testing.T) {
+	persister, err := setupTestTable()
+	if err != nil {
+		t.Fatalf("Error connecting to DB: %v", err)
+	}
+	defer deleteTestTable(persister) // nolint: errcheck
+
+	testDoc := testutils.BuildTestDocument()
+	err = persister.SaveDocument(testDoc, 0)
+	if err != nil {
+		t.Fatalf("Should have saved the document: err: %v", err)
+	}
+
+	d, _ := didlib.Parse(testDID)
+	deactivated, err := persister.GetDeactivated(d)
+	if err != nil || deactivated != nil {
+		t.Errorf("Should not have been deactivated: err: %v", err)
+	}
+	err = persister.DeactivateDocument(d)
+	if err != nil {
+		t.Fatalf("Should have deactivated the document: err: %v", err)
+	}
+	deactivated, err = persister.GetDeactivated(d)
+	if err != nil || deactivated == nil {
+		t.Errorf("Should have been deactivated: err: %v", err)
+	}
+	_, err = persister.GetDocument(d)
+	if err != cpersist.ErrPersisterNoResults {
+		t.Errorf("Should not have retrieved a deactivated document: err: %v", err)
+	}
+	err = persister.DeactivateDocument(d)
+	if err != cpersist.ErrPersisterNoResults {
+		t.Errorf("Should not have deactivated the document again: err: %v", err)
+	}
+}
+
+func TestGetDocumentsByIndex(t *testing.T) {
+	persister, err := setupTestTable()
+	if err != nil {
+		t.Fatalf("Error connecting to DB: %v", err)
+	}
+	defer deleteTestTable(persister) // nolint: errcheck
+
+	testDoc := testutils.BuildTestDocument()
+	err = persister.SaveDocument(testDoc, 0)
+	if err != nil {
+		t.Fatalf("Should have saved the document: err: %v", err)
+	}
+
+	docs, err := persister.GetDocumentsByPublicKey(*testDoc.PublicKeys[0].PublicKeyHex)
+	if err != nil {
+		t.Fatalf("Should have searched by public key: err: %v", err)
+	}
+	if len(docs) != 1 || docs[0].ID.String() != testDID {
+		t.Errorf("Should have found the document by public key")
+	}
+	docs, err = persister.GetDocumentsByServiceEndpoint("https://repository.example.com/service/8377464")
+	if err != nil {
+		t.Fatalf("Should have searched by service endpoint: err: %v", err)
+	}
+	if len(docs) != 1 {
+		t.Errorf("Should have found the document by service endpoint")
+	}
+
+	// Updated documents are reindexed
+	testDoc.Services = nil
+	err = persister.SaveDocument(testDoc, 1)
+	if err != nil {
+		t.Fatalf("Should have saved the document again: err: %v", err)
+	}
+	docs, err = persister.GetDocumentsByServiceEndpoint("https://repository.example.com/service/8377464")
+	if err != nil || len(docs) != 0 {
+		t.Errorf("Should not have found the document by a removed service endpoint: err: %v", err)
+	}
+
+	// Deactivated documents are not found
+	d, _ := didlib.Parse(testDID)
+	err = persister.DeactivateDocument(d)
+	if err != nil {
+		t.Fatalf("Should have deactivated the document: err: %v", err)
+	}
+	docs, err = persister.GetDocumentsByPublicKey(*testDoc.PublicKeys[0].PublicKeyHex)
+	if err != nil || len(docs) != 0 {
+		t.Errorf("Should not have found a deactivated document: err: %v", err)
+	}
+}
+
+func TestBackfillDocumentVersions(t *testing.T) {
+	persister, err := setupTestTable()
+	if err != nil {
+		t.Fatalf("Error connecting to DB: %v", err)
+	}
+	defer deleteTestTable(persister) // nolint: errcheck
+
+	// A document saved before versions were kept
+	testDoc := testutils.BuildTestDocument()
+	dbdoc := &PostgresDocument{}
+	err = dbdoc.FromDocument(testDoc)
+	if err != nil {
+		t.Fatalf("Should have set up the db doc: err: %v", err)
+	}
+	err = persister.db.Create(dbdoc).Error
+	if err != nil {
+		t.Fatalf("Should have saved the db doc: err: %v", err)
+	}
+
+	d, _ := didlib.Parse(testDID)
+	_, err = persister.GetLatestDocumentVersion(d)
+	if err != cpersist.ErrPersisterNoResults {
+		t.Fatalf("Should not have had a version: err: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		err = persister.BackfillDocumentVersions()
+		if err != nil {
+			t.Fatalf("Should have backfilled the versions: err: %v", err)
+		}
+	}
+	version, err := persister.GetLatestDocumentVersion(d)
+	if err != nil {
+		t.Fatalf("Should have backfilled a version: err: %v", err)
+	}
+	if version.Version != 1 || version.Document.ID.String() != testDID {
+		t.Errorf("Should have backfilled the document as version 1: %v", version.Version)
+	}
+	_, err = persister.GetDocumentVersionAtTime(d, time.Now())
+	if err != nil {
+		t.Errorf("Should have found the backfilled version at the time: err: %v", err)
+	}
+}
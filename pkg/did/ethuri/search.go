@@ -0,0 +1,84 @@
+package ethuri
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/joincivil/id-hub/pkg/did"
+)
+
+// IndexKind is the kind of value a DID document is indexed by for reverse
+// lookups
+type IndexKind string
+
+const (
+	// IndexKindPublicKeyHex indexes documents by the hex of their public keys
+	IndexKindPublicKeyHex IndexKind = "publicKeyHex"
+	// IndexKindEthereumAddress indexes documents by the ethereum addresses of
+	// their keys, given or derived from secp256k1 public keys
+	IndexKindEthereumAddress IndexKind = "ethereumAddress"
+	// IndexKindServiceEndpoint indexes documents by their string service endpoints
+	IndexKindServiceEndpoint IndexKind = "serviceEndpoint"
+)
+
+// IndexValue is a value a DID document is indexed by
+type IndexValue struct {
+	Kind  IndexKind
+	Value string
+}
+
+// NormalizeIndexValue returns the form a value of the given kind is indexed
+// and searched by, hex values are lowercase without 0x prefix
+func NormalizeIndexValue(kind IndexKind, value string) string {
+	value = strings.TrimSpace(value)
+	switch kind {
+	case IndexKindPublicKeyHex, IndexKindEthereumAddress:
+		return strings.TrimPrefix(strings.ToLower(value), "0x")
+	}
+	return value
+}
+
+// DocumentIndexValues returns the values a DID document is indexed by, the
+// public keys of the document and its authentications, their ethereum
+// addresses and the string service endpoints
+func DocumentIndexValues(doc *did.Document) []IndexValue {
+	values := []IndexValue{}
+	seen := map[IndexValue]bool{}
+	add := func(kind IndexKind, value string) {
+		v := IndexValue{Kind: kind, Value: NormalizeIndexValue(kind, value)}
+		if v.Value == "" || seen[v] {
+			return
+		}
+		seen[v] = true
+		values = append(values, v)
+	}
+
+	keys := make([]did.DocPublicKey, 0, len(doc.PublicKeys)+len(doc.Authentications))
+	keys = append(keys, doc.PublicKeys...)
+	for _, auth := range doc.Authentications {
+		if !auth.IDOnly {
+			keys = append(keys, auth.DocPublicKey)
+		}
+	}
+	for i := range keys {
+		key := &keys[i]
+		if key.PublicKeyHex != nil {
+			add(IndexKindPublicKeyHex, *key.PublicKeyHex)
+			pubKey, err := key.AsEcdsaPubKey()
+			if err == nil && pubKey.Curve == crypto.S256() {
+				add(IndexKindEthereumAddress, crypto.PubkeyToAddress(*pubKey).Hex())
+			}
+		}
+		if key.EthereumAddress != nil {
+			add(IndexKindEthereumAddress, *key.EthereumAddress)
+		}
+	}
+
+	for _, service := range doc.Services {
+		if endpoint, ok := service.ServiceEndpoint.(string); ok {
+			add(IndexKindServiceEndpoint, endpoint)
+		}
+	}
+	return values
+}
@@ -0,0 +1,75 @@
+package ethuri_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/joincivil/id-hub/pkg/did/ethuri"
+	"github.com/joincivil/id-hub/pkg/testutils"
+)
+
+func TestDocumentIndexValues(t *testing.T) {
+	doc := testutils.BuildTestDocument()
+	values := ethuri.DocumentIndexValues(doc)
+
+	pubBytes, _ := hex.DecodeString(*doc.PublicKeys[0].PublicKeyHex)
+	pubKey, err := crypto.UnmarshalPubkey(pubBytes)
+	if err != nil {
+		t.Fatalf("should have unmarshalled the test key: err: %v", err)
+	}
+	expected := []ethuri.IndexValue{
+		{Kind: ethuri.IndexKindPublicKeyHex, Value: *doc.PublicKeys[0].PublicKeyHex},
+		{Kind: ethuri.IndexKindEthereumAddress, Value: strings.ToLower(crypto.PubkeyToAddress(*pubKey).Hex()[2:])},
+		{Kind: ethuri.IndexKindServiceEndpoint, Value: "https://repository.example.com/service/8377464"},
+	}
+	for _, e := range expected {
+		found := false
+		for _, v := range values {
+			if v == e {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("should have indexed %v %v: %v", e.Kind, e.Value, values)
+		}
+	}
+}
+
+func TestSearchDocuments(t *testing.T) {
+	service := ethuri.NewService(&ethuri.InMemoryPersister{})
+	doc := testutils.BuildTestDocument()
+	err := service.SaveDocument(doc, 0)
+	if err != nil {
+		t.Fatalf("should have saved the document: err: %v", err)
+	}
+	pubKeyHex := *doc.PublicKeys[0].PublicKeyHex
+	pubBytes, _ := hex.DecodeString(pubKeyHex)
+	pubKey, _ := crypto.UnmarshalPubkey(pubBytes)
+
+	searches := map[ethuri.IndexKind]string{
+		ethuri.IndexKindPublicKeyHex:    "0x" + strings.ToUpper(pubKeyHex),
+		ethuri.IndexKindEthereumAddress: crypto.PubkeyToAddress(*pubKey).Hex(),
+		ethuri.IndexKindServiceEndpoint: "https://repository.example.com/service/8377464",
+	}
+	for kind, value := range searches {
+		docs, err := service.SearchDocuments(kind, value)
+		if err != nil {
+			t.Fatalf("should have searched by %v: err: %v", kind, err)
+		}
+		if len(docs) != 1 || docs[0].ID.String() != doc.ID.String() {
+			t.Errorf("should have found the document by %v: %v", kind, docs)
+		}
+	}
+
+	docs, err := service.SearchDocuments(ethuri.IndexKindServiceEndpoint, "https://example.com")
+	if err != nil || len(docs) != 0 {
+		t.Errorf("should not have found a document: err: %v", err)
+	}
+	_, err = service.SearchDocuments("unknown", "value")
+	if err == nil {
+		t.Errorf("should have failed for an unknown search kind")
+	}
+}
@@ -1,8 +1,12 @@
 package ethuri
 
 import (
+	"context"
+	"strconv"
 	"strings"
+	"time"
 
+	log "github.com/golang/glog"
 	cpersist "github.com/joincivil/go-common/pkg/persistence"
 	didlib "github.com/ockam-network/did"
 	"github.com/pkg/errors"
@@ -16,6 +20,8 @@ import (
 const (
 	// EthURISchemeMethod is the prefix string for all DIDs in the ethuri DID method
 	EthURISchemeMethod = "did:ethuri"
+	// Method is the did method of did:ethuri
+	Method = "ethuri"
 )
 
 // NewService is a convenience function to return a new populated did.Service object
@@ -29,35 +35,194 @@ func NewService(persister Persister) *Service {
 // managing DIDs and DID documents and should be used when possible.
 type Service struct {
 	persister Persister
+	anchor    DocumentAnchor
+	cache     did.ResolverCache
+}
+
+// SetDocumentAnchor sets the anchor that registers every saved version of a
+// document, so the document history is provable with the root commits
+func (s *Service) SetDocumentAnchor(anchor DocumentAnchor) {
+	s.anchor = anchor
+}
+
+// SetCache sets the resolver cache the documents are invalidated in when they
+// are saved or deactivated
+func (s *Service) SetCache(cache did.ResolverCache) {
+	s.cache = cache
+}
+
+// Methods implements the did.MethodResolver interface, the service is the
+// authority for did:ethuri
+func (s *Service) Methods() []string {
+	return []string{Method}
 }
 
 // Resolve implements the did.Resolver interface and returns the did document of a
-// given DID for the ethuri method.
-func (s *Service) Resolve(d *didlib.DID) (*did.Document, error) {
+// given DID for the ethuri method. If the DID is deactivated, returns a
+// did.DeactivatedError with its metadata instead.
+func (s *Service) Resolve(ctx context.Context, d *didlib.DID) (*did.Document, error) {
 	if !s.IsEthURI(d.String()) {
 		return nil, did.ErrResolverDIDNotFound
 	}
 
 	doc, err := s.GetDocumentFromDID(d)
 	if doc == nil && err == nil {
+		err = s.deactivatedError(d)
+		if err != nil {
+			return nil, err
+		}
 		return nil, did.ErrResolverDIDNotFound
 	}
 
 	return doc, err
 }
 
+// deactivatedError returns a did.DeactivatedError if the DID is deactivated
+func (s *Service) deactivatedError(d *didlib.DID) error {
+	deactivated, err := s.persister.GetDeactivated(d)
+	if err == cpersist.ErrPersisterNoResults || (err == nil && deactivated == nil) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "error getting deactivation of did")
+	}
+	return &did.DeactivatedError{
+		DID: did.MethodIDOnly(d),
+		Metadata: did.DocumentMetadata{
+			Updated:     deactivated,
+			Deactivated: true,
+		},
+	}
+}
+
+// VerifyDeactivation checks the document of the DID in p can be deactivated
+// with the proof of p, signed by an authentication key of the document, see
+// SignDeactivate. Returns the DID.
+func (s *Service) VerifyDeactivation(p *DeactivateParams) (*didlib.DID, error) {
+	d, err := didlib.Parse(p.Did)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing did for deactivate")
+	}
+	if !s.IsEthURI(p.Did) {
+		return nil, errors.New("only ethuri dids can be deactivated")
+	}
+
+	doc, err := s.GetDocumentFromDID(d)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get document for did")
+	}
+	if doc == nil {
+		err = s.deactivatedError(d)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New("no did found to deactivate")
+	}
+
+	err = VerifyDeactivateProof(doc, p)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// DeactivateDocument deactivates the document of the DID in p, authorized by
+// the proof of p signed by an authentication key of the document, see
+// SignDeactivate. Returns the metadata of the deactivated document.
+func (s *Service) DeactivateDocument(p *DeactivateParams) (*did.DocumentMetadata, error) {
+	d, err := s.VerifyDeactivation(p)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.persister.DeactivateDocument(d)
+	if err != nil {
+		return nil, errors.Wrap(err, "error deactivating document")
+	}
+	err = s.invalidate(d)
+	if err != nil {
+		return nil, err
+	}
+
+	derr, ok := did.IsDeactivated(s.deactivatedError(d))
+	if !ok {
+		return nil, errors.New("document was not deactivated")
+	}
+	return &derr.Metadata, nil
+}
+
+// ResolveVersion implements the did.VersionedResolver interface and returns the
+// version of the did document of a given DID with the version id, or that was
+// current at versionTime
+func (s *Service) ResolveVersion(ctx context.Context, d *didlib.DID, versionID string,
+	versionTime *time.Time) (*did.Document, error) {
+	if !s.IsEthURI(d.String()) {
+		return nil, did.ErrResolverDIDNotFound
+	}
+
+	var version *DocumentVersion
+	var err error
+	if versionID != "" {
+		number, perr := strconv.ParseUint(versionID, 10, 32)
+		if perr != nil {
+			return nil, errors.Wrap(perr, "invalid version id")
+		}
+		version, err = s.persister.GetDocumentVersion(d, uint(number))
+	} else if versionTime != nil {
+		version, err = s.persister.GetDocumentVersionAtTime(d, *versionTime)
+	} else {
+		return s.Resolve(ctx, d)
+	}
+	if err == cpersist.ErrPersisterNoResults {
+		return nil, did.ErrResolverDIDNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "error getting document version from did")
+	}
+	return version.Document, nil
+}
+
+// GetDocumentVersion retrieves a version of the DID document with its version
+// number and time
+func (s *Service) GetDocumentVersion(d *didlib.DID, version uint) (*DocumentVersion, error) {
+	return s.persister.GetDocumentVersion(d, version)
+}
+
+// GetLatestDocumentVersion retrieves the current version of the DID document
+// with its version number and time
+func (s *Service) GetLatestDocumentVersion(d *didlib.DID) (*DocumentVersion, error) {
+	return s.persister.GetLatestDocumentVersion(d)
+}
+
+// SearchDocuments returns the DID documents indexed by a value of the given
+// kind, a public key hex, an ethereum address or a service endpoint
+func (s *Service) SearchDocuments(kind IndexKind, value string) ([]*did.Document, error) {
+	switch kind {
+	case IndexKindPublicKeyHex:
+		return s.persister.GetDocumentsByPublicKey(value)
+	case IndexKindEthereumAddress:
+		return s.persister.GetDocumentsByEthereumAddress(value)
+	case IndexKindServiceEndpoint:
+		return s.persister.GetDocumentsByServiceEndpoint(value)
+	}
+	return nil, errors.Errorf("unsupported search kind: %v", kind)
+}
+
 // IsEthURI is a quick check to see if a did is for ethuri
 func (s *Service) IsEthURI(d string) bool {
 	return strings.HasPrefix(d, EthURISchemeMethod)
 }
 
-// CreateOrUpdateParams are input params for CreateOrUpdateDocument
+// CreateOrUpdateParams are input params for CreateOrUpdateDocument. Version is
+// the current version of the document to update, 0 for a new document. A new
+// document needs proofs by all of its authentication keys, Proofs has the
+// proofs by the keys other than the one of Proof.
 type CreateOrUpdateParams struct {
 	Did              *string
+	Version          uint
 	PublicKeys       []did.DocPublicKey
 	Auths            []did.DocAuthenicationWrapper
 	Services         []did.DocService
 	Proof            *linkeddata.Proof
+	Proofs           []linkeddata.Proof
 	KeepKeyFragments bool
 }
 
@@ -85,22 +250,84 @@ func (s *Service) GetDocumentFromDID(did *didlib.DID) (*did.Document, error) {
 	return doc, nil
 }
 
-// SaveDocument saves the DID document given the DID as a string id
-func (s *Service) SaveDocument(doc *did.Document) error {
-	return s.persister.SaveDocument(doc)
+// SaveDocument saves the DID document given the DID as a string id, built on
+// lastVersion, its latest version or 0 for a new document. If a
+// cache is set, the document is invalidated in it. If a document anchor is set,
+// the new version and any earlier versions of the document that are not anchored
+// yet are anchored after it is saved. If anchoring fails the version stays saved
+// and unanchored and is retried on the next save or by AnchorDocumentVersions.
+func (s *Service) SaveDocument(doc *did.Document, lastVersion uint) error {
+	err := s.persister.SaveDocument(doc, lastVersion)
+	if err != nil {
+		return err
+	}
+	err = s.invalidate(&doc.ID)
+	if err != nil {
+		return err
+	}
+	err = s.anchorVersions(&doc.ID)
+	if err != nil {
+		log.Errorf("Error anchoring versions of %v, will retry: err: %v", doc.ID.String(), err)
+	}
+	return nil
+}
+
+// AnchorDocumentVersions anchors the saved versions of every document that are
+// not anchored yet, used to retry the versions that failed to anchor and to
+// anchor the versions saved before anchoring
+func (s *Service) AnchorDocumentVersions() error {
+	return s.anchorVersions(nil)
+}
+
+// anchorVersions anchors the versions of the document of d, or of every
+// document if d is nil, that are not anchored in the order they were saved
+func (s *Service) anchorVersions(d *didlib.DID) error {
+	if s.anchor == nil {
+		return nil
+	}
+	versions, err := s.persister.GetUnanchoredDocumentVersions(d)
+	if err != nil {
+		return errors.Wrap(err, "error getting unanchored document versions")
+	}
+	for _, version := range versions {
+		err = s.anchor.AnchorDocumentVersion(version.Document, version.Version)
+		if err != nil {
+			return errors.Wrapf(err, "error anchoring document version %v", version.Version)
+		}
+		err = s.persister.SetDocumentVersionAnchored(&version.Document.ID, version.Version)
+		if err != nil {
+			return errors.Wrapf(err, "error setting document version %v anchored", version.Version)
+		}
+	}
+	return nil
+}
+
+// invalidate removes the document of the DID from the cache if set
+func (s *Service) invalidate(d *didlib.DID) error {
+	if s.cache == nil {
+		return nil
+	}
+	err := s.cache.Invalidate(d)
+	if err != nil {
+		return errors.Wrap(err, "error invalidating cached document")
+	}
+	return nil
 }
 
 // CreateOrUpdateDocument will create a new document or update an existing one given
 // the params in CreateOrUpdateParams.  If did is given and valid, will attempt to
-// retrieve the existing did and document and add any new data to the document
-// If no did is given, it will create a new document with a new DID and the given data.
-// In both cases it will persist to store.
+// retrieve the existing did and document and add any new data to the document,
+// the params need a proof signed by an authentication key of the document, see
+// SignUpdate. If no did is given, it will create a new document with a new DID and
+// the given data, if a proof is given it has to be signed by one of the new
+// authentication keys. In both cases it will persist to store.
 func (s *Service) CreateOrUpdateDocument(p *CreateOrUpdateParams) (*did.Document, error) {
 	var doc *did.Document
+	var lastVersion uint
 	var err error
 
 	if p.Did != nil {
-		doc, err = s.updateDocumentFromParams(p)
+		doc, lastVersion, err = s.updateDocumentFromParams(p)
 		if err != nil {
 			return nil, errors.Wrap(err, "error updating new document")
 		}
@@ -112,7 +339,8 @@ func (s *Service) CreateOrUpdateDocument(p *CreateOrUpdateParams) (*did.Document
 		}
 	}
 
-	err = s.SaveDocument(doc)
+	// Fails if the document was saved since the proof was verified
+	err = s.SaveDocument(doc, lastVersion)
 	if err != nil {
 		return nil, errors.Wrap(err, "error storing new did")
 	}
@@ -120,50 +348,66 @@ func (s *Service) CreateOrUpdateDocument(p *CreateOrUpdateParams) (*did.Document
 	return doc, nil
 }
 
-func (s *Service) updateDocumentFromParams(p *CreateOrUpdateParams) (*did.Document, error) {
+func (s *Service) updateDocumentFromParams(p *CreateOrUpdateParams) (*did.Document, uint, error) {
 	var doc *did.Document
 	var err error
 
 	// Validate the DID
 	if !did.ValidDid(*p.Did) {
-		return nil, errors.New("did is invalid")
+		return nil, 0, errors.New("did is invalid")
 	}
 
 	// Try to retrieve the DID document
 	doc, err = s.GetDocument(*p.Did)
 	if err != nil {
-		return nil, errors.Wrap(err, "unable to get document for did")
+		return nil, 0, errors.Wrap(err, "unable to get document for did")
 	}
 
 	if doc == nil {
-		return nil, errors.New("no did found to update")
+		d, _ := didlib.Parse(*p.Did)
+		err = s.deactivatedError(d)
+		if err != nil {
+			return nil, 0, err
+		}
+		return nil, 0, errors.New("no did found to update")
+	}
+
+	version, err := s.persister.GetLatestDocumentVersion(&doc.ID)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "unable to get document version")
+	}
+
+	// Verify before adding anything, adding keys sets the fragments of the params
+	err = VerifyUpdateProof(doc, version.Version, p)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	for _, pk := range p.PublicKeys {
-		err = doc.AddPublicKey(&pk, false, !p.KeepKeyFragments)
+		err = addPublicKey(doc, &pk, false, !p.KeepKeyFragments)
 		if err != nil {
-			return nil, errors.Wrap(err, "unable to add public key")
+			return nil, 0, errors.Wrap(err, "unable to add public key")
 		}
 	}
 
 	for _, auth := range p.Auths {
 		err = doc.AddAuthentication(&auth, !p.KeepKeyFragments)
 		if err != nil {
-			return nil, errors.Wrap(err, "unable to add authentication")
+			return nil, 0, errors.Wrap(err, "unable to add authentication")
 		}
 	}
 
 	for _, srv := range p.Services {
 		err = doc.AddService(&srv)
 		if err != nil {
-			return nil, errors.Wrap(err, "unable to add service")
+			return nil, 0, errors.Wrap(err, "unable to add service")
 		}
 	}
 
 	// Update proof
 	doc.Proof = p.Proof
 
-	return doc, nil
+	return doc, version.Version, nil
 }
 
 func (s *Service) createNewDocumentFromParams(p *CreateOrUpdateParams) (*did.Document, error) {
@@ -171,6 +415,13 @@ func (s *Service) createNewDocumentFromParams(p *CreateOrUpdateParams) (*did.Doc
 		return nil, errors.New("at least one public key required")
 	}
 
+	// Recover the signers before adding anything, adding keys sets the
+	// fragments of the params
+	signers, err := createProofSigners(p)
+	if err != nil {
+		return nil, err
+	}
+
 	// Generate a new document with the first key
 	doc, err := GenerateNewDocument(&p.PublicKeys[0], true, !p.KeepKeyFragments)
 	if err != nil {
@@ -179,7 +430,7 @@ func (s *Service) createNewDocumentFromParams(p *CreateOrUpdateParams) (*did.Doc
 
 	// Add rest of keys if more than one
 	for _, pk := range p.PublicKeys[1:] {
-		err = doc.AddPublicKey(&pk, false, !p.KeepKeyFragments)
+		err = addPublicKey(doc, &pk, false, !p.KeepKeyFragments)
 		if err != nil {
 			return nil, errors.Wrap(err, "unable to add public key")
 		}
@@ -201,6 +452,11 @@ func (s *Service) createNewDocumentFromParams(p *CreateOrUpdateParams) (*did.Doc
 		}
 	}
 
+	err = verifyCreateProof(doc, signers)
+	if err != nil {
+		return nil, err
+	}
+
 	doc.Proof = p.Proof
 
 	return doc, nil
@@ -16,7 +16,7 @@ func initPersister(t *testing.T) (ethuri.Persister, *gorm.DB) {
 		return nil, nil
 	}
 
-	err = db.AutoMigrate(&ethuri.PostgresDocument{}).Error
+	err = db.AutoMigrate(&ethuri.PostgresDocument{}, &ethuri.PostgresDocumentVersion{}, &ethuri.PostgresDocumentIndex{}).Error
 	if err != nil {
 		t.Fatalf("Should have auto-migrated")
 		return nil, nil
@@ -33,12 +33,12 @@ func initEthURIService(t *testing.T) (*ethuri.Service, *gorm.DB) {
 
 func TestServiceSaveGetDocument(t *testing.T) {
 	service, db := initEthURIService(t)
-	defer db.DropTable(&ethuri.PostgresDocument{})
+	defer db.DropTable(&ethuri.PostgresDocument{}, &ethuri.PostgresDocumentVersion{}, &ethuri.PostgresDocumentIndex{})
 
 	doc := testutils.BuildTestDocument()
 
 	// Save document
-	err := service.SaveDocument(doc)
+	err := service.SaveDocument(doc, 0)
 	if err != nil {
 		t.Errorf("Should have not gotten error saving document: err: %v", err)
 	}
@@ -64,12 +64,12 @@ func TestServiceSaveGetDocument(t *testing.T) {
 
 func TestServiceSaveGetDocumentErr(t *testing.T) {
 	service, db := initEthURIService(t)
-	defer db.DropTable(&ethuri.PostgresDocument{})
+	defer db.DropTable(&ethuri.PostgresDocument{}, &ethuri.PostgresDocumentVersion{}, &ethuri.PostgresDocumentIndex{})
 
 	doc := testutils.BuildTestDocument()
 
 	// Save document
-	err := service.SaveDocument(doc)
+	err := service.SaveDocument(doc, 0)
 	if err != nil {
 		t.Errorf("Should have not gotten error saving document: err: %v", err)
 	}
@@ -104,18 +104,30 @@ func TestServiceSaveGetDocumentErr(t *testing.T) {
 
 func TestCreateOrUpdateDocumentCreate(t *testing.T) {
 	service, db := initEthURIService(t)
-	defer db.DropTable(&ethuri.PostgresDocument{})
+	defer db.DropTable(&ethuri.PostgresDocument{}, &ethuri.PostgresDocumentVersion{}, &ethuri.PostgresDocumentIndex{})
 
 	doc := testutils.BuildTestDocument()
-
-	newDoc, err := service.CreateOrUpdateDocument(
-		&ethuri.CreateOrUpdateParams{
-			PublicKeys:       doc.PublicKeys,
-			Auths:            doc.Authentications,
-			Services:         doc.Services,
-			KeepKeyFragments: true,
-		},
-	)
+	// Use keys that can sign the proofs of the authentications
+	privKey1, key1 := newSecp256k1DocKey(t)
+	privKey2, key2 := newSecp256k1DocKey(t)
+	doc.PublicKeys[0].PublicKeyHex = key1.PublicKeyHex
+	doc.Authentications[1].PublicKeyHex = key2.PublicKeyHex
+
+	params := &ethuri.CreateOrUpdateParams{
+		PublicKeys:       doc.PublicKeys,
+		Auths:            doc.Authentications,
+		Services:         doc.Services,
+		KeepKeyFragments: true,
+	}
+	err := ethuri.SignUpdate(params, "keys-1", privKey1)
+	if err != nil {
+		t.Fatalf("Should have not gotten error signing create: err: %v", err)
+	}
+	err = ethuri.AddCreateProof(params, "keys-2", privKey2)
+	if err != nil {
+		t.Fatalf("Should have not gotten error signing create: err: %v", err)
+	}
+	newDoc, err := service.CreateOrUpdateDocument(params)
 	if err != nil {
 		t.Fatalf("Should have not gotten error creating or updating doc: err: %v", err)
 	}
@@ -145,36 +157,28 @@ func TestCreateOrUpdateDocumentCreate(t *testing.T) {
 
 func TestCreateOrUpdateDocumentUpdate(t *testing.T) {
 	service, db := initEthURIService(t)
-	defer db.DropTable(&ethuri.PostgresDocument{})
+	defer db.DropTable(&ethuri.PostgresDocument{}, &ethuri.PostgresDocumentVersion{}, &ethuri.PostgresDocumentIndex{})
 
-	doc := testutils.BuildTestDocument()
+	newDoc, privKey := createSignedDocument(t, service)
 
-	newDoc, err := service.CreateOrUpdateDocument(
-		&ethuri.CreateOrUpdateParams{
-			PublicKeys:       doc.PublicKeys,
-			Auths:            doc.Authentications,
-			Services:         doc.Services,
-			KeepKeyFragments: true,
-		},
-	)
+	params := &ethuri.CreateOrUpdateParams{
+		Did:              utils.StrToPtr(newDoc.ID.String()),
+		Version:          1,
+		PublicKeys:       newDoc.PublicKeys,
+		Auths:            newDoc.Authentications,
+		Services:         newDoc.Services,
+		KeepKeyFragments: true,
+	}
+	err := ethuri.SignUpdate(params, newDoc.Authentications[0].ID.String(), privKey)
 	if err != nil {
-		t.Fatalf("Should have not gotten error creating or updating doc: err: %v", err)
+		t.Fatalf("Should have not gotten error signing update: err: %v", err)
 	}
-
-	updatedDoc, err := service.CreateOrUpdateDocument(
-		&ethuri.CreateOrUpdateParams{
-			Did:              utils.StrToPtr(newDoc.ID.String()),
-			PublicKeys:       newDoc.PublicKeys,
-			Auths:            newDoc.Authentications,
-			Services:         newDoc.Services,
-			KeepKeyFragments: true,
-		},
-	)
+	updatedDoc, err := service.CreateOrUpdateDocument(params)
 	if err != nil {
 		t.Fatalf("Should have not gotten error creating or updating doc: err: %v", err)
 	}
 
-	if len(updatedDoc.PublicKeys) != len(doc.PublicKeys) {
+	if len(updatedDoc.PublicKeys) != len(newDoc.PublicKeys) {
 		t.Errorf("Should have not added additional public keys")
 	}
 
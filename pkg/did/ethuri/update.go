@@ -0,0 +1,276 @@
+package ethuri
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/linkeddata"
+)
+
+const (
+	// maxUpdateProofAge is how long after it is created a proof can update a document
+	maxUpdateProofAge = 10 * time.Minute
+	// updateProofClockSkew is how far in the future the proof created time can be
+	updateProofClockSkew = 5 * time.Minute
+)
+
+var (
+	// ErrUpdateProofRequired is returned when an update to an existing document
+	// has no proof
+	ErrUpdateProofRequired = errors.New("updating a did document requires a proof")
+	// ErrUpdateProofInvalid is returned when the proof of an update is not signed
+	// by an authentication key of the document
+	ErrUpdateProofInvalid = errors.New("the update proof is not signed by an authentication key of the document")
+	// ErrUpdateProofExpired is returned when the proof of an update was created
+	// too long ago or before the last update of the document
+	ErrUpdateProofExpired = errors.New("the update proof is expired")
+	// ErrUpdateVersionMismatch is returned when an update is not signed for the
+	// current version of the document
+	ErrUpdateVersionMismatch = errors.New("the update is not for the current version of the document")
+)
+
+// signedUpdate is the content of an update that is signed in its proof
+type signedUpdate struct {
+	DID             string                        `json:"did,omitempty"`
+	Version         uint                          `json:"version"`
+	PublicKeys      []did.DocPublicKey            `json:"publicKey"`
+	Authentications []did.DocAuthenicationWrapper `json:"authentication"`
+	Services        []did.DocService              `json:"service"`
+	Created         string                        `json:"created"`
+	Domain          string                        `json:"domain,omitempty"`
+	Nonce           string                        `json:"nonce,omitempty"`
+}
+
+// UpdateHash returns the hash signed by the proof of the update in p. It is the
+// keccak256 of the json of the did, version, keys, authentications and services
+// in p with the created time, domain and nonce of the proof.
+func UpdateHash(p *CreateOrUpdateParams) ([]byte, error) {
+	return proofHash(p, p.Proof)
+}
+
+// proofHash returns the hash of the update in p signed by proof
+func proofHash(p *CreateOrUpdateParams, proof *linkeddata.Proof) ([]byte, error) {
+	if proof == nil {
+		return nil, ErrUpdateProofRequired
+	}
+	update := signedUpdate{
+		Version:         p.Version,
+		PublicKeys:      p.PublicKeys,
+		Authentications: p.Auths,
+		Services:        p.Services,
+		Created:         proof.Created.UTC().Format(time.RFC3339),
+	}
+	if p.Did != nil {
+		update.DID = *p.Did
+	}
+	if update.PublicKeys == nil {
+		update.PublicKeys = []did.DocPublicKey{}
+	}
+	if update.Authentications == nil {
+		update.Authentications = []did.DocAuthenicationWrapper{}
+	}
+	if update.Services == nil {
+		update.Services = []did.DocService{}
+	}
+	if proof.Domain != nil {
+		update.Domain = *proof.Domain
+	}
+	if proof.Nonce != nil {
+		update.Nonce = *proof.Nonce
+	}
+
+	js, err := json.Marshal(update)
+	if err != nil {
+		return nil, errors.Wrap(err, "proofhash.marshal")
+	}
+	return crypto.Keccak256(js), nil
+}
+
+// SignUpdate sets the proof of the update in p, signed with the secp256k1 key
+// privKey with the key id keyID. p.Version has to be the current version of
+// the document, or 0 for a new document.
+func SignUpdate(p *CreateOrUpdateParams, keyID string, privKey *ecdsa.PrivateKey) error {
+	proof, err := signProof(p, keyID, privKey)
+	if err != nil {
+		return errors.Wrap(err, "signupdate.signproof")
+	}
+	p.Proof = proof
+	return nil
+}
+
+// AddCreateProof adds a proof of the new document in p signed with the
+// secp256k1 key privKey with the key id keyID. A new document needs a proof
+// by each of its authentication keys, the first is set with SignUpdate.
+func AddCreateProof(p *CreateOrUpdateParams, keyID string, privKey *ecdsa.PrivateKey) error {
+	proof, err := signProof(p, keyID, privKey)
+	if err != nil {
+		return errors.Wrap(err, "addcreateproof.signproof")
+	}
+	p.Proofs = append(p.Proofs, *proof)
+	return nil
+}
+
+func signProof(p *CreateOrUpdateParams, keyID string, privKey *ecdsa.PrivateKey) (*linkeddata.Proof, error) {
+	proof := &linkeddata.Proof{
+		Type:    string(linkeddata.SuiteTypeSecp256k1Signature),
+		Creator: keyID,
+		Created: time.Now().UTC().Truncate(time.Second),
+	}
+	hash, err := proofHash(p, proof)
+	if err != nil {
+		return nil, errors.Wrap(err, "signproof.proofhash")
+	}
+	sig, err := crypto.Sign(hash, privKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "signproof.sign")
+	}
+	proof.ProofValue = hex.EncodeToString(sig)
+	return proof, nil
+}
+
+// VerifyUpdateProof checks the update in p is signed by the authentication key
+// of doc that is the creator of the proof, that it is signed for version, the
+// current version of doc, and that the proof was created recently and after the
+// last update of doc
+func VerifyUpdateProof(doc *did.Document, version uint, p *CreateOrUpdateParams) error {
+	hash, err := UpdateHash(p)
+	if err != nil {
+		return err
+	}
+	if p.Did == nil {
+		return ErrUpdateProofInvalid
+	}
+	if p.Version != version {
+		return ErrUpdateVersionMismatch
+	}
+	return verifyAuthenticationProof(doc, *p.Did, p.Proof, hash)
+}
+
+// verifyAuthenticationProof checks the proof over hash for the DID theDID is
+// signed by the authentication key of doc that is its creator, and that it was
+// created recently and after the last update of doc
+func verifyAuthenticationProof(doc *did.Document, theDID string, proof *linkeddata.Proof,
+	hash []byte) error {
+	if theDID != did.MethodIDOnly(&doc.ID) {
+		return ErrUpdateProofInvalid
+	}
+	if doc.Updated != nil && proof.Created.Before(doc.Updated.Truncate(time.Second)) {
+		return ErrUpdateProofExpired
+	}
+
+	keyID, err := didlib.Parse(proof.Creator)
+	if err != nil || did.MethodIDOnly(keyID) != did.MethodIDOnly(&doc.ID) {
+		return ErrUpdateProofInvalid
+	}
+	key := authenticationKey(doc, keyID.Fragment)
+	if key == nil {
+		return ErrUpdateProofInvalid
+	}
+	signer, err := recoverUpdateSigner(proof, hash)
+	if err != nil {
+		return err
+	}
+	if !keyMatches(key, signer) {
+		return ErrUpdateProofInvalid
+	}
+	return nil
+}
+
+// createProofSigners returns the signers of the proofs of the new document in
+// p. It has to be called before the keys of p are added to the document, adding
+// them sets their fragments.
+func createProofSigners(p *CreateOrUpdateParams) ([]*ecdsa.PublicKey, error) {
+	if p.Proof == nil {
+		return nil, ErrUpdateProofRequired
+	}
+	proofs := append([]linkeddata.Proof{*p.Proof}, p.Proofs...)
+	signers := make([]*ecdsa.PublicKey, len(proofs))
+	for i := range proofs {
+		hash, err := proofHash(p, &proofs[i])
+		if err != nil {
+			return nil, err
+		}
+		signers[i], err = recoverUpdateSigner(&proofs[i], hash)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return signers, nil
+}
+
+// verifyCreateProof checks each authentication key of a new document signed
+// one of its proofs, proving the creator holds all of them. signers are the
+// signers of the proofs.
+func verifyCreateProof(doc *did.Document, signers []*ecdsa.PublicKey) error {
+	if len(doc.Authentications) == 0 {
+		return ErrUpdateProofInvalid
+	}
+AuthLoop:
+	for _, auth := range doc.Authentications {
+		key := authenticationKey(doc, auth.ID.Fragment)
+		if key == nil {
+			return ErrUpdateProofInvalid
+		}
+		for _, signer := range signers {
+			if keyMatches(key, signer) {
+				continue AuthLoop
+			}
+		}
+		return ErrUpdateProofInvalid
+	}
+	return nil
+}
+
+func recoverUpdateSigner(proof *linkeddata.Proof, hash []byte) (*ecdsa.PublicKey, error) {
+	if proof.Type != string(linkeddata.SuiteTypeSecp256k1Signature) {
+		return nil, errors.New("only Secp256k1 update proofs are supported")
+	}
+	now := time.Now()
+	if proof.Created.Before(now.Add(-maxUpdateProofAge)) || proof.Created.After(now.Add(updateProofClockSkew)) {
+		return nil, ErrUpdateProofExpired
+	}
+	sig, err := hex.DecodeString(proof.ProofValue)
+	if err != nil {
+		return nil, ErrUpdateProofInvalid
+	}
+	signer, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return nil, ErrUpdateProofInvalid
+	}
+	return signer, nil
+}
+
+// authenticationKey returns the key in the authentication section of doc with
+// the fragment, following references to the public keys
+func authenticationKey(doc *did.Document, fragment string) *did.DocPublicKey {
+	for _, auth := range doc.Authentications {
+		if auth.ID == nil || auth.ID.Fragment != fragment {
+			continue
+		}
+		if !auth.IDOnly {
+			key := auth.DocPublicKey
+			return &key
+		}
+		key, err := doc.GetPublicKeyFromFragment(fragment)
+		if err != nil {
+			return nil
+		}
+		return key
+	}
+	return nil
+}
+
+func keyMatches(key *did.DocPublicKey, signer *ecdsa.PublicKey) bool {
+	pubKey, err := key.AsEcdsaPubKey()
+	if err != nil || pubKey.Curve != crypto.S256() {
+		return false
+	}
+	return crypto.PubkeyToAddress(*pubKey) == crypto.PubkeyToAddress(*signer)
+}
@@ -0,0 +1,278 @@
+package ethuri_test
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/allegro/bigcache"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/did/ethuri"
+	"github.com/joincivil/id-hub/pkg/linkeddata"
+	"github.com/joincivil/id-hub/pkg/utils"
+)
+
+func newSecp256k1DocKey(t *testing.T) (*ecdsa.PrivateKey, did.DocPublicKey) {
+	privKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("error generating key: err: %v", err)
+	}
+	pubKeyHex := hex.EncodeToString(crypto.FromECDSAPub(&privKey.PublicKey))
+	return privKey, did.DocPublicKey{
+		Type:         linkeddata.SuiteTypeSecp256k1Verification,
+		PublicKeyHex: &pubKeyHex,
+	}
+}
+
+// createSignedDocument creates a document with a new key as its authentication
+// key, signed by the key
+func createSignedDocument(t *testing.T, service *ethuri.Service) (*did.Document, *ecdsa.PrivateKey) {
+	privKey, pubKey := newSecp256k1DocKey(t)
+	params := &ethuri.CreateOrUpdateParams{
+		PublicKeys: []did.DocPublicKey{pubKey},
+	}
+	err := ethuri.SignUpdate(params, "keys-1", privKey)
+	if err != nil {
+		t.Fatalf("error signing create: err: %v", err)
+	}
+	doc, err := service.CreateOrUpdateDocument(params)
+	if err != nil {
+		t.Fatalf("error creating document: err: %v", err)
+	}
+	return doc, privKey
+}
+
+func TestCreateDocumentProof(t *testing.T) {
+	service := ethuri.NewService(&ethuri.InMemoryPersister{})
+	doc, _ := createSignedDocument(t, service)
+	if len(doc.Authentications) != 1 || doc.Proof == nil {
+		t.Errorf("should have created the document with its proof")
+	}
+
+	// A proof by a key that is not in the new document
+	otherKey, _ := newSecp256k1DocKey(t)
+	_, pubKey := newSecp256k1DocKey(t)
+	params := &ethuri.CreateOrUpdateParams{
+		PublicKeys: []did.DocPublicKey{pubKey},
+	}
+	err := ethuri.SignUpdate(params, "keys-1", otherKey)
+	if err != nil {
+		t.Fatalf("error signing create: err: %v", err)
+	}
+	_, err = service.CreateOrUpdateDocument(params)
+	if errors.Cause(err) != ethuri.ErrUpdateProofInvalid {
+		t.Errorf("should have rejected a proof by another key: err: %v", err)
+	}
+
+	// No proof
+	params = &ethuri.CreateOrUpdateParams{
+		PublicKeys: []did.DocPublicKey{pubKey},
+	}
+	_, err = service.CreateOrUpdateDocument(params)
+	if errors.Cause(err) != ethuri.ErrUpdateProofRequired {
+		t.Errorf("should have required a proof: err: %v", err)
+	}
+}
+
+func TestCreateDocumentProofAllAuthentications(t *testing.T) {
+	service := ethuri.NewService(&ethuri.InMemoryPersister{})
+	privKey1, key1 := newSecp256k1DocKey(t)
+	privKey2, key2 := newSecp256k1DocKey(t)
+	newParams := func() *ethuri.CreateOrUpdateParams {
+		params := &ethuri.CreateOrUpdateParams{
+			PublicKeys: []did.DocPublicKey{key1},
+			Auths:      []did.DocAuthenicationWrapper{{DocPublicKey: key2}},
+		}
+		err := ethuri.SignUpdate(params, "keys-1", privKey1)
+		if err != nil {
+			t.Fatalf("error signing create: err: %v", err)
+		}
+		return params
+	}
+
+	// The second authentication key did not sign
+	_, err := service.CreateOrUpdateDocument(newParams())
+	if errors.Cause(err) != ethuri.ErrUpdateProofInvalid {
+		t.Errorf("should have required a proof by each authentication key: err: %v", err)
+	}
+
+	params := newParams()
+	err = ethuri.AddCreateProof(params, "keys-2", privKey2)
+	if err != nil {
+		t.Fatalf("error signing create: err: %v", err)
+	}
+	doc, err := service.CreateOrUpdateDocument(params)
+	if err != nil {
+		t.Fatalf("should have created the document: err: %v", err)
+	}
+	if len(doc.Authentications) != 2 {
+		t.Errorf("should have created the document with both authentications")
+	}
+}
+
+func TestUpdateDocumentProof(t *testing.T) {
+	service := ethuri.NewService(&ethuri.InMemoryPersister{})
+	doc, privKey := createSignedDocument(t, service)
+	keyID := doc.Authentications[0].ID.String()
+
+	_, newKey := newSecp256k1DocKey(t)
+	params := &ethuri.CreateOrUpdateParams{
+		Did:        utils.StrToPtr(doc.ID.String()),
+		Version:    1,
+		PublicKeys: []did.DocPublicKey{newKey},
+	}
+
+	// No proof
+	_, err := service.CreateOrUpdateDocument(params)
+	if errors.Cause(err) != ethuri.ErrUpdateProofRequired {
+		t.Errorf("should have required a proof: err: %v", err)
+	}
+
+	// Proof by a key that is not in the document
+	otherKey, _ := newSecp256k1DocKey(t)
+	err = ethuri.SignUpdate(params, keyID, otherKey)
+	if err != nil {
+		t.Fatalf("error signing update: err: %v", err)
+	}
+	_, err = service.CreateOrUpdateDocument(params)
+	if errors.Cause(err) != ethuri.ErrUpdateProofInvalid {
+		t.Errorf("should have rejected a proof by another key: err: %v", err)
+	}
+
+	// Proof for different content
+	err = ethuri.SignUpdate(params, keyID, privKey)
+	if err != nil {
+		t.Fatalf("error signing update: err: %v", err)
+	}
+	_, tamperedKey := newSecp256k1DocKey(t)
+	tampered := *params
+	tampered.PublicKeys = []did.DocPublicKey{tamperedKey}
+	_, err = service.CreateOrUpdateDocument(&tampered)
+	if errors.Cause(err) != ethuri.ErrUpdateProofInvalid {
+		t.Errorf("should have rejected a proof for other content: err: %v", err)
+	}
+
+	// Expired proof
+	expired := *params
+	expiredProof := *params.Proof
+	expiredProof.Created = time.Now().Add(-time.Hour)
+	expired.Proof = &expiredProof
+	_, err = service.CreateOrUpdateDocument(&expired)
+	if errors.Cause(err) != ethuri.ErrUpdateProofExpired {
+		t.Errorf("should have rejected an expired proof: err: %v", err)
+	}
+
+	updated, err := service.CreateOrUpdateDocument(params)
+	if err != nil {
+		t.Fatalf("should have updated the document: err: %v", err)
+	}
+	if len(updated.PublicKeys) != 2 {
+		t.Errorf("should have added the key: %v", len(updated.PublicKeys))
+	}
+
+	// The update can not be replayed on the new version
+	_, err = service.CreateOrUpdateDocument(params)
+	if errors.Cause(err) != ethuri.ErrUpdateVersionMismatch {
+		t.Errorf("should have rejected a replayed update: err: %v", err)
+	}
+}
+
+func TestUpdateDocumentProofNotAuthentication(t *testing.T) {
+	service := ethuri.NewService(&ethuri.InMemoryPersister{})
+	doc, privKey := createSignedDocument(t, service)
+
+	// Add a key that is not an authentication key
+	keyPrivKey, key := newSecp256k1DocKey(t)
+	params := &ethuri.CreateOrUpdateParams{
+		Did:        utils.StrToPtr(doc.ID.String()),
+		Version:    1,
+		PublicKeys: []did.DocPublicKey{key},
+	}
+	err := ethuri.SignUpdate(params, doc.Authentications[0].ID.String(), privKey)
+	if err != nil {
+		t.Fatalf("error signing update: err: %v", err)
+	}
+	doc, err = service.CreateOrUpdateDocument(params)
+	if err != nil {
+		t.Fatalf("should have updated the document: err: %v", err)
+	}
+
+	// It cannot update the document
+	_, srvKey := newSecp256k1DocKey(t)
+	params = &ethuri.CreateOrUpdateParams{
+		Did:        utils.StrToPtr(doc.ID.String()),
+		Version:    2,
+		PublicKeys: []did.DocPublicKey{srvKey},
+	}
+	err = ethuri.SignUpdate(params, doc.PublicKeys[1].ID.String(), keyPrivKey)
+	if err != nil {
+		t.Fatalf("error signing update: err: %v", err)
+	}
+	_, err = service.CreateOrUpdateDocument(params)
+	if errors.Cause(err) != ethuri.ErrUpdateProofInvalid {
+		t.Errorf("should have rejected a key that is not an authentication key: err: %v", err)
+	}
+}
+
+func TestSaveDocumentInvalidatesCache(t *testing.T) {
+	bcache, err := bigcache.NewBigCache(did.DefaultBigCacheConfig)
+	if err != nil {
+		t.Fatalf("error creating cache: err: %v", err)
+	}
+	cache := did.NewBigCacheResolverCache(bcache)
+	service := ethuri.NewService(&ethuri.InMemoryPersister{})
+	service.SetCache(cache)
+	doc, privKey := createSignedDocument(t, service)
+
+	_ = cache.Set(&doc.ID, doc)
+	err = service.SaveDocument(doc, 1)
+	if err != nil {
+		t.Fatalf("should have saved the document: err: %v", err)
+	}
+	_, err = cache.Get(&doc.ID)
+	if err != did.ErrResolverCacheDIDNotFound {
+		t.Errorf("should have invalidated the saved document: err: %v", err)
+	}
+
+	_ = cache.Set(&doc.ID, doc)
+	params := &ethuri.DeactivateParams{Did: doc.ID.String()}
+	err = ethuri.SignDeactivate(params, doc.Authentications[0].ID.String(), privKey)
+	if err != nil {
+		t.Fatalf("error signing deactivate: err: %v", err)
+	}
+	_, err = service.DeactivateDocument(params)
+	if err != nil {
+		t.Fatalf("should have deactivated the document: err: %v", err)
+	}
+	_, err = cache.Get(&doc.ID)
+	if err != did.ErrResolverCacheDIDNotFound {
+		t.Errorf("should have invalidated the deactivated document: err: %v", err)
+	}
+}
+
+func TestSaveDocumentVersionConflict(t *testing.T) {
+	service := ethuri.NewService(&ethuri.InMemoryPersister{})
+	doc, _ := createSignedDocument(t, service)
+
+	// Two updates built on version 1, the second would drop the first
+	err := service.SaveDocument(doc, 1)
+	if err != nil {
+		t.Fatalf("should have saved the update of version 1: err: %v", err)
+	}
+	err = service.SaveDocument(doc, 1)
+	if err != ethuri.ErrUpdateVersionMismatch {
+		t.Errorf("should not have saved a second update of version 1: err: %v", err)
+	}
+	err = service.SaveDocument(doc, 0)
+	if err != ethuri.ErrUpdateVersionMismatch {
+		t.Errorf("should not have saved over an existing document: err: %v", err)
+	}
+	version, err := service.GetLatestDocumentVersion(&doc.ID)
+	if err != nil || version.Version != 2 {
+		t.Errorf("should have kept version 2: %v, err: %v", version, err)
+	}
+}
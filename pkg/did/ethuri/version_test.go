@@ -0,0 +1,244 @@
+package ethuri_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	didlib "github.com/ockam-network/did"
+
+	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/did/ethuri"
+	"github.com/joincivil/id-hub/pkg/utils"
+)
+
+func TestResolveVersion(t *testing.T) {
+	persister := &ethuri.InMemoryPersister{}
+	service := ethuri.NewService(persister)
+	didService := did.NewService([]did.Resolver{service})
+	doc, privKey := createSignedDocument(t, service)
+	time.Sleep(10 * time.Millisecond)
+	beforeUpdate := time.Now()
+	time.Sleep(10 * time.Millisecond)
+
+	_, newKey := newSecp256k1DocKey(t)
+	params := &ethuri.CreateOrUpdateParams{
+		Did:        utils.StrToPtr(doc.ID.String()),
+		Version:    1,
+		PublicKeys: []did.DocPublicKey{newKey},
+	}
+	err := ethuri.SignUpdate(params, doc.Authentications[0].ID.String(), privKey)
+	if err != nil {
+		t.Fatalf("error signing update: err: %v", err)
+	}
+	_, err = service.CreateOrUpdateDocument(params)
+	if err != nil {
+		t.Fatalf("error updating document: err: %v", err)
+	}
+
+	first, err := service.ResolveVersion(context.Background(), &doc.ID, "1", nil)
+	if err != nil {
+		t.Fatalf("should have resolved the first version: err: %v", err)
+	}
+	if len(first.PublicKeys) != 1 {
+		t.Errorf("the first version should have 1 key: %v", len(first.PublicKeys))
+	}
+	second, err := service.ResolveVersion(context.Background(), &doc.ID, "2", nil)
+	if err != nil {
+		t.Fatalf("should have resolved the second version: err: %v", err)
+	}
+	if len(second.PublicKeys) != 2 {
+		t.Errorf("the second version should have 2 keys: %v", len(second.PublicKeys))
+	}
+	_, err = service.ResolveVersion(context.Background(), &doc.ID, "3", nil)
+	if err != did.ErrResolverDIDNotFound {
+		t.Errorf("should not have found a third version: err: %v", err)
+	}
+	_, err = service.ResolveVersion(context.Background(), &doc.ID, "latest", nil)
+	if err == nil {
+		t.Errorf("should have failed with an invalid version id")
+	}
+
+	atTime, err := service.ResolveVersion(context.Background(), &doc.ID, "", &beforeUpdate)
+	if err != nil {
+		t.Fatalf("should have resolved the version at the time: err: %v", err)
+	}
+	if len(atTime.PublicKeys) != 1 {
+		t.Errorf("should have resolved the first version at the time before the update")
+	}
+	beforeCreate := beforeUpdate.Add(-time.Hour)
+	_, err = service.ResolveVersion(context.Background(), &doc.ID, "", &beforeCreate)
+	if err != did.ErrResolverDIDNotFound {
+		t.Errorf("should not have found a version before the document: err: %v", err)
+	}
+
+	// DID URLs through the did service
+	resolved, err := didService.GetDocument(context.Background(), doc.ID.String()+"?versionId=1")
+	if err != nil {
+		t.Fatalf("should have resolved the did url: err: %v", err)
+	}
+	if len(resolved.PublicKeys) != 1 {
+		t.Errorf("should have resolved the first version")
+	}
+	resolved, err = didService.GetDocument(context.Background(), doc.ID.String()+"?versionTime="+
+		time.Now().Add(time.Minute).UTC().Format(time.RFC3339))
+	if err != nil {
+		t.Fatalf("should have resolved the did url: err: %v", err)
+	}
+	if len(resolved.PublicKeys) != 2 {
+		t.Errorf("should have resolved the second version")
+	}
+
+	// Keys at a time
+	newKeyID, _ := didlib.Parse(second.PublicKeys[1].ID.String())
+	_, err = didService.GetKeyFromDIDDocumentAtTime(context.Background(), newKeyID, beforeUpdate)
+	if err == nil {
+		t.Errorf("should not have found the key before it was added")
+	}
+	_, err = didService.GetKeyFromDIDDocumentAtTime(context.Background(), newKeyID, time.Now())
+	if err != nil {
+		t.Errorf("should have found the key after it was added: err: %v", err)
+	}
+	_, err = didService.GetKeyFromDIDDocumentAtTime(context.Background(), newKeyID, beforeCreate)
+	if err != nil {
+		t.Errorf("should have fallen back to the current document: err: %v", err)
+	}
+	_, err = didService.GetAssertionMethodAtTime(context.Background(), newKeyID, beforeUpdate, time.Now())
+	if err == nil {
+		t.Errorf("should not have found the assertion method before it was added")
+	}
+	_, err = didService.GetAssertionMethodAtTime(context.Background(), newKeyID, time.Now(), time.Now())
+	if err != nil {
+		t.Errorf("should have found the assertion method after it was added: err: %v", err)
+	}
+
+	// A version without the key removes it, backdated signatures received after
+	// that are not accepted
+	time.Sleep(10 * time.Millisecond)
+	afterAdd := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	err = persister.SaveDocument(first, 2)
+	if err != nil {
+		t.Fatalf("error saving version without the key: err: %v", err)
+	}
+	_, err = didService.GetAssertionMethodAtTime(context.Background(), newKeyID, afterAdd, afterAdd)
+	if err != nil {
+		t.Errorf("should have found the assertion method received before it was removed: err: %v", err)
+	}
+	_, err = didService.GetAssertionMethodAtTime(context.Background(), newKeyID, afterAdd, time.Now())
+	if err == nil {
+		t.Errorf("should not have found the assertion method received after it was removed")
+	}
+	_, err = didService.GetAssertionMethodAtTime(context.Background(), newKeyID,
+		time.Now().Add(time.Hour), afterAdd)
+	if err != nil {
+		t.Errorf("should have bounded the signed time by the received time: err: %v", err)
+	}
+
+	// Dereferencing keys of a version
+	_, err = didService.Dereference(context.Background(), doc.ID.String()+"?versionId=1#"+newKeyID.Fragment)
+	if err != did.ErrDereferenceNotFound {
+		t.Errorf("should not have dereferenced the key in the first version: err: %v", err)
+	}
+	result, err := didService.Dereference(context.Background(), doc.ID.String()+"?versionId=2#"+newKeyID.Fragment)
+	if err != nil || result.PublicKey == nil {
+		t.Errorf("should have dereferenced the key in the second version: err: %v", err)
+	}
+}
+
+type testAnchor struct {
+	versions []uint
+	docs     []*did.Document
+	fail     bool
+}
+
+func (a *testAnchor) AnchorDocumentVersion(doc *did.Document, version uint) error {
+	if a.fail {
+		return errors.New("anchor failed")
+	}
+	a.versions = append(a.versions, version)
+	a.docs = append(a.docs, doc)
+	return nil
+}
+
+func TestSaveDocumentAnchorsVersion(t *testing.T) {
+	service := ethuri.NewService(&ethuri.InMemoryPersister{})
+	anchor := &testAnchor{}
+	service.SetDocumentAnchor(anchor)
+	doc, privKey := createSignedDocument(t, service)
+
+	_, newKey := newSecp256k1DocKey(t)
+	params := &ethuri.CreateOrUpdateParams{
+		Did:        utils.StrToPtr(doc.ID.String()),
+		Version:    1,
+		PublicKeys: []did.DocPublicKey{newKey},
+	}
+	err := ethuri.SignUpdate(params, doc.Authentications[0].ID.String(), privKey)
+	if err != nil {
+		t.Fatalf("error signing update: err: %v", err)
+	}
+	_, err = service.CreateOrUpdateDocument(params)
+	if err != nil {
+		t.Fatalf("error updating document: err: %v", err)
+	}
+
+	if len(anchor.versions) != 2 || anchor.versions[0] != 1 || anchor.versions[1] != 2 {
+		t.Fatalf("should have anchored versions 1 and 2: %v", anchor.versions)
+	}
+	if len(anchor.docs[0].PublicKeys) != 1 || len(anchor.docs[1].PublicKeys) != 2 {
+		t.Errorf("should have anchored the saved versions of the document")
+	}
+}
+
+func TestSaveDocumentRetriesAnchoring(t *testing.T) {
+	persister := &ethuri.InMemoryPersister{}
+	service := ethuri.NewService(persister)
+	anchor := &testAnchor{fail: true}
+	service.SetDocumentAnchor(anchor)
+	doc, privKey := createSignedDocument(t, service)
+
+	unanchored, err := persister.GetUnanchoredDocumentVersions(&doc.ID)
+	if err != nil || len(unanchored) != 1 {
+		t.Fatalf("should have saved the first version unanchored: %v, err: %v", len(unanchored), err)
+	}
+
+	// the next save anchors the earlier version first
+	anchor.fail = false
+	_, newKey := newSecp256k1DocKey(t)
+	params := &ethuri.CreateOrUpdateParams{
+		Did:        utils.StrToPtr(doc.ID.String()),
+		Version:    1,
+		PublicKeys: []did.DocPublicKey{newKey},
+	}
+	err = ethuri.SignUpdate(params, doc.Authentications[0].ID.String(), privKey)
+	if err != nil {
+		t.Fatalf("error signing update: err: %v", err)
+	}
+	_, err = service.CreateOrUpdateDocument(params)
+	if err != nil {
+		t.Fatalf("error updating document: err: %v", err)
+	}
+	if len(anchor.versions) != 2 || anchor.versions[0] != 1 || anchor.versions[1] != 2 {
+		t.Fatalf("should have anchored versions 1 and 2: %v", anchor.versions)
+	}
+
+	// failed versions are retried by AnchorDocumentVersions
+	anchor.fail = true
+	err = service.SaveDocument(doc, 2)
+	if err != nil {
+		t.Fatalf("should have saved the document when anchoring failed: err: %v", err)
+	}
+	anchor.fail = false
+	err = service.AnchorDocumentVersions()
+	if err != nil {
+		t.Fatalf("should have anchored the versions: err: %v", err)
+	}
+	if len(anchor.versions) != 3 || anchor.versions[2] != 3 {
+		t.Errorf("should have anchored version 3: %v", anchor.versions)
+	}
+	unanchored, err = persister.GetUnanchoredDocumentVersions(nil)
+	if err != nil || len(unanchored) != 0 {
+		t.Errorf("should have anchored every version: %v, err: %v", len(unanchored), err)
+	}
+}
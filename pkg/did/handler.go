@@ -0,0 +1,64 @@
+package did
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi"
+	log "github.com/golang/glog"
+)
+
+const (
+	// IdentifiersPath is the path of the universal resolver driver endpoint
+	IdentifiersPath = "/1.0/identifiers/{did}"
+)
+
+// Handler serves DID resolution results as a universal resolver driver
+type Handler struct {
+	service *Service
+}
+
+// NewHandler returns a new did Handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// GetIdentifierHandler serves GET /1.0/identifiers/{did}
+func (h *Handler) GetIdentifierHandler(w http.ResponseWriter, r *http.Request) {
+	didURL, err := url.PathUnescape(chi.URLParam(r, "did"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// The query of the DID URL is in the query of the request if not escaped
+	if r.URL.RawQuery != "" {
+		didURL = didURL + "?" + r.URL.RawQuery
+	}
+
+	status := http.StatusOK
+	resp, err := h.service.ResolveResult(r.Context(), didURL)
+	if _, ok := IsDeactivated(err); ok {
+		status = http.StatusGone
+	} else if err != nil {
+		switch resp.DidResolutionMetadata.Error {
+		case ResolutionErrorInvalidDID:
+			status = http.StatusBadRequest
+		case ResolutionErrorNotFound:
+			status = http.StatusNotFound
+		default:
+			log.Errorf("Error resolving did: %v, err: %v", didURL, err)
+			status = http.StatusInternalServerError
+		}
+	}
+
+	js, err := json.Marshal(resp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ResolutionContentType)
+	w.WriteHeader(status)
+	_, _ = w.Write(js)
+}
@@ -0,0 +1,116 @@
+package did_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	cnum "github.com/joincivil/go-common/pkg/numbers"
+	cstr "github.com/joincivil/go-common/pkg/strings"
+	didlib "github.com/ockam-network/did"
+
+	"github.com/joincivil/id-hub/pkg/did"
+)
+
+type StaticResolver struct {
+	doc *did.Document
+}
+
+func (s *StaticResolver) Resolve(ctx context.Context, d *didlib.DID) (*did.Document, error) {
+	if did.MethodIDOnly(d) != s.doc.ID.String() {
+		return nil, did.ErrResolverDIDNotFound
+	}
+	return s.doc, nil
+}
+
+func newIdentifiersServer(resolvers []did.Resolver) *httptest.Server {
+	router := chi.NewRouter()
+	router.Get(did.IdentifiersPath, did.NewHandler(did.NewService(resolvers)).GetIdentifierHandler)
+	return httptest.NewServer(router)
+}
+
+func getIdentifier(t *testing.T, server *httptest.Server, didURL string) (int,
+	*did.UniversalResolverResponse) {
+	res, err := http.Get(server.URL + "/1.0/identifiers/" + didURL)
+	if err != nil {
+		t.Fatalf("Should have requested the identifier: err: %v", err)
+	}
+	defer res.Body.Close() // nolint: errcheck
+	resp := &did.UniversalResolverResponse{}
+	err = json.NewDecoder(res.Body).Decode(resp)
+	if err != nil {
+		t.Fatalf("Should have decoded the resolution result: err: %v", err)
+	}
+	return res.StatusCode, resp
+}
+
+func TestGetIdentifierHandler(t *testing.T) {
+	created := time.Now().UTC().Truncate(time.Second)
+	doc := &did.Document{}
+	err := json.Unmarshal([]byte(testDIDDocV1), doc)
+	if err != nil {
+		t.Fatalf("Should have unmarshalled document from json: err: %v", err)
+	}
+	doc.Created = &created
+	doc.Updated = &created
+
+	server := newIdentifiersServer([]did.Resolver{&StaticResolver{doc: doc}})
+	defer server.Close()
+
+	status, resp := getIdentifier(t, server, "did:example:123456789abcdefghi")
+	if status != http.StatusOK {
+		t.Fatalf("Should have resolved the did: %v", status)
+	}
+	if resp.DidDocument == nil || resp.DidDocument.ID.String() != doc.ID.String() {
+		t.Errorf("Should have returned the document")
+	}
+	if resp.DidResolutionMetadata == nil || resp.DidResolutionMetadata.ContentType != did.DocumentContentType {
+		t.Errorf("Should have returned the resolution metadata")
+	}
+	if resp.DidDocumentMetadata == nil || resp.DidDocumentMetadata.Created == nil ||
+		!resp.DidDocumentMetadata.Created.Equal(created) || resp.DidDocumentMetadata.Deactivated {
+		t.Errorf("Should have returned the document metadata")
+	}
+	if resp.Metadata == nil || resp.Metadata.DidURL.Did.Method != "example" {
+		t.Errorf("Should have returned the resolver metadata")
+	}
+
+	status, resp = getIdentifier(t, server, "did:example:notfound")
+	if status != http.StatusNotFound || resp.DidResolutionMetadata.Error != did.ResolutionErrorNotFound {
+		t.Errorf("Should have not found the did: %v", status)
+	}
+
+	status, resp = getIdentifier(t, server, "notadid")
+	if status != http.StatusBadRequest || resp.DidResolutionMetadata.Error != did.ResolutionErrorInvalidDID {
+		t.Errorf("Should have returned invalid did: %v", status)
+	}
+}
+
+func TestGetIdentifierHandlerDeactivated(t *testing.T) {
+	server := newIdentifiersServer([]did.Resolver{&DeactivatedResolver{}})
+	defer server.Close()
+
+	status, resp := getIdentifier(t, server, "did:example:123456789abcdefghi")
+	if status != http.StatusGone {
+		t.Errorf("Should have returned gone for the deactivated did: %v", status)
+	}
+	if resp.DidDocument != nil || resp.DidDocumentMetadata == nil || !resp.DidDocumentMetadata.Deactivated {
+		t.Errorf("Should have returned the deactivated document metadata")
+	}
+
+	// The hub can be used as a driver by another hub
+	u, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(u.Port())
+	res := did.NewHTTPUniversalResolver(cstr.StrToPtr(u.Hostname()), cnum.IntToPtr(port), nil)
+	d, _ := didlib.Parse("did:example:123456789abcdefghi")
+	_, err := res.Resolve(context.Background(), d)
+	if _, ok := did.IsDeactivated(err); !ok {
+		t.Errorf("Should have resolved the did as deactivated: err: %v", err)
+	}
+}
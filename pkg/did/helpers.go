@@ -98,7 +98,7 @@ func PublicKeyInSlice(pk DocPublicKey, pks []DocPublicKey) bool {
 		if pk.Type == sPk.Type {
 			for ind, kf := range keyFields {
 				skf = sKeyFields[ind]
-				if kf != nil && *kf != "" && *kf == *skf {
+				if kf != nil && skf != nil && *kf != "" && *kf == *skf {
 					return true
 				}
 
@@ -153,7 +153,7 @@ func AuthInSlice(auth DocAuthenicationWrapper, auths []DocAuthenicationWrapper)
 		if authKey.Type == sAuthKey.Type {
 			for ind, kf := range keyFields {
 				skf = sKeyFields[ind]
-				if kf != nil && *kf != "" && *kf == *skf {
+				if kf != nil && skf != nil && *kf != "" && *kf == *skf {
 					return true
 				}
 
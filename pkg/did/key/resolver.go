@@ -0,0 +1,169 @@
+package key
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/mr-tron/base58"
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/linkeddata"
+)
+
+// Implements the did.Resolver interface, so can be passed into did.Service
+
+const (
+	// Method is the did method of did:key
+	Method = "key"
+
+	// base58btcPrefix is the multibase prefix of base58btc
+	base58btcPrefix = "z"
+)
+
+// multicodec prefixes of the public key types, varint encoded
+var (
+	secp256k1Codec = []byte{0xe7, 0x01}
+	p256Codec      = []byte{0x80, 0x24}
+	ed25519Codec   = []byte{0xed, 0x01}
+)
+
+// Resolver builds the did documents of did:key dids from the key in the did,
+// it never uses the network
+type Resolver struct{}
+
+// NewResolver returns a new did:key Resolver
+func NewResolver() *Resolver {
+	return &Resolver{}
+}
+
+// Methods implements the did.MethodResolver interface
+func (r *Resolver) Methods() []string {
+	return []string{Method}
+}
+
+// Resolve implements the did.Resolver interface and returns the did document of
+// a did:key did
+func (r *Resolver) Resolve(ctx context.Context, d *didlib.DID) (*did.Document, error) {
+	if d.Method != Method {
+		return nil, did.ErrResolverDIDNotFound
+	}
+	return NewDocument(d)
+}
+
+// NewDocument returns the did document of a did:key did, it has the key as its
+// only public key with the multibase value of the key as its fragment
+func NewDocument(d *didlib.DID) (*did.Document, error) {
+	if d.Method != Method || !strings.HasPrefix(d.ID, base58btcPrefix) {
+		return nil, errors.Errorf("not a base58btc did:key: %v", d.String())
+	}
+	keyBys, err := base58.Decode(d.ID[len(base58btcPrefix):])
+	if err != nil {
+		return nil, errors.Wrap(err, "newdocument.decode")
+	}
+
+	docID, err := didlib.Parse(did.MethodIDOnly(d))
+	if err != nil {
+		return nil, errors.Wrap(err, "newdocument.parse")
+	}
+	pubKey, err := docPublicKey(keyBys)
+	if err != nil {
+		return nil, err
+	}
+	pubKey.ID = did.CopyDID(docID)
+	pubKey.ID.Fragment = d.ID
+	pubKey.Controller = did.CopyDID(docID)
+
+	doc := &did.Document{
+		Context:    did.DefaultDIDContextV1,
+		ID:         *docID,
+		PublicKeys: []did.DocPublicKey{},
+		Services:   []did.DocService{},
+	}
+	err = doc.AddPublicKey(pubKey, true, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "newdocument.addpublickey")
+	}
+	for _, rel := range []did.VerificationRelationship{
+		did.RelationshipAssertionMethod,
+		did.RelationshipCapabilityInvocation,
+		did.RelationshipCapabilityDelegation,
+	} {
+		err = doc.AddKeyToRelationship(pubKey.ID, rel)
+		if err != nil {
+			return nil, errors.Wrap(err, "newdocument.addkeytorelationship")
+		}
+	}
+	// The document is derived from the did so it never changes
+	doc.Updated = nil
+	return doc, nil
+}
+
+func docPublicKey(keyBys []byte) (*did.DocPublicKey, error) {
+	switch {
+	case hasCodec(keyBys, secp256k1Codec):
+		pubKey, err := crypto.DecompressPubkey(keyBys[len(secp256k1Codec):])
+		if err != nil {
+			return nil, errors.Wrap(err, "docpublickey.decompresspubkey")
+		}
+		pubKeyHex := hex.EncodeToString(crypto.FromECDSAPub(pubKey))
+		return &did.DocPublicKey{
+			Type:         linkeddata.SuiteTypeSecp256k1Verification,
+			PublicKeyHex: &pubKeyHex,
+		}, nil
+
+	case hasCodec(keyBys, p256Codec):
+		x, y := elliptic.UnmarshalCompressed(elliptic.P256(), keyBys[len(p256Codec):])
+		if x == nil {
+			return nil, errors.New("invalid P-256 public key")
+		}
+		pubKeyHex := hex.EncodeToString(elliptic.Marshal(elliptic.P256(), x, y))
+		return &did.DocPublicKey{
+			Type:         linkeddata.SuiteTypeSecp256r1Verification,
+			PublicKeyHex: &pubKeyHex,
+		}, nil
+
+	case hasCodec(keyBys, ed25519Codec):
+		if len(keyBys)-len(ed25519Codec) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 public key")
+		}
+		pubKeyBase58 := base58.Encode(keyBys[len(ed25519Codec):])
+		return &did.DocPublicKey{
+			Type:            linkeddata.SuiteTypeEd25519Verification,
+			PublicKeyBase58: &pubKeyBase58,
+		}, nil
+	}
+	return nil, errors.New("unsupported did:key public key type")
+}
+
+func hasCodec(keyBys []byte, codec []byte) bool {
+	return len(keyBys) > len(codec) && keyBys[0] == codec[0] && keyBys[1] == codec[1]
+}
+
+// DIDFromEcdsaPubKey returns the did:key did of a secp256k1 or P-256 public key
+func DIDFromEcdsaPubKey(pubKey *ecdsa.PublicKey) (*didlib.DID, error) {
+	switch pubKey.Curve {
+	case crypto.S256():
+		return newDID(secp256k1Codec, crypto.CompressPubkey(pubKey))
+	case elliptic.P256():
+		return newDID(p256Codec, elliptic.MarshalCompressed(elliptic.P256(), pubKey.X, pubKey.Y))
+	}
+	return nil, errors.New("unsupported curve")
+}
+
+// DIDFromEd25519PubKey returns the did:key did of an Ed25519 public key
+func DIDFromEd25519PubKey(pubKey ed25519.PublicKey) (*didlib.DID, error) {
+	return newDID(ed25519Codec, pubKey)
+}
+
+func newDID(codec []byte, keyBys []byte) (*didlib.DID, error) {
+	value := base58.Encode(append(append([]byte{}, codec...), keyBys...))
+	return didlib.Parse(fmt.Sprintf("did:%v:%v%v", Method, base58btcPrefix, value))
+}
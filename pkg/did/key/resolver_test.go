@@ -0,0 +1,143 @@
+package key_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/ethereum/go-ethereum/crypto"
+	ctime "github.com/joincivil/go-common/pkg/time"
+	didlib "github.com/ockam-network/did"
+
+	"github.com/joincivil/id-hub/pkg/auth"
+	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/did/key"
+	"github.com/joincivil/id-hub/pkg/didjwt"
+	"github.com/joincivil/id-hub/pkg/linkeddata"
+)
+
+const (
+	// Ed25519 did:key test vector and the base58 of its key
+	testEd25519DID       = "did:key:z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK"
+	testEd25519PubBase58 = "48GdbJyVULjHDaBNS6ct9oAGtckZUS5v8asrPzvZ7R1w"
+)
+
+func TestResolveEd25519(t *testing.T) {
+	d, _ := didlib.Parse(testEd25519DID)
+	doc, err := key.NewResolver().Resolve(context.Background(), d)
+	if err != nil {
+		t.Fatalf("Should have resolved the did: err: %v", err)
+	}
+	if doc.ID.String() != testEd25519DID || len(doc.PublicKeys) != 1 || len(doc.Authentications) != 1 {
+		t.Fatalf("Should have built the document: %v", doc)
+	}
+	if len(doc.AssertionMethods) != 1 || len(doc.CapabilityInvocations) != 1 ||
+		len(doc.CapabilityDelegations) != 1 {
+		t.Errorf("Should have added the key to the verification relationships")
+	}
+	pubKey, err := doc.GetPublicKeyFromFragment(d.ID)
+	if err != nil {
+		t.Fatalf("Should have found the key by its fragment: err: %v", err)
+	}
+	if pubKey.Type != linkeddata.SuiteTypeEd25519Verification || *pubKey.PublicKeyBase58 != testEd25519PubBase58 {
+		t.Errorf("Should have set the Ed25519 key: %v", *pubKey.PublicKeyBase58)
+	}
+
+	edPub, _, _ := ed25519.GenerateKey(rand.Reader)
+	edDID, err := key.DIDFromEd25519PubKey(edPub)
+	if err != nil || !strings.HasPrefix(edDID.String(), "did:key:z6Mk") {
+		t.Errorf("Should have made an Ed25519 did: %v, err: %v", edDID, err)
+	}
+}
+
+func TestResolveEcdsa(t *testing.T) {
+	k1Key, _ := crypto.GenerateKey()
+	p256Key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	tests := []struct {
+		privKey *ecdsa.PrivateKey
+		prefix  string
+		suite   linkeddata.SuiteType
+	}{
+		{k1Key, "did:key:zQ3s", linkeddata.SuiteTypeSecp256k1Verification},
+		{p256Key, "did:key:zDn", linkeddata.SuiteTypeSecp256r1Verification},
+	}
+	for _, test := range tests {
+		d, err := key.DIDFromEcdsaPubKey(&test.privKey.PublicKey)
+		if err != nil {
+			t.Fatalf("Should have made the did: err: %v", err)
+		}
+		if !strings.HasPrefix(d.String(), test.prefix) {
+			t.Errorf("Should have the multicodec prefix %v: %v", test.prefix, d.String())
+		}
+		doc, err := key.NewResolver().Resolve(context.Background(), d)
+		if err != nil {
+			t.Fatalf("Should have resolved the did: err: %v", err)
+		}
+		if doc.PublicKeys[0].Type != test.suite {
+			t.Errorf("Should have set the key type: %v", doc.PublicKeys[0].Type)
+		}
+		pubKey, err := doc.PublicKeys[0].AsEcdsaPubKey()
+		if err != nil {
+			t.Fatalf("Should have decoded the key: err: %v", err)
+		}
+		if pubKey.X.Cmp(test.privKey.X) != 0 || pubKey.Y.Cmp(test.privKey.Y) != 0 {
+			t.Errorf("Should have decompressed the key")
+		}
+	}
+}
+
+func TestResolveInvalid(t *testing.T) {
+	resolver := key.NewResolver()
+	d, _ := didlib.Parse("did:ethuri:e7ab0c43-d9fe-4a61-87a3-3fa99ce879e1")
+	_, err := resolver.Resolve(context.Background(), d)
+	if err != did.ErrResolverDIDNotFound {
+		t.Errorf("Should not have resolved another method: err: %v", err)
+	}
+	for _, invalid := range []string{"did:key:abc", "did:key:z111", "did:key:zQ3shabc"} {
+		d, _ = didlib.Parse(invalid)
+		_, err = resolver.Resolve(context.Background(), d)
+		if err == nil {
+			t.Errorf("Should not have resolved %v", invalid)
+		}
+	}
+}
+
+func TestOffline(t *testing.T) {
+	didService := did.NewService([]did.Resolver{key.NewResolver()})
+
+	// Request signatures
+	k1Key, _ := crypto.GenerateKey()
+	k1DID, _ := key.DIDFromEcdsaPubKey(&k1Key.PublicKey)
+	reqTs := ctime.CurrentEpochSecsInInt()
+	sig, err := auth.SignEcdsaRequestMessage(k1Key, k1DID.String(), reqTs)
+	if err != nil {
+		t.Fatalf("Should have signed the request: err: %v", err)
+	}
+	err = auth.VerifyEcdsaRequestSignatureWithDid(context.Background(), didService, linkeddata.SuiteTypeSecp256k1Verification,
+		sig, reqTs, k1DID.String(), auth.DefaultRequestGracePeriodSecs)
+	if err != nil {
+		t.Errorf("Should have verified the request signature: err: %v", err)
+	}
+
+	// JWTs
+	p256Key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	p256DID, _ := key.DIDFromEcdsaPubKey(&p256Key.PublicKey)
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, &didjwt.VCClaimsJWT{
+		Data:           "data",
+		StandardClaims: jwt.StandardClaims{Issuer: p256DID.String()},
+	})
+	tokenS, err := token.SignedString(p256Key)
+	if err != nil {
+		t.Fatalf("Should have signed the jwt: err: %v", err)
+	}
+	parsed, err := didjwt.NewService(didService).ParseJWT(context.Background(), tokenS)
+	if err != nil || !parsed.Valid {
+		t.Errorf("Should have verified the jwt: err: %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package did
 import (
 	"bytes"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"encoding/hex"
 	"encoding/json"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/crypto"
 	log "github.com/golang/glog"
+	"github.com/mr-tron/base58"
 
 	"github.com/joincivil/go-common/pkg/eth"
 
@@ -26,24 +28,54 @@ import (
 )
 
 const (
-	// DefaultDIDContextV1 is the default context for DID documents
-	DefaultDIDContextV1 = "https://www.w3.org/2019/did/v1"
+	// DefaultDIDContextV1 is the default context for DID documents, the
+	// DID Core v1.0 context
+	DefaultDIDContextV1 = "https://www.w3.org/ns/did/v1"
+	// DIDContextDraft2019 is the context of DID documents following the 2019
+	// draft, these are read as DID Core v1.0 documents
+	DIDContextDraft2019 = "https://www.w3.org/2019/did/v1"
+)
+
+// VerificationRelationship is a DID Core v1.0 verification relationship, it
+// expresses what the verification methods referenced in it can be used for
+type VerificationRelationship string
+
+const (
+	// RelationshipAuthentication is for keys that authenticate as the DID
+	RelationshipAuthentication VerificationRelationship = "authentication"
+	// RelationshipAssertionMethod is for keys that sign credentials
+	RelationshipAssertionMethod VerificationRelationship = "assertionMethod"
+	// RelationshipKeyAgreement is for keys that encrypt to the DID subject
+	RelationshipKeyAgreement VerificationRelationship = "keyAgreement"
+	// RelationshipCapabilityInvocation is for keys that invoke capabilities,
+	// such as updating the DID document
+	RelationshipCapabilityInvocation VerificationRelationship = "capabilityInvocation"
+	// RelationshipCapabilityDelegation is for keys that delegate capabilities
+	RelationshipCapabilityDelegation VerificationRelationship = "capabilityDelegation"
 )
 
 // https://github.com/ockam-network/did as base DID parser/handler.
 
 // Document is the base definition of a DID document
-// https://w3c-ccg.github.io/did-spec/#did-documents
+// https://www.w3.org/TR/did-core/#core-properties
+// PublicKeys are the verification methods of the document, read from both the
+// verificationMethod and the 2019 draft publicKey property. The verification
+// relationships either reference a verification method or embed a key.
 type Document struct {
-	Context         string                    `json:"@context"`
-	ID              didlib.DID                `json:"id"`
-	Controller      *didlib.DID               `json:"controller,omitempty"`
-	PublicKeys      []DocPublicKey            `json:"publicKey"`
-	Authentications []DocAuthenicationWrapper `json:"authentication,omitempty"`
-	Services        []DocService              `json:"service,omitempty"`
-	Created         *time.Time                `json:"created,omitempty"`
-	Updated         *time.Time                `json:"updated,omitempty"`
-	Proof           *linkeddata.Proof         `json:"proof,omitempty"`
+	Context               string                    `json:"@context"`
+	AdditionalContexts    []string                  `json:"-"`
+	ID                    didlib.DID                `json:"id"`
+	Controller            *didlib.DID               `json:"controller,omitempty"`
+	PublicKeys            []DocPublicKey            `json:"verificationMethod,omitempty"`
+	Authentications       []DocAuthenicationWrapper `json:"authentication,omitempty"`
+	AssertionMethods      []DocAuthenicationWrapper `json:"assertionMethod,omitempty"`
+	KeyAgreements         []DocAuthenicationWrapper `json:"keyAgreement,omitempty"`
+	CapabilityInvocations []DocAuthenicationWrapper `json:"capabilityInvocation,omitempty"`
+	CapabilityDelegations []DocAuthenicationWrapper `json:"capabilityDelegation,omitempty"`
+	Services              []DocService              `json:"service,omitempty"`
+	Created               *time.Time                `json:"created,omitempty"`
+	Updated               *time.Time                `json:"updated,omitempty"`
+	Proof                 *linkeddata.Proof         `json:"proof,omitempty"`
 }
 
 func (d Document) String() string {
@@ -70,12 +102,17 @@ func (d Document) String() string {
 	return buf.String()
 }
 
-// UnmarshalJSON implements the Unmarshaller interface for Document
+// UnmarshalJSON implements the Unmarshaller interface for Document. Reads both
+// DID Core v1.0 documents and documents following the 2019 draft. In 2019 draft
+// documents, without verificationMethod or assertionMethod, every public key
+// could sign credentials, so they are read as assertion methods.
 func (d *Document) UnmarshalJSON(b []byte) error {
 	type docAlias Document
 	aux := &struct {
-		ID         string `json:"id"`
-		Controller string `json:"controller,omitempty"`
+		Context    json.RawMessage `json:"@context"`
+		ID         string          `json:"id"`
+		Controller string          `json:"controller,omitempty"`
+		PublicKey  []DocPublicKey  `json:"publicKey,omitempty"`
 		*docAlias
 	}{
 		docAlias: (*docAlias)(d),
@@ -86,8 +123,13 @@ func (d *Document) UnmarshalJSON(b []byte) error {
 		return errors.Wrap(err, "unable to unmarshal document")
 	}
 
+	err = d.setContexts(aux.Context)
+	if err != nil {
+		return err
+	}
+
 	// Set the DID as a struct
-	id, err := didlib.Parse(aux.ID)
+	id, err := Parse(aux.ID)
 	if err != nil {
 		return errors.Wrap(err, "unable to parse did for document")
 	}
@@ -95,28 +137,135 @@ func (d *Document) UnmarshalJSON(b []byte) error {
 
 	// Set the controller as a struct
 	if aux.Controller != "" {
-		controller, err := didlib.Parse(aux.Controller)
+		controller, err := Parse(aux.Controller)
 		if err != nil {
 			return errors.Wrap(err, "unable to parse controller for document")
 		}
 		d.Controller = controller
 	}
 
+	draft := d.PublicKeys == nil && d.AssertionMethods == nil
+	for _, pk := range aux.PublicKey {
+		if !PublicKeyInSlice(pk, d.PublicKeys) {
+			d.PublicKeys = append(d.PublicKeys, pk)
+		}
+	}
+
+	d.resolveRelativeIDs()
+
+	if draft {
+		for _, pk := range d.PublicKeys {
+			if pk.ID != nil {
+				d.AssertionMethods = append(d.AssertionMethods, DocAuthenicationWrapper{
+					DocPublicKey: DocPublicKey{ID: CopyDID(pk.ID)},
+					IDOnly:       true,
+				})
+			}
+		}
+	}
+
 	return nil
 }
 
-// MarshalJSON implements the Marshaller interface for Document
+// setContexts sets the context from a single context or a list of contexts,
+// the 2019 draft context is replaced with the DID Core v1.0 context
+func (d *Document) setContexts(raw json.RawMessage) error {
+	d.AdditionalContexts = nil
+	if len(raw) == 0 || string(raw) == "null" {
+		d.Context = ""
+		return nil
+	}
+
+	contexts := []interface{}{}
+	err := json.Unmarshal(raw, &contexts)
+	if err != nil {
+		var context interface{}
+		err = json.Unmarshal(raw, &context)
+		if err != nil {
+			return errors.Wrap(err, "unable to unmarshal context")
+		}
+		contexts = []interface{}{context}
+	}
+
+	d.Context = ""
+	for i, c := range contexts {
+		context, ok := c.(string)
+		if !ok {
+			// Embedded contexts are kept as their JSON
+			bys, err := json.Marshal(c)
+			if err != nil {
+				return errors.Wrap(err, "unable to marshal embedded context")
+			}
+			context = string(bys)
+		}
+		if context == DIDContextDraft2019 {
+			context = DefaultDIDContextV1
+		}
+		if i == 0 {
+			d.Context = context
+		} else {
+			d.AdditionalContexts = append(d.AdditionalContexts, context)
+		}
+	}
+	return nil
+}
+
+// resolveRelativeIDs sets the DID of the document on relative DID URLs, which
+// are only a fragment, of the keys, relationships and services
+func (d *Document) resolveRelativeIDs() {
+	resolve := func(id *didlib.DID) *didlib.DID {
+		if id == nil || id.Method != "" {
+			return id
+		}
+		resolved := CopyDID(&d.ID)
+		resolved.Fragment = id.Fragment
+		return resolved
+	}
+
+	for i := range d.PublicKeys {
+		d.PublicKeys[i].ID = resolve(d.PublicKeys[i].ID)
+	}
+	for _, rel := range d.relationships() {
+		for i := range *rel {
+			(*rel)[i].ID = resolve((*rel)[i].ID)
+		}
+	}
+	for i := range d.Services {
+		d.Services[i].ID = *resolve(&d.Services[i].ID)
+	}
+}
+
+// MarshalJSON implements the Marshaller interface for Document, the document
+// is always marshalled as a DID Core v1.0 document
 func (d *Document) MarshalJSON() ([]byte, error) {
 	type docAlias Document
 	aux := &struct {
-		ID         string `json:"id"`
-		Controller string `json:"controller,omitempty"`
+		Context    interface{} `json:"@context"`
+		ID         string      `json:"id"`
+		Controller string      `json:"controller,omitempty"`
 		*docAlias
 	}{
 		ID:       d.ID.String(),
 		docAlias: (*docAlias)(d),
 	}
 
+	context := d.Context
+	if context == DIDContextDraft2019 {
+		context = DefaultDIDContextV1
+	}
+	aux.Context = context
+	if len(d.AdditionalContexts) > 0 {
+		contexts := []interface{}{context}
+		for _, c := range d.AdditionalContexts {
+			if strings.HasPrefix(c, "{") {
+				contexts = append(contexts, json.RawMessage(c))
+			} else {
+				contexts = append(contexts, c)
+			}
+		}
+		aux.Context = contexts
+	}
+
 	if d.Controller != nil && d.Controller.String() != "" {
 		aux.Controller = d.Controller.String()
 	}
@@ -124,6 +273,116 @@ func (d *Document) MarshalJSON() ([]byte, error) {
 	return json.Marshal(aux)
 }
 
+// relationships returns every verification relationship of the document
+func (d *Document) relationships() []*[]DocAuthenicationWrapper {
+	return []*[]DocAuthenicationWrapper{
+		&d.Authentications,
+		&d.AssertionMethods,
+		&d.KeyAgreements,
+		&d.CapabilityInvocations,
+		&d.CapabilityDelegations,
+	}
+}
+
+// parseDIDURL parses a DID URL, a relative DID URL that is only a fragment is
+// returned without a method and id, see Document.resolveRelativeIDs
+func parseDIDURL(s string) (*didlib.DID, error) {
+	if strings.HasPrefix(s, "#") {
+		return &didlib.DID{Fragment: s[1:]}, nil
+	}
+	return Parse(s)
+}
+
+// relationship returns a verification relationship of the document to update
+func (d *Document) relationship(rel VerificationRelationship) *[]DocAuthenicationWrapper {
+	switch rel {
+	case RelationshipAuthentication:
+		return &d.Authentications
+	case RelationshipAssertionMethod:
+		return &d.AssertionMethods
+	case RelationshipKeyAgreement:
+		return &d.KeyAgreements
+	case RelationshipCapabilityInvocation:
+		return &d.CapabilityInvocations
+	case RelationshipCapabilityDelegation:
+		return &d.CapabilityDelegations
+	}
+	return nil
+}
+
+// Relationship returns the verification methods in a verification relationship
+func (d *Document) Relationship(rel VerificationRelationship) []DocAuthenicationWrapper {
+	relationship := d.relationship(rel)
+	if relationship == nil {
+		return nil
+	}
+	return *relationship
+}
+
+// AddKeyToRelationship adds a reference to a verification method of the
+// document to a verification relationship
+func (d *Document) AddKeyToRelationship(keyID *didlib.DID, rel VerificationRelationship) error {
+	relationship := d.relationship(rel)
+	if relationship == nil {
+		return errors.Errorf("unknown verification relationship: %v", rel)
+	}
+
+	if keyID == nil {
+		return errors.New("no key id to add to relationship")
+	}
+	if _, err := d.GetPublicKeyFromFragment(keyID.Fragment); err != nil {
+		return errors.Errorf("relationship reference has no matching key: %v", keyID.String())
+	}
+	for _, v := range *relationship {
+		if v.ID != nil && v.ID.String() == keyID.String() {
+			return nil
+		}
+	}
+
+	*relationship = append(*relationship, DocAuthenicationWrapper{
+		DocPublicKey: DocPublicKey{ID: CopyDID(keyID)},
+		IDOnly:       true,
+	})
+	return nil
+}
+
+// GetRelationshipKeyFromFragment returns the key with the given fragment if it
+// is in a verification relationship, either referenced or embedded
+func (d *Document) GetRelationshipKeyFromFragment(rel VerificationRelationship,
+	fragment string) (*DocPublicKey, error) {
+	for _, v := range d.Relationship(rel) {
+		if v.ID == nil || v.ID.Fragment != fragment {
+			continue
+		}
+		if !v.IDOnly {
+			key := v.DocPublicKey
+			return &key, nil
+		}
+		return d.GetPublicKeyFromFragment(fragment)
+	}
+	return nil, errors.Errorf("DID does not have a key that matches the fragment in %v", rel)
+}
+
+// RelationshipKeys returns the keys in a verification relationship, resolving
+// references to the verification methods of the document
+func (d *Document) RelationshipKeys(rel VerificationRelationship) []DocPublicKey {
+	keys := []DocPublicKey{}
+	for _, v := range d.Relationship(rel) {
+		if !v.IDOnly {
+			keys = append(keys, v.DocPublicKey)
+			continue
+		}
+		if v.ID == nil {
+			continue
+		}
+		key, err := d.GetPublicKeyFromFragment(v.ID.Fragment)
+		if err == nil {
+			keys = append(keys, *key)
+		}
+	}
+	return keys
+}
+
 // AddPublicKey adds another public key.
 // If addRefToAuth is true, also adds a reference to the key in the authentication field.
 func (d *Document) AddPublicKey(pk *DocPublicKey, addRefToAuth bool, addFragment bool) error {
@@ -188,6 +447,18 @@ func (d *Document) GetPublicKeyFromFragment(fragment string) (*DocPublicKey, err
 	return nil, errors.New("DID does not have a key that matches the fragment")
 }
 
+// GetServiceFromFragment returns the service with the given fragment if it exists
+func (d *Document) GetServiceFromFragment(fragment string) (*DocService, error) {
+	for _, v := range d.Services {
+		if v.ID.Fragment == fragment {
+			srv := v
+			return &srv, nil
+		}
+	}
+
+	return nil, errors.New("DID does not have a service that matches the fragment")
+}
+
 // AddAuthentication adds another authentication value to the list.  Could be
 // just a reference to an existing key or a key only used for authentication
 func (d *Document) AddAuthentication(auth *DocAuthenicationWrapper, addFragment bool) error {
@@ -340,9 +611,10 @@ func (p *DocPublicKey) SetIDFragment(fragment string) *DocPublicKey {
 func (p *DocPublicKey) UnmarshalJSON(b []byte) error {
 	type pkAlias DocPublicKey
 	aux := &struct {
-		ID         string `json:"id,omitempty"`
-		Owner      string `json:"owner,omitempty"`
-		Controller string `json:"controller,omitempty"`
+		ID           string          `json:"id,omitempty"`
+		Owner        string          `json:"owner,omitempty"`
+		Controller   string          `json:"controller,omitempty"`
+		PublicKeyJwk json.RawMessage `json:"publicKeyJwk,omitempty"`
 		*pkAlias
 	}{
 		pkAlias: (*pkAlias)(p),
@@ -353,9 +625,29 @@ func (p *DocPublicKey) UnmarshalJSON(b []byte) error {
 		return errors.Wrap(err, "unable to unmarshal public key")
 	}
 
+	// DID Core v1.0 keys have a JWK object, the 2019 draft a JWK string, both
+	// are kept as the JWK JSON
+	if len(aux.PublicKeyJwk) > 0 && string(aux.PublicKeyJwk) != "null" {
+		jwk := string(aux.PublicKeyJwk)
+		if strings.HasPrefix(jwk, "\"") {
+			err = json.Unmarshal(aux.PublicKeyJwk, &jwk)
+			if err != nil {
+				return errors.Wrap(err, "unable to unmarshal jwk for public key")
+			}
+		} else {
+			buf := &bytes.Buffer{}
+			err = json.Compact(buf, aux.PublicKeyJwk)
+			if err != nil {
+				return errors.Wrap(err, "unable to compact jwk for public key")
+			}
+			jwk = buf.String()
+		}
+		p.PublicKeyJwk = &jwk
+	}
+
 	// Set the DID as a struct
 	if aux.ID != "" {
-		id, err := didlib.Parse(aux.ID)
+		id, err := parseDIDURL(aux.ID)
 		if err != nil {
 			return errors.Wrap(err, "unable to parse did for public key")
 		}
@@ -363,7 +655,7 @@ func (p *DocPublicKey) UnmarshalJSON(b []byte) error {
 	}
 
 	if aux.Owner != "" {
-		owner, err := didlib.Parse(aux.Owner)
+		owner, err := Parse(aux.Owner)
 		if err != nil {
 			return errors.Wrap(err, "unable to parse owner for public key")
 		}
@@ -371,7 +663,7 @@ func (p *DocPublicKey) UnmarshalJSON(b []byte) error {
 	}
 
 	if aux.Controller != "" {
-		controller, err := didlib.Parse(aux.Controller)
+		controller, err := Parse(aux.Controller)
 		if err != nil {
 			return errors.Wrap(err, "unable to parse did for public key")
 		}
@@ -389,14 +681,23 @@ func (p *DocPublicKey) UnmarshalJSON(b []byte) error {
 func (p *DocPublicKey) MarshalJSON() ([]byte, error) {
 	type pkAlias DocPublicKey
 	aux := &struct {
-		ID         string `json:"id,omitempty"`
-		Owner      string `json:"owner,omitempty"`
-		Controller string `json:"controller,omitempty"`
+		ID           string      `json:"id,omitempty"`
+		Owner        string      `json:"owner,omitempty"`
+		Controller   string      `json:"controller,omitempty"`
+		PublicKeyJwk interface{} `json:"publicKeyJwk,omitempty"`
 		*pkAlias
 	}{
 		pkAlias: (*pkAlias)(p),
 	}
 
+	// JWK objects are marshalled as objects, as in DID Core v1.0
+	if p.PublicKeyJwk != nil {
+		aux.PublicKeyJwk = *p.PublicKeyJwk
+		if strings.HasPrefix(*p.PublicKeyJwk, "{") && json.Valid([]byte(*p.PublicKeyJwk)) {
+			aux.PublicKeyJwk = json.RawMessage(*p.PublicKeyJwk)
+		}
+	}
+
 	if p.ID != nil {
 		aux.ID = p.ID.String()
 	}
@@ -441,6 +742,26 @@ func (p *DocPublicKey) AsEcdsaPubKey() (*ecdsa.PublicKey, error) {
 	return nil, errors.New("not in ecdsa secp256k1 or secp256r1 suite")
 }
 
+// AsEd25519PubKey returns this key as an Ed25519 public key. Will return error
+// if not an Ed25519 key with a base58 value
+func (p *DocPublicKey) AsEd25519PubKey() (ed25519.PublicKey, error) {
+	if p.Type != linkeddata.SuiteTypeEd25519Verification &&
+		p.Type != linkeddata.SuiteTypeEd25519Signature {
+		return nil, errors.New("not in ed25519 suite")
+	}
+	if p.PublicKeyBase58 == nil || *p.PublicKeyBase58 == "" {
+		return nil, errors.New("no base58 key found")
+	}
+	pubBytes, err := base58.Decode(*p.PublicKeyBase58)
+	if err != nil {
+		return nil, errors.Wrap(err, "asEd25519 base58 decode failed")
+	}
+	if len(pubBytes) != ed25519.PublicKeySize {
+		return nil, errors.New("asEd25519 invalid pub key size")
+	}
+	return ed25519.PublicKey(pubBytes), nil
+}
+
 // DocAuthenicationWrapper allows us to handle two different types for an authentication
 // value.  This can either be an ID to a public key or a public key.
 type DocAuthenicationWrapper struct {
@@ -477,7 +798,7 @@ func (a *DocAuthenicationWrapper) UnmarshalJSON(b []byte) error {
 	// If it is a DID string
 	// Strip out any whitespace or quotes
 	id := strings.Trim(string(b), "\" ")
-	d, err := didlib.Parse(id)
+	d, err := parseDIDURL(id)
 	if err != nil {
 		return errors.Wrapf(err, "unable to parse auth did: %v", string(b))
 	}
@@ -540,6 +861,8 @@ func (s *DocService) PopulateServiceEndpointVals() error {
 		// valid type
 		// XXX(PN): do more for validation of JSON-LD
 		s.ServiceEndpointLD = val
+	case []interface{}:
+		// valid type in DID Core v1.0, a set of URIs or objects
 	default:
 		return errors.Errorf("invalid type for service endpoint value: %T", val)
 	}
@@ -562,7 +885,7 @@ func (s *DocService) UnmarshalJSON(b []byte) error {
 	}
 
 	if aux.ID != "" {
-		id, err := didlib.Parse(aux.ID)
+		id, err := parseDIDURL(aux.ID)
 		if err != nil {
 			return errors.Wrap(err, "unable to parse did for service")
 		}
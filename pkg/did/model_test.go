@@ -119,8 +119,14 @@ func TestDocumentModelMarshal(t *testing.T) {
 	if !strings.Contains(jsonStr, "authentication") {
 		t.Errorf("Should have contained authentication")
 	}
-	if !strings.Contains(jsonStr, "publicKey") {
-		t.Errorf("Should have contained publicKey")
+	if !strings.Contains(jsonStr, "verificationMethod") {
+		t.Errorf("Should have contained verificationMethod")
+	}
+	if strings.Contains(jsonStr, `"publicKey":[{`) {
+		t.Errorf("Should not have contained the draft publicKey property")
+	}
+	if !strings.Contains(jsonStr, "assertionMethod") {
+		t.Errorf("Should have contained assertionMethod")
 	}
 	if !strings.Contains(jsonStr, "created") {
 		t.Errorf("Should have contained created")
@@ -491,3 +497,151 @@ func TestPublicKeyAsEcdsa(t *testing.T) {
 		t.Errorf("should have not returned nil key for invalid ecdsa key")
 	}
 }
+
+const testDIDDocV1 = `
+{
+	"@context": ["https://www.w3.org/ns/did/v1", "https://w3id.org/security/suites/jws-2020/v1"],
+	"id": "did:example:123456789abcdefghi",
+	"verificationMethod": [
+		{
+		"id": "#keys-1",
+		"type": "EcdsaSecp256k1VerificationKey2019",
+		"controller": "did:example:123456789abcdefghi",
+		"publicKeyHex": "04f3df3cea421eac2a7f5dbd8e8d505470d42150334f512bd6383c7dc91bf8fa4d5458d498b4dcd05574c902fb4c233005b3f5f3ff3904b41be186ddbda600580b"
+		},
+		{
+		"id": "did:example:123456789abcdefghi#keys-2",
+		"type": "JsonWebKey2020",
+		"controller": "did:example:123456789abcdefghi",
+		"publicKeyJwk": {"kty": "EC", "crv": "secp256k1", "x": "8943", "y": "4543"}
+		}
+	],
+	"authentication": ["#keys-1"],
+	"assertionMethod": ["did:example:123456789abcdefghi#keys-1"],
+	"keyAgreement": [
+		{
+		"id": "#keys-3",
+		"type": "X25519KeyAgreementKey2019",
+		"controller": "did:example:123456789abcdefghi",
+		"publicKeyBase58": "JhNWeSVLMYccCk7iopQW4guaSJTojqpMEELgSLhKwRr"
+		}
+	],
+	"capabilityInvocation": ["#keys-2"],
+	"capabilityDelegation": ["#keys-2"]
+}
+`
+
+func TestDocumentModelUnmarshalV1(t *testing.T) {
+	doc := &did.Document{}
+	err := json.Unmarshal([]byte(testDIDDocV1), doc)
+	if err != nil {
+		t.Fatalf("Should have unmarshalled document from json: err: %v", err)
+	}
+	if doc.Context != did.DefaultDIDContextV1 {
+		t.Errorf("Should have returned the v1 context")
+	}
+	if len(doc.AdditionalContexts) != 1 {
+		t.Errorf("Should have returned the additional context")
+	}
+	if len(doc.PublicKeys) != 2 {
+		t.Fatalf("Should have returned 2 verification methods")
+	}
+	if doc.PublicKeys[0].ID.String() != "did:example:123456789abcdefghi#keys-1" {
+		t.Errorf("Should have resolved the relative key id: %v", doc.PublicKeys[0].ID.String())
+	}
+	if doc.PublicKeys[1].PublicKeyJwk == nil || !strings.Contains(*doc.PublicKeys[1].PublicKeyJwk, "secp256k1") {
+		t.Errorf("Should have returned the jwk")
+	}
+
+	key, err := doc.GetRelationshipKeyFromFragment(did.RelationshipAuthentication, "keys-1")
+	if err != nil || key.PublicKeyHex == nil {
+		t.Errorf("Should have returned the authentication key: err: %v", err)
+	}
+	_, err = doc.GetRelationshipKeyFromFragment(did.RelationshipAssertionMethod, "keys-2")
+	if err == nil {
+		t.Errorf("Should not have returned a key not in assertion methods")
+	}
+	key, err = doc.GetRelationshipKeyFromFragment(did.RelationshipKeyAgreement, "keys-3")
+	if err != nil || key.PublicKeyBase58 == nil {
+		t.Errorf("Should have returned the embedded key agreement key: err: %v", err)
+	}
+	if len(doc.RelationshipKeys(did.RelationshipCapabilityInvocation)) != 1 {
+		t.Errorf("Should have returned the capability invocation key")
+	}
+	if len(doc.RelationshipKeys(did.RelationshipCapabilityDelegation)) != 1 {
+		t.Errorf("Should have returned the capability delegation key")
+	}
+
+	bys, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Should have marshalled document: err: %v", err)
+	}
+	jsonStr := string(bys)
+	if !strings.Contains(jsonStr, "jws-2020") {
+		t.Errorf("Should have kept the additional context")
+	}
+	if !strings.Contains(jsonStr, `"publicKeyJwk":{`) {
+		t.Errorf("Should have marshalled the jwk as an object")
+	}
+	for _, prop := range []string{"keyAgreement", "capabilityInvocation", "capabilityDelegation"} {
+		if !strings.Contains(jsonStr, prop) {
+			t.Errorf("Should have contained %v", prop)
+		}
+	}
+}
+
+func TestDocumentModelUnmarshalDraft(t *testing.T) {
+	doc := &did.Document{}
+	err := json.Unmarshal([]byte(testDIDDoc), doc)
+	if err != nil {
+		t.Fatalf("Should have unmarshalled document from json: err: %v", err)
+	}
+	// Every key of a draft document can assert
+	if len(doc.AssertionMethods) != len(doc.PublicKeys) {
+		t.Errorf("Should have added the keys to the assertion methods")
+	}
+	_, err = doc.GetRelationshipKeyFromFragment(did.RelationshipAssertionMethod, "keys-1")
+	if err != nil {
+		t.Errorf("Should have returned the key as an assertion method: err: %v", err)
+	}
+
+	draft := `{"@context": "https://www.w3.org/2019/did/v1", "id": "did:example:123456789abcdefghi"}`
+	doc = &did.Document{}
+	err = json.Unmarshal([]byte(draft), doc)
+	if err != nil {
+		t.Fatalf("Should have unmarshalled document from json: err: %v", err)
+	}
+	if doc.Context != did.DefaultDIDContextV1 {
+		t.Errorf("Should have upgraded the draft context")
+	}
+}
+
+func TestAddKeyToRelationship(t *testing.T) {
+	doc := &did.Document{}
+	err := json.Unmarshal([]byte(testDIDDocV1), doc)
+	if err != nil {
+		t.Fatalf("Should have unmarshalled document from json: err: %v", err)
+	}
+	keyID := doc.PublicKeys[1].ID
+	err = doc.AddKeyToRelationship(keyID, did.RelationshipAssertionMethod)
+	if err != nil {
+		t.Errorf("Should have added the key to the relationship: err: %v", err)
+	}
+	err = doc.AddKeyToRelationship(keyID, did.RelationshipAssertionMethod)
+	if err != nil {
+		t.Errorf("Should have ignored adding the key again: err: %v", err)
+	}
+	if len(doc.AssertionMethods) != 2 {
+		t.Errorf("Should have had 2 assertion methods")
+	}
+
+	unknown, _ := didlib.Parse("did:example:123456789abcdefghi#keys-9")
+	err = doc.AddKeyToRelationship(unknown, did.RelationshipAssertionMethod)
+	if err == nil {
+		t.Errorf("Should not have added a key not in the document")
+	}
+	err = doc.AddKeyToRelationship(keyID, did.VerificationRelationship("unknown"))
+	if err == nil {
+		t.Errorf("Should not have added to an unknown relationship")
+	}
+}
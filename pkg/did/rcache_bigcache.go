@@ -2,6 +2,8 @@ package did
 
 import (
 	"encoding/json"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/allegro/bigcache"
@@ -12,16 +14,19 @@ import (
 var (
 	// DefaultBigCacheConfig is a default cache config to use
 	DefaultBigCacheConfig = bigcache.Config{
-		Shards: 1024,
+		Shards: 64,
 		// NOTE(PN): Have to test some values here. Most of the time DIDs will not change often
 		// but there may be scenario where a DID doc is updated with keys and there is an
 		// expectation of immediate usage
-		LifeWindow:         5 * time.Minute,
-		CleanWindow:        5 * time.Minute,
-		MaxEntriesInWindow: 1000 * 10 * 60,
-		MaxEntrySize:       500,
+		LifeWindow:  5 * time.Minute,
+		CleanWindow: 5 * time.Minute,
+		// Sizes the initial shards, documents with a few keys and services are
+		// 1-2KB of json
+		MaxEntriesInWindow: 10000,
+		MaxEntrySize:       2048,
 		Verbose:            true,
-		HardMaxCacheSize:   16384,
+		// In MB
+		HardMaxCacheSize: 256,
 	}
 )
 
@@ -29,13 +34,17 @@ var (
 // BigCacheResolverCache
 func NewBigCacheResolverCache(cache *bigcache.BigCache) *BigCacheResolverCache {
 	return &BigCacheResolverCache{
-		cache: cache,
+		cache:       cache,
+		keyVersions: map[string]int64{},
 	}
 }
 
-// BigCacheResolverCache implements a ResolverCache using allegro/bigcache
+// BigCacheResolverCache implements a VersionedResolverCache using
+// allegro/bigcache, its invalidations are local to the process
 type BigCacheResolverCache struct {
-	cache *bigcache.BigCache
+	cache       *bigcache.BigCache
+	mu          sync.Mutex
+	keyVersions map[string]int64
 }
 
 // Get retrieves the did document out of the cache
@@ -44,7 +53,8 @@ func (c *BigCacheResolverCache) Get(d *didlib.DID) (*Document, error) {
 		return nil, errors.New("did is nil")
 	}
 
-	docBys, err := c.cache.Get(d.String())
+	keyVersion, _ := c.KeyVersion(d)
+	docBys, err := c.cache.Get(c.cacheKey(d, keyVersion))
 	if err != nil {
 		if err == bigcache.ErrEntryNotFound {
 			return nil, ErrResolverCacheDIDNotFound
@@ -68,15 +78,58 @@ func (c *BigCacheResolverCache) Get(d *didlib.DID) (*Document, error) {
 
 // Set sets the did document in the cache given the did
 func (c *BigCacheResolverCache) Set(d *didlib.DID, doc *Document) error {
+	keyVersion, _ := c.KeyVersion(d)
+	return c.SetVersion(d, keyVersion, doc)
+}
+
+// SetNotFound does nothing, not found results are not cached in process
+func (c *BigCacheResolverCache) SetNotFound(d *didlib.DID) error {
+	return nil
+}
+
+// KeyVersion returns the version the entries of the did are keyed by
+func (c *BigCacheResolverCache) KeyVersion(d *didlib.DID) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.keyVersions[MethodIDOnly(d)], nil
+}
+
+// SetVersion sets the did document in the cache at a key version, not found
+// results are not cached in process
+func (c *BigCacheResolverCache) SetVersion(d *didlib.DID, keyVersion int64, doc *Document) error {
+	if doc == nil {
+		return nil
+	}
 	bys, err := json.Marshal(doc)
 	if err != nil {
 		return errors.Wrap(err, "set.marshal")
 	}
 
-	err = c.cache.Set(d.String(), bys)
+	err = c.cache.Set(c.cacheKey(d, keyVersion), bys)
 	if err != nil {
 		return errors.Wrap(err, "set.didcacheset")
 	}
 
 	return nil
 }
+
+// Invalidate advances the key version of the did and removes the document of
+// the previous version from the cache
+func (c *BigCacheResolverCache) Invalidate(d *didlib.DID) error {
+	c.mu.Lock()
+	keyVersion := c.keyVersions[MethodIDOnly(d)]
+	c.keyVersions[MethodIDOnly(d)] = keyVersion + 1
+	c.mu.Unlock()
+
+	err := c.cache.Delete(c.cacheKey(d, keyVersion))
+	if err != nil && err != bigcache.ErrEntryNotFound {
+		return errors.Wrap(err, "invalidate.didcachedelete")
+	}
+	return nil
+}
+
+// cacheKey keys the entries by the method and id only, so DIDs with fragments
+// or params share the entry of their document, and by the key version
+func (c *BigCacheResolverCache) cacheKey(d *didlib.DID, keyVersion int64) string {
+	return fmt.Sprintf("%v#%v", MethodIDOnly(d), keyVersion)
+}
@@ -0,0 +1,262 @@
+package did
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/gomodule/redigo/redis"
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+)
+
+const (
+	redisResolverCacheKeyFmt        = "%v:did:%v"
+	redisResolverCacheVersionKeyFmt = "%v:didversion:%v"
+
+	// Entries are prefixed with their encoding
+	redisEntryJSON     = 'j'
+	redisEntryGzip     = 'z'
+	redisEntryNotFound = 'n'
+)
+
+var (
+	// DefaultRedisResolverCacheConfig is a default redis cache config to use.
+	// ethuri documents are kept longer as they are invalidated when saved.
+	DefaultRedisResolverCacheConfig = RedisResolverCacheConfig{
+		Namespace:  "idhub",
+		ExpirySecs: 60 * 5,
+		MethodExpirySecs: map[string]int{
+			"ethuri": 60 * 60,
+		},
+		NotFoundExpirySecs: 30,
+		CompressMinBytes:   1024,
+	}
+)
+
+// RedisPool is a pool of redis connections
+type RedisPool interface {
+	Get() redis.Conn
+}
+
+// RedisResolverCacheConfig configures a RedisResolverCache
+type RedisResolverCacheConfig struct {
+	// Namespace prefixes the keys of the cache
+	Namespace string
+	// ExpirySecs is the expiry of documents of methods not in MethodExpirySecs
+	ExpirySecs int
+	// MethodExpirySecs is the expiry of documents by DID method, an expiry of 0
+	// disables caching for the method
+	MethodExpirySecs map[string]int
+	// NotFoundExpirySecs is the expiry of not found results, 0 disables
+	// negative caching
+	NotFoundExpirySecs int
+	// CompressMinBytes is the size of the document json from which it is
+	// stored gzipped, 0 disables compression
+	CompressMinBytes int
+}
+
+// NewRedisResolverCache is a convenience function to init and return a new
+// RedisResolverCache
+func NewRedisResolverCache(pool RedisPool, config RedisResolverCacheConfig) *RedisResolverCache {
+	return &RedisResolverCache{
+		pool:   pool,
+		config: config,
+	}
+}
+
+// RedisResolverCache implements a VersionedResolverCache backed by redis, so the
+// cache and its invalidations are shared between instances of the hub. The key
+// versions are kept without expiry, so an entry of an earlier version can not
+// be keyed by the current version again.
+type RedisResolverCache struct {
+	pool   RedisPool
+	config RedisResolverCacheConfig
+}
+
+// Get retrieves the did document out of redis. Returns ErrResolverDIDNotFound
+// if the DID is cached as not found.
+func (c *RedisResolverCache) Get(d *didlib.DID) (*Document, error) {
+	if d == nil {
+		return nil, errors.New("did is nil")
+	}
+	conn := c.pool.Get()
+	defer conn.Close() // nolint: errcheck
+
+	keyVersion, err := c.keyVersion(conn, d)
+	if err != nil {
+		return nil, errors.Wrap(err, "redisresolvercache.get")
+	}
+	value, err := redis.Bytes(conn.Do("GET", c.cacheKey(d, keyVersion)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return nil, ErrResolverCacheDIDNotFound
+		}
+		return nil, errors.Wrap(err, "redisresolvercache.get")
+	}
+	if len(value) == 0 {
+		return nil, errors.New("Invalid empty doc entry")
+	}
+
+	bys := value[1:]
+	switch value[0] {
+	case redisEntryNotFound:
+		return nil, ErrResolverDIDNotFound
+	case redisEntryGzip:
+		bys, err = gunzip(bys)
+		if err != nil {
+			return nil, errors.Wrap(err, "redisresolvercache.gunzip")
+		}
+	case redisEntryJSON:
+	default:
+		return nil, errors.Errorf("Invalid doc entry encoding: %v", value[0])
+	}
+
+	var doc *Document
+	err = json.Unmarshal(bys, &doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "redisresolvercache.unmarshal")
+	}
+	return doc, nil
+}
+
+// Set sets the did document in redis given the did, with the expiry of its
+// method
+func (c *RedisResolverCache) Set(d *didlib.DID, doc *Document) error {
+	keyVersion, err := c.KeyVersion(d)
+	if err != nil {
+		return err
+	}
+	return c.SetVersion(d, keyVersion, doc)
+}
+
+// SetNotFound caches the did as not found
+func (c *RedisResolverCache) SetNotFound(d *didlib.DID) error {
+	keyVersion, err := c.KeyVersion(d)
+	if err != nil {
+		return err
+	}
+	return c.SetVersion(d, keyVersion, nil)
+}
+
+// KeyVersion returns the version the entries of the did are keyed by
+func (c *RedisResolverCache) KeyVersion(d *didlib.DID) (int64, error) {
+	conn := c.pool.Get()
+	defer conn.Close() // nolint: errcheck
+	return c.keyVersion(conn, d)
+}
+
+// SetVersion sets the did document in redis at a key version, or the did as
+// not found if doc is nil
+func (c *RedisResolverCache) SetVersion(d *didlib.DID, keyVersion int64, doc *Document) error {
+	if doc == nil {
+		if c.config.NotFoundExpirySecs <= 0 {
+			return nil
+		}
+		return c.set(d, keyVersion, []byte{redisEntryNotFound}, c.config.NotFoundExpirySecs)
+	}
+
+	expirySecs := c.expirySecs(d.Method)
+	if expirySecs <= 0 {
+		return nil
+	}
+	bys, err := json.Marshal(doc)
+	if err != nil {
+		return errors.Wrap(err, "redisresolvercache.marshal")
+	}
+
+	value := append([]byte{redisEntryJSON}, bys...)
+	if c.config.CompressMinBytes > 0 && len(bys) >= c.config.CompressMinBytes {
+		zipped, err := gzipBytes(bys)
+		if err != nil {
+			return errors.Wrap(err, "redisresolvercache.gzip")
+		}
+		value = append([]byte{redisEntryGzip}, zipped...)
+	}
+	return c.set(d, keyVersion, value, expirySecs)
+}
+
+// Invalidate advances the key version of the did and removes the entry of the
+// previous version from redis
+func (c *RedisResolverCache) Invalidate(d *didlib.DID) error {
+	conn := c.pool.Get()
+	defer conn.Close() // nolint: errcheck
+
+	keyVersion, err := redis.Int64(conn.Do("INCR", c.versionKey(d)))
+	if err != nil {
+		return errors.Wrap(err, "redisresolvercache.invalidate")
+	}
+	_, err = conn.Do("DEL", c.cacheKey(d, keyVersion-1))
+	if err != nil {
+		return errors.Wrap(err, "redisresolvercache.invalidate")
+	}
+	return nil
+}
+
+func (c *RedisResolverCache) keyVersion(conn redis.Conn, d *didlib.DID) (int64, error) {
+	keyVersion, err := redis.Int64(conn.Do("GET", c.versionKey(d)))
+	if err == redis.ErrNil {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Wrap(err, "redisresolvercache.keyversion")
+	}
+	return keyVersion, nil
+}
+
+func (c *RedisResolverCache) set(d *didlib.DID, keyVersion int64, value []byte, expirySecs int) error {
+	conn := c.pool.Get()
+	defer conn.Close() // nolint: errcheck
+
+	_, err := conn.Do("SET", c.cacheKey(d, keyVersion), value, "EX", expirySecs)
+	if err != nil {
+		return errors.Wrap(err, "redisresolvercache.set")
+	}
+	return nil
+}
+
+func (c *RedisResolverCache) expirySecs(method string) int {
+	if secs, ok := c.config.MethodExpirySecs[method]; ok {
+		return secs
+	}
+	return c.config.ExpirySecs
+}
+
+// cacheKey keys the entries by the method and id only, so DIDs with fragments
+// or params share the entry of their document, and by the key version after
+// the first invalidation
+func (c *RedisResolverCache) cacheKey(d *didlib.DID, keyVersion int64) string {
+	key := fmt.Sprintf(redisResolverCacheKeyFmt, c.config.Namespace, MethodIDOnly(d))
+	if keyVersion == 0 {
+		return key
+	}
+	return fmt.Sprintf("%v#%v", key, keyVersion)
+}
+
+func (c *RedisResolverCache) versionKey(d *didlib.DID) string {
+	return fmt.Sprintf(redisResolverCacheVersionKeyFmt, c.config.Namespace, MethodIDOnly(d))
+}
+
+func gzipBytes(bys []byte) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	zw := gzip.NewWriter(buf)
+	_, err := zw.Write(bys)
+	if err != nil {
+		return nil, err
+	}
+	err = zw.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func gunzip(bys []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(bys))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close() // nolint: errcheck
+	return ioutil.ReadAll(zr)
+}
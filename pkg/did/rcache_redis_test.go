@@ -0,0 +1,219 @@
+package did_test
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gomodule/redigo/redis"
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/did"
+)
+
+type testRedisConn struct {
+	values  map[string][]byte
+	expiry  map[string]int
+	failing bool
+}
+
+func (c *testRedisConn) Close() error { return nil }
+func (c *testRedisConn) Err() error   { return nil }
+func (c *testRedisConn) Flush() error { return nil }
+
+func (c *testRedisConn) Send(cmd string, args ...interface{}) error { return nil }
+
+func (c *testRedisConn) Receive() (interface{}, error) { return nil, nil }
+
+func (c *testRedisConn) Do(cmd string, args ...interface{}) (interface{}, error) {
+	if c.failing {
+		return nil, errors.New("redis is down")
+	}
+	key := args[0].(string)
+	switch cmd {
+	case "GET":
+		value, ok := c.values[key]
+		if !ok {
+			return nil, nil
+		}
+		return value, nil
+	case "SET":
+		c.values[key] = args[1].([]byte)
+		c.expiry[key] = args[3].(int)
+		return "OK", nil
+	case "DEL":
+		delete(c.values, key)
+		return int64(1), nil
+	case "INCR":
+		value, _ := strconv.ParseInt(string(c.values[key]), 10, 64)
+		c.values[key] = []byte(strconv.FormatInt(value+1, 10))
+		return value + 1, nil
+	}
+	return nil, errors.Errorf("unsupported command: %v", cmd)
+}
+
+type testRedisPool struct {
+	conn *testRedisConn
+}
+
+func (p *testRedisPool) Get() redis.Conn {
+	return p.conn
+}
+
+func newTestRedisPool() *testRedisPool {
+	return &testRedisPool{conn: &testRedisConn{
+		values: map[string][]byte{},
+		expiry: map[string]int{},
+	}}
+}
+
+func TestRedisResolverCache(t *testing.T) {
+	pool := newTestRedisPool()
+	rcache := did.NewRedisResolverCache(pool, did.DefaultRedisResolverCacheConfig)
+
+	doc := &did.Document{}
+	err := json.Unmarshal([]byte(testDIDDocV1), doc)
+	if err != nil {
+		t.Fatalf("Should have unmarshalled document from json: err: %v", err)
+	}
+	d, _ := didlib.Parse("did:example:123456789abcdefghi#keys-1")
+
+	_, err = rcache.Get(d)
+	if err != did.ErrResolverCacheDIDNotFound {
+		t.Errorf("Should have not found the did in the cache: err: %v", err)
+	}
+
+	err = rcache.Set(d, doc)
+	if err != nil {
+		t.Fatalf("Should have set the doc: err: %v", err)
+	}
+	key := "idhub:did:did:example:123456789abcdefghi"
+	if pool.conn.values[key][0] != 'z' {
+		t.Errorf("Should have compressed the doc")
+	}
+	if pool.conn.expiry[key] != did.DefaultRedisResolverCacheConfig.ExpirySecs {
+		t.Errorf("Should have set the default expiry: %v", pool.conn.expiry[key])
+	}
+
+	cached, err := rcache.Get(d)
+	if err != nil {
+		t.Fatalf("Should have gotten the doc: err: %v", err)
+	}
+	if cached.ID.String() != doc.ID.String() || len(cached.PublicKeys) != len(doc.PublicKeys) {
+		t.Errorf("Should have gotten the same doc")
+	}
+
+	err = rcache.Invalidate(d)
+	if err != nil {
+		t.Errorf("Should have invalidated the doc: err: %v", err)
+	}
+	_, err = rcache.Get(d)
+	if err != did.ErrResolverCacheDIDNotFound {
+		t.Errorf("Should have invalidated the did: err: %v", err)
+	}
+
+	err = rcache.SetNotFound(d)
+	if err != nil {
+		t.Errorf("Should have set not found: err: %v", err)
+	}
+	_, err = rcache.Get(d)
+	if err != did.ErrResolverDIDNotFound {
+		t.Errorf("Should have gotten the did as not found: err: %v", err)
+	}
+	// Entries are keyed by the key version after an invalidation
+	if pool.conn.expiry[key+"#1"] != did.DefaultRedisResolverCacheConfig.NotFoundExpirySecs {
+		t.Errorf("Should have set the not found expiry: %v", pool.conn.expiry[key+"#1"])
+	}
+}
+
+func TestRedisResolverCacheKeyVersion(t *testing.T) {
+	pool := newTestRedisPool()
+	rcache := did.NewRedisResolverCache(pool, did.DefaultRedisResolverCacheConfig)
+	doc := &did.Document{}
+	err := json.Unmarshal([]byte(testDIDDocV1), doc)
+	if err != nil {
+		t.Fatalf("Should have unmarshalled document from json: err: %v", err)
+	}
+	d := &doc.ID
+
+	// A document resolved before an invalidation is cached at the version read
+	// before resolving it and not returned after the invalidation
+	keyVersion, err := rcache.KeyVersion(d)
+	if err != nil {
+		t.Fatalf("Should have gotten the key version: err: %v", err)
+	}
+	err = rcache.Invalidate(d)
+	if err != nil {
+		t.Fatalf("Should have invalidated the did: err: %v", err)
+	}
+	err = rcache.SetVersion(d, keyVersion, doc)
+	if err != nil {
+		t.Fatalf("Should have set the doc: err: %v", err)
+	}
+	_, err = rcache.Get(d)
+	if err != did.ErrResolverCacheDIDNotFound {
+		t.Errorf("Should not have gotten the doc resolved before the invalidation: err: %v", err)
+	}
+
+	keyVersion, _ = rcache.KeyVersion(d)
+	if keyVersion != 1 {
+		t.Errorf("Should have advanced the key version: %v", keyVersion)
+	}
+	_ = rcache.SetVersion(d, keyVersion, doc)
+	_, err = rcache.Get(d)
+	if err != nil {
+		t.Errorf("Should have gotten the doc at the current key version: err: %v", err)
+	}
+}
+
+func TestRedisResolverCacheConfig(t *testing.T) {
+	pool := newTestRedisPool()
+	rcache := did.NewRedisResolverCache(pool, did.RedisResolverCacheConfig{
+		Namespace:        "test",
+		ExpirySecs:       10,
+		MethodExpirySecs: map[string]int{"ethuri": 20, "key": 0},
+	})
+	doc := &did.Document{}
+
+	d, _ := didlib.Parse("did:ethuri:e7ab0c43-d9fe-4a61-87a3-3fa99ce879e1")
+	_ = rcache.Set(d, doc)
+	key := "test:did:" + d.String()
+	if pool.conn.expiry[key] != 20 || pool.conn.values[key][0] != 'j' {
+		t.Errorf("Should have set the uncompressed doc with the method expiry: %v", pool.conn.expiry[key])
+	}
+
+	d, _ = didlib.Parse("did:key:z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK")
+	_ = rcache.Set(d, doc)
+	if _, ok := pool.conn.values["test:did:"+d.String()]; ok {
+		t.Errorf("Should not have cached a method with 0 expiry")
+	}
+
+	_ = rcache.SetNotFound(d)
+	_, err := rcache.Get(d)
+	if err != did.ErrResolverCacheDIDNotFound {
+		t.Errorf("Should not have cached not found: err: %v", err)
+	}
+
+	pool.conn.failing = true
+	_, err = rcache.Get(d)
+	if err == nil || !strings.Contains(err.Error(), "redisresolvercache.get") {
+		t.Errorf("Should have returned the redis error: err: %v", err)
+	}
+}
+
+func TestHTTPUniversalResolverNotFoundCache(t *testing.T) {
+	pool := newTestRedisPool()
+	rcache := did.NewRedisResolverCache(pool, did.DefaultRedisResolverCacheConfig)
+	d, _ := didlib.Parse("did:web:uport.me")
+	_ = rcache.SetNotFound(d)
+
+	// Not found is returned from the cache without requesting the resolver
+	res := did.NewHTTPUniversalResolver(nil, nil, rcache)
+	_, err := res.Resolve(context.Background(), d)
+	if err != did.ErrResolverDIDNotFound {
+		t.Errorf("Should have not found the did from the cache: err: %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package did
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// ResolutionContentType is the content type of DID resolution results
+	ResolutionContentType = "application/ld+json;profile=\"https://w3id.org/did-resolution\""
+	// DocumentContentType is the content type of resolved DID documents
+	DocumentContentType = "application/did+ld+json"
+
+	// ResolutionErrorInvalidDID is the resolution error for a malformed DID
+	ResolutionErrorInvalidDID = "invalidDid"
+	// ResolutionErrorNotFound is the resolution error for a DID that is not found
+	ResolutionErrorNotFound = "notFound"
+	// ResolutionErrorInternal is the resolution error for any other failure
+	ResolutionErrorInternal = "internalError"
+
+	driverID = "civil/id-hub"
+)
+
+// ResolutionMetadata is the metadata of a DID resolution
+type ResolutionMetadata struct {
+	ContentType string `json:"contentType,omitempty"`
+	Error       string `json:"error,omitempty"`
+	Duration    int64  `json:"duration,omitempty"`
+}
+
+// ResolveResult resolves a DID or DID URL with versionId or versionTime
+// parameters into a DID resolution result in the shape of the universal
+// resolver response. The result is returned with an error too, with the error
+// of the resolution metadata set, and the document metadata of deactivated DIDs.
+func (s *Service) ResolveResult(ctx context.Context, didURL string) (*UniversalResolverResponse, error) {
+	start := time.Now()
+	resp := &UniversalResolverResponse{
+		Metadata:              &UniversalResolverMetadata{Identifier: didURL, DriverID: driverID},
+		DidResolutionMetadata: &ResolutionMetadata{},
+		DidDocumentMetadata:   &DocumentMetadata{},
+	}
+	defer func() {
+		duration := int64(time.Since(start) / time.Millisecond)
+		resp.Metadata.Duration = int(duration)
+		resp.DidResolutionMetadata.Duration = duration
+	}()
+
+	d, params, err := ParseDIDURL(didURL)
+	if err != nil {
+		resp.DidResolutionMetadata.Error = ResolutionErrorInvalidDID
+		return resp, errors.Wrap(err, "resolveresult.parsedidurl")
+	}
+	resp.Metadata.DidURL.DidURLString = didURL
+	resp.Metadata.DidURL.Did.DidString = MethodIDOnly(d)
+	resp.Metadata.DidURL.Did.Method = d.Method
+	resp.Metadata.DidURL.Did.MethodSpecificID = d.ID
+	resp.Metadata.DidURL.Query = params.Encode()
+	resp.Metadata.DidURL.Fragment = d.Fragment
+
+	doc, err := s.GetDocument(ctx, didURL)
+	if derr, ok := IsDeactivated(err); ok {
+		metadata := derr.Metadata
+		metadata.Deactivated = true
+		resp.DidDocumentMetadata = &metadata
+		resp.DidResolutionMetadata.ContentType = DocumentContentType
+		return resp, err
+	}
+	if err != nil || doc == nil {
+		resp.DidResolutionMetadata.Error = ResolutionErrorInternal
+		if err == nil || errors.Cause(err) == ErrResolverDIDNotFound {
+			resp.DidResolutionMetadata.Error = ResolutionErrorNotFound
+			err = ErrResolverDIDNotFound
+		}
+		return resp, err
+	}
+
+	resp.DidDocument = doc
+	resp.DidResolutionMetadata.ContentType = DocumentContentType
+	resp.DidDocumentMetadata.Created = doc.Created
+	resp.DidDocumentMetadata.Updated = doc.Updated
+	return resp, nil
+}
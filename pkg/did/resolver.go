@@ -1,6 +1,10 @@
 package did
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	didlib "github.com/ockam-network/did"
 
 	"github.com/pkg/errors"
@@ -9,8 +13,19 @@ import (
 // Resolver interface that defines a DID resolver
 type Resolver interface {
 	// Resolve returns the DID document given the DID. Expects
-	// ErrResolverDIDNotFound as error when DID is not found.
-	Resolve(d *didlib.DID) (*Document, error)
+	// ErrResolverDIDNotFound as error when DID is not found. Resolvers should
+	// stop when ctx is done.
+	Resolve(ctx context.Context, d *didlib.DID) (*Document, error)
+}
+
+// MethodResolver is a Resolver that is authoritative for the DIDs of some
+// methods. DIDs of its methods are only resolved by the MethodResolvers of the
+// method, resolvers that are not MethodResolvers are only used for DIDs of
+// methods without one.
+type MethodResolver interface {
+	Resolver
+	// Methods returns the DID methods the resolver is authoritative for
+	Methods() []string
 }
 
 var (
@@ -19,10 +34,77 @@ var (
 
 	// ErrResolverCacheDIDNotFound error indicating that DID not found in cache
 	ErrResolverCacheDIDNotFound = errors.New("did doc not found in cache")
+
+	// ErrResolverDIDDeactivated error indicating that the DID is deactivated
+	ErrResolverDIDDeactivated = errors.New("did is deactivated")
+
+	// ErrResolverUnavailable error indicating that the circuit breaker of a
+	// resolver is open after consecutive failures
+	ErrResolverUnavailable = errors.New("did resolver is unavailable")
 )
 
+// DocumentMetadata is the metadata of a resolved DID document
+type DocumentMetadata struct {
+	Created     *time.Time `json:"created,omitempty"`
+	Updated     *time.Time `json:"updated,omitempty"`
+	Deactivated bool       `json:"deactivated,omitempty"`
+}
+
+// DeactivatedError is returned by a resolver instead of the document of a
+// deactivated DID. Its cause is ErrResolverDIDDeactivated.
+type DeactivatedError struct {
+	DID      string
+	Metadata DocumentMetadata
+}
+
+func (e *DeactivatedError) Error() string {
+	return fmt.Sprintf("%v: %v", ErrResolverDIDDeactivated.Error(), e.DID)
+}
+
+// Cause returns ErrResolverDIDDeactivated for errors.Cause
+func (e *DeactivatedError) Cause() error {
+	return ErrResolverDIDDeactivated
+}
+
+// IsDeactivated returns the DeactivatedError in the causes of err if the
+// error is for a deactivated DID
+func IsDeactivated(err error) (*DeactivatedError, bool) {
+	for err != nil {
+		if derr, ok := err.(*DeactivatedError); ok {
+			return derr, true
+		}
+		cause, ok := err.(interface{ Cause() error })
+		if !ok {
+			return nil, false
+		}
+		err = cause.Cause()
+	}
+	return nil, false
+}
+
 // ResolverCache interface defines a DID document cache for the resolver
 type ResolverCache interface {
+	// Get returns the cached DID document. Expects ErrResolverCacheDIDNotFound
+	// as error when the DID is not cached and ErrResolverDIDNotFound when it is
+	// cached as not found.
 	Get(d *didlib.DID) (*Document, error)
+	// Set caches the DID document
 	Set(d *didlib.DID, doc *Document) error
+	// SetNotFound caches that the DID was not found
+	SetNotFound(d *didlib.DID) error
+	// Invalidate removes the DID from the cache
+	Invalidate(d *didlib.DID) error
+}
+
+// VersionedResolverCache is a ResolverCache that keys the entries of a DID by
+// a version that Invalidate advances. A document resolved before an
+// invalidation is cached at the version read before resolving it, so it is not
+// returned after the invalidation.
+type VersionedResolverCache interface {
+	ResolverCache
+	// KeyVersion returns the version the entries of the DID are keyed by
+	KeyVersion(d *didlib.DID) (int64, error)
+	// SetVersion caches the DID document at a key version, or that the DID was
+	// not found if doc is nil
+	SetVersion(d *didlib.DID, keyVersion int64, doc *Document) error
 }
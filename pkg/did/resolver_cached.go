@@ -0,0 +1,242 @@
+package did
+
+import (
+	"context"
+	"expvar"
+	"sync"
+	"time"
+
+	log "github.com/golang/glog"
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ResolverMetricsName is the name the resolver metrics are published with
+	// in expvar
+	ResolverMetricsName = "did_resolver"
+
+	// maxMetricsMethods limits the methods with their own metrics, DIDs of any
+	// method can be requested
+	maxMetricsMethods  = 64
+	otherMetricsMethod = "other"
+)
+
+var (
+	resolverMetrics = newResolverMetrics(expvar.NewMap(ResolverMetricsName))
+)
+
+// NewCachedResolver is a convenience function to init and return a new
+// CachedResolver. The cache can be nil to only de-duplicate lookups and record
+// metrics.
+func NewCachedResolver(resolver Resolver, cache ResolverCache) *CachedResolver {
+	return &CachedResolver{
+		resolver: resolver,
+		cache:    cache,
+		flights:  &flightGroup{},
+		metrics:  resolverMetrics,
+	}
+}
+
+// CachedResolver wraps a Resolver with a cache, de-duplication of concurrent
+// lookups of the same DID and per method metrics of the lookups, published in
+// expvar as ResolverMetricsName. It keeps the methods and versions of the
+// wrapped resolver.
+type CachedResolver struct {
+	resolver Resolver
+	cache    ResolverCache
+	flights  *flightGroup
+	metrics  *resolverMetricsMap
+}
+
+// Methods implements the MethodResolver interface, returns the methods of the
+// wrapped resolver if it is a MethodResolver
+func (c *CachedResolver) Methods() []string {
+	if mr, ok := c.resolver.(MethodResolver); ok {
+		return mr.Methods()
+	}
+	return nil
+}
+
+// ResolveVersion implements the VersionedResolver interface, versions are
+// resolved by the wrapped resolver without caching
+func (c *CachedResolver) ResolveVersion(ctx context.Context, d *didlib.DID, versionID string,
+	versionTime *time.Time) (*Document, error) {
+	vr, ok := c.resolver.(VersionedResolver)
+	if !ok {
+		return nil, ErrResolverDIDNotFound
+	}
+	return vr.ResolveVersion(ctx, d, versionID, versionTime)
+}
+
+// Resolve implements the Resolver interface and returns the did document from
+// the cache or the wrapped resolver
+func (c *CachedResolver) Resolve(ctx context.Context, d *didlib.DID) (*Document, error) {
+	start := time.Now()
+	doc, cached, err := c.resolve(ctx, d)
+	c.metrics.record(d.Method, time.Since(start), err, cached)
+	return doc, err
+}
+
+func (c *CachedResolver) resolve(ctx context.Context, d *didlib.DID) (*Document, bool, error) {
+	if c.cache != nil {
+		doc, err := c.cache.Get(d)
+		if err == nil && doc != nil {
+			return doc, true, nil
+		}
+		switch errors.Cause(err) {
+		case ErrResolverDIDNotFound:
+			return nil, true, ErrResolverDIDNotFound
+		case nil, ErrResolverCacheDIDNotFound:
+		default:
+			// The resolver is still used if the cache is unavailable
+			log.Errorf("Error getting cached did: %v, err: %v", d.String(), err)
+		}
+	}
+
+	key := MethodIDOnly(d)
+	for {
+		doc, shared, err := c.flights.do(ctx, key, func() (*Document, error) {
+			return c.resolveAndCache(ctx, d)
+		})
+		// Retry if the call joined was cancelled by the context of its caller
+		if shared && isContextError(err) && ctx.Err() == nil {
+			continue
+		}
+		return doc, false, err
+	}
+}
+
+// resolveAndCache resolves the DID and caches the result. With a
+// VersionedResolverCache the result is cached at the key version read before
+// resolving, so a result resolved before an invalidation is not returned after it.
+func (c *CachedResolver) resolveAndCache(ctx context.Context, d *didlib.DID) (*Document, error) {
+	var keyVersion int64
+	vcache, versioned := c.cache.(VersionedResolverCache)
+	if versioned {
+		var verr error
+		keyVersion, verr = vcache.KeyVersion(d)
+		if verr != nil {
+			log.Errorf("Error getting cache key version of did: %v, err: %v", d.String(), verr)
+			return c.resolver.Resolve(ctx, d)
+		}
+	}
+
+	doc, err := c.resolver.Resolve(ctx, d)
+	if c.cache == nil {
+		return doc, err
+	}
+
+	var cerr error
+	if err == nil && doc != nil {
+		if versioned {
+			cerr = vcache.SetVersion(d, keyVersion, doc)
+		} else {
+			cerr = c.cache.Set(d, doc)
+		}
+	} else if (err == nil && doc == nil) || errors.Cause(err) == ErrResolverDIDNotFound {
+		if versioned {
+			cerr = vcache.SetVersion(d, keyVersion, nil)
+		} else {
+			cerr = c.cache.SetNotFound(d)
+		}
+	}
+	if cerr != nil {
+		log.Errorf("Error caching did: %v, err: %v", d.String(), cerr)
+	}
+	return doc, err
+}
+
+func isContextError(err error) bool {
+	cause := errors.Cause(err)
+	return cause == context.Canceled || cause == context.DeadlineExceeded
+}
+
+// flightGroup runs a single call at a time for a key, concurrent calls for the
+// key share its result
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+type flight struct {
+	done chan struct{}
+	doc  *Document
+	err  error
+}
+
+// do runs fn if there is no call for the key running, otherwise waits for the
+// running call and returns its result, or when ctx is done. shared is true if
+// the result is of another call.
+func (g *flightGroup) do(ctx context.Context, key string,
+	fn func() (*Document, error)) (doc *Document, shared bool, err error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = map[string]*flight{}
+	}
+	if f, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		select {
+		case <-f.done:
+			return f.doc, true, f.err
+		case <-ctx.Done():
+			return nil, false, errors.Wrap(ctx.Err(), "resolve")
+		}
+	}
+	f := &flight{done: make(chan struct{})}
+	g.calls[key] = f
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(f.done)
+	}()
+	f.doc, f.err = fn()
+	return f.doc, false, f.err
+}
+
+// resolverMetricsMap records the lookups of DIDs by method as
+// <method>.requests, .errors, .not_found, .cache_hits and .latency_us, the
+// total latency of the requests in microseconds
+type resolverMetricsMap struct {
+	mu      sync.Mutex
+	vars    *expvar.Map
+	methods map[string]bool
+}
+
+func newResolverMetrics(vars *expvar.Map) *resolverMetricsMap {
+	return &resolverMetricsMap{vars: vars, methods: map[string]bool{}}
+}
+
+func (m *resolverMetricsMap) record(method string, latency time.Duration, err error, cached bool) {
+	method = m.method(method)
+	m.vars.Add(method+".requests", 1)
+	m.vars.Add(method+".latency_us", int64(latency/time.Microsecond))
+	if cached {
+		m.vars.Add(method+".cache_hits", 1)
+	}
+	if _, deactivated := IsDeactivated(err); err == nil || deactivated {
+		return
+	}
+	if errors.Cause(err) == ErrResolverDIDNotFound {
+		m.vars.Add(method+".not_found", 1)
+		return
+	}
+	m.vars.Add(method+".errors", 1)
+}
+
+// method returns the method the metrics are recorded under
+func (m *resolverMetricsMap) method(method string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.methods[method] {
+		return method
+	}
+	if len(m.methods) >= maxMetricsMethods {
+		return otherMetricsMethod
+	}
+	m.methods[method] = true
+	return method
+}
@@ -0,0 +1,168 @@
+package did_test
+
+import (
+	"context"
+	"expvar"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/allegro/bigcache"
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/did"
+)
+
+func resolverMetric(t *testing.T, key string) int64 {
+	metrics, ok := expvar.Get(did.ResolverMetricsName).(*expvar.Map)
+	if !ok {
+		t.Fatalf("Should have published the resolver metrics")
+	}
+	value, ok := metrics.Get(key).(*expvar.Int)
+	if !ok {
+		return 0
+	}
+	return value.Value()
+}
+
+func TestCachedResolver(t *testing.T) {
+	doc := testDocument(t)
+	inner := &CountingResolver{doc: doc}
+	cache, _ := bigcache.NewBigCache(testBigCacheConfig)
+	res := did.NewCachedResolver(inner, did.NewBigCacheResolverCache(cache))
+	d, _ := didlib.Parse("did:cached:123456789abcdefghi")
+
+	for i := 0; i < 3; i++ {
+		resolved, err := res.Resolve(context.Background(), d)
+		if err != nil || resolved == nil || resolved.ID.String() != doc.ID.String() {
+			t.Fatalf("Should have resolved the did: err: %v", err)
+		}
+	}
+	if inner.Calls() != 1 {
+		t.Errorf("Should have resolved the did from the cache: %v", inner.Calls())
+	}
+	if resolverMetric(t, "cached.requests") != 3 || resolverMetric(t, "cached.cache_hits") != 2 {
+		t.Errorf("Should have recorded the requests and cache hits")
+	}
+	if resolverMetric(t, "cached.errors") != 0 {
+		t.Errorf("Should not have recorded errors")
+	}
+}
+
+func TestCachedResolverNotFound(t *testing.T) {
+	inner := &CountingResolver{err: did.ErrResolverDIDNotFound}
+	res := did.NewCachedResolver(inner, did.NewRedisResolverCache(
+		newTestRedisPool(),
+		did.DefaultRedisResolverCacheConfig,
+	))
+	d, _ := didlib.Parse("did:cachednotfound:123456789abcdefghi")
+
+	for i := 0; i < 2; i++ {
+		_, err := res.Resolve(context.Background(), d)
+		if err != did.ErrResolverDIDNotFound {
+			t.Errorf("Should have not found the did: err: %v", err)
+		}
+	}
+	if inner.Calls() != 1 {
+		t.Errorf("Should have cached the did as not found: %v", inner.Calls())
+	}
+	if resolverMetric(t, "cachednotfound.not_found") != 2 {
+		t.Errorf("Should have recorded the not found results")
+	}
+
+	failing := &CountingResolver{err: errors.New("upstream error")}
+	res = did.NewCachedResolver(failing, nil)
+	d, _ = didlib.Parse("did:cachederror:123456789abcdefghi")
+	_, _ = res.Resolve(context.Background(), d)
+	_, _ = res.Resolve(context.Background(), d)
+	if failing.Calls() != 2 || resolverMetric(t, "cachederror.errors") != 2 {
+		t.Errorf("Should not have cached errors")
+	}
+}
+
+func TestCachedResolverSingleflight(t *testing.T) {
+	doc := testDocument(t)
+	inner := &CountingResolver{doc: doc, delay: 100 * time.Millisecond}
+	res := did.NewCachedResolver(inner, nil)
+	d, _ := didlib.Parse("did:example:123456789abcdefghi")
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resolved, err := res.Resolve(context.Background(), d)
+			if err != nil || resolved == nil {
+				t.Errorf("Should have resolved the did: err: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if inner.Calls() != 1 {
+		t.Errorf("Should have resolved the did once for concurrent lookups: %v", inner.Calls())
+	}
+
+	// A lookup joining a cancelled lookup resolves the did itself
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		_, _ = res.Resolve(ctx, d)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	resolved, err := res.Resolve(context.Background(), d)
+	if err != nil || resolved == nil {
+		t.Errorf("Should have resolved the did after the joined lookup was cancelled: err: %v", err)
+	}
+}
+
+func TestCachedResolverRouting(t *testing.T) {
+	doc := testDocument(t)
+	fallback := &CountingResolver{err: did.ErrResolverDIDNotFound}
+	method := &MethodStaticResolver{StaticResolver: StaticResolver{doc: doc}, methods: []string{"example"}}
+	serv := did.NewService([]did.Resolver{
+		did.NewCachedResolver(fallback, nil),
+		did.NewCachedResolver(method, nil),
+	})
+
+	resolved, err := serv.GetDocument(context.Background(), "did:example:123456789abcdefghi")
+	if err != nil || resolved == nil {
+		t.Fatalf("Should have resolved the did: err: %v", err)
+	}
+	if fallback.Calls() != 0 {
+		t.Errorf("Should have kept the methods of the wrapped resolver: %v", fallback.Calls())
+	}
+}
+
+// invalidatingResolver invalidates the did in the cache while resolving it, as
+// a document saved during the lookup does
+type invalidatingResolver struct {
+	CountingResolver
+	cache did.ResolverCache
+}
+
+func (r *invalidatingResolver) Resolve(ctx context.Context, d *didlib.DID) (*did.Document, error) {
+	doc, err := r.CountingResolver.Resolve(ctx, d)
+	_ = r.cache.Invalidate(d)
+	return doc, err
+}
+
+func TestCachedResolverInvalidatedDuringResolve(t *testing.T) {
+	cache, _ := bigcache.NewBigCache(testBigCacheConfig)
+	rcache := did.NewBigCacheResolverCache(cache)
+	inner := &invalidatingResolver{CountingResolver: CountingResolver{doc: testDocument(t)}, cache: rcache}
+	res := did.NewCachedResolver(inner, rcache)
+	d, _ := didlib.Parse("did:invalidated:123456789abcdefghi")
+
+	_, err := res.Resolve(context.Background(), d)
+	if err != nil {
+		t.Fatalf("Should have resolved the did: err: %v", err)
+	}
+	_, err = rcache.Get(d)
+	if err != did.ErrResolverCacheDIDNotFound {
+		t.Errorf("Should not have cached the document resolved before the invalidation: err: %v", err)
+	}
+}
@@ -4,17 +4,18 @@ package did
 // https://github.com/decentralized-identity/universal-resolver/
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
 
 	log "github.com/golang/glog"
 	"github.com/pkg/errors"
 
 	"github.com/ockam-network/did"
-
-	chttp "github.com/joincivil/go-common/pkg/http"
 )
 
 const (
@@ -28,11 +29,13 @@ const (
 
 // UniversalResolverResponse is the response from the universal resolver
 type UniversalResolverResponse struct {
-	DidDocument    *Document                  `json:"didDocument"`
-	Metadata       *UniversalResolverMetadata `json:"resolverMetadata"`
-	Content        *string                    `json:"content"`
-	ContentType    *string                    `json:"contentType"`
-	MethodMetadata *map[string]interface{}    `json:"methodMetadata"`
+	DidDocument           *Document                  `json:"didDocument"`
+	Metadata              *UniversalResolverMetadata `json:"resolverMetadata"`
+	Content               *string                    `json:"content"`
+	ContentType           *string                    `json:"contentType"`
+	MethodMetadata        *map[string]interface{}    `json:"methodMetadata"`
+	DidResolutionMetadata *ResolutionMetadata        `json:"didResolutionMetadata,omitempty"`
+	DidDocumentMetadata   *DocumentMetadata          `json:"didDocumentMetadata,omitempty"`
 }
 
 // UniversalResolverMetadata is the metadata response for the universal resolver response
@@ -74,6 +77,7 @@ func NewHTTPUniversalResolver(resolverHost *string, resolverPort *int,
 		resolverHost: host,
 		resolverPort: port,
 		cache:        cache,
+		client:       &http.Client{},
 	}
 }
 
@@ -83,26 +87,38 @@ type HTTPUniversalResolver struct {
 	resolverHost string
 	resolverPort int
 	cache        ResolverCache
+	client       *http.Client
 }
 
 // Resolve returns the DID document given the DID
 // Implements the Resolver interface.
-func (h *HTTPUniversalResolver) Resolve(d *did.DID) (*Document, error) {
+func (h *HTTPUniversalResolver) Resolve(ctx context.Context, d *did.DID) (*Document, error) {
 	if h.cache != nil {
 		doc, err := h.cache.Get(d)
 		if err == nil && doc != nil {
 			return doc, nil
 		}
+		if errors.Cause(err) == ErrResolverDIDNotFound {
+			return nil, ErrResolverDIDNotFound
+		}
 
 		if err != nil && errors.Cause(err) != ErrResolverCacheDIDNotFound {
 			return nil, errors.Wrap(err, "resolve.get")
 		}
 	}
 
-	resp, err := h.RawResolve(d)
+	resp, err := h.RawResolve(ctx, d)
+	if err == ErrResolverDIDNotFound && h.cache != nil {
+		if cerr := h.cache.SetNotFound(d); cerr != nil {
+			log.Errorf("Error caching not found did: %v, err: %v", d.String(), cerr)
+		}
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "resolve.rawresolve")
 	}
+	if resp.DidDocumentMetadata != nil && resp.DidDocumentMetadata.Deactivated {
+		return nil, &DeactivatedError{DID: d.String(), Metadata: *resp.DidDocumentMetadata}
+	}
 
 	if h.cache != nil && resp != nil {
 		err = h.cache.Set(d, resp.DidDocument)
@@ -114,34 +130,57 @@ func (h *HTTPUniversalResolver) Resolve(d *did.DID) (*Document, error) {
 	return resp.DidDocument, nil
 }
 
-// RawResolve returns the full universal resolver resp given the DID
-func (h *HTTPUniversalResolver) RawResolve(d *did.DID) (*UniversalResolverResponse, error) {
+// RawResolve returns the full universal resolver resp given the DID. Failed
+// requests and server errors are retried until ctx is done.
+func (h *HTTPUniversalResolver) RawResolve(ctx context.Context, d *did.DID) (
+	*UniversalResolverResponse, error) {
 	if d == nil {
 		return nil, errors.New("Invalid DID")
 	}
 
-	client := chttp.NewRestHelper("", "")
-	res, err := client.SendRequestToURLWithRetry(
-		h.fullResolverURL(d),
-		http.MethodGet,
-		nil,
-		nil,
-		reqMaxAtts,
-		reqBaseWaitMs,
-	)
-	if err != nil {
-		cause := errors.Cause(err)
-		if strings.Contains(strings.ToLower(cause.Error()),
-			"resolve problem for") {
-			log.Infof("Resolver err: %v", cause.Error())
-			return nil, ErrResolverDIDNotFound
+	var status int
+	var body []byte
+	var err error
+	for attempt := 1; attempt <= reqMaxAtts; attempt++ {
+		status, body, err = h.send(ctx, d)
+		if err == nil && status < http.StatusInternalServerError {
+			break
 		}
-
+		if attempt == reqMaxAtts {
+			break
+		}
+		log.Infof("err with request, sleep/attempt again, waiting %v ms...", reqBaseWaitMs*attempt)
+		select {
+		case <-time.After(time.Duration(reqBaseWaitMs*attempt) * time.Millisecond):
+		case <-ctx.Done():
+			return nil, errors.Wrap(ctx.Err(), "resolve.sendrequest")
+		}
+	}
+	if err != nil {
 		return nil, errors.Wrap(err, "resolve.sendrequest")
 	}
 
 	resp := &UniversalResolverResponse{}
-	err = json.Unmarshal(res, resp)
+	switch {
+	case status == http.StatusNotFound ||
+		strings.Contains(strings.ToLower(string(body)), "resolve problem for"):
+		log.Infof("Resolver err: %v, %v", status, string(body))
+		return nil, ErrResolverDIDNotFound
+
+	// Drivers respond with 410 Gone for deactivated DIDs
+	case status == http.StatusGone:
+		metadata := DocumentMetadata{}
+		if json.Unmarshal(body, resp) == nil && resp.DidDocumentMetadata != nil {
+			metadata = *resp.DidDocumentMetadata
+		}
+		metadata.Deactivated = true
+		return nil, &DeactivatedError{DID: d.String(), Metadata: metadata}
+
+	case status != http.StatusOK:
+		return nil, errors.Errorf("resolve request failed: %v, %v", status, string(body))
+	}
+
+	err = json.Unmarshal(body, resp)
 	if err != nil {
 		return nil, errors.Wrap(err, "resolve.unmarshal")
 	}
@@ -149,6 +188,23 @@ func (h *HTTPUniversalResolver) RawResolve(d *did.DID) (*UniversalResolverRespon
 	return resp, nil
 }
 
+func (h *HTTPUniversalResolver) send(ctx context.Context, d *did.DID) (int, []byte, error) {
+	req, err := http.NewRequest(http.MethodGet, h.fullResolverURL(d), nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	res, err := h.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, nil, err
+	}
+	defer res.Body.Close() // nolint: errcheck
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return res.StatusCode, body, nil
+}
+
 func (h *HTTPUniversalResolver) fullResolverURL(d *did.DID) string {
 	return fmt.Sprintf(uniResolverURL, h.resolverHost, h.resolverPort, d.String())
 }
@@ -7,6 +7,7 @@ package did_test
 // kubectl port-forward deployment/unir-uni-resolver-web 8888:8080 --namespace=staging
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 
@@ -27,7 +28,7 @@ func TestHTTPUniversalResolverLiveBadDid(t *testing.T) {
 	res := did.NewHTTPUniversalResolver(cstr.StrToPtr(resolverHost), cnum.IntToPtr(resolverPort), nil)
 
 	dd, _ := didlib.Parse("did:web:idontexist.co")
-	doc, err := res.Resolve(dd)
+	doc, err := res.Resolve(context.Background(), dd)
 	if err == nil {
 		t.Fatalf("Should have gotten error resolving did")
 	}
@@ -43,7 +44,7 @@ func TestHTTPUniversalResolverLiveWeb(t *testing.T) {
 	res := did.NewHTTPUniversalResolver(cstr.StrToPtr(resolverHost), cnum.IntToPtr(resolverPort), nil)
 
 	dd, _ := didlib.Parse("did:web:uport.me")
-	doc, err := res.Resolve(dd)
+	doc, err := res.Resolve(context.Background(), dd)
 	if err != nil {
 		t.Fatalf("Should not have gotten error resolving did: err: %v", err)
 	}
@@ -95,7 +96,7 @@ func TestHTTPUniversalResolverLiveEthr(t *testing.T) {
 	res := did.NewHTTPUniversalResolver(cstr.StrToPtr(resolverHost), cnum.IntToPtr(resolverPort), nil)
 
 	dd, _ := didlib.Parse("did:ethr:0x3b0BC51Ab9De1e5B7B6E34E5b960285805C41736")
-	doc, err := res.Resolve(dd)
+	doc, err := res.Resolve(context.Background(), dd)
 	if err != nil {
 		t.Fatalf("Should not have gotten error resolving did: err: %v", err)
 	}
@@ -111,7 +112,7 @@ func TestHTTPUniversalResolverLiveEthr(t *testing.T) {
 // 	res := did.NewHTTPUniversalResolver(cstr.StrToPtr(resolverHost), cnum.IntToPtr(resolverPort), nil)
 
 // 	dd, _ := didlib.Parse("did:ccp:ceNobbK6Me9F5zwyE3MKY88QZLw")
-// 	doc, err := res.Resolve(dd)
+// 	doc, err := res.Resolve(context.Background(), dd)
 // 	if err != nil {
 // 		t.Fatalf("Should not have gotten error resolving did: err: %v", err)
 // 	}
@@ -132,7 +133,7 @@ func TestHTTPUniversalResolverLiveEthr(t *testing.T) {
 
 // 	dd, err := didlib.Parse("did:nacl:Md8JiMIwsapml_FtQ2ngnGftNP5UmVCAUuhnLyAsPxI")
 // 	t.Logf("err = %v", err)
-// 	doc, err := res.Resolve(dd)
+// 	doc, err := res.Resolve(context.Background(), dd)
 // 	if err != nil {
 // 		t.Fatalf("Should not have gotten error resolving did: err: %v", err)
 // 	}
@@ -149,7 +150,7 @@ func TestHTTPUniversalResolverLiveEthr(t *testing.T) {
 // 	res := did.NewHTTPUniversalResolver(cstr.StrToPtr(resolverHost), cnum.IntToPtr(resolverPort), nil)
 
 // 	dd, _ := didlib.Parse("did:sov:WRfXPg8dantKVubE3HX8pw")
-// 	doc, err := res.Resolve(dd)
+// 	doc, err := res.Resolve(context.Background(), dd)
 // 	if err != nil {
 // 		t.Fatalf("Should not have gotten error resolving did: err: %v", err)
 // 	}
@@ -166,7 +167,7 @@ func TestHTTPUniversalResolverLiveEthr(t *testing.T) {
 // 	res := did.NewHTTPUniversalResolver(cstr.StrToPtr(resolverHost), cnum.IntToPtr(resolverPort), nil)
 
 // 	dd, _ := didlib.Parse("did:btcr:xz35-jznz-q6mr-7q6")
-// 	doc, err := res.Resolve(dd)
+// 	doc, err := res.Resolve(context.Background(), dd)
 // 	if err != nil {
 // 		t.Fatalf("Should not have gotten error resolving did: err: %v", err)
 // 	}
@@ -183,7 +184,7 @@ func TestHTTPUniversalResolverLiveEthr(t *testing.T) {
 // 	res := did.NewHTTPUniversalResolver(cstr.StrToPtr(resolverHost), cnum.IntToPtr(resolverPort), nil)
 
 // 	dd, _ := didlib.Parse("did:work:2UUHQCd4psvkPLZGnWY33L")
-// 	doc, err := res.Resolve(dd)
+// 	doc, err := res.Resolve(context.Background(), dd)
 // 	if err != nil {
 // 		t.Fatalf("Should not have gotten error resolving did: err: %v", err)
 // 	}
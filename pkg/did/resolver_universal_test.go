@@ -1,6 +1,7 @@
 package did_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -36,6 +37,14 @@ func (b *BadResolverCache) Set(d *didlib.DID, doc *did.Document) error {
 	return errors.New("here lies an error")
 }
 
+func (b *BadResolverCache) SetNotFound(d *didlib.DID) error {
+	return errors.New("here lies an error")
+}
+
+func (b *BadResolverCache) Invalidate(d *didlib.DID) error {
+	return errors.New("here lies an error")
+}
+
 const (
 	validResponse = `{
 		"didDocument": {
@@ -130,7 +139,7 @@ func TestHTTPUniversalResolver(t *testing.T) {
 	res := did.NewHTTPUniversalResolver(cstr.StrToPtr(host), cnum.IntToPtr(port), nil)
 
 	dd, _ := didlib.Parse("did:web:uport.me")
-	doc, err := res.Resolve(dd)
+	doc, err := res.Resolve(context.Background(), dd)
 	if err != nil {
 		t.Fatalf("Should not have gotten error resolving did: err: %v", err)
 	}
@@ -192,7 +201,7 @@ func TestHTTPUniversalResolverWithBadCache(t *testing.T) {
 	res := did.NewHTTPUniversalResolver(cstr.StrToPtr(host), cnum.IntToPtr(port), rcache)
 
 	dd, _ := didlib.Parse("did:web:uport.me")
-	doc, err := res.Resolve(dd)
+	doc, err := res.Resolve(context.Background(), dd)
 	if err == nil {
 		t.Fatalf("Should have gotten error resolving did")
 	}
@@ -208,7 +217,7 @@ func TestHTTPUniversalResolverWithBadCache(t *testing.T) {
 	res = did.NewHTTPUniversalResolver(cstr.StrToPtr(host), cnum.IntToPtr(port), rcache)
 
 	dd, _ = didlib.Parse("did:web:uport.me")
-	doc, err = res.Resolve(dd)
+	doc, err = res.Resolve(context.Background(), dd)
 	if err == nil {
 		t.Fatalf("Should have gotten error resolving did")
 	}
@@ -249,7 +258,7 @@ func TestHTTPUniversalResolverWithCache(t *testing.T) {
 	res := did.NewHTTPUniversalResolver(cstr.StrToPtr(host), cnum.IntToPtr(port), rcache)
 
 	dd, _ := didlib.Parse("did:web:uport.me")
-	doc, err := res.Resolve(dd)
+	doc, err := res.Resolve(context.Background(), dd)
 	if err != nil {
 		t.Fatalf("Should not have gotten error resolving did: err: %v", err)
 	}
@@ -257,7 +266,7 @@ func TestHTTPUniversalResolverWithCache(t *testing.T) {
 		t.Errorf("Should have received a valid doc")
 	}
 
-	doc, err = res.Resolve(dd)
+	doc, err = res.Resolve(context.Background(), dd)
 	if err != nil {
 		t.Fatalf("Should not have gotten error resolving did: err: %v", err)
 	}
@@ -265,7 +274,7 @@ func TestHTTPUniversalResolverWithCache(t *testing.T) {
 		t.Errorf("Should have received a valid doc")
 	}
 
-	doc, err = res.Resolve(dd)
+	doc, err = res.Resolve(context.Background(), dd)
 	if err != nil {
 		t.Fatalf("Should not have gotten error resolving did: err: %v", err)
 	}
@@ -289,7 +298,7 @@ func TestHTTPUniversalRawResolver(t *testing.T) {
 	res := did.NewHTTPUniversalResolver(cstr.StrToPtr(host), cnum.IntToPtr(port), nil)
 
 	dd, _ := didlib.Parse("did:web:uport.me")
-	resp, err := res.RawResolve(dd)
+	resp, err := res.RawResolve(context.Background(), dd)
 
 	if resp.Metadata.Duration != 1349 {
 		t.Fatalf("Should have gotten 1349")
@@ -358,7 +367,7 @@ func TestHTTPUniversalResolverErrorEmptyDID(t *testing.T) {
 
 	res := did.NewHTTPUniversalResolver(cstr.StrToPtr(host), cnum.IntToPtr(port), nil)
 
-	resp, err := res.Resolve(nil)
+	resp, err := res.Resolve(context.Background(), nil)
 	if err == nil {
 		t.Errorf("Should have returned an error")
 	}
@@ -380,7 +389,7 @@ func TestHTTPUniversalResolverErrorHttp(t *testing.T) {
 	res := did.NewHTTPUniversalResolver(cstr.StrToPtr(host), cnum.IntToPtr(port), nil)
 
 	dd, _ := didlib.Parse("did:web:uport.me")
-	resp, err := res.Resolve(dd)
+	resp, err := res.Resolve(context.Background(), dd)
 	if err == nil {
 		t.Errorf("Should have returned an error")
 	}
@@ -403,7 +412,7 @@ func TestHTTPUniversalResolverErrorHttpResolverProblem(t *testing.T) {
 	res := did.NewHTTPUniversalResolver(cstr.StrToPtr(host), cnum.IntToPtr(port), nil)
 
 	dd, _ := didlib.Parse("did:web:uport.me")
-	resp, err := res.Resolve(dd)
+	resp, err := res.Resolve(context.Background(), dd)
 	if err == nil {
 		t.Errorf("Should have returned an error")
 	}
@@ -425,7 +434,7 @@ func TestHTTPUniversalRawResolverError(t *testing.T) {
 	res := did.NewHTTPUniversalResolver(cstr.StrToPtr(host), cnum.IntToPtr(port), nil)
 
 	dd, _ := didlib.Parse("did:web:uport.me")
-	resp, err := res.Resolve(dd)
+	resp, err := res.Resolve(context.Background(), dd)
 	if err == nil {
 		t.Errorf("Should have returned an error")
 	}
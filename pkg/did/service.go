@@ -1,61 +1,119 @@
 package did
 
 import (
-	"sync"
+	"context"
+	"time"
 
 	log "github.com/golang/glog"
 	"github.com/pkg/errors"
 
-	"github.com/Jeffail/tunny"
 	didlib "github.com/ockam-network/did"
 )
 
 const (
-	numWorkers = 3
+	// DefaultResolverTimeout is the timeout of a resolver call if not set with
+	// SetResolverTimeout
+	DefaultResolverTimeout = 10 * time.Second
+	// DefaultBreakerThreshold is the number of consecutive failures of a
+	// resolver that opens its circuit breaker
+	DefaultBreakerThreshold = 5
+	// DefaultBreakerCooldown is how long an open circuit breaker skips its
+	// resolver before trying it again
+	DefaultBreakerCooldown = 30 * time.Second
 )
 
 // NewService is a convenience function to return a new populated did.Service object
 func NewService(resolvers []Resolver) *Service {
-	service := &Service{
-		resolvers: resolvers,
+	entries := make([]*resolverEntry, len(resolvers))
+	for i, r := range resolvers {
+		entries[i] = &resolverEntry{
+			resolver: r,
+			timeout:  DefaultResolverTimeout,
+			breaker:  newCircuitBreaker(DefaultBreakerThreshold, DefaultBreakerCooldown),
+		}
+		if mr, ok := r.(MethodResolver); ok {
+			entries[i].methods = mr.Methods()
+		}
 	}
-	pool := tunny.NewFunc(
-		numWorkers,
-		service.resolveProcess,
-	)
 	return &Service{
-		resolvers:   resolvers,
-		resProcPool: pool,
+		resolvers: entries,
 	}
 }
 
 // Service is the service module for DIDs. It is the direct interface for
 // managing DIDs and DID documents and should be used when possible.
 type Service struct {
-	resolvers   []Resolver
-	resProcPool *tunny.Pool
+	resolvers []*resolverEntry
+}
+
+// SetResolverTimeout sets the timeout of the calls to a resolver of the service
+func (s *Service) SetResolverTimeout(r Resolver, timeout time.Duration) {
+	if e := s.entry(r); e != nil {
+		e.timeout = timeout
+	}
+}
+
+// SetCircuitBreaker sets the circuit breaker of a resolver of the service, it
+// is skipped for cooldown after threshold consecutive failures. A threshold of
+// 0 disables the circuit breaker.
+func (s *Service) SetCircuitBreaker(r Resolver, threshold int, cooldown time.Duration) {
+	if e := s.entry(r); e != nil {
+		e.breaker = newCircuitBreaker(threshold, cooldown)
+	}
 }
 
 // GetDocument retrieves the DID document given the DID as a string id
 // If document is not found, will return a nil Document.
-func (s *Service) GetDocument(did string) (*Document, error) {
-	d, err := didlib.Parse(did)
+// The did can be a DID URL with versionId or versionTime parameters to get an
+// earlier version of the document.
+func (s *Service) GetDocument(ctx context.Context, did string) (*Document, error) {
+	d, params, err := ParseDIDURL(did)
 	if err != nil {
 		return nil, errors.Wrap(err, "error parsing did for get document")
 	}
+	versionID, versionTime, err := VersionFromParams(params)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing did version for get document")
+	}
+	if versionID != "" || versionTime != nil {
+		return s.GetDocumentVersion(ctx, d, versionID, versionTime)
+	}
 
-	return s.resolve(d)
+	return s.resolve(ctx, d)
+}
+
+// GetDocumentVersion retrieves the version of the DID document with versionID,
+// or if versionID is empty the version that was current at versionTime, from
+// the resolvers for the method that support versions
+func (s *Service) GetDocumentVersion(ctx context.Context, did *didlib.DID, versionID string,
+	versionTime *time.Time) (*Document, error) {
+	for _, e := range s.resolversFor(did.Method) {
+		vr, ok := e.resolver.(VersionedResolver)
+		if !ok {
+			continue
+		}
+		doc, err := s.call(ctx, e, func(ctx context.Context) (*Document, error) {
+			return vr.ResolveVersion(ctx, did, versionID, versionTime)
+		})
+		if err == nil {
+			return doc, nil
+		}
+		if err != ErrResolverDIDNotFound {
+			log.Infof("unresolved version: %T, did: %v err: %v", e.resolver, did.String(), err)
+		}
+	}
+	return nil, ErrResolverDIDNotFound
 }
 
 // GetDocumentFromDID retrieves the DID document given the DID as a DID object
 // If document is not found, will return a nil Document.
-func (s *Service) GetDocumentFromDID(did *didlib.DID) (*Document, error) {
-	return s.resolve(did)
+func (s *Service) GetDocumentFromDID(ctx context.Context, did *didlib.DID) (*Document, error) {
+	return s.resolve(ctx, did)
 }
 
 // GetKeyFromDIDDocument returns a public key document from a did with a fragment if it can be found
 // errors if fragment is empty
-func (s *Service) GetKeyFromDIDDocument(did *didlib.DID) (*DocPublicKey, error) {
+func (s *Service) GetKeyFromDIDDocument(ctx context.Context, did *didlib.DID) (*DocPublicKey, error) {
 	// Make copy to avoid side-effects to altering by reference
 	d := CopyDID(did)
 	fragment := d.Fragment
@@ -64,7 +122,7 @@ func (s *Service) GetKeyFromDIDDocument(did *didlib.DID) (*DocPublicKey, error)
 	}
 	d.Fragment = ""
 
-	doc, err := s.GetDocumentFromDID(d)
+	doc, err := s.GetDocumentFromDID(ctx, d)
 	if err != nil {
 		return nil, err
 	}
@@ -76,58 +134,220 @@ func (s *Service) GetKeyFromDIDDocument(did *didlib.DID) (*DocPublicKey, error)
 	return doc.GetPublicKeyFromFragment(fragment)
 }
 
-type resolveParams struct {
-	r Resolver
-	d *didlib.DID
+// GetKeyFromDIDDocumentAtTime returns a public key document from a did with a
+// fragment in the version of the document that was current at t. If no version
+// of the document is found at t, the key is taken from the current document.
+func (s *Service) GetKeyFromDIDDocumentAtTime(ctx context.Context, did *didlib.DID,
+	t time.Time) (*DocPublicKey, error) {
+	d := CopyDID(did)
+	fragment := d.Fragment
+	if fragment == "" {
+		return nil, errors.New("no fragment on did")
+	}
+	d.Fragment = ""
+
+	doc, err := s.GetDocumentVersion(ctx, d, "", &t)
+	if err == ErrResolverDIDNotFound {
+		return s.GetKeyFromDIDDocument(ctx, did)
+	} else if err != nil {
+		return nil, err
+	}
+	return doc.GetPublicKeyFromFragment(fragment)
+}
+
+// GetAssertionMethodAtTime returns the public key document of a did with a
+// fragment if it is an assertion method of the version of the document that was
+// current at t. t is usually set by the signer, so it is bounded by the trusted
+// time the hub received the signature at and the key also has to be an assertion
+// method of the version current at the trusted time, a key removed from the
+// document before then is not accepted for a backdated t. If no version is found
+// at a time the key is taken from the current document.
+func (s *Service) GetAssertionMethodAtTime(ctx context.Context, did *didlib.DID,
+	t time.Time, trusted time.Time) (*DocPublicKey, error) {
+	d := CopyDID(did)
+	fragment := d.Fragment
+	if fragment == "" {
+		return nil, errors.New("no fragment on did")
+	}
+	d.Fragment = ""
+
+	if t.After(trusted) {
+		t = trusted
+	}
+	trustedKey, err := s.assertionMethodAtTime(ctx, d, fragment, trusted)
+	if err != nil {
+		return nil, err
+	}
+	key, err := s.assertionMethodAtTime(ctx, d, fragment, t)
+	if err != nil {
+		return nil, err
+	}
+	if !sameKeyMaterial(key, trustedKey) {
+		return nil, errors.New("key changed since the signed time")
+	}
+	return trustedKey, nil
+}
+
+func (s *Service) assertionMethodAtTime(ctx context.Context, d *didlib.DID, fragment string,
+	t time.Time) (*DocPublicKey, error) {
+	doc, err := s.GetDocumentVersion(ctx, d, "", &t)
+	if err == ErrResolverDIDNotFound {
+		doc, err = s.GetDocumentFromDID(ctx, d)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, errors.New("no did document found")
+	}
+	return doc.GetRelationshipKeyFromFragment(RelationshipAssertionMethod, fragment)
+}
+
+// sameKeyMaterial returns if two public key documents have the same key values
+func sameKeyMaterial(a *DocPublicKey, b *DocPublicKey) bool {
+	values := func(k *DocPublicKey) []*string {
+		return []*string{k.PublicKeyPem, k.PublicKeyJwk, k.PublicKeyHex, k.PublicKeyBase64,
+			k.PublicKeyBase58, k.PublicKeyMultibase, k.EthereumAddress}
+	}
+	av, bv := values(a), values(b)
+	for i := range av {
+		if (av[i] == nil) != (bv[i] == nil) || (av[i] != nil && *av[i] != *bv[i]) {
+			return false
+		}
+	}
+	return a.Type == b.Type
 }
 
-func (s *Service) resolveProcess(payload interface{}) interface{} {
-	p, ok := payload.(resolveParams)
-	if !ok {
-		log.Errorf("Payload is not resolveParams: %v", payload)
-		return nil
+type resolverEntry struct {
+	resolver Resolver
+	methods  []string
+	timeout  time.Duration
+	breaker  *circuitBreaker
+}
+
+// authoritative returns if the resolver is authoritative for the method
+func (e *resolverEntry) authoritative(method string) bool {
+	for _, m := range e.methods {
+		if m == method {
+			return true
+		}
 	}
+	return false
+}
 
-	doc, err := p.r.Resolve(p.d)
-	if err == nil && doc != nil {
-		log.Infof("resolved: %T, did: %v", p.r, p.d.String())
-		return doc
+// resolversFor returns the resolvers that are authoritative for the method, or
+// the resolvers that are not authoritative for any method if there are none
+func (s *Service) resolversFor(method string) []*resolverEntry {
+	entries := []*resolverEntry{}
+	for _, e := range s.resolvers {
+		if e.authoritative(method) {
+			entries = append(entries, e)
+		}
+	}
+	if len(entries) > 0 {
+		return entries
 	}
+	for _, e := range s.resolvers {
+		if len(e.methods) == 0 {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
 
-	log.Infof("unresolved: %T, did: %v err: %v", p.r, p.d.String(), err)
+func (s *Service) entry(r Resolver) *resolverEntry {
+	for _, e := range s.resolvers {
+		if e.resolver == r {
+			return e
+		}
+	}
 	return nil
 }
 
-// resolve runs through the given slice of Resolvers, spawns them in different
-// goroutines and returns the first valid result.
-func (s *Service) resolve(d *didlib.DID) (*Document, error) {
-	results := make([]*Document, len(s.resolvers))
-	var wg sync.WaitGroup
+type resolveResult struct {
+	doc *Document
+	err error
+}
 
-	for ind, r := range s.resolvers {
-		wg.Add(1)
-		go func(r Resolver, d *didlib.DID, ind int) {
-			defer wg.Done()
-			result := s.resProcPool.Process(resolveParams{
-				r: r,
-				d: d,
-			})
+// call runs fn with the timeout of the resolver, through its circuit breaker.
+// Documents, DIDs not found and deactivated DIDs are successful calls, other
+// errors and timeouts are failures unless ctx is done.
+func (s *Service) call(ctx context.Context, e *resolverEntry,
+	fn func(ctx context.Context) (*Document, error)) (*Document, error) {
+	if !e.breaker.allow() {
+		return nil, ErrResolverUnavailable
+	}
+	callCtx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	// Buffered so resolvers that ignore the context do not block when done
+	results := make(chan resolveResult, 1)
+	go func() {
+		doc, err := fn(callCtx)
+		results <- resolveResult{doc: doc, err: err}
+	}()
+
+	var res resolveResult
+	select {
+	case res = <-results:
+	case <-callCtx.Done():
+		res = resolveResult{err: errors.Wrap(callCtx.Err(), "resolver call")}
+	}
+	if res.err == nil && res.doc == nil {
+		res.err = ErrResolverDIDNotFound
+	}
+
+	_, deactivated := IsDeactivated(res.err)
+	if res.err == nil || errors.Cause(res.err) == ErrResolverDIDNotFound || deactivated {
+		e.breaker.success()
+	} else if ctx.Err() == nil {
+		e.breaker.failure()
+	} else {
+		e.breaker.cancelled()
+	}
+	return res.doc, res.err
+}
+
+// resolve runs the resolvers for the method of the DID concurrently and returns
+// the first document or DeactivatedError, without waiting for the other
+// resolvers. It returns when ctx is done.
+func (s *Service) resolve(ctx context.Context, d *didlib.DID) (*Document, error) {
+	entries := s.resolversFor(d.Method)
+
+	// Stops the remaining resolvers when an answer is returned
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-			doc, ok := result.(*Document)
-			if ok {
-				results[ind] = doc
+	results := make(chan resolveResult, len(entries))
+	for _, e := range entries {
+		go func(e *resolverEntry) {
+			doc, err := s.call(ctx, e, func(ctx context.Context) (*Document, error) {
+				return e.resolver.Resolve(ctx, d)
+			})
+			if err != nil {
+				if _, ok := IsDeactivated(err); ok {
+					log.Infof("deactivated: %T, did: %v", e.resolver, d.String())
+				} else {
+					log.Infof("unresolved: %T, did: %v err: %v", e.resolver, d.String(), err)
+				}
 			} else {
-				results[ind] = nil
+				log.Infof("resolved: %T, did: %v", e.resolver, d.String())
 			}
-		}(r, d, ind)
+			results <- resolveResult{doc: doc, err: err}
+		}(e)
 	}
 
-	wg.Wait()
-
-	// Return the first non-nil result
-	for _, doc := range results {
-		if doc != nil {
-			return doc, nil
+	for range entries {
+		select {
+		case res := <-results:
+			if res.err == nil {
+				return res.doc, nil
+			}
+			if derr, ok := IsDeactivated(res.err); ok {
+				return nil, derr
+			}
+		case <-ctx.Done():
+			return nil, errors.Wrap(ctx.Err(), "resolve")
 		}
 	}
 
@@ -1,26 +1,41 @@
 package did_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	cnum "github.com/joincivil/go-common/pkg/numbers"
 	cstr "github.com/joincivil/go-common/pkg/strings"
 	"github.com/joincivil/id-hub/pkg/did"
 	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
 )
 
 type NoResolutionResolver struct {
 }
 
-func (n *NoResolutionResolver) Resolve(d *didlib.DID) (*did.Document, error) {
+func (n *NoResolutionResolver) Resolve(ctx context.Context, d *didlib.DID) (*did.Document, error) {
 	return nil, did.ErrResolverDIDNotFound
 }
 
+type DeactivatedResolver struct {
+}
+
+func (n *DeactivatedResolver) Resolve(ctx context.Context, d *didlib.DID) (*did.Document, error) {
+	return nil, &did.DeactivatedError{
+		DID:      d.String(),
+		Metadata: did.DocumentMetadata{Deactivated: true},
+	}
+}
+
 func TestGetDocument(t *testing.T) {
 	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintln(w, validResponse)
@@ -35,7 +50,7 @@ func TestGetDocument(t *testing.T) {
 
 	serv := did.NewService([]did.Resolver{res})
 
-	doc, err := serv.GetDocument("did:web:uport.me")
+	doc, err := serv.GetDocument(context.Background(), "did:web:uport.me")
 	if err != nil {
 		t.Errorf("Should not have gotten error: err: %v", err)
 	}
@@ -44,7 +59,7 @@ func TestGetDocument(t *testing.T) {
 		t.Errorf("Should have gotten the correct ID")
 	}
 
-	doc, err = serv.GetDocument("did")
+	doc, err = serv.GetDocument(context.Background(), "did")
 	if err == nil {
 		t.Errorf("Should have gotten error")
 	}
@@ -58,7 +73,7 @@ func TestGetDocumentNoResolution(t *testing.T) {
 
 	serv := did.NewService([]did.Resolver{res})
 
-	doc, err := serv.GetDocument("did:web:idontexist.co")
+	doc, err := serv.GetDocument(context.Background(), "did:web:idontexist.co")
 	if err == nil {
 		t.Errorf("Should have gotten error")
 	}
@@ -70,6 +85,30 @@ func TestGetDocumentNoResolution(t *testing.T) {
 	}
 }
 
+func TestGetDocumentDeactivated(t *testing.T) {
+	serv := did.NewService([]did.Resolver{&NoResolutionResolver{}, &DeactivatedResolver{}})
+
+	doc, err := serv.GetDocument(context.Background(), "did:ethuri:e7ab0c43-d9fe-4a61-87a3-3fa99ce879e1")
+	if doc != nil {
+		t.Errorf("Should have gotten empty doc")
+	}
+	derr, ok := did.IsDeactivated(errors.Wrap(err, "wrapped"))
+	if !ok {
+		t.Fatalf("Should have gotten deactivated error: err: %v", err)
+	}
+	if !derr.Metadata.Deactivated {
+		t.Errorf("Should have gotten deactivated metadata")
+	}
+	if errors.Cause(err) != did.ErrResolverDIDDeactivated {
+		t.Errorf("Should have had the deactivated error as cause")
+	}
+
+	_, ok = did.IsDeactivated(did.ErrResolverDIDNotFound)
+	if ok {
+		t.Errorf("Should not have been a deactivated error")
+	}
+}
+
 func TestGetDocumentFromDID(t *testing.T) {
 	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintln(w, validResponse)
@@ -86,7 +125,7 @@ func TestGetDocumentFromDID(t *testing.T) {
 
 	dd, _ := didlib.Parse("did:web:uport.me")
 
-	doc, err := serv.GetDocumentFromDID(dd)
+	doc, err := serv.GetDocumentFromDID(context.Background(), dd)
 	if err != nil {
 		t.Errorf("Should not have gotten error: err: %v", err)
 	}
@@ -112,7 +151,7 @@ func TestGetKeyFromDIDDocument(t *testing.T) {
 
 	// Test normal scenario
 	dd, _ := didlib.Parse("did:web:uport.me#owner")
-	key, err := serv.GetKeyFromDIDDocument(dd)
+	key, err := serv.GetKeyFromDIDDocument(context.Background(), dd)
 	if err != nil {
 		t.Errorf("Should not have gotten error: err: %v", err)
 	}
@@ -123,7 +162,7 @@ func TestGetKeyFromDIDDocument(t *testing.T) {
 
 	// Test no fragment
 	dd, _ = didlib.Parse("did:web:uport.me")
-	_, err = serv.GetKeyFromDIDDocument(dd)
+	_, err = serv.GetKeyFromDIDDocument(context.Background(), dd)
 	if err == nil {
 		t.Errorf("Should have gotten error: err: %v", err)
 	}
@@ -135,8 +174,161 @@ func TestGetKeyFromDIDDocumentNoDID(t *testing.T) {
 
 	// Test no did
 	dd, _ := didlib.Parse("did:web:civil.co#owner")
-	_, err := serv.GetKeyFromDIDDocument(dd)
+	_, err := serv.GetKeyFromDIDDocument(context.Background(), dd)
 	if err == nil {
 		t.Errorf("Should have gotten error: err: %v", err)
 	}
 }
+
+type MethodStaticResolver struct {
+	StaticResolver
+	methods []string
+}
+
+func (m *MethodStaticResolver) Methods() []string {
+	return m.methods
+}
+
+type CountingResolver struct {
+	calls int32
+	delay time.Duration
+	doc   *did.Document
+	err   error
+}
+
+func (c *CountingResolver) Resolve(ctx context.Context, d *didlib.DID) (*did.Document, error) {
+	atomic.AddInt32(&c.calls, 1)
+	if c.delay > 0 {
+		select {
+		case <-time.After(c.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return c.doc, c.err
+}
+
+func (c *CountingResolver) Calls() int {
+	return int(atomic.LoadInt32(&c.calls))
+}
+
+func testDocument(t *testing.T) *did.Document {
+	doc := &did.Document{}
+	err := json.Unmarshal([]byte(testDIDDocV1), doc)
+	if err != nil {
+		t.Fatalf("Should have unmarshalled document from json: err: %v", err)
+	}
+	return doc
+}
+
+func TestGetDocumentMethodRouting(t *testing.T) {
+	doc := testDocument(t)
+	fallback := &CountingResolver{err: did.ErrResolverDIDNotFound}
+	method := &MethodStaticResolver{StaticResolver: StaticResolver{doc: doc}, methods: []string{"example"}}
+	serv := did.NewService([]did.Resolver{fallback, method})
+
+	resolved, err := serv.GetDocument(context.Background(), "did:example:123456789abcdefghi")
+	if err != nil || resolved == nil {
+		t.Fatalf("Should have resolved the did: err: %v", err)
+	}
+	_, err = serv.GetDocument(context.Background(), "did:example:notfound")
+	if err != did.ErrResolverDIDNotFound {
+		t.Errorf("Should have not found the did: err: %v", err)
+	}
+	if fallback.Calls() != 0 {
+		t.Errorf("Should not have called the fallback resolver for a routed method: %v", fallback.Calls())
+	}
+
+	_, err = serv.GetDocument(context.Background(), "did:other:123456789abcdefghi")
+	if err != did.ErrResolverDIDNotFound {
+		t.Errorf("Should have not found the did: err: %v", err)
+	}
+	if fallback.Calls() != 1 {
+		t.Errorf("Should have called the fallback resolver for other methods: %v", fallback.Calls())
+	}
+}
+
+func TestGetDocumentFirstAnswer(t *testing.T) {
+	doc := testDocument(t)
+	slow := &CountingResolver{delay: 5 * time.Second}
+	serv := did.NewService([]did.Resolver{slow, &StaticResolver{doc: doc}})
+
+	start := time.Now()
+	resolved, err := serv.GetDocument(context.Background(), "did:example:123456789abcdefghi")
+	if err != nil || resolved == nil {
+		t.Fatalf("Should have resolved the did: err: %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("Should not have waited for the slow resolver")
+	}
+}
+
+func TestGetDocumentTimeout(t *testing.T) {
+	slow := &CountingResolver{delay: 5 * time.Second}
+	serv := did.NewService([]did.Resolver{slow})
+	serv.SetResolverTimeout(slow, 50*time.Millisecond)
+
+	start := time.Now()
+	_, err := serv.GetDocument(context.Background(), "did:example:123456789abcdefghi")
+	if err != did.ErrResolverDIDNotFound {
+		t.Errorf("Should have not found the did: err: %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("Should have timed out the slow resolver")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	serv.SetResolverTimeout(slow, did.DefaultResolverTimeout)
+	_, err = serv.GetDocument(ctx, "did:example:123456789abcdefghi")
+	if errors.Cause(err) != context.DeadlineExceeded {
+		t.Errorf("Should have returned when the context was done: err: %v", err)
+	}
+}
+
+func TestGetDocumentCircuitBreaker(t *testing.T) {
+	failing := &CountingResolver{err: errors.New("upstream error")}
+	serv := did.NewService([]did.Resolver{failing})
+	serv.SetCircuitBreaker(failing, 2, 50*time.Millisecond)
+
+	for i := 0; i < 4; i++ {
+		_, err := serv.GetDocument(context.Background(), "did:example:123456789abcdefghi")
+		if err != did.ErrResolverDIDNotFound {
+			t.Errorf("Should have not found the did: err: %v", err)
+		}
+	}
+	if failing.Calls() != 2 {
+		t.Errorf("Should have skipped the resolver when the breaker is open: %v", failing.Calls())
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	failing.err = did.ErrResolverDIDNotFound
+	_, _ = serv.GetDocument(context.Background(), "did:example:123456789abcdefghi")
+	_, _ = serv.GetDocument(context.Background(), "did:example:123456789abcdefghi")
+	if failing.Calls() != 4 {
+		t.Errorf("Should have closed the breaker after a successful trial: %v", failing.Calls())
+	}
+}
+
+func TestGetDocumentCircuitBreakerCancelledTrial(t *testing.T) {
+	slow := &CountingResolver{delay: 5 * time.Second}
+	serv := did.NewService([]did.Resolver{slow})
+	serv.SetResolverTimeout(slow, 30*time.Millisecond)
+	serv.SetCircuitBreaker(slow, 1, 20*time.Millisecond)
+
+	_, _ = serv.GetDocument(context.Background(), "did:example:123456789abcdefghi")
+	time.Sleep(30 * time.Millisecond)
+
+	// the trial call is cancelled by the caller, which does not keep the
+	// breaker waiting for it
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, _ = serv.GetDocument(ctx, "did:example:123456789abcdefghi")
+	if slow.Calls() != 2 {
+		t.Fatalf("Should have made the trial call: %v", slow.Calls())
+	}
+	_, _ = serv.GetDocument(context.Background(), "did:example:123456789abcdefghi")
+	if slow.Calls() != 3 {
+		t.Errorf("Should have made another trial call after the cancelled one: %v", slow.Calls())
+	}
+}
@@ -2,16 +2,65 @@ package did
 
 import (
 	"fmt"
+	"strings"
 
 	log "github.com/golang/glog"
 
 	didlib "github.com/ockam-network/did"
 )
 
+// Parse parses a DID like didlib.Parse, but also allows the pct-encoded
+// characters DID Core allows in the method specific id, such as the port in
+// did:web:example.com%3A8443. The id is kept encoded.
+func Parse(did string) (*didlib.DID, error) {
+	if !strings.HasPrefix(did, "did:") {
+		return didlib.Parse(did)
+	}
+	methodEnd := strings.Index(did[4:], ":")
+	if methodEnd < 0 {
+		return didlib.Parse(did)
+	}
+	idStart := 4 + methodEnd + 1
+	idEnd := len(did)
+	if ind := strings.IndexAny(did[idStart:], "/#"); ind >= 0 {
+		idEnd = idStart + ind
+	}
+	id := did[idStart:idEnd]
+	if !strings.Contains(id, "%") {
+		return didlib.Parse(did)
+	}
+
+	// Parse with each pct-encoded character replaced by a valid id character,
+	// then put the encoded id back
+	var replaced strings.Builder
+	for i := 0; i < len(id); i++ {
+		if id[i] != '%' {
+			replaced.WriteByte(id[i]) // nolint, returned error is always nil
+			continue
+		}
+		if i+2 >= len(id) || !isHexDigit(id[i+1]) || !isHexDigit(id[i+2]) {
+			return nil, fmt.Errorf("invalid pct-encoded character in did: %v", did)
+		}
+		replaced.WriteByte('x') // nolint, returned error is always nil
+		i += 2
+	}
+	d, err := didlib.Parse(did[:idStart] + replaced.String() + did[idEnd:])
+	if err != nil {
+		return nil, err
+	}
+	d.ID = id
+	d.IDStrings = strings.Split(id, ":")
+	return d, nil
+}
+
+func isHexDigit(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
+
 // MethodIDOnlyFromString returns did string without any fragments or paths from
 // a full DID string
 func MethodIDOnlyFromString(did string) (string, error) {
-	d, err := didlib.Parse(did)
+	d, err := Parse(did)
 	if err != nil {
 		return "", err
 	}
@@ -25,7 +74,7 @@ func MethodIDOnly(did *didlib.DID) string {
 
 // CopyDID is a convenience function to make a copy a DID struct
 func CopyDID(d *didlib.DID) *didlib.DID {
-	cpy, err := didlib.Parse(d.String())
+	cpy, err := Parse(d.String())
 	if err != nil {
 		log.Errorf("Error parsing did string for copy: err: %v", err)
 		return nil
@@ -35,6 +84,6 @@ func CopyDID(d *didlib.DID) *didlib.DID {
 
 // ValidDid returns true if the given did string is of a valid DID format
 func ValidDid(did string) bool {
-	_, err := didlib.Parse(did)
+	_, err := Parse(did)
 	return err == nil
 }
@@ -86,3 +86,27 @@ func TestValidDid(t *testing.T) {
 		t.Errorf("Should have returned true as valid did")
 	}
 }
+
+func TestParsePctEncoded(t *testing.T) {
+	d, err := did.Parse("did:web:example.com%3A8443:user#keys-1")
+	if err != nil {
+		t.Fatalf("Should have parsed the did: err: %v", err)
+	}
+	if d.ID != "example.com%3A8443:user" || len(d.IDStrings) != 2 ||
+		d.IDStrings[0] != "example.com%3A8443" || d.Fragment != "keys-1" {
+		t.Errorf("Should have kept the encoded id: %+v", d)
+	}
+	if d.String() != "did:web:example.com%3A8443:user#keys-1" {
+		t.Errorf("Should have encoded the did: %v", d.String())
+	}
+	if did.MethodIDOnly(did.CopyDID(d)) != "did:web:example.com%3A8443:user" {
+		t.Errorf("Should have copied the did")
+	}
+
+	for _, in := range []string{"did:web:example.com%3", "did:web:example.com%zz", "did:web:%3A$"} {
+		_, err = did.Parse(in)
+		if err == nil {
+			t.Errorf("Should not have parsed %v", in)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package did
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+)
+
+const (
+	// VersionIDParam is the DID URL parameter for a version of a document
+	VersionIDParam = "versionId"
+	// VersionTimeParam is the DID URL parameter for the version of a document
+	// that was current at a time
+	VersionTimeParam = "versionTime"
+)
+
+// VersionedResolver is a Resolver that also resolves earlier versions of
+// DID documents
+type VersionedResolver interface {
+	Resolver
+	// ResolveVersion returns the version of the DID document with versionID, or
+	// if versionID is empty the version that was current at versionTime. Expects
+	// ErrResolverDIDNotFound as error when the version is not found.
+	ResolveVersion(ctx context.Context, d *didlib.DID, versionID string,
+		versionTime *time.Time) (*Document, error)
+}
+
+// ParseDIDURL parses a DID URL into the DID, including any fragment, and the
+// parameters in its query
+func ParseDIDURL(didURL string) (*didlib.DID, url.Values, error) {
+	fragment := ""
+	if ind := strings.Index(didURL, "#"); ind >= 0 {
+		fragment = didURL[ind:]
+		didURL = didURL[:ind]
+	}
+	query := ""
+	if ind := strings.Index(didURL, "?"); ind >= 0 {
+		query = didURL[ind+1:]
+		didURL = didURL[:ind]
+	}
+
+	d, err := Parse(didURL + fragment)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "parsedidurl.parse")
+	}
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "parsedidurl.parsequery")
+	}
+	return d, params, nil
+}
+
+// VersionFromParams returns the version id and version time in DID URL parameters
+func VersionFromParams(params url.Values) (string, *time.Time, error) {
+	versionID := params.Get(VersionIDParam)
+	var versionTime *time.Time
+	if params.Get(VersionTimeParam) != "" {
+		t, err := time.Parse(time.RFC3339, params.Get(VersionTimeParam))
+		if err != nil {
+			return "", nil, errors.Wrap(err, "versionfromparams.parse")
+		}
+		versionTime = &t
+	}
+	return versionID, versionTime, nil
+}
@@ -0,0 +1,54 @@
+package did_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joincivil/id-hub/pkg/did"
+)
+
+func TestParseDIDURL(t *testing.T) {
+	d, params, err := did.ParseDIDURL(
+		"did:ethuri:c81773d7-fc03-44eb-b28e-6eff2c291485?versionId=2#keys-1")
+	if err != nil {
+		t.Fatalf("should have parsed the did url: err: %v", err)
+	}
+	if did.MethodIDOnly(d) != "did:ethuri:c81773d7-fc03-44eb-b28e-6eff2c291485" {
+		t.Errorf("wrong did: %v", d.String())
+	}
+	if d.Fragment != "keys-1" {
+		t.Errorf("should have kept the fragment: %v", d.Fragment)
+	}
+	versionID, versionTime, err := did.VersionFromParams(params)
+	if err != nil {
+		t.Fatalf("should have gotten the version: err: %v", err)
+	}
+	if versionID != "2" || versionTime != nil {
+		t.Errorf("wrong version: %v %v", versionID, versionTime)
+	}
+
+	_, params, err = did.ParseDIDURL(
+		"did:ethuri:c81773d7-fc03-44eb-b28e-6eff2c291485?versionTime=2020-01-02T03:04:05Z")
+	if err != nil {
+		t.Fatalf("should have parsed the did url: err: %v", err)
+	}
+	versionID, versionTime, err = did.VersionFromParams(params)
+	if err != nil {
+		t.Fatalf("should have gotten the version: err: %v", err)
+	}
+	expected := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	if versionID != "" || versionTime == nil || !versionTime.Equal(expected) {
+		t.Errorf("wrong version time: %v", versionTime)
+	}
+
+	_, params, _ = did.ParseDIDURL("did:ethuri:123?versionTime=yesterday")
+	_, _, err = did.VersionFromParams(params)
+	if err == nil {
+		t.Errorf("should have failed to parse the version time")
+	}
+
+	_, _, err = did.ParseDIDURL("notadid?versionId=1")
+	if err == nil {
+		t.Errorf("should have failed to parse an invalid did")
+	}
+}
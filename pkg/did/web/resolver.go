@@ -0,0 +1,173 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/utils"
+)
+
+// Implements the did.Resolver interface, so can be passed into did.Service
+
+const (
+	// Method is the did method of did:web
+	Method = "web"
+
+	defaultTimeout = 10 * time.Second
+	// maxDocumentSize is the most bytes read from a did document
+	maxDocumentSize = 1 << 20
+
+	wellKnownPath = "/.well-known"
+	documentName  = "did.json"
+)
+
+var (
+	// ErrDocumentIDMismatch is returned when the id of the fetched document is
+	// not the did that was resolved
+	ErrDocumentIDMismatch = errors.New("the did document id does not match the did")
+)
+
+// Resolver fetches the did documents of did:web dids from the https location
+// of the did
+type Resolver struct {
+	client *http.Client
+	cache  did.ResolverCache
+}
+
+// NewResolver returns a new did:web Resolver that fetches documents with
+// client and caches them in cache. A default client that only connects to
+// public addresses is used if client is nil and documents are not cached if
+// cache is nil.
+func NewResolver(client *http.Client, cache did.ResolverCache) *Resolver {
+	if client == nil {
+		client = utils.NewPublicHTTPClient(defaultTimeout)
+	}
+	return &Resolver{
+		client: client,
+		cache:  cache,
+	}
+}
+
+// DocumentURL returns the https url of the did document of a did:web did.
+// did:web:example.com is at https://example.com/.well-known/did.json,
+// did:web:example.com:user:alice at https://example.com/user/alice/did.json and
+// did:web:example.com%3A8443 at https://example.com:8443/.well-known/did.json.
+func DocumentURL(d *didlib.DID) (string, error) {
+	if d.Method != Method || len(d.IDStrings) == 0 || d.IDStrings[0] == "" {
+		return "", errors.Errorf("not a did:web did: %v", d.String())
+	}
+	segments := make([]string, len(d.IDStrings))
+	for i, segment := range d.IDStrings {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			return "", errors.Wrap(err, "documenturl.pathunescape")
+		}
+		// Encoded separators would change the host or path of the url
+		if unescaped == "" || unescaped == "." || unescaped == ".." ||
+			strings.ContainsAny(unescaped, "/?#@\\") {
+			return "", errors.Errorf("invalid did:web did: %v", d.String())
+		}
+		if i > 0 {
+			unescaped = url.PathEscape(unescaped)
+		}
+		segments[i] = unescaped
+	}
+	host := segments[0]
+	if strings.Contains(host, ":") {
+		// Only a port can follow the host
+		if _, port, err := net.SplitHostPort(host); err != nil || port == "" {
+			return "", errors.Errorf("invalid did:web host: %v", host)
+		}
+	}
+
+	path := wellKnownPath
+	if len(segments) > 1 {
+		path = "/" + strings.Join(segments[1:], "/")
+	}
+	return fmt.Sprintf("https://%v%v/%v", host, path, documentName), nil
+}
+
+// Methods implements the did.MethodResolver interface
+func (r *Resolver) Methods() []string {
+	return []string{Method}
+}
+
+// Resolve implements the did.Resolver interface and returns the did document of
+// a did:web did
+func (r *Resolver) Resolve(ctx context.Context, d *didlib.DID) (*did.Document, error) {
+	if d.Method != Method {
+		return nil, did.ErrResolverDIDNotFound
+	}
+	docDID, err := did.Parse(did.MethodIDOnly(d))
+	if err != nil {
+		return nil, errors.Wrap(err, "resolve.parse")
+	}
+
+	if r.cache != nil {
+		doc, err := r.cache.Get(docDID)
+		if err == nil && doc != nil {
+			return doc, nil
+		}
+
+		if err != nil && errors.Cause(err) != did.ErrResolverCacheDIDNotFound {
+			return nil, errors.Wrap(err, "resolve.get")
+		}
+	}
+
+	doc, err := r.fetch(ctx, docDID)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.cache != nil {
+		err = r.cache.Set(docDID, doc)
+		if err != nil {
+			return nil, errors.Wrap(err, "resolve.set")
+		}
+	}
+	return doc, nil
+}
+
+func (r *Resolver) fetch(ctx context.Context, d *didlib.DID) (*did.Document, error) {
+	docURL, err := DocumentURL(d)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodGet, docURL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch.newrequest")
+	}
+	resp, err := r.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch.get")
+	}
+	defer resp.Body.Close() // nolint: errcheck
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return nil, did.ErrResolverDIDNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("did document returned status %v", resp.StatusCode)
+	}
+
+	doc := &did.Document{}
+	err = json.NewDecoder(io.LimitReader(resp.Body, maxDocumentSize)).Decode(doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch.decode")
+	}
+	if did.MethodIDOnly(&doc.ID) != d.String() {
+		return nil, ErrDocumentIDMismatch
+	}
+	return doc, nil
+}
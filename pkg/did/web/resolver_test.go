@@ -0,0 +1,177 @@
+package web_test
+
+import (
+	"context"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/allegro/bigcache"
+	"github.com/ethereum/go-ethereum/crypto"
+	didlib "github.com/ockam-network/did"
+
+	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/did/web"
+	"github.com/joincivil/id-hub/pkg/linkeddata"
+	"github.com/joincivil/id-hub/pkg/utils"
+)
+
+func testDocument(t *testing.T, id string) []byte {
+	d, _ := did.Parse(id)
+	privKey, _ := crypto.GenerateKey()
+	pubKeyHex := hex.EncodeToString(crypto.FromECDSAPub(&privKey.PublicKey))
+	doc := &did.Document{
+		Context:    did.DefaultDIDContextV1,
+		ID:         *d,
+		PublicKeys: []did.DocPublicKey{},
+	}
+	err := doc.AddPublicKey(&did.DocPublicKey{
+		Type:         linkeddata.SuiteTypeSecp256k1Verification,
+		PublicKeyHex: &pubKeyHex,
+	}, true, true)
+	if err != nil {
+		t.Fatalf("Should have added the key: err: %v", err)
+	}
+	bys, err := doc.MarshalJSON()
+	if err != nil {
+		t.Fatalf("Should have marshalled the doc: err: %v", err)
+	}
+	return bys
+}
+
+// newTestServer serves did documents at their paths and returns a client that
+// sends every request to the server
+func newTestServer(t *testing.T, docs map[string][]byte, requests *int32) (*httptest.Server, *http.Client) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		doc, ok := docs[r.Host+r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(doc)
+	}))
+	client := server.Client()
+	transport := client.Transport.(*http.Transport)
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+	}
+	return server, client
+}
+
+func TestDocumentURL(t *testing.T) {
+	tests := map[string]string{
+		"did:web:example.com":                 "https://example.com/.well-known/did.json",
+		"did:web:example.com:user:alice":      "https://example.com/user/alice/did.json",
+		"did:web:example.com:user:alice#key":  "https://example.com/user/alice/did.json",
+		"did:web:example.com%3A8443":          "https://example.com:8443/.well-known/did.json",
+		"did:web:example.com%3A8443:user%20x": "https://example.com:8443/user%20x/did.json",
+	}
+	for in, expected := range tests {
+		d, err := did.Parse(in)
+		if err != nil {
+			t.Fatalf("Should have parsed %v: err: %v", in, err)
+		}
+		docURL, err := web.DocumentURL(d)
+		if err != nil || docURL != expected {
+			t.Errorf("Should have mapped %v to %v: %v, err: %v", in, expected, docURL, err)
+		}
+	}
+	for _, in := range []string{
+		"did:web:example.com%2Fother",
+		"did:web:evil.com%40example.com",
+		"did:web:example.com:%2E%2E",
+		"did:web:example.com%3A",
+	} {
+		d, err := did.Parse(in)
+		if err != nil {
+			t.Fatalf("Should have parsed %v: err: %v", in, err)
+		}
+		_, err = web.DocumentURL(d)
+		if err == nil {
+			t.Errorf("Should not have mapped %v", in)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	var requests int32
+	docs := map[string][]byte{
+		"example.com/.well-known/did.json":      testDocument(t, "did:web:example.com"),
+		"example.com/user/alice/did.json":       testDocument(t, "did:web:example.com:user:alice"),
+		"example.com/user/mallory/did.json":     testDocument(t, "did:web:example.com:user:alice"),
+		"example.com:8443/.well-known/did.json": testDocument(t, "did:web:example.com%3A8443"),
+	}
+	server, client := newTestServer(t, docs, &requests)
+	defer server.Close()
+
+	bcache, err := bigcache.NewBigCache(did.DefaultBigCacheConfig)
+	if err != nil {
+		t.Fatalf("Should have made the cache: err: %v", err)
+	}
+	resolver := web.NewResolver(client, did.NewBigCacheResolverCache(bcache))
+
+	for _, id := range []string{"did:web:example.com", "did:web:example.com:user:alice#keys-1",
+		"did:web:example.com%3A8443"} {
+		d, _ := did.Parse(id)
+		doc, err := resolver.Resolve(context.Background(), d)
+		if err != nil {
+			t.Fatalf("Should have resolved %v: err: %v", id, err)
+		}
+		if doc.ID.String() != did.MethodIDOnly(d) || len(doc.PublicKeys) != 1 {
+			t.Errorf("Should have returned the document of %v: %v", id, doc)
+		}
+	}
+
+	// Resolved from the cache
+	d, _ := didlib.Parse("did:web:example.com:user:alice")
+	_, err = resolver.Resolve(context.Background(), d)
+	if err != nil {
+		t.Fatalf("Should have resolved from the cache: err: %v", err)
+	}
+	if atomic.LoadInt32(&requests) != 3 {
+		t.Errorf("Should have cached the documents: %v requests", requests)
+	}
+
+	d, _ = didlib.Parse("did:web:example.com:user:mallory")
+	_, err = resolver.Resolve(context.Background(), d)
+	if err != web.ErrDocumentIDMismatch {
+		t.Errorf("Should not have resolved a document for another did: err: %v", err)
+	}
+
+	d, _ = didlib.Parse("did:web:example.com:user:bob")
+	_, err = resolver.Resolve(context.Background(), d)
+	if err != did.ErrResolverDIDNotFound {
+		t.Errorf("Should not have found a missing document: err: %v", err)
+	}
+
+	d, _ = didlib.Parse("did:key:z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK")
+	_, err = resolver.Resolve(context.Background(), d)
+	if err != did.ErrResolverDIDNotFound {
+		t.Errorf("Should not have resolved another method: err: %v", err)
+	}
+}
+
+func TestResolveNonPublicAddress(t *testing.T) {
+	var requests int32
+	server, _ := newTestServer(t, map[string][]byte{}, &requests)
+	defer server.Close()
+
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	d, err := did.Parse("did:web:localhost%3A" + port)
+	if err != nil {
+		t.Fatalf("Should have parsed the did: err: %v", err)
+	}
+	_, err = web.NewResolver(nil, nil).Resolve(context.Background(), d)
+	if err == nil || !strings.Contains(err.Error(), utils.ErrNonPublicAddress.Error()) {
+		t.Errorf("Should not have connected to a loopback address: err: %v", err)
+	}
+	if atomic.LoadInt32(&requests) != 0 {
+		t.Errorf("Should not have sent a request: %v requests", requests)
+	}
+}
@@ -1,7 +1,7 @@
 package didjwt
 
 import (
-	"crypto/ecdsa"
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -23,41 +23,43 @@ func NewService(didService *did.Service) *Service {
 	}
 }
 
+// IssuerClaims are jwt claims issued by a did
+type IssuerClaims interface {
+	jwt.Claims
+	GetIssuer() string
+}
+
 // ParseJWT takes a jwt token finds the correct key to verify it and returns the token
-func (s *Service) ParseJWT(tokenString string) (*jwt.Token, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &VCClaimsJWT{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodECDSA); !ok {
+func (s *Service) ParseJWT(ctx context.Context, tokenString string) (*jwt.Token, error) {
+	return s.ParseJWTWithClaims(ctx, tokenString, &VCClaimsJWT{})
+}
+
+// ParseJWTWithClaims parses a jwt token into claims, verifies it with a key of
+// the did that issued it and returns the token. ES256 tokens are verified with
+// secp256r1 and secp256k1 keys and EdDSA tokens with Ed25519 keys. The issuer
+// did is resolved with ctx.
+func (s *Service) ParseJWTWithClaims(ctx context.Context, tokenString string, claims IssuerClaims) (*jwt.Token, error) {
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodECDSA); !ok && token.Method != SigningMethodEdDSA {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		if claims, ok := token.Claims.(*VCClaimsJWT); ok && claims.Issuer != "" {
-			didDoc, err := s.didService.GetDocument(claims.Issuer)
+		if claims, ok := token.Claims.(IssuerClaims); ok && claims.GetIssuer() != "" {
+			didDoc, err := s.didService.GetDocument(ctx, claims.GetIssuer())
 			if err != nil {
 				return nil, err
 			}
-			var key *ecdsa.PublicKey
-			for _, v := range didDoc.PublicKeys {
-				// only support secp256r1 and secp256k1 for now
-				if v.Type == linkeddata.SuiteTypeSecp256k1Verification ||
-					v.Type == linkeddata.SuiteTypeSecp256r1Verification {
-					key, err = v.AsEcdsaPubKey()
-					if err != nil {
-						continue
-					}
-
-					parts := strings.Split(token.Raw, ".")
-					fParts := []string{parts[0], parts[1]}
-					signingString := strings.Join(fParts, ".")
-					err = jwt.SigningMethodES256.Verify(signingString, parts[2], key)
-					if err == nil {
-						break
-					}
-					key = nil
+			parts := strings.Split(token.Raw, ".")
+			signingString := strings.Join(parts[0:2], ".")
+			for _, v := range didDoc.RelationshipKeys(did.RelationshipAssertionMethod) {
+				key, err := tokenKey(token.Method, &v)
+				if err != nil || key == nil {
+					continue
+				}
+				if token.Method.Verify(signingString, parts[2], key) == nil {
+					return key, nil
 				}
 			}
-			if key == nil {
-				return nil, errors.New("could not verify the token with any of the DID's keys")
-			}
-			return key, nil
+			return nil, errors.New("could not verify the token with any of the DID's keys")
 		}
 		return nil, errors.New("couldn't get public key from DID")
 	})
@@ -68,3 +70,20 @@ func (s *Service) ParseJWT(tokenString string) (*jwt.Token, error) {
 
 	return token, nil
 }
+
+// tokenKey returns the public key of a did key to verify a token signed with
+// method, or nil if the key can not verify it
+func tokenKey(method jwt.SigningMethod, pk *did.DocPublicKey) (interface{}, error) {
+	if method == SigningMethodEdDSA {
+		if pk.Type != linkeddata.SuiteTypeEd25519Verification {
+			return nil, nil
+		}
+		return pk.AsEd25519PubKey()
+	}
+	// only support secp256r1 and secp256k1 for ecdsa
+	if pk.Type != linkeddata.SuiteTypeSecp256k1Verification &&
+		pk.Type != linkeddata.SuiteTypeSecp256r1Verification {
+		return nil, nil
+	}
+	return pk.AsEcdsaPubKey()
+}
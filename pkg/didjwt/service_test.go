@@ -1,7 +1,9 @@
 package didjwt_test
 
 import (
+	"context"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"encoding/hex"
@@ -13,6 +15,7 @@ import (
 	"github.com/joincivil/id-hub/pkg/claimsstore"
 	"github.com/joincivil/id-hub/pkg/did"
 	"github.com/joincivil/id-hub/pkg/did/ethuri"
+	"github.com/joincivil/id-hub/pkg/did/key"
 	"github.com/joincivil/id-hub/pkg/didjwt"
 	"github.com/joincivil/id-hub/pkg/linkeddata"
 	"github.com/joincivil/id-hub/pkg/testutils"
@@ -24,8 +27,8 @@ func setupConnection() (*gorm.DB, error) {
 	if err != nil {
 		return nil, err
 	}
-	db.DropTable(&ethuri.PostgresDocument{}, &claimsstore.RootCommit{}, &claimsstore.Node{})
-	err = db.AutoMigrate(&ethuri.PostgresDocument{}, &claimsstore.SignedClaimPostgres{},
+	db.DropTable(&ethuri.PostgresDocument{}, &ethuri.PostgresDocumentVersion{}, &ethuri.PostgresDocumentIndex{}, &claimsstore.RootCommit{}, &claimsstore.Node{})
+	err = db.AutoMigrate(&ethuri.PostgresDocument{}, &ethuri.PostgresDocumentVersion{}, &ethuri.PostgresDocumentIndex{}, &claimsstore.SignedClaimPostgres{},
 		&claimsstore.Node{}, &claimsstore.RootCommit{}).Error
 	if err != nil {
 		return nil, err
@@ -51,7 +54,7 @@ func addPubKey(ethURI *ethuri.Service, pubKey *ecdsa.PublicKey,
 		return err
 	}
 
-	if err := ethURI.SaveDocument(doc); err != nil {
+	if err := ethURI.SaveDocument(doc, 0); err != nil {
 		return err
 	}
 	return nil
@@ -95,7 +98,7 @@ func TestParseJWT(t *testing.T) {
 	if err != nil {
 		t.Errorf("error making the did doc: %v", err)
 	}
-	if err := ethURIRes.SaveDocument(didDoc); err != nil {
+	if err := ethURIRes.SaveDocument(didDoc, 0); err != nil {
 		t.Errorf("error saving the did doc: %v", err)
 	}
 
@@ -136,7 +139,7 @@ func TestParseJWT(t *testing.T) {
 		t.Errorf("error creating token string: %v", err)
 	}
 
-	parsedToken, err := didJWTService.ParseJWT(tokenS)
+	parsedToken, err := didJWTService.ParseJWT(context.Background(), tokenS)
 
 	if err != nil {
 		t.Errorf("could not verify the token: %v", err)
@@ -166,9 +169,46 @@ func TestParseJWT(t *testing.T) {
 		t.Errorf("error creating token string: %v", err)
 	}
 
-	_, err = didJWTService.ParseJWT(tokenS)
+	_, err = didJWTService.ParseJWT(context.Background(), tokenS)
 	if err == nil {
 		t.Errorf("should not have been able to verify a jwt made with pk not assigned to did")
 	}
 
 }
+
+func TestParseJWTEdDSA(t *testing.T) {
+	didService := did.NewService([]did.Resolver{key.NewResolver()})
+	didJWTService := didjwt.NewService(didService)
+
+	pubKey, privKey, _ := ed25519.GenerateKey(rand.Reader)
+	issuer, err := key.DIDFromEd25519PubKey(pubKey)
+	if err != nil {
+		t.Fatalf("error making the did: %v", err)
+	}
+
+	claims := &didjwt.VCClaimsJWT{
+		StandardClaims: jwt.StandardClaims{Issuer: issuer.String()},
+	}
+	tokenS, err := jwt.NewWithClaims(didjwt.SigningMethodEdDSA, claims).SignedString(privKey)
+	if err != nil {
+		t.Fatalf("error creating token string: %v", err)
+	}
+	parsedToken, err := didJWTService.ParseJWT(context.Background(), tokenS)
+	if err != nil || !parsedToken.Valid {
+		t.Errorf("Should have verified the EdDSA token: err: %v", err)
+	}
+
+	_, otherPrivKey, _ := ed25519.GenerateKey(rand.Reader)
+	tokenS, _ = jwt.NewWithClaims(didjwt.SigningMethodEdDSA, claims).SignedString(otherPrivKey)
+	_, err = didJWTService.ParseJWT(context.Background(), tokenS)
+	if err == nil {
+		t.Errorf("Should not have verified a token signed by another key")
+	}
+
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	tokenS, _ = jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(ecKey)
+	_, err = didJWTService.ParseJWT(context.Background(), tokenS)
+	if err == nil {
+		t.Errorf("Should not have verified an ES256 token with an Ed25519 did")
+	}
+}
@@ -7,3 +7,8 @@ type VCClaimsJWT struct {
 	Data string `json:"data"`
 	jwt.StandardClaims
 }
+
+// GetIssuer returns the did that issued the jwt
+func (c *VCClaimsJWT) GetIssuer() string {
+	return c.Issuer
+}
@@ -0,0 +1,59 @@
+package domainlinkage
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/utils"
+)
+
+const (
+	defaultFetchTimeout = 10 * time.Second
+	// maxConfigurationSize is the most bytes read from a did configuration
+	maxConfigurationSize = 1 << 20
+)
+
+// Fetcher fetches the did configuration of a remote origin
+type Fetcher interface {
+	Fetch(origin string) (*Configuration, error)
+}
+
+// HTTPFetcher fetches did configurations from the well known path of the origin
+type HTTPFetcher struct {
+	client *http.Client
+}
+
+// NewHTTPFetcher returns a new HTTPFetcher that only connects to public addresses
+func NewHTTPFetcher() *HTTPFetcher {
+	return NewHTTPFetcherWithClient(utils.NewPublicHTTPClient(defaultFetchTimeout))
+}
+
+// NewHTTPFetcherWithClient returns a new HTTPFetcher that fetches with client
+func NewHTTPFetcherWithClient(client *http.Client) *HTTPFetcher {
+	return &HTTPFetcher{
+		client: client,
+	}
+}
+
+// Fetch returns the did configuration served by origin
+func (f *HTTPFetcher) Fetch(origin string) (*Configuration, error) {
+	resp, err := f.client.Get(origin + WellKnownPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch.get")
+	}
+	defer resp.Body.Close() // nolint: errcheck
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("did configuration returned status %v", resp.StatusCode)
+	}
+
+	config := &Configuration{}
+	err = json.NewDecoder(io.LimitReader(resp.Body, maxConfigurationSize)).Decode(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch.decode")
+	}
+	return config, nil
+}
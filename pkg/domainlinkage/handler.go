@@ -0,0 +1,42 @@
+package domainlinkage
+
+import (
+	"encoding/json"
+	"net/http"
+
+	log "github.com/golang/glog"
+)
+
+// Handler serves the did configuration of the hosted origins
+type Handler struct {
+	service *Service
+}
+
+// NewHandler returns a new domain linkage Handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// GetConfigurationHandler serves GET /.well-known/did-configuration.json for
+// the origin of the request host
+func (h *Handler) GetConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	origin, ok := h.service.HostedOrigin(r.Host)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	config, err := h.service.GetConfiguration(origin)
+	if err != nil {
+		log.Errorf("Error getting the did configuration for %v: err: %v", origin, err)
+		http.Error(w, "unable to get the did configuration", http.StatusInternalServerError)
+		return
+	}
+	js, err := json.Marshal(config)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(js)
+}
@@ -0,0 +1,111 @@
+package domainlinkage
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/claimtypes"
+)
+
+const (
+	// WellKnownPath is the path of the did configuration of a domain
+	WellKnownPath = "/.well-known/did-configuration.json"
+	// ConfigurationContext is the context of the did configuration and the
+	// domain linkage credentials
+	ConfigurationContext = "https://identity.foundation/.well-known/did-configuration/v1"
+	// CredentialContext is the context of verifiable credentials
+	CredentialContext = "https://www.w3.org/2018/credentials/v1"
+	// DomainLinkageCredentialType is the type of a domain linkage credential
+	DomainLinkageCredentialType = "DomainLinkageCredential"
+)
+
+// Configuration is the DIF well known did configuration served by a domain
+type Configuration struct {
+	Context    string   `json:"@context"`
+	LinkedDIDs []string `json:"linked_dids"`
+}
+
+// CredentialSubject is the subject of a domain linkage credential
+type CredentialSubject struct {
+	ID     string `json:"id"`
+	Origin string `json:"origin"`
+}
+
+// Credential is the vc of a domain linkage credential jwt
+type Credential struct {
+	Context           []string          `json:"@context"`
+	Type              []string          `json:"type"`
+	CredentialSubject CredentialSubject `json:"credentialSubject"`
+}
+
+// Claims are the claims of a domain linkage credential jwt
+type Claims struct {
+	VC Credential `json:"vc"`
+	jwt.StandardClaims
+}
+
+// GetIssuer returns the did that issued the credential
+func (c *Claims) GetIssuer() string {
+	return c.Issuer
+}
+
+// NewClaims returns the claims of a domain linkage credential linking did to origin
+func NewClaims(did string, origin string, issuedAt int64, expiresAt int64) *Claims {
+	return &Claims{
+		VC: Credential{
+			Context: []string{CredentialContext, ConfigurationContext},
+			Type: []string{
+				string(claimtypes.VerifiableCredentialType),
+				DomainLinkageCredentialType,
+			},
+			CredentialSubject: CredentialSubject{
+				ID:     did,
+				Origin: origin,
+			},
+		},
+		StandardClaims: jwt.StandardClaims{
+			Issuer:    did,
+			Subject:   did,
+			IssuedAt:  issuedAt,
+			NotBefore: issuedAt,
+			ExpiresAt: expiresAt,
+		},
+	}
+}
+
+// Linkage is the GORM model for a domain linkage credential. Hosted linkages
+// are for domains hosted by the hub and are served in their did configuration,
+// the others were verified from the did configuration of a remote domain.
+type Linkage struct {
+	gorm.Model
+	DID       string `gorm:"column:did;index"`
+	Origin    string `gorm:"index"`
+	JWT       string `gorm:"column:jwt"`
+	Hosted    bool
+	ExpiresAt int64
+}
+
+// TableName sets the table name for domain linkages
+func (Linkage) TableName() string {
+	return "domain_linkages"
+}
+
+// NormalizeOrigin returns the scheme and host of an origin or domain. Domains
+// without a scheme are https, and only https origins are allowed.
+func NormalizeOrigin(origin string) (string, error) {
+	if !strings.Contains(origin, "://") {
+		origin = "https://" + origin
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return "", errors.Wrap(err, "normalizeorigin.parse")
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return "", errors.Errorf("invalid origin: %v", origin)
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host), nil
+}
@@ -0,0 +1,104 @@
+package domainlinkage
+
+import (
+	"sync"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Persister stores domain linkages
+type Persister interface {
+	// SaveLinkage saves a linkage, replacing the linkage of the did to the origin
+	// with the same hosted value
+	SaveLinkage(linkage *Linkage) error
+	// GetLinkagesForDID returns the linkages of a did
+	GetLinkagesForDID(did string) ([]*Linkage, error)
+	// GetHostedLinkages returns the hosted linkages for an origin
+	GetHostedLinkages(origin string) ([]*Linkage, error)
+}
+
+// PGPersister stores domain linkages in postgres
+type PGPersister struct {
+	db *gorm.DB
+}
+
+// NewPGPersister returns a new PGPersister
+func NewPGPersister(db *gorm.DB) *PGPersister {
+	return &PGPersister{db: db}
+}
+
+// SaveLinkage saves a linkage
+func (p *PGPersister) SaveLinkage(linkage *Linkage) error {
+	tx := p.db.Begin()
+	err := tx.Unscoped().Where("did = ? AND origin = ? AND hosted = ?",
+		linkage.DID, linkage.Origin, linkage.Hosted).Delete(&Linkage{}).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Create(linkage).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// GetLinkagesForDID returns the linkages of a did
+func (p *PGPersister) GetLinkagesForDID(did string) ([]*Linkage, error) {
+	linkages := []*Linkage{}
+	err := p.db.Where("did = ?", did).Order("origin").Find(&linkages).Error
+	return linkages, err
+}
+
+// GetHostedLinkages returns the hosted linkages for an origin
+func (p *PGPersister) GetHostedLinkages(origin string) ([]*Linkage, error) {
+	linkages := []*Linkage{}
+	err := p.db.Where("origin = ? AND hosted = ?", origin, true).Order("did").Find(&linkages).Error
+	return linkages, err
+}
+
+// InMemoryPersister stores domain linkages in memory, mainly used for testing
+type InMemoryPersister struct {
+	mutex    sync.Mutex
+	linkages []*Linkage
+}
+
+// SaveLinkage saves a linkage
+func (p *InMemoryPersister) SaveLinkage(linkage *Linkage) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for i, l := range p.linkages {
+		if l.DID == linkage.DID && l.Origin == linkage.Origin && l.Hosted == linkage.Hosted {
+			p.linkages[i] = linkage
+			return nil
+		}
+	}
+	p.linkages = append(p.linkages, linkage)
+	return nil
+}
+
+// GetLinkagesForDID returns the linkages of a did
+func (p *InMemoryPersister) GetLinkagesForDID(did string) ([]*Linkage, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	linkages := []*Linkage{}
+	for _, l := range p.linkages {
+		if l.DID == did {
+			linkages = append(linkages, l)
+		}
+	}
+	return linkages, nil
+}
+
+// GetHostedLinkages returns the hosted linkages for an origin
+func (p *InMemoryPersister) GetHostedLinkages(origin string) ([]*Linkage, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	linkages := []*Linkage{}
+	for _, l := range p.linkages {
+		if l.Origin == origin && l.Hosted {
+			linkages = append(linkages, l)
+		}
+	}
+	return linkages, nil
+}
@@ -0,0 +1,280 @@
+package domainlinkage
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	log "github.com/golang/glog"
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/didjwt"
+)
+
+var (
+	// ErrOriginNotHosted is returned when a hosted linkage is for an origin the
+	// hub does not host
+	ErrOriginNotHosted = errors.New("the origin is not hosted by the hub")
+	// ErrInvalidCredential is returned when a domain linkage credential is not
+	// valid for the did and origin
+	ErrInvalidCredential = errors.New("invalid domain linkage credential")
+	// ErrNoLinkage is returned when the did configuration of an origin has no
+	// valid credential for a did
+	ErrNoLinkage = errors.New("the origin has no valid domain linkage credential for the did")
+)
+
+// Signer signs domain linkage credentials, it is implemented by hub.Identity
+type Signer interface {
+	// KeyID returns the did url of the signing key
+	KeyID() string
+	SignJWT(claims jwt.Claims) (string, error)
+}
+
+// Service issues, stores and verifies domain linkage credentials
+type Service struct {
+	persister     Persister
+	jwtService    *didjwt.Service
+	fetcher       Fetcher
+	hostedOrigins []string
+}
+
+// NewService returns a new domain linkage Service for the hosted origins
+func NewService(persister Persister, jwtService *didjwt.Service, fetcher Fetcher,
+	hostedOrigins []string) (*Service, error) {
+	origins := make([]string, len(hostedOrigins))
+	for i, origin := range hostedOrigins {
+		normalized, err := NormalizeOrigin(origin)
+		if err != nil {
+			return nil, errors.Wrap(err, "newservice.normalizeorigin")
+		}
+		origins[i] = normalized
+	}
+	return &Service{
+		persister:     persister,
+		jwtService:    jwtService,
+		fetcher:       fetcher,
+		hostedOrigins: origins,
+	}, nil
+}
+
+// HostedOrigin returns the hosted origin for a host, false if the hub does not
+// host it
+func (s *Service) HostedOrigin(host string) (string, bool) {
+	host = strings.ToLower(host)
+	for _, origin := range s.hostedOrigins {
+		if origin[strings.Index(origin, "://")+3:] == host {
+			return origin, true
+		}
+	}
+	return "", false
+}
+
+func (s *Service) isHosted(origin string) bool {
+	for _, hosted := range s.hostedOrigins {
+		if hosted == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// Issue issues and stores a domain linkage credential signed by signer that
+// links its did to a hosted origin
+func (s *Service) Issue(ctx context.Context, signer Signer, origin string, validFor time.Duration) (*Linkage, error) {
+	origin, err := NormalizeOrigin(origin)
+	if err != nil {
+		return nil, err
+	}
+	if !s.isHosted(origin) {
+		return nil, ErrOriginNotHosted
+	}
+	signerDID, err := did.MethodIDOnlyFromString(signer.KeyID())
+	if err != nil {
+		return nil, errors.Wrap(err, "issue.methodidonlyfromstring")
+	}
+
+	now := time.Now()
+	token, err := signer.SignJWT(NewClaims(signerDID, origin, now.Unix(), now.Add(validFor).Unix()))
+	if err != nil {
+		return nil, errors.Wrap(err, "issue.signjwt")
+	}
+	return s.SaveCredential(ctx, token, nil)
+}
+
+// EnsureIssued issues a credential linking the did of signer to every hosted
+// origin that has no unexpired one
+func (s *Service) EnsureIssued(ctx context.Context, signer Signer, validFor time.Duration) error {
+	signerDID, err := did.MethodIDOnlyFromString(signer.KeyID())
+	if err != nil {
+		return errors.Wrap(err, "ensureissued.methodidonlyfromstring")
+	}
+	linkages, err := s.persister.GetLinkagesForDID(signerDID)
+	if err != nil {
+		return errors.Wrap(err, "ensureissued.getlinkagesfordid")
+	}
+	now := time.Now().Unix()
+	linked := map[string]bool{}
+	for _, linkage := range linkages {
+		if linkage.Hosted && linkage.ExpiresAt > now {
+			linked[linkage.Origin] = true
+		}
+	}
+	for _, origin := range s.hostedOrigins {
+		if linked[origin] {
+			continue
+		}
+		_, err = s.Issue(ctx, signer, origin, validFor)
+		if err != nil {
+			return errors.Wrapf(err, "ensureissued.issue: origin: %v", origin)
+		}
+		log.Infof("Issued domain linkage credential for %v to %v", signerDID, origin)
+	}
+	return nil
+}
+
+// SaveCredential verifies and stores a domain linkage credential jwt for a
+// hosted origin so it is served in the did configuration of the origin. If
+// sender is not nil it has to be the did the credential links.
+func (s *Service) SaveCredential(ctx context.Context, token string, sender *didlib.DID) (*Linkage, error) {
+	claims, err := s.VerifyCredential(ctx, token, "")
+	if err != nil {
+		return nil, err
+	}
+	if sender != nil && did.MethodIDOnly(sender) != claims.Issuer {
+		return nil, ErrInvalidCredential
+	}
+	if !s.isHosted(claims.VC.CredentialSubject.Origin) {
+		return nil, ErrOriginNotHosted
+	}
+
+	linkage := &Linkage{
+		DID:       claims.Issuer,
+		Origin:    claims.VC.CredentialSubject.Origin,
+		JWT:       token,
+		Hosted:    true,
+		ExpiresAt: claims.ExpiresAt,
+	}
+	err = s.persister.SaveLinkage(linkage)
+	if err != nil {
+		return nil, errors.Wrap(err, "savecredential.savelinkage")
+	}
+	return linkage, nil
+}
+
+// VerifyCredential checks a domain linkage credential jwt is signed by the did
+// it links, is current and, if origin is not empty, links to origin
+func (s *Service) VerifyCredential(ctx context.Context, token string, origin string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := s.jwtService.ParseJWTWithClaims(ctx, token, claims)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidCredential, err.Error())
+	}
+
+	if claims.ExpiresAt == 0 || claims.Issuer == "" || claims.Subject != claims.Issuer ||
+		claims.VC.CredentialSubject.ID != claims.Issuer {
+		return nil, ErrInvalidCredential
+	}
+	issuer, err := did.MethodIDOnlyFromString(claims.Issuer)
+	if err != nil || issuer != claims.Issuer {
+		return nil, ErrInvalidCredential
+	}
+	isLinkage := false
+	for _, t := range claims.VC.Type {
+		if t == DomainLinkageCredentialType {
+			isLinkage = true
+		}
+	}
+	if !isLinkage {
+		return nil, ErrInvalidCredential
+	}
+	credOrigin, err := NormalizeOrigin(claims.VC.CredentialSubject.Origin)
+	if err != nil || credOrigin != claims.VC.CredentialSubject.Origin {
+		return nil, ErrInvalidCredential
+	}
+	if origin != "" && credOrigin != origin {
+		return nil, ErrInvalidCredential
+	}
+	return claims, nil
+}
+
+// GetConfiguration returns the did configuration of a hosted origin with the
+// unexpired credentials linked to it
+func (s *Service) GetConfiguration(origin string) (*Configuration, error) {
+	if !s.isHosted(origin) {
+		return nil, ErrOriginNotHosted
+	}
+	linkages, err := s.persister.GetHostedLinkages(origin)
+	if err != nil {
+		return nil, errors.Wrap(err, "getconfiguration.gethostedlinkages")
+	}
+	config := &Configuration{
+		Context:    ConfigurationContext,
+		LinkedDIDs: []string{},
+	}
+	now := time.Now().Unix()
+	for _, linkage := range linkages {
+		if linkage.ExpiresAt > now {
+			config.LinkedDIDs = append(config.LinkedDIDs, linkage.JWT)
+		}
+	}
+	return config, nil
+}
+
+// VerifyDomain fetches the did configuration of a remote origin and stores the
+// linkage if it has a valid credential for the did
+func (s *Service) VerifyDomain(ctx context.Context, userDid *didlib.DID, origin string) (*Linkage, error) {
+	origin, err := NormalizeOrigin(origin)
+	if err != nil {
+		return nil, err
+	}
+	config, err := s.fetcher.Fetch(origin)
+	if err != nil {
+		return nil, errors.Wrap(err, "verifydomain.fetch")
+	}
+
+	didString := did.MethodIDOnly(userDid)
+	for _, token := range config.LinkedDIDs {
+		claims, err := s.VerifyCredential(ctx, token, origin)
+		if err != nil {
+			log.Infof("Skipping domain linkage credential from %v: err: %v", origin, err)
+			continue
+		}
+		if claims.Issuer != didString {
+			continue
+		}
+
+		linkage := &Linkage{
+			DID:       didString,
+			Origin:    origin,
+			JWT:       token,
+			ExpiresAt: claims.ExpiresAt,
+		}
+		err = s.persister.SaveLinkage(linkage)
+		if err != nil {
+			return nil, errors.Wrap(err, "verifydomain.savelinkage")
+		}
+		return linkage, nil
+	}
+	return nil, ErrNoLinkage
+}
+
+// GetLinkedDomains returns the origins with an unexpired linkage to a did
+func (s *Service) GetLinkedDomains(userDid *didlib.DID) ([]string, error) {
+	linkages, err := s.persister.GetLinkagesForDID(did.MethodIDOnly(userDid))
+	if err != nil {
+		return nil, errors.Wrap(err, "getlinkeddomains.getlinkagesfordid")
+	}
+	now := time.Now().Unix()
+	seen := map[string]bool{}
+	origins := []string{}
+	for _, linkage := range linkages {
+		if linkage.ExpiresAt > now && !seen[linkage.Origin] {
+			seen[linkage.Origin] = true
+			origins = append(origins, linkage.Origin)
+		}
+	}
+	return origins, nil
+}
@@ -0,0 +1,291 @@
+package domainlinkage_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/joincivil/go-common/pkg/lock"
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/did/ethuri"
+	"github.com/joincivil/id-hub/pkg/didjwt"
+	"github.com/joincivil/id-hub/pkg/domainlinkage"
+	"github.com/joincivil/id-hub/pkg/hub"
+	"github.com/joincivil/id-hub/pkg/utils"
+)
+
+type memoryIdentityPersister struct {
+	identity *hub.StoredIdentity
+}
+
+func (p *memoryIdentityPersister) GetIdentity() (*hub.StoredIdentity, error) {
+	if p.identity == nil {
+		return nil, hub.ErrNoHubIdentity
+	}
+	return p.identity, nil
+}
+
+func (p *memoryIdentityPersister) SaveIdentity(identity *hub.StoredIdentity) error {
+	p.identity = identity
+	return nil
+}
+
+type testFetcher struct {
+	configs map[string]*domainlinkage.Configuration
+}
+
+func (f *testFetcher) Fetch(origin string) (*domainlinkage.Configuration, error) {
+	config, ok := f.configs[origin]
+	if !ok {
+		return nil, domainlinkage.ErrNoLinkage
+	}
+	return config, nil
+}
+
+func newTestIdentity(t *testing.T, didPersister ethuri.Persister) *hub.Identity {
+	privKey, _ := crypto.GenerateKey()
+	identity, err := hub.LoadOrCreateIdentity(&memoryIdentityPersister{}, didPersister, privKey, lock.NewLocalDLock())
+	if err != nil {
+		t.Fatalf("Should have created the identity: err: %v", err)
+	}
+	return identity
+}
+
+func newTestJWTService(didPersister ethuri.Persister) *didjwt.Service {
+	didService := did.NewService([]did.Resolver{ethuri.NewService(didPersister)})
+	return didjwt.NewService(didService)
+}
+
+func TestIssueAndServeConfiguration(t *testing.T) {
+	didPersister := &ethuri.InMemoryPersister{}
+	identity := newTestIdentity(t, didPersister)
+	service, err := domainlinkage.NewService(&domainlinkage.InMemoryPersister{},
+		newTestJWTService(didPersister), &testFetcher{}, []string{"hub.example.com"})
+	if err != nil {
+		t.Fatalf("Should have created the service: err: %v", err)
+	}
+
+	_, err = service.Issue(context.Background(), identity, "other.example.com", time.Hour)
+	if err != domainlinkage.ErrOriginNotHosted {
+		t.Errorf("Should not have issued for a domain the hub does not host: err: %v", err)
+	}
+
+	err = service.EnsureIssued(context.Background(), identity, time.Hour)
+	if err != nil {
+		t.Fatalf("Should have issued the hosted credentials: err: %v", err)
+	}
+	domains, err := service.GetLinkedDomains(identity.DID)
+	if err != nil || len(domains) != 1 || domains[0] != "https://hub.example.com" {
+		t.Errorf("Should have linked the hosted domain: %v, err: %v", domains, err)
+	}
+
+	handler := domainlinkage.NewHandler(service)
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "http://hub.example.com"+domainlinkage.WellKnownPath, nil)
+	handler.GetConfigurationHandler(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Should have served the configuration: %v", rec.Code)
+	}
+	config := &domainlinkage.Configuration{}
+	err = json.Unmarshal(rec.Body.Bytes(), config)
+	if err != nil {
+		t.Fatalf("Should have returned json: err: %v", err)
+	}
+	if config.Context != domainlinkage.ConfigurationContext || len(config.LinkedDIDs) != 1 {
+		t.Fatalf("Should have served the credential: %+v", config)
+	}
+	claims, err := service.VerifyCredential(context.Background(), config.LinkedDIDs[0], "https://hub.example.com")
+	if err != nil {
+		t.Fatalf("Should have verified the served credential: err: %v", err)
+	}
+	if claims.Issuer != identity.DID.String() {
+		t.Errorf("Should have been issued by the hub did: %v", claims.Issuer)
+	}
+	_, err = service.VerifyCredential(context.Background(), config.LinkedDIDs[0], "https://other.example.com")
+	if err != domainlinkage.ErrInvalidCredential {
+		t.Errorf("Should not have verified the credential for another origin: err: %v", err)
+	}
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "http://other.example.com"+domainlinkage.WellKnownPath, nil)
+	handler.GetConfigurationHandler(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Should not have served a configuration for another host: %v", rec.Code)
+	}
+}
+
+func TestSaveCredential(t *testing.T) {
+	didPersister := &ethuri.InMemoryPersister{}
+	identity := newTestIdentity(t, didPersister)
+	other := newTestIdentity(t, didPersister)
+	service, err := domainlinkage.NewService(&domainlinkage.InMemoryPersister{},
+		newTestJWTService(didPersister), &testFetcher{}, []string{"https://hub.example.com"})
+	if err != nil {
+		t.Fatalf("Should have created the service: err: %v", err)
+	}
+
+	now := time.Now().Unix()
+	token, err := identity.SignJWT(domainlinkage.NewClaims(identity.DID.String(),
+		"https://hub.example.com", now, now+3600))
+	if err != nil {
+		t.Fatalf("Should have signed the credential: err: %v", err)
+	}
+	_, err = service.SaveCredential(context.Background(), token, other.DID)
+	if err != domainlinkage.ErrInvalidCredential {
+		t.Errorf("Should not have saved a credential for another did: err: %v", err)
+	}
+	linkage, err := service.SaveCredential(context.Background(), token, identity.DID)
+	if err != nil {
+		t.Fatalf("Should have saved the credential: err: %v", err)
+	}
+	if !linkage.Hosted || linkage.Origin != "https://hub.example.com" {
+		t.Errorf("Should have saved a hosted linkage: %+v", linkage)
+	}
+
+	// Signed by another did
+	forged, _ := other.SignJWT(domainlinkage.NewClaims(identity.DID.String(),
+		"https://hub.example.com", now, now+3600))
+	_, err = service.SaveCredential(context.Background(), forged, nil)
+	if err == nil || !strings.Contains(err.Error(), domainlinkage.ErrInvalidCredential.Error()) {
+		t.Errorf("Should not have saved a credential signed by another did: err: %v", err)
+	}
+
+	expired, _ := identity.SignJWT(domainlinkage.NewClaims(identity.DID.String(),
+		"https://hub.example.com", now-7200, now-3600))
+	_, err = service.SaveCredential(context.Background(), expired, nil)
+	if err == nil {
+		t.Errorf("Should not have saved an expired credential")
+	}
+}
+
+type stubFetcher struct {
+	configuration func(origin string) *domainlinkage.Configuration
+}
+
+func (f *stubFetcher) Fetch(origin string) (*domainlinkage.Configuration, error) {
+	configuration := f.configuration(origin)
+	if configuration == nil {
+		return nil, errors.Errorf("no configuration for %v", origin)
+	}
+	return configuration, nil
+}
+
+func TestVerifyDomain(t *testing.T) {
+	didPersister := &ethuri.InMemoryPersister{}
+	identity := newTestIdentity(t, didPersister)
+	other := newTestIdentity(t, didPersister)
+
+	remote := "https://remote.example.com"
+	fetcher := &stubFetcher{configuration: func(origin string) *domainlinkage.Configuration {
+		if origin != remote {
+			return nil
+		}
+		now := time.Now().Unix()
+		token, _ := identity.SignJWT(domainlinkage.NewClaims(identity.DID.String(), origin, now, now+3600))
+		return &domainlinkage.Configuration{
+			Context:    domainlinkage.ConfigurationContext,
+			LinkedDIDs: []string{"not a jwt", token},
+		}
+	}}
+
+	service, err := domainlinkage.NewService(&domainlinkage.InMemoryPersister{},
+		newTestJWTService(didPersister), fetcher, nil)
+	if err != nil {
+		t.Fatalf("Should have created the service: err: %v", err)
+	}
+
+	linkage, err := service.VerifyDomain(context.Background(), identity.DID, remote)
+	if err != nil {
+		t.Fatalf("Should have verified the domain: err: %v", err)
+	}
+	if linkage.Hosted || linkage.Origin != remote {
+		t.Errorf("Should have saved a remote linkage: %+v", linkage)
+	}
+	domains, err := service.GetLinkedDomains(identity.DID)
+	if err != nil || len(domains) != 1 || domains[0] != remote {
+		t.Errorf("Should have linked the remote domain: %v, err: %v", domains, err)
+	}
+
+	_, err = service.VerifyDomain(context.Background(), other.DID, remote)
+	if err != domainlinkage.ErrNoLinkage {
+		t.Errorf("Should not have verified the domain for another did: err: %v", err)
+	}
+	_, err = service.VerifyDomain(context.Background(), identity.DID, "https://unknown.example.com")
+	if err == nil || err == domainlinkage.ErrNoLinkage {
+		t.Errorf("Should have failed to fetch from an unknown origin: err: %v", err)
+	}
+	_, err = service.VerifyDomain(context.Background(), identity.DID, "http://remote.example.com")
+	if err == nil {
+		t.Errorf("Should not have verified a non https origin")
+	}
+}
+
+func TestHTTPFetcherNonPublicAddress(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	_, err := domainlinkage.NewHTTPFetcher().Fetch(server.URL)
+	if err == nil || !strings.Contains(err.Error(), utils.ErrNonPublicAddress.Error()) {
+		t.Errorf("Should not have fetched from a loopback address: err: %v", err)
+	}
+}
+
+func TestHTTPFetcher(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case domainlinkage.WellKnownPath:
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(&domainlinkage.Configuration{
+				Context:    domainlinkage.ConfigurationContext,
+				LinkedDIDs: []string{"token"},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	fetcher := domainlinkage.NewHTTPFetcherWithClient(server.Client())
+	config, err := fetcher.Fetch(server.URL)
+	if err != nil {
+		t.Fatalf("Should have fetched the did configuration: err: %v", err)
+	}
+	if config.Context != domainlinkage.ConfigurationContext || len(config.LinkedDIDs) != 1 ||
+		config.LinkedDIDs[0] != "token" {
+		t.Errorf("Should have decoded the did configuration: %+v", config)
+	}
+
+	_, err = fetcher.Fetch(server.URL + "/missing")
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Should have failed on a non 200 status: err: %v", err)
+	}
+}
+
+func TestNormalizeOrigin(t *testing.T) {
+	tests := map[string]string{
+		"example.com":             "https://example.com",
+		"https://Example.com/foo": "https://example.com",
+		"https://localhost:8443":  "https://localhost:8443",
+	}
+	for in, expected := range tests {
+		origin, err := domainlinkage.NormalizeOrigin(in)
+		if err != nil || origin != expected {
+			t.Errorf("Should have normalized %v to %v: %v, err: %v", in, expected, origin, err)
+		}
+	}
+	for _, in := range []string{"ftp://example.com", "http://example.com"} {
+		_, err := domainlinkage.NormalizeOrigin(in)
+		if err == nil {
+			t.Errorf("Should not have normalized a non https origin: %v", in)
+		}
+	}
+}
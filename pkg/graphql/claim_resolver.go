@@ -128,12 +128,13 @@ func (r *mutationResolver) ClaimSave(ctx context.Context, in *ClaimSaveRequestIn
 		return nil, errors.Wrap(err, "error parsing issuer did")
 	}
 
-	err = r.ClaimService.CreateTreeForDID(issuerDID)
+	claimService := r.ClaimService.WithSender(fcd.Did)
+	err = claimService.CreateTreeForDID(ctx, issuerDID)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to create tree for did if not exists")
 	}
 
-	err = r.ClaimService.ClaimContent(cc)
+	err = claimService.ClaimContent(ctx, cc)
 	if err != nil {
 		return nil, errors.Wrap(err, "error calling claimcontent")
 	}
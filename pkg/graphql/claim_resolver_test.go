@@ -67,7 +67,7 @@ func initPersister(t *testing.T) (ethuri.Persister, *gorm.DB) {
 		return nil, nil
 	}
 
-	err = db.AutoMigrate(&ethuri.PostgresDocument{}).Error
+	err = db.AutoMigrate(&ethuri.PostgresDocument{}, &ethuri.PostgresDocumentVersion{}, &ethuri.PostgresDocumentIndex{}).Error
 	if err != nil {
 		t.Fatalf("Should have auto-migrated")
 		return nil, nil
@@ -91,9 +91,13 @@ func makeService(db *gorm.DB, didService *did.Service, signedClaimStore *claimss
 	dlock := lock.NewLocalDLock()
 
 	committer := &claims.FakeRootCommitter{CurrentBlockNumber: big.NewInt(2)}
-	rootService, _ := claims.NewRootService(treeStore, committer, rootCommitStore)
+	signerKey, _ := crypto.GenerateKey()
+	rootService, _ := claims.NewRootService(treeStore, committer, rootCommitStore, claims.NewTreeHeadSigner(signerKey))
 
-	claimService, err := claims.NewService(treeStore, signedClaimStore, didService, rootService, dlock)
+	revocationStore := claimsstore.NewRevocationPGPersister(db)
+
+	claimService, err := claims.NewService(treeStore, nodepersister, signedClaimStore, revocationStore,
+		didService, rootService, dlock)
 	didJWTService := didjwt.NewService(didService)
 	jwtStore := claimsstore.NewJWTClaimPGPersister(db, didJWTService)
 	jwtService := claims.NewJWTService(didJWTService, jwtStore, claimService, &testutils.FakePubSubService{})
@@ -112,7 +116,7 @@ func createDID(service *ethuri.Service, claimerDid *didlib.DID) error {
 	if err != nil {
 		return err
 	}
-	return service.SaveDocument(doc)
+	return service.SaveDocument(doc, 0)
 }
 
 func setupResolver(t *testing.T) (*graphql.Resolver, *claims.RootService, *ethuri.Service, error) {
@@ -120,11 +124,11 @@ func setupResolver(t *testing.T) (*graphql.Resolver, *claims.RootService, *ethur
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	db.DropTable(&ethuri.PostgresDocument{}, &claimsstore.RootCommit{},
+	db.DropTable(&ethuri.PostgresDocument{}, &ethuri.PostgresDocumentVersion{}, &ethuri.PostgresDocumentIndex{}, &claimsstore.RootCommit{},
 		&claimsstore.Node{}, &claimsstore.SignedClaimPostgres{},
-		&claimsstore.JWTClaimPostgres{})
-	err = db.AutoMigrate(&ethuri.PostgresDocument{}, &claimsstore.SignedClaimPostgres{}, &claimsstore.Node{}, &claimsstore.RootCommit{},
-		&claimsstore.JWTClaimPostgres{}).Error
+		&claimsstore.JWTClaimPostgres{}, &claimsstore.Revocation{}, &claimsstore.TreeChange{})
+	err = db.AutoMigrate(&ethuri.PostgresDocument{}, &ethuri.PostgresDocumentVersion{}, &ethuri.PostgresDocumentIndex{}, &claimsstore.SignedClaimPostgres{}, &claimsstore.Node{}, &claimsstore.RootCommit{},
+		&claimsstore.JWTClaimPostgres{}, &claimsstore.Revocation{}, &claimsstore.TreeChange{}).Error
 	if err != nil {
 		return nil, nil, nil, err
 	}
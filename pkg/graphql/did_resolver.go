@@ -40,12 +40,147 @@ func (r *queryResolver) DidGet(ctx context.Context, in *DidGetRequestInput) (
 		return nil, errors.New("did is empty")
 	}
 
-	doc, err := r.DidService.GetDocument(*in.Did)
+	doc, err := r.DidService.GetDocument(ctx, *in.Did)
+	if derr, ok := did.IsDeactivated(err); ok {
+		deactivated := true
+		return &DidGetResponse{
+			Deactivated:   &deactivated,
+			DeactivatedAt: derr.Metadata.Updated,
+		}, nil
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to retrieve did document")
 	}
 
-	return &DidGetResponse{Doc: doc}, nil
+	resp := &DidGetResponse{Doc: doc}
+	if r.EthURIService != nil && r.EthURIService.IsEthURI(doc.ID.String()) {
+		resp.Version, err = r.ethURIVersion(doc)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if r.DomainLinkageService != nil {
+		resp.LinkedDomains, err = r.DomainLinkageService.GetLinkedDomains(&doc.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to retrieve linked domains")
+		}
+	}
+	return resp, nil
+}
+
+// DidSearch returns the ethuri did documents bound to a public key, ethereum
+// address or service endpoint
+func (r *queryResolver) DidSearch(ctx context.Context, in DidSearchInput) (
+	*DidSearchResponse, error) {
+	if r.EthURIService == nil {
+		return nil, errors.New("ethuri documents are not enabled")
+	}
+
+	kind, value, err := ConvertInputDidSearch(&in)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to convert input")
+	}
+
+	docs, err := r.EthURIService.SearchDocuments(kind, value)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to search did documents")
+	}
+	return &DidSearchResponse{Docs: docs}, nil
+}
+
+// DidDereference dereferences a did url to a document, key, service or service
+// endpoint
+func (r *queryResolver) DidDereference(ctx context.Context, in DidDereferenceInput) (
+	*DidDereferenceResponse, error) {
+	result, err := r.DidService.Dereference(ctx, in.DidURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to dereference did url")
+	}
+	return &DidDereferenceResponse{
+		Doc:             result.Document,
+		PublicKey:       result.PublicKey,
+		Service:         result.Service,
+		ServiceEndpoint: result.ServiceEndpoint,
+	}, nil
+}
+
+// Mutations
+
+// DidSave creates or updates an ethuri did document, authorized by the proof
+// signed by an authentication key of the document
+func (r *mutationResolver) DidSave(ctx context.Context, in DidSaveInput) (*DidSaveResponse, error) {
+	if r.EthURIService == nil {
+		return nil, errors.New("ethuri documents are not enabled")
+	}
+	if in.Did != nil && !r.EthURIService.IsEthURI(*in.Did) {
+		return nil, errors.New("only ethuri dids can be saved")
+	}
+
+	params, err := ConvertInputDidSave(&in)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to convert input")
+	}
+
+	doc, err := r.EthURIService.CreateOrUpdateDocument(params)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to save did document")
+	}
+	version, err := r.ethURIVersion(doc)
+	if err != nil {
+		return nil, err
+	}
+	return &DidSaveResponse{Doc: doc, Version: version}, nil
+}
+
+// ethURIVersion returns the current version number of an ethuri document
+func (r *Resolver) ethURIVersion(doc *did.Document) (*int, error) {
+	version, err := r.EthURIService.GetLatestDocumentVersion(&doc.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to retrieve did document version")
+	}
+	number := int(version.Version)
+	return &number, nil
+}
+
+// DidDeactivate deactivates an ethuri did document, authorized by the proof
+// signed by an authentication key of the document, and optionally revokes
+// every registered document in the tree of the did. Claims are revoked before
+// the document is deactivated, so a failed revocation can be retried.
+func (r *mutationResolver) DidDeactivate(ctx context.Context, in DidDeactivateInput) (
+	*DidDeactivateResponse, error) {
+	if r.EthURIService == nil {
+		return nil, errors.New("ethuri documents are not enabled")
+	}
+
+	params, err := ConvertInputDidDeactivate(&in)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to convert input")
+	}
+	if params.RevokeClaims && r.ClaimService == nil {
+		return nil, errors.New("claims are not enabled")
+	}
+
+	revokedClaims := 0
+	if params.RevokeClaims {
+		d, err := r.EthURIService.VerifyDeactivation(params)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to deactivate did document")
+		}
+		revokedClaims, err = r.ClaimService.WithSender(in.Did).RevokeAllRegisteredDocuments(d)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to revoke claims, did document not deactivated")
+		}
+	}
+
+	metadata, err := r.EthURIService.DeactivateDocument(params)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to deactivate did document")
+	}
+	return &DidDeactivateResponse{
+		Did:           in.Did,
+		DeactivatedAt: metadata.Updated,
+		RevokedClaims: revokedClaims,
+	}, nil
 }
 
 // Did Resolvers
@@ -103,3 +238,11 @@ func (r *didDocumentResolver) Controller(ctx context.Context, obj *did.Document)
 	}
 	return nil, nil
 }
+func (r *didDocumentResolver) VerificationMethods(ctx context.Context, obj *did.Document) (
+	[]*did.DocPublicKey, error) {
+	keys := make([]*did.DocPublicKey, len(obj.PublicKeys))
+	for i := range obj.PublicKeys {
+		keys[i] = &obj.PublicKeys[i]
+	}
+	return keys, nil
+}
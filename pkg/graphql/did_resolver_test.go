@@ -0,0 +1,217 @@
+package graphql_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/did/ethuri"
+	"github.com/joincivil/id-hub/pkg/graphql"
+	"github.com/joincivil/id-hub/pkg/linkeddata"
+	"github.com/joincivil/id-hub/pkg/utils"
+)
+
+// signDidSaveInput signs the input like a client would, converting it to the
+// update params and setting the proof of the signed params
+func signDidSaveInput(t *testing.T, in *graphql.DidSaveInput, keyID string, privKey *ecdsa.PrivateKey) {
+	params, err := graphql.ConvertInputDidSave(in)
+	if err != nil {
+		t.Fatalf("error converting input: err: %v", err)
+	}
+	err = ethuri.SignUpdate(params, keyID, privKey)
+	if err != nil {
+		t.Fatalf("error signing update: err: %v", err)
+	}
+	in.Proof = &graphql.LinkedDataProofInput{
+		Type:       &params.Proof.Type,
+		Creator:    &params.Proof.Creator,
+		Created:    &params.Proof.Created,
+		ProofValue: &params.Proof.ProofValue,
+	}
+}
+
+func newPublicKeyInput(t *testing.T) (*ecdsa.PrivateKey, *graphql.DidDocPublicKeyInput) {
+	privKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("error generating key: err: %v", err)
+	}
+	return privKey, &graphql.DidDocPublicKeyInput{
+		Type:         string(linkeddata.SuiteTypeSecp256k1Verification),
+		PublicKeyHex: utils.StrToPtr(hex.EncodeToString(crypto.FromECDSAPub(&privKey.PublicKey))),
+	}
+}
+
+func TestDidSave(t *testing.T) {
+	resolver := &graphql.Resolver{
+		EthURIService: ethuri.NewService(&ethuri.InMemoryPersister{}),
+	}
+	ctx := context.Background()
+
+	privKey, keyInput := newPublicKeyInput(t)
+	in := graphql.DidSaveInput{
+		PublicKeys: []*graphql.DidDocPublicKeyInput{keyInput},
+	}
+	signDidSaveInput(t, &in, "keys-1", privKey)
+	resp, err := resolver.Mutation().DidSave(ctx, in)
+	if err != nil {
+		t.Fatalf("should have created the document: err: %v", err)
+	}
+	doc := resp.Doc
+	if len(doc.PublicKeys) != 1 || len(doc.Authentications) != 1 {
+		t.Fatalf("should have created the document with the key")
+	}
+	if resp.Version == nil || *resp.Version != 1 {
+		t.Fatalf("should have returned the first version")
+	}
+
+	_, newKeyInput := newPublicKeyInput(t)
+	in = graphql.DidSaveInput{
+		Did:        utils.StrToPtr(doc.ID.String()),
+		Version:    resp.Version,
+		PublicKeys: []*graphql.DidDocPublicKeyInput{newKeyInput},
+		Services: []*graphql.DidDocServiceInput{{
+			ID:              doc.ID.String() + "#hub",
+			Type:            "IdentityHub",
+			ServiceEndpoint: utils.AnyValue{Value: "https://hub.example.com"},
+		}},
+	}
+
+	// Signed by a key that is not in the document
+	otherKey, _ := newPublicKeyInput(t)
+	signDidSaveInput(t, &in, doc.Authentications[0].ID.String(), otherKey)
+	_, err = resolver.Mutation().DidSave(ctx, in)
+	if err == nil {
+		t.Errorf("should have rejected an update signed by another key")
+	}
+
+	signDidSaveInput(t, &in, doc.Authentications[0].ID.String(), privKey)
+	resp, err = resolver.Mutation().DidSave(ctx, in)
+	if err != nil {
+		t.Fatalf("should have updated the document: err: %v", err)
+	}
+	if len(resp.Doc.PublicKeys) != 2 || len(resp.Doc.Services) != 1 {
+		t.Errorf("should have added the key and service")
+	}
+	if resp.Version == nil || *resp.Version != 2 {
+		t.Errorf("should have returned the new version")
+	}
+	if resp.DocRaw() == nil {
+		t.Errorf("should have returned the raw document")
+	}
+
+	in.Did = utils.StrToPtr("did:web:example.com")
+	_, err = resolver.Mutation().DidSave(ctx, in)
+	if err == nil {
+		t.Errorf("should only save ethuri dids")
+	}
+}
+
+func TestDidDeactivate(t *testing.T) {
+	ethURIService := ethuri.NewService(&ethuri.InMemoryPersister{})
+	resolver := &graphql.Resolver{
+		DidService:    did.NewService([]did.Resolver{ethURIService}),
+		EthURIService: ethURIService,
+	}
+	ctx := context.Background()
+
+	privKey, keyInput := newPublicKeyInput(t)
+	saveIn := graphql.DidSaveInput{
+		PublicKeys: []*graphql.DidDocPublicKeyInput{keyInput},
+	}
+	signDidSaveInput(t, &saveIn, "keys-1", privKey)
+	saveResp, err := resolver.Mutation().DidSave(ctx, saveIn)
+	if err != nil {
+		t.Fatalf("should have created the document: err: %v", err)
+	}
+	docID := saveResp.Doc.ID.String()
+
+	in := graphql.DidDeactivateInput{Did: docID}
+	params, err := graphql.ConvertInputDidDeactivate(&in)
+	if err != nil {
+		t.Fatalf("error converting input: err: %v", err)
+	}
+	err = ethuri.SignDeactivate(params, saveResp.Doc.Authentications[0].ID.String(), privKey)
+	if err != nil {
+		t.Fatalf("error signing deactivate: err: %v", err)
+	}
+	in.Proof = &graphql.LinkedDataProofInput{
+		Type:       &params.Proof.Type,
+		Creator:    &params.Proof.Creator,
+		Created:    &params.Proof.Created,
+		ProofValue: &params.Proof.ProofValue,
+	}
+
+	// Revoking the claims needs the claims service
+	revokeClaims := true
+	in.RevokeClaims = &revokeClaims
+	_, err = resolver.Mutation().DidDeactivate(ctx, in)
+	if err == nil {
+		t.Errorf("should have failed to revoke claims without a claims service")
+	}
+
+	in.RevokeClaims = nil
+	resp, err := resolver.Mutation().DidDeactivate(ctx, in)
+	if err != nil {
+		t.Fatalf("should have deactivated the document: err: %v", err)
+	}
+	if resp.Did != docID || resp.DeactivatedAt == nil || resp.RevokedClaims != 0 {
+		t.Errorf("wrong deactivate response: %+v", resp)
+	}
+
+	getResp, err := resolver.Query().DidGet(ctx, &graphql.DidGetRequestInput{Did: &docID})
+	if err != nil {
+		t.Fatalf("should have gotten the deactivated did: err: %v", err)
+	}
+	if getResp.Doc != nil || getResp.DocRaw() != nil {
+		t.Errorf("should not have returned a document")
+	}
+	if getResp.Deactivated == nil || !*getResp.Deactivated || getResp.DeactivatedAt == nil {
+		t.Errorf("should have returned the deactivated metadata")
+	}
+}
+
+func TestDidSearch(t *testing.T) {
+	resolver := &graphql.Resolver{
+		EthURIService: ethuri.NewService(&ethuri.InMemoryPersister{}),
+	}
+	ctx := context.Background()
+
+	privKey, keyInput := newPublicKeyInput(t)
+	saveIn := graphql.DidSaveInput{
+		PublicKeys: []*graphql.DidDocPublicKeyInput{keyInput},
+	}
+	signDidSaveInput(t, &saveIn, "keys-1", privKey)
+	saveResp, err := resolver.Mutation().DidSave(ctx, saveIn)
+	if err != nil {
+		t.Fatalf("should have created the document: err: %v", err)
+	}
+
+	address := crypto.PubkeyToAddress(privKey.PublicKey).Hex()
+	resp, err := resolver.Query().DidSearch(ctx, graphql.DidSearchInput{EthereumAddress: &address})
+	if err != nil {
+		t.Fatalf("should have searched by address: err: %v", err)
+	}
+	if len(resp.Docs) != 1 || resp.Docs[0].ID.String() != saveResp.Doc.ID.String() {
+		t.Errorf("should have found the document by address")
+	}
+	resp, err = resolver.Query().DidSearch(ctx, graphql.DidSearchInput{PublicKeyHex: keyInput.PublicKeyHex})
+	if err != nil || len(resp.Docs) != 1 {
+		t.Errorf("should have found the document by public key: err: %v", err)
+	}
+
+	_, err = resolver.Query().DidSearch(ctx, graphql.DidSearchInput{})
+	if err == nil {
+		t.Errorf("should have required a search value")
+	}
+	_, err = resolver.Query().DidSearch(ctx, graphql.DidSearchInput{
+		EthereumAddress: &address,
+		PublicKeyHex:    keyInput.PublicKeyHex,
+	})
+	if err == nil {
+		t.Errorf("should have required only one search value")
+	}
+}
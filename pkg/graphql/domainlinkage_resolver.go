@@ -0,0 +1,74 @@
+package graphql
+
+import (
+	"context"
+	"time"
+
+	log "github.com/golang/glog"
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/auth"
+	"github.com/joincivil/id-hub/pkg/domainlinkage"
+)
+
+// DomainLinkage returns the resolver for domain linkages
+func (r *Resolver) DomainLinkage() DomainLinkageResolver {
+	return &domainLinkageResolver{r}
+}
+
+// Mutations
+
+// DomainLinkageSave saves a domain linkage credential signed by the sender did
+func (r *mutationResolver) DomainLinkageSave(ctx context.Context,
+	in DomainLinkageSaveInput) (*domainlinkage.Linkage, error) {
+	// Auth needed here, DID owner only
+	fcd, authErr := auth.ForContext(ctx, r.DidService, nil)
+	if authErr != nil {
+		log.Infof("Access denied err: %v", authErr)
+		return nil, ErrAccessDenied
+	}
+	if r.DomainLinkageService == nil {
+		return nil, errors.New("domain linkage is not enabled")
+	}
+
+	senderDID, err := didlib.Parse(fcd.Did)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't parse sender did")
+	}
+	return r.DomainLinkageService.SaveCredential(ctx, in.Jwt, senderDID)
+}
+
+// DomainLinkageVerify verifies the did configuration of a remote domain links to a did
+func (r *mutationResolver) DomainLinkageVerify(ctx context.Context,
+	in DomainLinkageVerifyInput) (*domainlinkage.Linkage, error) {
+	// Auth needed here, DID owner only
+	fcd, authErr := auth.ForContext(ctx, r.DidService, nil)
+	if authErr != nil {
+		log.Infof("Access denied err: %v", authErr)
+		return nil, ErrAccessDenied
+	}
+	if r.DomainLinkageService == nil {
+		return nil, errors.New("domain linkage is not enabled")
+	}
+	userDID, err := didlib.Parse(in.Did)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't parse did")
+	}
+	if userDID.String() != fcd.Did {
+		log.Infof("Access denied, %v can not verify domains for %v", fcd.Did, in.Did)
+		return nil, ErrAccessDenied
+	}
+	return r.DomainLinkageService.VerifyDomain(ctx, userDID, in.Origin)
+}
+
+type domainLinkageResolver struct{ *Resolver }
+
+// Expires resolves the expiry of the credential to a time
+func (r *domainLinkageResolver) Expires(ctx context.Context, obj *domainlinkage.Linkage) (*time.Time, error) {
+	if obj.ExpiresAt == 0 {
+		return nil, nil
+	}
+	expires := time.Unix(obj.ExpiresAt, 0).UTC()
+	return &expires, nil
+}
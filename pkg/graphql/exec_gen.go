@@ -15,9 +15,11 @@ import (
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/introspection"
 	"github.com/joincivil/go-common/pkg/article"
+	"github.com/joincivil/id-hub/pkg/claims"
 	"github.com/joincivil/id-hub/pkg/claimsstore"
 	"github.com/joincivil/id-hub/pkg/claimtypes"
 	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/domainlinkage"
 	"github.com/joincivil/id-hub/pkg/linkeddata"
 	"github.com/joincivil/id-hub/pkg/utils"
 	"github.com/vektah/gqlparser"
@@ -48,9 +50,13 @@ type ResolverRoot interface {
 	DidDocPublicKey() DidDocPublicKeyResolver
 	DidDocService() DidDocServiceResolver
 	DidDocument() DidDocumentResolver
+	DomainLinkage() DomainLinkageResolver
 	Edge() EdgeResolver
 	Mutation() MutationResolver
 	Query() QueryResolver
+	TreeChange() TreeChangeResolver
+	TreeDiff() TreeDiffResolver
+	TreeDiffChange() TreeDiffChangeResolver
 }
 
 type DirectiveRoot struct {
@@ -133,6 +139,19 @@ type ComplexityRoot struct {
 		Metadata func(childComplexity int) int
 	}
 
+	DidDeactivateResponse struct {
+		DeactivatedAt func(childComplexity int) int
+		Did           func(childComplexity int) int
+		RevokedClaims func(childComplexity int) int
+	}
+
+	DidDereferenceResponse struct {
+		Doc             func(childComplexity int) int
+		PublicKey       func(childComplexity int) int
+		Service         func(childComplexity int) int
+		ServiceEndpoint func(childComplexity int) int
+	}
+
 	DidDocAuthentication struct {
 		IDOnly    func(childComplexity int) int
 		PublicKey func(childComplexity int) int
@@ -160,25 +179,47 @@ type ComplexityRoot struct {
 	}
 
 	DidDocument struct {
-		Authentications func(childComplexity int) int
-		Context         func(childComplexity int) int
-		Controller      func(childComplexity int) int
-		Created         func(childComplexity int) int
-		ID              func(childComplexity int) int
-		Proof           func(childComplexity int) int
-		PublicKeys      func(childComplexity int) int
-		Services        func(childComplexity int) int
-		Updated         func(childComplexity int) int
+		AssertionMethods      func(childComplexity int) int
+		Authentications       func(childComplexity int) int
+		CapabilityDelegations func(childComplexity int) int
+		CapabilityInvocations func(childComplexity int) int
+		Context               func(childComplexity int) int
+		Controller            func(childComplexity int) int
+		Created               func(childComplexity int) int
+		ID                    func(childComplexity int) int
+		KeyAgreements         func(childComplexity int) int
+		Proof                 func(childComplexity int) int
+		PublicKeys            func(childComplexity int) int
+		Services              func(childComplexity int) int
+		Updated               func(childComplexity int) int
+		VerificationMethods   func(childComplexity int) int
 	}
 
 	DidGetResponse struct {
-		Doc    func(childComplexity int) int
-		DocRaw func(childComplexity int) int
+		Deactivated   func(childComplexity int) int
+		DeactivatedAt func(childComplexity int) int
+		Doc           func(childComplexity int) int
+		DocRaw        func(childComplexity int) int
+		LinkedDomains func(childComplexity int) int
+		Version       func(childComplexity int) int
 	}
 
 	DidSaveResponse struct {
-		Doc    func(childComplexity int) int
-		DocRaw func(childComplexity int) int
+		Doc     func(childComplexity int) int
+		DocRaw  func(childComplexity int) int
+		Version func(childComplexity int) int
+	}
+
+	DidSearchResponse struct {
+		Docs func(childComplexity int) int
+	}
+
+	DomainLinkage struct {
+		DID     func(childComplexity int) int
+		Expires func(childComplexity int) int
+		Hosted  func(childComplexity int) int
+		JWT     func(childComplexity int) int
+		Origin  func(childComplexity int) int
 	}
 
 	Edge struct {
@@ -202,17 +243,25 @@ type ComplexityRoot struct {
 	}
 
 	Mutation struct {
-		AddEdge   func(childComplexity int, edgeJwt *string) int
-		ClaimSave func(childComplexity int, in *ClaimSaveRequestInput) int
-		Version   func(childComplexity int) int
+		AddEdge             func(childComplexity int, edgeJwt *string) int
+		ClaimSave           func(childComplexity int, in *ClaimSaveRequestInput) int
+		DidDeactivate       func(childComplexity int, in DidDeactivateInput) int
+		DidSave             func(childComplexity int, in DidSaveInput) int
+		DomainLinkageSave   func(childComplexity int, in DomainLinkageSaveInput) int
+		DomainLinkageVerify func(childComplexity int, in DomainLinkageVerifyInput) int
+		Version             func(childComplexity int) int
 	}
 
 	Query struct {
-		ClaimGet   func(childComplexity int, in *ClaimGetRequestInput) int
-		ClaimProof func(childComplexity int, in *ClaimProofRequestInput) int
-		DidGet     func(childComplexity int, in *DidGetRequestInput) int
-		FindEdges  func(childComplexity int, in *FindEdgesInput) int
-		Version    func(childComplexity int) int
+		ClaimGet       func(childComplexity int, in *ClaimGetRequestInput) int
+		ClaimProof     func(childComplexity int, in *ClaimProofRequestInput) int
+		DidDereference func(childComplexity int, in DidDereferenceInput) int
+		DidGet         func(childComplexity int, in *DidGetRequestInput) int
+		DidSearch      func(childComplexity int, in DidSearchInput) int
+		FindEdges      func(childComplexity int, in *FindEdgesInput) int
+		TreeChanges    func(childComplexity int, in TreeChangesInput) int
+		TreeDiff       func(childComplexity int, in TreeDiffInput) int
+		Version        func(childComplexity int) int
 	}
 
 	RootOnBlockChainProof struct {
@@ -223,6 +272,51 @@ type ComplexityRoot struct {
 		TxHash           func(childComplexity int) int
 		Type             func(childComplexity int) int
 	}
+
+	TreeChange struct {
+		ClaimType     func(childComplexity int) int
+		ClaimVersion  func(childComplexity int) int
+		ClaimedSender func(childComplexity int) int
+		ContentHash   func(childComplexity int) int
+		CreatedAt     func(childComplexity int) int
+		DID           func(childComplexity int) int
+		DIDRoot       func(childComplexity int) int
+		DocType       func(childComplexity int) int
+		ID            func(childComplexity int) int
+		Operation     func(childComplexity int) int
+		Sender        func(childComplexity int) int
+		Tree          func(childComplexity int) int
+	}
+
+	TreeChangesResponse struct {
+		Changes func(childComplexity int) int
+		Total   func(childComplexity int) int
+	}
+
+	TreeDiff struct {
+		DID              func(childComplexity int) int
+		DocumentVersions func(childComplexity int) int
+		FromRoot         func(childComplexity int) int
+		Keys             func(childComplexity int) int
+		Registrations    func(childComplexity int) int
+		Revocations      func(childComplexity int) int
+		ToRoot           func(childComplexity int) int
+		Unknown          func(childComplexity int) int
+	}
+
+	TreeDiffChange struct {
+		ClaimType     func(childComplexity int) int
+		ContentHash   func(childComplexity int) int
+		Credential    func(childComplexity int) int
+		CredentialRaw func(childComplexity int) int
+		DocType       func(childComplexity int) int
+		DocVersion    func(childComplexity int) int
+		DocumentHash  func(childComplexity int) int
+		Entry         func(childComplexity int) int
+		JWT           func(childComplexity int) int
+		PublicKey     func(childComplexity int) int
+		Type          func(childComplexity int) int
+	}
 }
 
 type ArticleMetadataResolver interface {
@@ -253,6 +347,11 @@ type DidDocumentResolver interface {
 	ID(ctx context.Context, obj *did.Document) (*string, error)
 
 	Controller(ctx context.Context, obj *did.Document) (*string, error)
+
+	VerificationMethods(ctx context.Context, obj *did.Document) ([]*did.DocPublicKey, error)
+}
+type DomainLinkageResolver interface {
+	Expires(ctx context.Context, obj *domainlinkage.Linkage) (*time.Time, error)
 }
 type EdgeResolver interface {
 	From(ctx context.Context, obj *claimsstore.JWTClaimPostgres) (string, error)
@@ -264,15 +363,47 @@ type EdgeResolver interface {
 }
 type MutationResolver interface {
 	Version(ctx context.Context) (string, error)
+	DidSave(ctx context.Context, in DidSaveInput) (*DidSaveResponse, error)
+	DidDeactivate(ctx context.Context, in DidDeactivateInput) (*DidDeactivateResponse, error)
+	DomainLinkageSave(ctx context.Context, in DomainLinkageSaveInput) (*domainlinkage.Linkage, error)
+	DomainLinkageVerify(ctx context.Context, in DomainLinkageVerifyInput) (*domainlinkage.Linkage, error)
 	ClaimSave(ctx context.Context, in *ClaimSaveRequestInput) (*ClaimSaveResponse, error)
 	AddEdge(ctx context.Context, edgeJwt *string) (*claimsstore.JWTClaimPostgres, error)
 }
 type QueryResolver interface {
 	Version(ctx context.Context) (string, error)
 	DidGet(ctx context.Context, in *DidGetRequestInput) (*DidGetResponse, error)
+	DidSearch(ctx context.Context, in DidSearchInput) (*DidSearchResponse, error)
+	DidDereference(ctx context.Context, in DidDereferenceInput) (*DidDereferenceResponse, error)
 	ClaimGet(ctx context.Context, in *ClaimGetRequestInput) (*ClaimGetResponse, error)
 	ClaimProof(ctx context.Context, in *ClaimProofRequestInput) (*ClaimProofResponse, error)
 	FindEdges(ctx context.Context, in *FindEdgesInput) ([]*claimsstore.JWTClaimPostgres, error)
+	TreeChanges(ctx context.Context, in TreeChangesInput) (*TreeChangesResponse, error)
+	TreeDiff(ctx context.Context, in TreeDiffInput) (*claims.TreeDiff, error)
+}
+type TreeChangeResolver interface {
+	ID(ctx context.Context, obj *claimsstore.TreeChange) (string, error)
+
+	ClaimVersion(ctx context.Context, obj *claimsstore.TreeChange) (int, error)
+	DocType(ctx context.Context, obj *claimsstore.TreeChange) (*int, error)
+
+	Operation(ctx context.Context, obj *claimsstore.TreeChange) (string, error)
+
+	CreatedAt(ctx context.Context, obj *claimsstore.TreeChange) (string, error)
+}
+type TreeDiffResolver interface {
+	FromRoot(ctx context.Context, obj *claims.TreeDiff) (string, error)
+	ToRoot(ctx context.Context, obj *claims.TreeDiff) (string, error)
+}
+type TreeDiffChangeResolver interface {
+	Type(ctx context.Context, obj *claims.TreeDiffChange) (string, error)
+
+	DocType(ctx context.Context, obj *claims.TreeDiffChange) (*int, error)
+
+	DocVersion(ctx context.Context, obj *claims.TreeDiffChange) (*int, error)
+
+	Credential(ctx context.Context, obj *claims.TreeDiffChange) (*claimtypes.ContentCredential, error)
+	CredentialRaw(ctx context.Context, obj *claims.TreeDiffChange) (*string, error)
 }
 
 type executableSchema struct {
@@ -612,6 +743,55 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ContentClaimCredentialSubject.Metadata(childComplexity), true
 
+	case "DidDeactivateResponse.deactivatedAt":
+		if e.complexity.DidDeactivateResponse.DeactivatedAt == nil {
+			break
+		}
+
+		return e.complexity.DidDeactivateResponse.DeactivatedAt(childComplexity), true
+
+	case "DidDeactivateResponse.did":
+		if e.complexity.DidDeactivateResponse.Did == nil {
+			break
+		}
+
+		return e.complexity.DidDeactivateResponse.Did(childComplexity), true
+
+	case "DidDeactivateResponse.revokedClaims":
+		if e.complexity.DidDeactivateResponse.RevokedClaims == nil {
+			break
+		}
+
+		return e.complexity.DidDeactivateResponse.RevokedClaims(childComplexity), true
+
+	case "DidDereferenceResponse.doc":
+		if e.complexity.DidDereferenceResponse.Doc == nil {
+			break
+		}
+
+		return e.complexity.DidDereferenceResponse.Doc(childComplexity), true
+
+	case "DidDereferenceResponse.publicKey":
+		if e.complexity.DidDereferenceResponse.PublicKey == nil {
+			break
+		}
+
+		return e.complexity.DidDereferenceResponse.PublicKey(childComplexity), true
+
+	case "DidDereferenceResponse.service":
+		if e.complexity.DidDereferenceResponse.Service == nil {
+			break
+		}
+
+		return e.complexity.DidDereferenceResponse.Service(childComplexity), true
+
+	case "DidDereferenceResponse.serviceEndpoint":
+		if e.complexity.DidDereferenceResponse.ServiceEndpoint == nil {
+			break
+		}
+
+		return e.complexity.DidDereferenceResponse.ServiceEndpoint(childComplexity), true
+
 	case "DidDocAuthentication.idOnly":
 		if e.complexity.DidDocAuthentication.IDOnly == nil {
 			break
@@ -731,6 +911,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.DidDocService.Type(childComplexity), true
 
+	case "DidDocument.assertionMethods":
+		if e.complexity.DidDocument.AssertionMethods == nil {
+			break
+		}
+
+		return e.complexity.DidDocument.AssertionMethods(childComplexity), true
+
 	case "DidDocument.authentications":
 		if e.complexity.DidDocument.Authentications == nil {
 			break
@@ -738,6 +925,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.DidDocument.Authentications(childComplexity), true
 
+	case "DidDocument.capabilityDelegations":
+		if e.complexity.DidDocument.CapabilityDelegations == nil {
+			break
+		}
+
+		return e.complexity.DidDocument.CapabilityDelegations(childComplexity), true
+
+	case "DidDocument.capabilityInvocations":
+		if e.complexity.DidDocument.CapabilityInvocations == nil {
+			break
+		}
+
+		return e.complexity.DidDocument.CapabilityInvocations(childComplexity), true
+
 	case "DidDocument.context":
 		if e.complexity.DidDocument.Context == nil {
 			break
@@ -766,6 +967,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.DidDocument.ID(childComplexity), true
 
+	case "DidDocument.keyAgreements":
+		if e.complexity.DidDocument.KeyAgreements == nil {
+			break
+		}
+
+		return e.complexity.DidDocument.KeyAgreements(childComplexity), true
+
 	case "DidDocument.proof":
 		if e.complexity.DidDocument.Proof == nil {
 			break
@@ -794,6 +1002,27 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.DidDocument.Updated(childComplexity), true
 
+	case "DidDocument.verificationMethods":
+		if e.complexity.DidDocument.VerificationMethods == nil {
+			break
+		}
+
+		return e.complexity.DidDocument.VerificationMethods(childComplexity), true
+
+	case "DidGetResponse.deactivated":
+		if e.complexity.DidGetResponse.Deactivated == nil {
+			break
+		}
+
+		return e.complexity.DidGetResponse.Deactivated(childComplexity), true
+
+	case "DidGetResponse.deactivatedAt":
+		if e.complexity.DidGetResponse.DeactivatedAt == nil {
+			break
+		}
+
+		return e.complexity.DidGetResponse.DeactivatedAt(childComplexity), true
+
 	case "DidGetResponse.doc":
 		if e.complexity.DidGetResponse.Doc == nil {
 			break
@@ -808,6 +1037,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.DidGetResponse.DocRaw(childComplexity), true
 
+	case "DidGetResponse.linkedDomains":
+		if e.complexity.DidGetResponse.LinkedDomains == nil {
+			break
+		}
+
+		return e.complexity.DidGetResponse.LinkedDomains(childComplexity), true
+
+	case "DidGetResponse.version":
+		if e.complexity.DidGetResponse.Version == nil {
+			break
+		}
+
+		return e.complexity.DidGetResponse.Version(childComplexity), true
+
 	case "DidSaveResponse.doc":
 		if e.complexity.DidSaveResponse.Doc == nil {
 			break
@@ -822,6 +1065,55 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.DidSaveResponse.DocRaw(childComplexity), true
 
+	case "DidSaveResponse.version":
+		if e.complexity.DidSaveResponse.Version == nil {
+			break
+		}
+
+		return e.complexity.DidSaveResponse.Version(childComplexity), true
+
+	case "DidSearchResponse.docs":
+		if e.complexity.DidSearchResponse.Docs == nil {
+			break
+		}
+
+		return e.complexity.DidSearchResponse.Docs(childComplexity), true
+
+	case "DomainLinkage.did":
+		if e.complexity.DomainLinkage.DID == nil {
+			break
+		}
+
+		return e.complexity.DomainLinkage.DID(childComplexity), true
+
+	case "DomainLinkage.expires":
+		if e.complexity.DomainLinkage.Expires == nil {
+			break
+		}
+
+		return e.complexity.DomainLinkage.Expires(childComplexity), true
+
+	case "DomainLinkage.hosted":
+		if e.complexity.DomainLinkage.Hosted == nil {
+			break
+		}
+
+		return e.complexity.DomainLinkage.Hosted(childComplexity), true
+
+	case "DomainLinkage.jwt":
+		if e.complexity.DomainLinkage.JWT == nil {
+			break
+		}
+
+		return e.complexity.DomainLinkage.JWT(childComplexity), true
+
+	case "DomainLinkage.origin":
+		if e.complexity.DomainLinkage.Origin == nil {
+			break
+		}
+
+		return e.complexity.DomainLinkage.Origin(childComplexity), true
+
 	case "Edge.data":
 		if e.complexity.Edge.Data == nil {
 			break
@@ -944,6 +1236,54 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Mutation.ClaimSave(childComplexity, args["in"].(*ClaimSaveRequestInput)), true
 
+	case "Mutation.didDeactivate":
+		if e.complexity.Mutation.DidDeactivate == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_didDeactivate_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.DidDeactivate(childComplexity, args["in"].(DidDeactivateInput)), true
+
+	case "Mutation.didSave":
+		if e.complexity.Mutation.DidSave == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_didSave_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.DidSave(childComplexity, args["in"].(DidSaveInput)), true
+
+	case "Mutation.domainLinkageSave":
+		if e.complexity.Mutation.DomainLinkageSave == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_domainLinkageSave_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.DomainLinkageSave(childComplexity, args["in"].(DomainLinkageSaveInput)), true
+
+	case "Mutation.domainLinkageVerify":
+		if e.complexity.Mutation.DomainLinkageVerify == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_domainLinkageVerify_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.DomainLinkageVerify(childComplexity, args["in"].(DomainLinkageVerifyInput)), true
+
 	case "Mutation.version":
 		if e.complexity.Mutation.Version == nil {
 			break
@@ -975,6 +1315,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.ClaimProof(childComplexity, args["in"].(*ClaimProofRequestInput)), true
 
+	case "Query.didDereference":
+		if e.complexity.Query.DidDereference == nil {
+			break
+		}
+
+		args, err := ec.field_Query_didDereference_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.DidDereference(childComplexity, args["in"].(DidDereferenceInput)), true
+
 	case "Query.didGet":
 		if e.complexity.Query.DidGet == nil {
 			break
@@ -987,6 +1339,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.DidGet(childComplexity, args["in"].(*DidGetRequestInput)), true
 
+	case "Query.didSearch":
+		if e.complexity.Query.DidSearch == nil {
+			break
+		}
+
+		args, err := ec.field_Query_didSearch_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.DidSearch(childComplexity, args["in"].(DidSearchInput)), true
+
 	case "Query.findEdges":
 		if e.complexity.Query.FindEdges == nil {
 			break
@@ -999,6 +1363,30 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.FindEdges(childComplexity, args["in"].(*FindEdgesInput)), true
 
+	case "Query.treeChanges":
+		if e.complexity.Query.TreeChanges == nil {
+			break
+		}
+
+		args, err := ec.field_Query_treeChanges_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.TreeChanges(childComplexity, args["in"].(TreeChangesInput)), true
+
+	case "Query.treeDiff":
+		if e.complexity.Query.TreeDiff == nil {
+			break
+		}
+
+		args, err := ec.field_Query_treeDiff_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.TreeDiff(childComplexity, args["in"].(TreeDiffInput)), true
+
 	case "Query.version":
 		if e.complexity.Query.Version == nil {
 			break
@@ -1048,82 +1436,313 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.RootOnBlockChainProof.Type(childComplexity), true
 
-	}
-	return 0, false
-}
+	case "TreeChange.claimType":
+		if e.complexity.TreeChange.ClaimType == nil {
+			break
+		}
 
-func (e *executableSchema) Query(ctx context.Context, op *ast.OperationDefinition) *graphql.Response {
-	ec := executionContext{graphql.GetRequestContext(ctx), e}
+		return e.complexity.TreeChange.ClaimType(childComplexity), true
 
-	buf := ec.RequestMiddleware(ctx, func(ctx context.Context) []byte {
-		data := ec._Query(ctx, op.SelectionSet)
-		var buf bytes.Buffer
-		data.MarshalGQL(&buf)
-		return buf.Bytes()
-	})
+	case "TreeChange.claimVersion":
+		if e.complexity.TreeChange.ClaimVersion == nil {
+			break
+		}
 
-	return &graphql.Response{
-		Data:       buf,
-		Errors:     ec.Errors,
-		Extensions: ec.Extensions,
-	}
-}
+		return e.complexity.TreeChange.ClaimVersion(childComplexity), true
 
-func (e *executableSchema) Mutation(ctx context.Context, op *ast.OperationDefinition) *graphql.Response {
-	ec := executionContext{graphql.GetRequestContext(ctx), e}
+	case "TreeChange.claimedSender":
+		if e.complexity.TreeChange.ClaimedSender == nil {
+			break
+		}
 
-	buf := ec.RequestMiddleware(ctx, func(ctx context.Context) []byte {
-		data := ec._Mutation(ctx, op.SelectionSet)
-		var buf bytes.Buffer
-		data.MarshalGQL(&buf)
-		return buf.Bytes()
-	})
+		return e.complexity.TreeChange.ClaimedSender(childComplexity), true
 
-	return &graphql.Response{
-		Data:       buf,
-		Errors:     ec.Errors,
-		Extensions: ec.Extensions,
-	}
-}
+	case "TreeChange.contentHash":
+		if e.complexity.TreeChange.ContentHash == nil {
+			break
+		}
 
-func (e *executableSchema) Subscription(ctx context.Context, op *ast.OperationDefinition) func() *graphql.Response {
-	return graphql.OneShot(graphql.ErrorResponse(ctx, "subscriptions are not supported"))
-}
+		return e.complexity.TreeChange.ContentHash(childComplexity), true
 
-type executionContext struct {
-	*graphql.RequestContext
-	*executableSchema
-}
+	case "TreeChange.createdAt":
+		if e.complexity.TreeChange.CreatedAt == nil {
+			break
+		}
 
-func (ec *executionContext) introspectSchema() (*introspection.Schema, error) {
-	if ec.DisableIntrospection {
-		return nil, errors.New("introspection disabled")
-	}
-	return introspection.WrapSchema(parsedSchema), nil
-}
+		return e.complexity.TreeChange.CreatedAt(childComplexity), true
 
-func (ec *executionContext) introspectType(name string) (*introspection.Type, error) {
-	if ec.DisableIntrospection {
-		return nil, errors.New("introspection disabled")
-	}
-	return introspection.WrapTypeFromDef(parsedSchema, parsedSchema.Types[name]), nil
-}
+	case "TreeChange.did":
+		if e.complexity.TreeChange.DID == nil {
+			break
+		}
 
-var parsedSchema = gqlparser.MustLoadSchema(
-	&ast.Source{Name: "base.graphql", Input: `# Base GraphQL Schema
-# All the other schemas will extend on this base schema
+		return e.complexity.TreeChange.DID(childComplexity), true
 
-type Query {
-    version: String!
-}
+	case "TreeChange.didRoot":
+		if e.complexity.TreeChange.DIDRoot == nil {
+			break
+		}
 
-type Mutation {
-    version: String!
-}`},
-	&ast.Source{Name: "claim.graphql", Input: `# Claim specific schema
+		return e.complexity.TreeChange.DIDRoot(childComplexity), true
 
-extend type Query {
-	claimGet(in: ClaimGetRequestInput): ClaimGetResponse
+	case "TreeChange.docType":
+		if e.complexity.TreeChange.DocType == nil {
+			break
+		}
+
+		return e.complexity.TreeChange.DocType(childComplexity), true
+
+	case "TreeChange.id":
+		if e.complexity.TreeChange.ID == nil {
+			break
+		}
+
+		return e.complexity.TreeChange.ID(childComplexity), true
+
+	case "TreeChange.operation":
+		if e.complexity.TreeChange.Operation == nil {
+			break
+		}
+
+		return e.complexity.TreeChange.Operation(childComplexity), true
+
+	case "TreeChange.sender":
+		if e.complexity.TreeChange.Sender == nil {
+			break
+		}
+
+		return e.complexity.TreeChange.Sender(childComplexity), true
+
+	case "TreeChange.tree":
+		if e.complexity.TreeChange.Tree == nil {
+			break
+		}
+
+		return e.complexity.TreeChange.Tree(childComplexity), true
+
+	case "TreeChangesResponse.changes":
+		if e.complexity.TreeChangesResponse.Changes == nil {
+			break
+		}
+
+		return e.complexity.TreeChangesResponse.Changes(childComplexity), true
+
+	case "TreeChangesResponse.total":
+		if e.complexity.TreeChangesResponse.Total == nil {
+			break
+		}
+
+		return e.complexity.TreeChangesResponse.Total(childComplexity), true
+
+	case "TreeDiff.did":
+		if e.complexity.TreeDiff.DID == nil {
+			break
+		}
+
+		return e.complexity.TreeDiff.DID(childComplexity), true
+
+	case "TreeDiff.documentVersions":
+		if e.complexity.TreeDiff.DocumentVersions == nil {
+			break
+		}
+
+		return e.complexity.TreeDiff.DocumentVersions(childComplexity), true
+
+	case "TreeDiff.fromRoot":
+		if e.complexity.TreeDiff.FromRoot == nil {
+			break
+		}
+
+		return e.complexity.TreeDiff.FromRoot(childComplexity), true
+
+	case "TreeDiff.keys":
+		if e.complexity.TreeDiff.Keys == nil {
+			break
+		}
+
+		return e.complexity.TreeDiff.Keys(childComplexity), true
+
+	case "TreeDiff.registrations":
+		if e.complexity.TreeDiff.Registrations == nil {
+			break
+		}
+
+		return e.complexity.TreeDiff.Registrations(childComplexity), true
+
+	case "TreeDiff.revocations":
+		if e.complexity.TreeDiff.Revocations == nil {
+			break
+		}
+
+		return e.complexity.TreeDiff.Revocations(childComplexity), true
+
+	case "TreeDiff.toRoot":
+		if e.complexity.TreeDiff.ToRoot == nil {
+			break
+		}
+
+		return e.complexity.TreeDiff.ToRoot(childComplexity), true
+
+	case "TreeDiff.unknown":
+		if e.complexity.TreeDiff.Unknown == nil {
+			break
+		}
+
+		return e.complexity.TreeDiff.Unknown(childComplexity), true
+
+	case "TreeDiffChange.claimType":
+		if e.complexity.TreeDiffChange.ClaimType == nil {
+			break
+		}
+
+		return e.complexity.TreeDiffChange.ClaimType(childComplexity), true
+
+	case "TreeDiffChange.contentHash":
+		if e.complexity.TreeDiffChange.ContentHash == nil {
+			break
+		}
+
+		return e.complexity.TreeDiffChange.ContentHash(childComplexity), true
+
+	case "TreeDiffChange.credential":
+		if e.complexity.TreeDiffChange.Credential == nil {
+			break
+		}
+
+		return e.complexity.TreeDiffChange.Credential(childComplexity), true
+
+	case "TreeDiffChange.credentialRaw":
+		if e.complexity.TreeDiffChange.CredentialRaw == nil {
+			break
+		}
+
+		return e.complexity.TreeDiffChange.CredentialRaw(childComplexity), true
+
+	case "TreeDiffChange.docType":
+		if e.complexity.TreeDiffChange.DocType == nil {
+			break
+		}
+
+		return e.complexity.TreeDiffChange.DocType(childComplexity), true
+
+	case "TreeDiffChange.docVersion":
+		if e.complexity.TreeDiffChange.DocVersion == nil {
+			break
+		}
+
+		return e.complexity.TreeDiffChange.DocVersion(childComplexity), true
+
+	case "TreeDiffChange.documentHash":
+		if e.complexity.TreeDiffChange.DocumentHash == nil {
+			break
+		}
+
+		return e.complexity.TreeDiffChange.DocumentHash(childComplexity), true
+
+	case "TreeDiffChange.entry":
+		if e.complexity.TreeDiffChange.Entry == nil {
+			break
+		}
+
+		return e.complexity.TreeDiffChange.Entry(childComplexity), true
+
+	case "TreeDiffChange.jwt":
+		if e.complexity.TreeDiffChange.JWT == nil {
+			break
+		}
+
+		return e.complexity.TreeDiffChange.JWT(childComplexity), true
+
+	case "TreeDiffChange.publicKey":
+		if e.complexity.TreeDiffChange.PublicKey == nil {
+			break
+		}
+
+		return e.complexity.TreeDiffChange.PublicKey(childComplexity), true
+
+	case "TreeDiffChange.type":
+		if e.complexity.TreeDiffChange.Type == nil {
+			break
+		}
+
+		return e.complexity.TreeDiffChange.Type(childComplexity), true
+
+	}
+	return 0, false
+}
+
+func (e *executableSchema) Query(ctx context.Context, op *ast.OperationDefinition) *graphql.Response {
+	ec := executionContext{graphql.GetRequestContext(ctx), e}
+
+	buf := ec.RequestMiddleware(ctx, func(ctx context.Context) []byte {
+		data := ec._Query(ctx, op.SelectionSet)
+		var buf bytes.Buffer
+		data.MarshalGQL(&buf)
+		return buf.Bytes()
+	})
+
+	return &graphql.Response{
+		Data:       buf,
+		Errors:     ec.Errors,
+		Extensions: ec.Extensions,
+	}
+}
+
+func (e *executableSchema) Mutation(ctx context.Context, op *ast.OperationDefinition) *graphql.Response {
+	ec := executionContext{graphql.GetRequestContext(ctx), e}
+
+	buf := ec.RequestMiddleware(ctx, func(ctx context.Context) []byte {
+		data := ec._Mutation(ctx, op.SelectionSet)
+		var buf bytes.Buffer
+		data.MarshalGQL(&buf)
+		return buf.Bytes()
+	})
+
+	return &graphql.Response{
+		Data:       buf,
+		Errors:     ec.Errors,
+		Extensions: ec.Extensions,
+	}
+}
+
+func (e *executableSchema) Subscription(ctx context.Context, op *ast.OperationDefinition) func() *graphql.Response {
+	return graphql.OneShot(graphql.ErrorResponse(ctx, "subscriptions are not supported"))
+}
+
+type executionContext struct {
+	*graphql.RequestContext
+	*executableSchema
+}
+
+func (ec *executionContext) introspectSchema() (*introspection.Schema, error) {
+	if ec.DisableIntrospection {
+		return nil, errors.New("introspection disabled")
+	}
+	return introspection.WrapSchema(parsedSchema), nil
+}
+
+func (ec *executionContext) introspectType(name string) (*introspection.Type, error) {
+	if ec.DisableIntrospection {
+		return nil, errors.New("introspection disabled")
+	}
+	return introspection.WrapTypeFromDef(parsedSchema, parsedSchema.Types[name]), nil
+}
+
+var parsedSchema = gqlparser.MustLoadSchema(
+	&ast.Source{Name: "base.graphql", Input: `# Base GraphQL Schema
+# All the other schemas will extend on this base schema
+
+type Query {
+    version: String!
+}
+
+type Mutation {
+    version: String!
+}`},
+	&ast.Source{Name: "claim.graphql", Input: `# Claim specific schema
+
+extend type Query {
+	claimGet(in: ClaimGetRequestInput): ClaimGetResponse
 	claimProof(in: ClaimProofRequestInput): ClaimProofResponse
 }
 
@@ -1291,20 +1910,138 @@ type ArticleMetadataImage {
 
 extend type Query {
 	didGet(in: DidGetRequestInput): DidGetResponse
+	# Finds the ethuri DID documents bound to a public key hex, an ethereum
+	# address or a service endpoint. Exactly one of them has to be given.
+	didSearch(in: DidSearchInput!): DidSearchResponse
+	# Dereferences a DID URL to its document, a key or service by fragment, or
+	# with ?service=<fragment>&relativeRef=<ref> to a service endpoint URL.
+	# versionId and versionTime select an earlier version of the document.
+	didDereference(in: DidDereferenceInput!): DidDereferenceResponse
+}
+
+extend type Mutation {
+	# Creates an ethuri DID document, or adds keys, authentications and services to
+	# an existing one. An update has to be signed by an authentication key of the
+	# document for its current version, returned in version by didGet. A create
+	# has to be signed by every authentication key of the new document, by the
+	# key of proof and the others in proofs.
+	didSave(in: DidSaveInput!): DidSaveResponse
+	# Deactivates an ethuri DID document, it is no longer resolved and no new
+	# claims can be added for it. With revokeClaims every registered document in
+	# the DID's tree is revoked. The proof has to be signed by an authentication
+	# key of the document.
+	didDeactivate(in: DidDeactivateInput!): DidDeactivateResponse
+	# Saves a domain linkage credential jwt signed by the sender DID for a domain
+	# hosted by the hub, it is served in the domain's did-configuration.json
+	domainLinkageSave(in: DomainLinkageSaveInput!): DomainLinkage
+	# Verifies a remote domain links to a DID through its did-configuration.json
+	domainLinkageVerify(in: DomainLinkageVerifyInput!): DomainLinkage
 }
 
 input DidGetRequestInput {
 	did: String
 }
 
+input DidSearchInput {
+	publicKeyHex: String
+	ethereumAddress: String
+	serviceEndpoint: String
+}
+
+type DidSearchResponse {
+	docs: [DidDocument!]!
+}
+
+input DidDereferenceInput {
+	didUrl: String!
+}
+
+type DidDereferenceResponse {
+	doc: DidDocument
+	publicKey: DidDocPublicKey
+	service: DidDocService
+	serviceEndpoint: String
+}
+
 type DidGetResponse {
 	doc: DidDocument
 	docRaw: String
+	# version is the current version of ethuri documents
+	version: Int
+	linkedDomains: [String!]
+	deactivated: Boolean
+	deactivatedAt: Time
+}
+
+input DidSaveInput {
+	did: String
+	publicKeys: [DidDocPublicKeyInput!]
+	authentications: [DidDocAuthenticationInput!]
+	services: [DidDocServiceInput!]
+	version: Int
+	proof: LinkedDataProofInput!
+	proofs: [LinkedDataProofInput!]
+}
+
+input DidDeactivateInput {
+	did: String!
+	revokeClaims: Boolean
+	proof: LinkedDataProofInput!
+}
+
+input DidDocPublicKeyInput {
+	id: String
+	type: String!
+	controller: String
+	publicKeyPem: String
+	publicKeyJwk: String
+	publicKeyHex: String
+	publicKeyBase64: String
+	publicKeyBase58: String
+	publicKeyMultibase: String
+	ethereumAddress: String
+}
+
+input DidDocAuthenticationInput {
+	publicKey: DidDocPublicKeyInput!
+	idOnly: Boolean
+}
+
+input DidDocServiceInput {
+	id: String!
+	type: String!
+	description: String
+	publicKey: String
+	serviceEndpoint: AnyValue!
+}
+
+input DomainLinkageSaveInput {
+	jwt: String!
+}
+
+input DomainLinkageVerifyInput {
+	did: String!
+	origin: String!
+}
+
+type DomainLinkage {
+	did: String!
+	origin: String!
+	hosted: Boolean!
+	expires: Time
+	jwt: String!
 }
 
 type DidSaveResponse {
 	doc: DidDocument
 	docRaw: String
+	version: Int
+}
+
+type DidDeactivateResponse {
+	did: String!
+	deactivatedAt: Time
+	revokedClaims: Int!
 }
 
 type DidDocAuthentication {
@@ -1338,7 +2075,12 @@ type DidDocument {
 	context: String
 	controller: String
 	publicKeys: [DidDocPublicKey!]
+	verificationMethods: [DidDocPublicKey!]
 	authentications: [DidDocAuthentication!]
+	assertionMethods: [DidDocAuthentication!]
+	keyAgreements: [DidDocAuthentication!]
+	capabilityInvocations: [DidDocAuthentication!]
+	capabilityDelegations: [DidDocAuthentication!]
 	services: [DidDocService!]
 	created: Time
 	updated: Time
@@ -1404,6 +2146,102 @@ type Edge {
     data: String
     proof: [Proof]
 }
+`},
+	&ast.Source{Name: "tree.graphql", Input: `# Tree specific schema
+
+extend type Query {
+	# Find the changes made to the trees of a did, oldest first
+	treeChanges(in: TreeChangesInput!): TreeChangesResponse
+	# Find the registrations, revocations, keys and document versions added to
+	# the tree of a did between two roots
+	treeDiff(in: TreeDiffInput!): TreeDiff
+}
+
+## Inputs
+
+input TreeChangesInput {
+	did: String!
+	# number of changes to skip
+	offset: Int
+	# number of changes to return, defaults to 50 and is at most 500
+	limit: Int
+}
+
+# The from and to roots are either the hex of a did tree root or the hex of a
+# committed root tree root. Without a from root the diff starts at the empty tree
+# and without a to root it ends at the current root of the did tree.
+input TreeDiffInput {
+	did: String!
+	fromRoot: String
+	fromCommit: String
+	toRoot: String
+	toCommit: String
+}
+
+type TreeChangesResponse {
+	changes: [TreeChange!]!
+	# total number of changes for the did
+	total: Int!
+}
+
+type TreeChange {
+	id: ID!
+	did: String!
+	# prefix of the tree changed, the did for its tree or the root tree prefix
+	tree: String!
+	# hex of the claim type
+	claimType: String!
+	claimVersion: Int!
+	# doc type of registered documents
+	docType: Int
+	# hex of the content hash of registered documents
+	contentHash: String
+	# one of add_key, register, revoke, document_version, set_root or add
+	operation: String!
+	# did that authenticated the change, if any
+	sender: String
+	# did an unauthenticated request claimed to be sent by, not verified
+	claimedSender: String
+	# root of the did tree after the change
+	didRoot: String
+	createdAt: String!
+}
+
+type TreeDiff {
+	did: String!
+	fromRoot: String!
+	toRoot: String!
+	registrations: [TreeDiffChange!]!
+	revocations: [TreeDiffChange!]!
+	keys: [TreeDiffChange!]!
+	documentVersions: [TreeDiffChange!]!
+	# claims of types not expected in a did tree
+	unknown: [TreeDiffChange!]!
+}
+
+type TreeDiffChange {
+	# one of registration, revocation, key, documentVersion or unknown
+	type: String!
+	# hex of the claim entry
+	entry: String!
+	docType: Int
+	# hex of the content hash of registered documents
+	contentHash: String
+	# hex of the compressed public key of key claims
+	publicKey: String
+	# version of the did document of document versions
+	docVersion: Int
+	# hex of the keccak256 of the did document of document versions
+	documentHash: String
+	# hex of the claim type of unknown claims
+	claimType: String
+	# the content credential registered, if stored
+	credential: Claim
+	# the credential registered as json, if stored
+	credentialRaw: String
+	# the jwt registered, if stored
+	jwt: String
+}
 `},
 )
 
@@ -1439,11 +2277,67 @@ func (ec *executionContext) field_Mutation_claimSave_args(ctx context.Context, r
 	return args, nil
 }
 
-func (ec *executionContext) field_Query___type_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Mutation_didDeactivate_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
-	var arg0 string
-	if tmp, ok := rawArgs["name"]; ok {
+	var arg0 DidDeactivateInput
+	if tmp, ok := rawArgs["in"]; ok {
+		arg0, err = ec.unmarshalNDidDeactivateInput2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDeactivateInput(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["in"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_didSave_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 DidSaveInput
+	if tmp, ok := rawArgs["in"]; ok {
+		arg0, err = ec.unmarshalNDidSaveInput2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidSaveInput(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["in"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_domainLinkageSave_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 DomainLinkageSaveInput
+	if tmp, ok := rawArgs["in"]; ok {
+		arg0, err = ec.unmarshalNDomainLinkageSaveInput2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDomainLinkageSaveInput(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["in"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_domainLinkageVerify_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 DomainLinkageVerifyInput
+	if tmp, ok := rawArgs["in"]; ok {
+		arg0, err = ec.unmarshalNDomainLinkageVerifyInput2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDomainLinkageVerifyInput(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["in"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query___type_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["name"]; ok {
 		arg0, err = ec.unmarshalNString2string(ctx, tmp)
 		if err != nil {
 			return nil, err
@@ -1481,6 +2375,20 @@ func (ec *executionContext) field_Query_claimProof_args(ctx context.Context, raw
 	return args, nil
 }
 
+func (ec *executionContext) field_Query_didDereference_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 DidDereferenceInput
+	if tmp, ok := rawArgs["in"]; ok {
+		arg0, err = ec.unmarshalNDidDereferenceInput2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDereferenceInput(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["in"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Query_didGet_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -1495,6 +2403,20 @@ func (ec *executionContext) field_Query_didGet_args(ctx context.Context, rawArgs
 	return args, nil
 }
 
+func (ec *executionContext) field_Query_didSearch_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 DidSearchInput
+	if tmp, ok := rawArgs["in"]; ok {
+		arg0, err = ec.unmarshalNDidSearchInput2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidSearchInput(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["in"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Query_findEdges_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -1509,6 +2431,34 @@ func (ec *executionContext) field_Query_findEdges_args(ctx context.Context, rawA
 	return args, nil
 }
 
+func (ec *executionContext) field_Query_treeChanges_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 TreeChangesInput
+	if tmp, ok := rawArgs["in"]; ok {
+		arg0, err = ec.unmarshalNTreeChangesInput2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐTreeChangesInput(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["in"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_treeDiff_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 TreeDiffInput
+	if tmp, ok := rawArgs["in"]; ok {
+		arg0, err = ec.unmarshalNTreeDiffInput2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐTreeDiffInput(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["in"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field___Type_enumValues_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -3181,7 +4131,7 @@ func (ec *executionContext) _ContentClaimCredentialSubject_metadata(ctx context.
 	return ec.marshalNArticleMetadata2ᚖgithubᚗcomᚋjoincivilᚋgoᚑcommonᚋpkgᚋarticleᚐMetadata(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocAuthentication_publicKey(ctx context.Context, field graphql.CollectedField, obj *did.DocAuthenicationWrapper) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDeactivateResponse_did(ctx context.Context, field graphql.CollectedField, obj *DidDeactivateResponse) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -3191,31 +4141,34 @@ func (ec *executionContext) _DidDocAuthentication_publicKey(ctx context.Context,
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidDocAuthentication",
+		Object:   "DidDeactivateResponse",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.DidDocAuthentication().PublicKey(rctx, obj)
+		return obj.Did, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*did.DocPublicKey)
+	res := resTmp.(string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalODidDocPublicKey2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocPublicKey(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocAuthentication_idOnly(ctx context.Context, field graphql.CollectedField, obj *did.DocAuthenicationWrapper) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDeactivateResponse_deactivatedAt(ctx context.Context, field graphql.CollectedField, obj *DidDeactivateResponse) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -3225,7 +4178,7 @@ func (ec *executionContext) _DidDocAuthentication_idOnly(ctx context.Context, fi
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidDocAuthentication",
+		Object:   "DidDeactivateResponse",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -3234,7 +4187,7 @@ func (ec *executionContext) _DidDocAuthentication_idOnly(ctx context.Context, fi
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.IDOnly, nil
+		return obj.DeactivatedAt, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3243,13 +4196,13 @@ func (ec *executionContext) _DidDocAuthentication_idOnly(ctx context.Context, fi
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(*time.Time)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalOTime2ᚖtimeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocPublicKey_id(ctx context.Context, field graphql.CollectedField, obj *did.DocPublicKey) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDeactivateResponse_revokedClaims(ctx context.Context, field graphql.CollectedField, obj *DidDeactivateResponse) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -3259,31 +4212,34 @@ func (ec *executionContext) _DidDocPublicKey_id(ctx context.Context, field graph
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidDocPublicKey",
+		Object:   "DidDeactivateResponse",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.DidDocPublicKey().ID(rctx, obj)
+		return obj.RevokedClaims, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(int)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocPublicKey_type(ctx context.Context, field graphql.CollectedField, obj *did.DocPublicKey) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDereferenceResponse_doc(ctx context.Context, field graphql.CollectedField, obj *DidDereferenceResponse) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -3293,16 +4249,16 @@ func (ec *executionContext) _DidDocPublicKey_type(ctx context.Context, field gra
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidDocPublicKey",
+		Object:   "DidDereferenceResponse",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.DidDocPublicKey().Type(rctx, obj)
+		return obj.Doc, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3311,13 +4267,13 @@ func (ec *executionContext) _DidDocPublicKey_type(ctx context.Context, field gra
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*did.Document)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalODidDocument2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocument(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocPublicKey_controller(ctx context.Context, field graphql.CollectedField, obj *did.DocPublicKey) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDereferenceResponse_publicKey(ctx context.Context, field graphql.CollectedField, obj *DidDereferenceResponse) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -3327,16 +4283,16 @@ func (ec *executionContext) _DidDocPublicKey_controller(ctx context.Context, fie
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidDocPublicKey",
+		Object:   "DidDereferenceResponse",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.DidDocPublicKey().Controller(rctx, obj)
+		return obj.PublicKey, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3345,13 +4301,13 @@ func (ec *executionContext) _DidDocPublicKey_controller(ctx context.Context, fie
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*did.DocPublicKey)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalODidDocPublicKey2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocPublicKey(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocPublicKey_publicKeyPem(ctx context.Context, field graphql.CollectedField, obj *did.DocPublicKey) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDereferenceResponse_service(ctx context.Context, field graphql.CollectedField, obj *DidDereferenceResponse) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -3361,7 +4317,7 @@ func (ec *executionContext) _DidDocPublicKey_publicKeyPem(ctx context.Context, f
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidDocPublicKey",
+		Object:   "DidDereferenceResponse",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -3370,7 +4326,7 @@ func (ec *executionContext) _DidDocPublicKey_publicKeyPem(ctx context.Context, f
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PublicKeyPem, nil
+		return obj.Service, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3379,13 +4335,13 @@ func (ec *executionContext) _DidDocPublicKey_publicKeyPem(ctx context.Context, f
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*did.DocService)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalODidDocService2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocService(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocPublicKey_publicKeyJwk(ctx context.Context, field graphql.CollectedField, obj *did.DocPublicKey) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDereferenceResponse_serviceEndpoint(ctx context.Context, field graphql.CollectedField, obj *DidDereferenceResponse) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -3395,7 +4351,7 @@ func (ec *executionContext) _DidDocPublicKey_publicKeyJwk(ctx context.Context, f
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidDocPublicKey",
+		Object:   "DidDereferenceResponse",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -3404,7 +4360,7 @@ func (ec *executionContext) _DidDocPublicKey_publicKeyJwk(ctx context.Context, f
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PublicKeyJwk, nil
+		return obj.ServiceEndpoint, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3419,7 +4375,7 @@ func (ec *executionContext) _DidDocPublicKey_publicKeyJwk(ctx context.Context, f
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocPublicKey_publicKeyHex(ctx context.Context, field graphql.CollectedField, obj *did.DocPublicKey) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocAuthentication_publicKey(ctx context.Context, field graphql.CollectedField, obj *did.DocAuthenicationWrapper) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -3429,16 +4385,16 @@ func (ec *executionContext) _DidDocPublicKey_publicKeyHex(ctx context.Context, f
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidDocPublicKey",
+		Object:   "DidDocAuthentication",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PublicKeyHex, nil
+		return ec.resolvers.DidDocAuthentication().PublicKey(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3447,13 +4403,13 @@ func (ec *executionContext) _DidDocPublicKey_publicKeyHex(ctx context.Context, f
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*did.DocPublicKey)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalODidDocPublicKey2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocPublicKey(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocPublicKey_publicKeyBase64(ctx context.Context, field graphql.CollectedField, obj *did.DocPublicKey) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocAuthentication_idOnly(ctx context.Context, field graphql.CollectedField, obj *did.DocAuthenicationWrapper) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -3463,7 +4419,7 @@ func (ec *executionContext) _DidDocPublicKey_publicKeyBase64(ctx context.Context
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidDocPublicKey",
+		Object:   "DidDocAuthentication",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -3472,7 +4428,7 @@ func (ec *executionContext) _DidDocPublicKey_publicKeyBase64(ctx context.Context
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PublicKeyBase64, nil
+		return obj.IDOnly, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3481,13 +4437,13 @@ func (ec *executionContext) _DidDocPublicKey_publicKeyBase64(ctx context.Context
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(bool)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocPublicKey_publicKeyBase58(ctx context.Context, field graphql.CollectedField, obj *did.DocPublicKey) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocPublicKey_id(ctx context.Context, field graphql.CollectedField, obj *did.DocPublicKey) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -3500,13 +4456,13 @@ func (ec *executionContext) _DidDocPublicKey_publicKeyBase58(ctx context.Context
 		Object:   "DidDocPublicKey",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PublicKeyBase58, nil
+		return ec.resolvers.DidDocPublicKey().ID(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3521,7 +4477,7 @@ func (ec *executionContext) _DidDocPublicKey_publicKeyBase58(ctx context.Context
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocPublicKey_publicKeyMultibase(ctx context.Context, field graphql.CollectedField, obj *did.DocPublicKey) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocPublicKey_type(ctx context.Context, field graphql.CollectedField, obj *did.DocPublicKey) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -3534,13 +4490,13 @@ func (ec *executionContext) _DidDocPublicKey_publicKeyMultibase(ctx context.Cont
 		Object:   "DidDocPublicKey",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PublicKeyMultibase, nil
+		return ec.resolvers.DidDocPublicKey().Type(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3555,7 +4511,7 @@ func (ec *executionContext) _DidDocPublicKey_publicKeyMultibase(ctx context.Cont
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocPublicKey_ethereumAddress(ctx context.Context, field graphql.CollectedField, obj *did.DocPublicKey) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocPublicKey_controller(ctx context.Context, field graphql.CollectedField, obj *did.DocPublicKey) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -3568,13 +4524,13 @@ func (ec *executionContext) _DidDocPublicKey_ethereumAddress(ctx context.Context
 		Object:   "DidDocPublicKey",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.EthereumAddress, nil
+		return ec.resolvers.DidDocPublicKey().Controller(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3589,7 +4545,7 @@ func (ec *executionContext) _DidDocPublicKey_ethereumAddress(ctx context.Context
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocService_id(ctx context.Context, field graphql.CollectedField, obj *did.DocService) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocPublicKey_publicKeyPem(ctx context.Context, field graphql.CollectedField, obj *did.DocPublicKey) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -3599,16 +4555,16 @@ func (ec *executionContext) _DidDocService_id(ctx context.Context, field graphql
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidDocService",
+		Object:   "DidDocPublicKey",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.DidDocService().ID(rctx, obj)
+		return obj.PublicKeyPem, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3623,7 +4579,7 @@ func (ec *executionContext) _DidDocService_id(ctx context.Context, field graphql
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocService_type(ctx context.Context, field graphql.CollectedField, obj *did.DocService) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocPublicKey_publicKeyJwk(ctx context.Context, field graphql.CollectedField, obj *did.DocPublicKey) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -3633,7 +4589,7 @@ func (ec *executionContext) _DidDocService_type(ctx context.Context, field graph
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidDocService",
+		Object:   "DidDocPublicKey",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -3642,7 +4598,7 @@ func (ec *executionContext) _DidDocService_type(ctx context.Context, field graph
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Type, nil
+		return obj.PublicKeyJwk, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3651,13 +4607,13 @@ func (ec *executionContext) _DidDocService_type(ctx context.Context, field graph
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocService_description(ctx context.Context, field graphql.CollectedField, obj *did.DocService) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocPublicKey_publicKeyHex(ctx context.Context, field graphql.CollectedField, obj *did.DocPublicKey) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -3667,7 +4623,7 @@ func (ec *executionContext) _DidDocService_description(ctx context.Context, fiel
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidDocService",
+		Object:   "DidDocPublicKey",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -3676,7 +4632,7 @@ func (ec *executionContext) _DidDocService_description(ctx context.Context, fiel
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Description, nil
+		return obj.PublicKeyHex, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3685,13 +4641,13 @@ func (ec *executionContext) _DidDocService_description(ctx context.Context, fiel
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocService_publicKey(ctx context.Context, field graphql.CollectedField, obj *did.DocService) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocPublicKey_publicKeyBase64(ctx context.Context, field graphql.CollectedField, obj *did.DocPublicKey) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -3701,7 +4657,7 @@ func (ec *executionContext) _DidDocService_publicKey(ctx context.Context, field
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidDocService",
+		Object:   "DidDocPublicKey",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -3710,7 +4666,7 @@ func (ec *executionContext) _DidDocService_publicKey(ctx context.Context, field
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PublicKey, nil
+		return obj.PublicKeyBase64, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3719,13 +4675,13 @@ func (ec *executionContext) _DidDocService_publicKey(ctx context.Context, field
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocService_serviceEndpoint(ctx context.Context, field graphql.CollectedField, obj *did.DocService) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocPublicKey_publicKeyBase58(ctx context.Context, field graphql.CollectedField, obj *did.DocPublicKey) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -3735,16 +4691,16 @@ func (ec *executionContext) _DidDocService_serviceEndpoint(ctx context.Context,
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidDocService",
+		Object:   "DidDocPublicKey",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.DidDocService().ServiceEndpoint(rctx, obj)
+		return obj.PublicKeyBase58, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3753,13 +4709,13 @@ func (ec *executionContext) _DidDocService_serviceEndpoint(ctx context.Context,
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*utils.AnyValue)
+	res := resTmp.(*string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOAnyValue2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋutilsᚐAnyValue(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocument_id(ctx context.Context, field graphql.CollectedField, obj *did.Document) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocPublicKey_publicKeyMultibase(ctx context.Context, field graphql.CollectedField, obj *did.DocPublicKey) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -3769,16 +4725,16 @@ func (ec *executionContext) _DidDocument_id(ctx context.Context, field graphql.C
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidDocument",
+		Object:   "DidDocPublicKey",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.DidDocument().ID(rctx, obj)
+		return obj.PublicKeyMultibase, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3793,7 +4749,7 @@ func (ec *executionContext) _DidDocument_id(ctx context.Context, field graphql.C
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocument_context(ctx context.Context, field graphql.CollectedField, obj *did.Document) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocPublicKey_ethereumAddress(ctx context.Context, field graphql.CollectedField, obj *did.DocPublicKey) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -3803,7 +4759,7 @@ func (ec *executionContext) _DidDocument_context(ctx context.Context, field grap
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidDocument",
+		Object:   "DidDocPublicKey",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -3812,7 +4768,7 @@ func (ec *executionContext) _DidDocument_context(ctx context.Context, field grap
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Context, nil
+		return obj.EthereumAddress, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3821,13 +4777,13 @@ func (ec *executionContext) _DidDocument_context(ctx context.Context, field grap
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocument_controller(ctx context.Context, field graphql.CollectedField, obj *did.Document) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocService_id(ctx context.Context, field graphql.CollectedField, obj *did.DocService) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -3837,7 +4793,7 @@ func (ec *executionContext) _DidDocument_controller(ctx context.Context, field g
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidDocument",
+		Object:   "DidDocService",
 		Field:    field,
 		Args:     nil,
 		IsMethod: true,
@@ -3846,7 +4802,7 @@ func (ec *executionContext) _DidDocument_controller(ctx context.Context, field g
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.DidDocument().Controller(rctx, obj)
+		return ec.resolvers.DidDocService().ID(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3861,7 +4817,7 @@ func (ec *executionContext) _DidDocument_controller(ctx context.Context, field g
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocument_publicKeys(ctx context.Context, field graphql.CollectedField, obj *did.Document) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocService_type(ctx context.Context, field graphql.CollectedField, obj *did.DocService) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -3871,7 +4827,7 @@ func (ec *executionContext) _DidDocument_publicKeys(ctx context.Context, field g
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidDocument",
+		Object:   "DidDocService",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -3880,7 +4836,7 @@ func (ec *executionContext) _DidDocument_publicKeys(ctx context.Context, field g
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PublicKeys, nil
+		return obj.Type, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3889,13 +4845,13 @@ func (ec *executionContext) _DidDocument_publicKeys(ctx context.Context, field g
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]did.DocPublicKey)
+	res := resTmp.(string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalODidDocPublicKey2ᚕgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocPublicKey(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocument_authentications(ctx context.Context, field graphql.CollectedField, obj *did.Document) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocService_description(ctx context.Context, field graphql.CollectedField, obj *did.DocService) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -3905,7 +4861,7 @@ func (ec *executionContext) _DidDocument_authentications(ctx context.Context, fi
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidDocument",
+		Object:   "DidDocService",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -3914,7 +4870,7 @@ func (ec *executionContext) _DidDocument_authentications(ctx context.Context, fi
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Authentications, nil
+		return obj.Description, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3923,13 +4879,13 @@ func (ec *executionContext) _DidDocument_authentications(ctx context.Context, fi
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]did.DocAuthenicationWrapper)
+	res := resTmp.(string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalODidDocAuthentication2ᚕgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocAuthenicationWrapper(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocument_services(ctx context.Context, field graphql.CollectedField, obj *did.Document) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocService_publicKey(ctx context.Context, field graphql.CollectedField, obj *did.DocService) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -3939,7 +4895,7 @@ func (ec *executionContext) _DidDocument_services(ctx context.Context, field gra
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidDocument",
+		Object:   "DidDocService",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -3948,7 +4904,7 @@ func (ec *executionContext) _DidDocument_services(ctx context.Context, field gra
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Services, nil
+		return obj.PublicKey, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3957,13 +4913,13 @@ func (ec *executionContext) _DidDocument_services(ctx context.Context, field gra
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]did.DocService)
+	res := resTmp.(string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalODidDocService2ᚕgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocService(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocument_created(ctx context.Context, field graphql.CollectedField, obj *did.Document) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocService_serviceEndpoint(ctx context.Context, field graphql.CollectedField, obj *did.DocService) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -3973,16 +4929,16 @@ func (ec *executionContext) _DidDocument_created(ctx context.Context, field grap
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidDocument",
+		Object:   "DidDocService",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Created, nil
+		return ec.resolvers.DidDocService().ServiceEndpoint(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3991,13 +4947,13 @@ func (ec *executionContext) _DidDocument_created(ctx context.Context, field grap
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*time.Time)
+	res := resTmp.(*utils.AnyValue)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOTime2ᚖtimeᚐTime(ctx, field.Selections, res)
+	return ec.marshalOAnyValue2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋutilsᚐAnyValue(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocument_updated(ctx context.Context, field graphql.CollectedField, obj *did.Document) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocument_id(ctx context.Context, field graphql.CollectedField, obj *did.Document) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4010,13 +4966,13 @@ func (ec *executionContext) _DidDocument_updated(ctx context.Context, field grap
 		Object:   "DidDocument",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Updated, nil
+		return ec.resolvers.DidDocument().ID(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4025,13 +4981,13 @@ func (ec *executionContext) _DidDocument_updated(ctx context.Context, field grap
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*time.Time)
+	res := resTmp.(*string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOTime2ᚖtimeᚐTime(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidDocument_proof(ctx context.Context, field graphql.CollectedField, obj *did.Document) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocument_context(ctx context.Context, field graphql.CollectedField, obj *did.Document) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4050,7 +5006,7 @@ func (ec *executionContext) _DidDocument_proof(ctx context.Context, field graphq
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Proof, nil
+		return obj.Context, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4059,13 +5015,13 @@ func (ec *executionContext) _DidDocument_proof(ctx context.Context, field graphq
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*linkeddata.Proof)
+	res := resTmp.(string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOLinkedDataProof2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋlinkeddataᚐProof(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidGetResponse_doc(ctx context.Context, field graphql.CollectedField, obj *DidGetResponse) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocument_controller(ctx context.Context, field graphql.CollectedField, obj *did.Document) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4075,16 +5031,16 @@ func (ec *executionContext) _DidGetResponse_doc(ctx context.Context, field graph
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidGetResponse",
+		Object:   "DidDocument",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Doc, nil
+		return ec.resolvers.DidDocument().Controller(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4093,13 +5049,13 @@ func (ec *executionContext) _DidGetResponse_doc(ctx context.Context, field graph
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*did.Document)
+	res := resTmp.(*string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalODidDocument2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocument(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidGetResponse_docRaw(ctx context.Context, field graphql.CollectedField, obj *DidGetResponse) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocument_publicKeys(ctx context.Context, field graphql.CollectedField, obj *did.Document) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4109,16 +5065,16 @@ func (ec *executionContext) _DidGetResponse_docRaw(ctx context.Context, field gr
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidGetResponse",
+		Object:   "DidDocument",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.DocRaw(), nil
+		return obj.PublicKeys, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4127,13 +5083,13 @@ func (ec *executionContext) _DidGetResponse_docRaw(ctx context.Context, field gr
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.([]did.DocPublicKey)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalODidDocPublicKey2ᚕgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocPublicKey(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidSaveResponse_doc(ctx context.Context, field graphql.CollectedField, obj *DidSaveResponse) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocument_verificationMethods(ctx context.Context, field graphql.CollectedField, obj *did.Document) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4143,16 +5099,16 @@ func (ec *executionContext) _DidSaveResponse_doc(ctx context.Context, field grap
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidSaveResponse",
+		Object:   "DidDocument",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Doc, nil
+		return ec.resolvers.DidDocument().VerificationMethods(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4161,13 +5117,13 @@ func (ec *executionContext) _DidSaveResponse_doc(ctx context.Context, field grap
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*did.Document)
+	res := resTmp.([]*did.DocPublicKey)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalODidDocument2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocument(ctx, field.Selections, res)
+	return ec.marshalODidDocPublicKey2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocPublicKey(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _DidSaveResponse_docRaw(ctx context.Context, field graphql.CollectedField, obj *DidSaveResponse) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocument_authentications(ctx context.Context, field graphql.CollectedField, obj *did.Document) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4177,16 +5133,16 @@ func (ec *executionContext) _DidSaveResponse_docRaw(ctx context.Context, field g
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "DidSaveResponse",
+		Object:   "DidDocument",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.DocRaw(), nil
+		return obj.Authentications, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4195,13 +5151,13 @@ func (ec *executionContext) _DidSaveResponse_docRaw(ctx context.Context, field g
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.([]did.DocAuthenicationWrapper)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalODidDocAuthentication2ᚕgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocAuthenicationWrapper(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Edge_hash(ctx context.Context, field graphql.CollectedField, obj *claimsstore.JWTClaimPostgres) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocument_assertionMethods(ctx context.Context, field graphql.CollectedField, obj *did.Document) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4211,7 +5167,7 @@ func (ec *executionContext) _Edge_hash(ctx context.Context, field graphql.Collec
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "Edge",
+		Object:   "DidDocument",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -4220,25 +5176,22 @@ func (ec *executionContext) _Edge_hash(ctx context.Context, field graphql.Collec
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Hash, nil
+		return obj.AssertionMethods, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !ec.HasError(rctx) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]did.DocAuthenicationWrapper)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	return ec.marshalODidDocAuthentication2ᚕgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocAuthenicationWrapper(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Edge_jwt(ctx context.Context, field graphql.CollectedField, obj *claimsstore.JWTClaimPostgres) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocument_keyAgreements(ctx context.Context, field graphql.CollectedField, obj *did.Document) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4248,7 +5201,7 @@ func (ec *executionContext) _Edge_jwt(ctx context.Context, field graphql.Collect
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "Edge",
+		Object:   "DidDocument",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -4257,25 +5210,22 @@ func (ec *executionContext) _Edge_jwt(ctx context.Context, field graphql.Collect
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.JWT, nil
+		return obj.KeyAgreements, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !ec.HasError(rctx) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]did.DocAuthenicationWrapper)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalODidDocAuthentication2ᚕgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocAuthenicationWrapper(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Edge_from(ctx context.Context, field graphql.CollectedField, obj *claimsstore.JWTClaimPostgres) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocument_capabilityInvocations(ctx context.Context, field graphql.CollectedField, obj *did.Document) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4285,34 +5235,31 @@ func (ec *executionContext) _Edge_from(ctx context.Context, field graphql.Collec
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "Edge",
+		Object:   "DidDocument",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Edge().From(rctx, obj)
+		return obj.CapabilityInvocations, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !ec.HasError(rctx) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]did.DocAuthenicationWrapper)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalODidDocAuthentication2ᚕgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocAuthenicationWrapper(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Edge_to(ctx context.Context, field graphql.CollectedField, obj *claimsstore.JWTClaimPostgres) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocument_capabilityDelegations(ctx context.Context, field graphql.CollectedField, obj *did.Document) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4322,16 +5269,16 @@ func (ec *executionContext) _Edge_to(ctx context.Context, field graphql.Collecte
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "Edge",
+		Object:   "DidDocument",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Edge().To(rctx, obj)
+		return obj.CapabilityDelegations, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4340,13 +5287,13 @@ func (ec *executionContext) _Edge_to(ctx context.Context, field graphql.Collecte
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.([]did.DocAuthenicationWrapper)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalODidDocAuthentication2ᚕgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocAuthenicationWrapper(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Edge_type(ctx context.Context, field graphql.CollectedField, obj *claimsstore.JWTClaimPostgres) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocument_services(ctx context.Context, field graphql.CollectedField, obj *did.Document) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4356,7 +5303,7 @@ func (ec *executionContext) _Edge_type(ctx context.Context, field graphql.Collec
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "Edge",
+		Object:   "DidDocument",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -4365,7 +5312,7 @@ func (ec *executionContext) _Edge_type(ctx context.Context, field graphql.Collec
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Type, nil
+		return obj.Services, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4374,13 +5321,13 @@ func (ec *executionContext) _Edge_type(ctx context.Context, field graphql.Collec
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]did.DocService)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalODidDocService2ᚕgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocService(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Edge_time(ctx context.Context, field graphql.CollectedField, obj *claimsstore.JWTClaimPostgres) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocument_created(ctx context.Context, field graphql.CollectedField, obj *did.Document) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4390,34 +5337,31 @@ func (ec *executionContext) _Edge_time(ctx context.Context, field graphql.Collec
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "Edge",
+		Object:   "DidDocument",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Edge().Time(rctx, obj)
+		return obj.Created, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !ec.HasError(rctx) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*time.Time)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOTime2ᚖtimeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Edge_data(ctx context.Context, field graphql.CollectedField, obj *claimsstore.JWTClaimPostgres) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocument_updated(ctx context.Context, field graphql.CollectedField, obj *did.Document) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4427,7 +5371,7 @@ func (ec *executionContext) _Edge_data(ctx context.Context, field graphql.Collec
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "Edge",
+		Object:   "DidDocument",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -4436,7 +5380,7 @@ func (ec *executionContext) _Edge_data(ctx context.Context, field graphql.Collec
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Data, nil
+		return obj.Updated, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4445,13 +5389,13 @@ func (ec *executionContext) _Edge_data(ctx context.Context, field graphql.Collec
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*time.Time)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOTime2ᚖtimeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Edge_proof(ctx context.Context, field graphql.CollectedField, obj *claimsstore.JWTClaimPostgres) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidDocument_proof(ctx context.Context, field graphql.CollectedField, obj *did.Document) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4461,16 +5405,16 @@ func (ec *executionContext) _Edge_proof(ctx context.Context, field graphql.Colle
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "Edge",
+		Object:   "DidDocument",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Edge().Proof(rctx, obj)
+		return obj.Proof, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4479,13 +5423,13 @@ func (ec *executionContext) _Edge_proof(ctx context.Context, field graphql.Colle
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]Proof)
+	res := resTmp.(*linkeddata.Proof)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOProof2ᚕgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐProof(ctx, field.Selections, res)
+	return ec.marshalOLinkedDataProof2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋlinkeddataᚐProof(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _LinkedDataProof_type(ctx context.Context, field graphql.CollectedField, obj *linkeddata.Proof) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidGetResponse_doc(ctx context.Context, field graphql.CollectedField, obj *DidGetResponse) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4495,7 +5439,7 @@ func (ec *executionContext) _LinkedDataProof_type(ctx context.Context, field gra
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "LinkedDataProof",
+		Object:   "DidGetResponse",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -4504,7 +5448,7 @@ func (ec *executionContext) _LinkedDataProof_type(ctx context.Context, field gra
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Type, nil
+		return obj.Doc, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4513,13 +5457,13 @@ func (ec *executionContext) _LinkedDataProof_type(ctx context.Context, field gra
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*did.Document)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalODidDocument2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocument(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _LinkedDataProof_creator(ctx context.Context, field graphql.CollectedField, obj *linkeddata.Proof) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidGetResponse_docRaw(ctx context.Context, field graphql.CollectedField, obj *DidGetResponse) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4529,16 +5473,16 @@ func (ec *executionContext) _LinkedDataProof_creator(ctx context.Context, field
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "LinkedDataProof",
+		Object:   "DidGetResponse",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Creator, nil
+		return obj.DocRaw(), nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4547,13 +5491,13 @@ func (ec *executionContext) _LinkedDataProof_creator(ctx context.Context, field
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _LinkedDataProof_created(ctx context.Context, field graphql.CollectedField, obj *linkeddata.Proof) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidGetResponse_version(ctx context.Context, field graphql.CollectedField, obj *DidGetResponse) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4563,7 +5507,7 @@ func (ec *executionContext) _LinkedDataProof_created(ctx context.Context, field
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "LinkedDataProof",
+		Object:   "DidGetResponse",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -4572,7 +5516,7 @@ func (ec *executionContext) _LinkedDataProof_created(ctx context.Context, field
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Created, nil
+		return obj.Version, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4581,13 +5525,13 @@ func (ec *executionContext) _LinkedDataProof_created(ctx context.Context, field
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(time.Time)
+	res := resTmp.(*int)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOTime2timeᚐTime(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _LinkedDataProof_proofValue(ctx context.Context, field graphql.CollectedField, obj *linkeddata.Proof) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidGetResponse_linkedDomains(ctx context.Context, field graphql.CollectedField, obj *DidGetResponse) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4597,7 +5541,7 @@ func (ec *executionContext) _LinkedDataProof_proofValue(ctx context.Context, fie
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "LinkedDataProof",
+		Object:   "DidGetResponse",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -4606,7 +5550,7 @@ func (ec *executionContext) _LinkedDataProof_proofValue(ctx context.Context, fie
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ProofValue, nil
+		return obj.LinkedDomains, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4615,13 +5559,13 @@ func (ec *executionContext) _LinkedDataProof_proofValue(ctx context.Context, fie
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOString2ᚕstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _LinkedDataProof_domain(ctx context.Context, field graphql.CollectedField, obj *linkeddata.Proof) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidGetResponse_deactivated(ctx context.Context, field graphql.CollectedField, obj *DidGetResponse) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4631,7 +5575,7 @@ func (ec *executionContext) _LinkedDataProof_domain(ctx context.Context, field g
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "LinkedDataProof",
+		Object:   "DidGetResponse",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -4640,7 +5584,7 @@ func (ec *executionContext) _LinkedDataProof_domain(ctx context.Context, field g
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Domain, nil
+		return obj.Deactivated, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4649,13 +5593,13 @@ func (ec *executionContext) _LinkedDataProof_domain(ctx context.Context, field g
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*bool)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOBoolean2ᚖbool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _LinkedDataProof_nonce(ctx context.Context, field graphql.CollectedField, obj *linkeddata.Proof) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidGetResponse_deactivatedAt(ctx context.Context, field graphql.CollectedField, obj *DidGetResponse) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4665,7 +5609,7 @@ func (ec *executionContext) _LinkedDataProof_nonce(ctx context.Context, field gr
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "LinkedDataProof",
+		Object:   "DidGetResponse",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -4674,7 +5618,7 @@ func (ec *executionContext) _LinkedDataProof_nonce(ctx context.Context, field gr
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Nonce, nil
+		return obj.DeactivatedAt, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4683,13 +5627,13 @@ func (ec *executionContext) _LinkedDataProof_nonce(ctx context.Context, field gr
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*time.Time)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOTime2ᚖtimeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_version(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidSaveResponse_doc(ctx context.Context, field graphql.CollectedField, obj *DidSaveResponse) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4699,34 +5643,31 @@ func (ec *executionContext) _Mutation_version(ctx context.Context, field graphql
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "Mutation",
+		Object:   "DidSaveResponse",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().Version(rctx)
+		return obj.Doc, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !ec.HasError(rctx) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*did.Document)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalODidDocument2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocument(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_claimSave(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidSaveResponse_docRaw(ctx context.Context, field graphql.CollectedField, obj *DidSaveResponse) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4736,23 +5677,16 @@ func (ec *executionContext) _Mutation_claimSave(ctx context.Context, field graph
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "Mutation",
+		Object:   "DidSaveResponse",
 		Field:    field,
 		Args:     nil,
 		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_claimSave_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().ClaimSave(rctx, args["in"].(*ClaimSaveRequestInput))
+		return obj.DocRaw(), nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4761,13 +5695,13 @@ func (ec *executionContext) _Mutation_claimSave(ctx context.Context, field graph
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*ClaimSaveResponse)
+	res := resTmp.(*string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOClaimSaveResponse2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐClaimSaveResponse(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_addEdge(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidSaveResponse_version(ctx context.Context, field graphql.CollectedField, obj *DidSaveResponse) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4777,23 +5711,16 @@ func (ec *executionContext) _Mutation_addEdge(ctx context.Context, field graphql
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "Mutation",
+		Object:   "DidSaveResponse",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_addEdge_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().AddEdge(rctx, args["edgeJWT"].(*string))
+		return obj.Version, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4802,13 +5729,13 @@ func (ec *executionContext) _Mutation_addEdge(ctx context.Context, field graphql
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*claimsstore.JWTClaimPostgres)
+	res := resTmp.(*int)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOEdge2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimsstoreᚐJWTClaimPostgres(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_version(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _DidSearchResponse_docs(ctx context.Context, field graphql.CollectedField, obj *DidSearchResponse) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4818,16 +5745,16 @@ func (ec *executionContext) _Query_version(ctx context.Context, field graphql.Co
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "Query",
+		Object:   "DidSearchResponse",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Version(rctx)
+		return obj.Docs, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4839,13 +5766,13 @@ func (ec *executionContext) _Query_version(ctx context.Context, field graphql.Co
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]*did.Document)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNDidDocument2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocument(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_didGet(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _DomainLinkage_did(ctx context.Context, field graphql.CollectedField, obj *domainlinkage.Linkage) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4855,38 +5782,34 @@ func (ec *executionContext) _Query_didGet(ctx context.Context, field graphql.Col
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "Query",
+		Object:   "DomainLinkage",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_didGet_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().DidGet(rctx, args["in"].(*DidGetRequestInput))
+		return obj.DID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*DidGetResponse)
+	res := resTmp.(string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalODidGetResponse2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidGetResponse(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_claimGet(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _DomainLinkage_origin(ctx context.Context, field graphql.CollectedField, obj *domainlinkage.Linkage) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4896,38 +5819,34 @@ func (ec *executionContext) _Query_claimGet(ctx context.Context, field graphql.C
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "Query",
+		Object:   "DomainLinkage",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_claimGet_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ClaimGet(rctx, args["in"].(*ClaimGetRequestInput))
+		return obj.Origin, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*ClaimGetResponse)
+	res := resTmp.(string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOClaimGetResponse2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐClaimGetResponse(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_claimProof(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _DomainLinkage_hosted(ctx context.Context, field graphql.CollectedField, obj *domainlinkage.Linkage) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4937,38 +5856,34 @@ func (ec *executionContext) _Query_claimProof(ctx context.Context, field graphql
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "Query",
+		Object:   "DomainLinkage",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_claimProof_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ClaimProof(rctx, args["in"].(*ClaimProofRequestInput))
+		return obj.Hosted, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*ClaimProofResponse)
+	res := resTmp.(bool)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOClaimProofResponse2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐClaimProofResponse(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_findEdges(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _DomainLinkage_expires(ctx context.Context, field graphql.CollectedField, obj *domainlinkage.Linkage) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -4978,41 +5893,31 @@ func (ec *executionContext) _Query_findEdges(ctx context.Context, field graphql.
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "Query",
+		Object:   "DomainLinkage",
 		Field:    field,
 		Args:     nil,
 		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_findEdges_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().FindEdges(rctx, args["in"].(*FindEdgesInput))
+		return ec.resolvers.DomainLinkage().Expires(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !ec.HasError(rctx) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.([]*claimsstore.JWTClaimPostgres)
+	res := resTmp.(*time.Time)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalNEdge2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimsstoreᚐJWTClaimPostgres(ctx, field.Selections, res)
+	return ec.marshalOTime2ᚖtimeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _DomainLinkage_jwt(ctx context.Context, field graphql.CollectedField, obj *domainlinkage.Linkage) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5022,38 +5927,34 @@ func (ec *executionContext) _Query___type(ctx context.Context, field graphql.Col
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "Query",
+		Object:   "DomainLinkage",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query___type_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.introspectType(args["name"].(string))
+		return obj.JWT, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Type)
+	res := resTmp.(string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Edge_hash(ctx context.Context, field graphql.CollectedField, obj *claimsstore.JWTClaimPostgres) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5063,31 +5964,34 @@ func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.C
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "Query",
+		Object:   "Edge",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.introspectSchema()
+		return obj.Hash, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Schema)
+	res := resTmp.(string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema(ctx, field.Selections, res)
+	return ec.marshalNID2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _RootOnBlockChainProof_type(ctx context.Context, field graphql.CollectedField, obj *RootOnBlockChainProof) (ret graphql.Marshaler) {
+func (ec *executionContext) _Edge_jwt(ctx context.Context, field graphql.CollectedField, obj *claimsstore.JWTClaimPostgres) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5097,7 +6001,7 @@ func (ec *executionContext) _RootOnBlockChainProof_type(ctx context.Context, fie
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "RootOnBlockChainProof",
+		Object:   "Edge",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -5106,7 +6010,7 @@ func (ec *executionContext) _RootOnBlockChainProof_type(ctx context.Context, fie
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Type, nil
+		return obj.JWT, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5124,7 +6028,7 @@ func (ec *executionContext) _RootOnBlockChainProof_type(ctx context.Context, fie
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _RootOnBlockChainProof_blockNumber(ctx context.Context, field graphql.CollectedField, obj *RootOnBlockChainProof) (ret graphql.Marshaler) {
+func (ec *executionContext) _Edge_from(ctx context.Context, field graphql.CollectedField, obj *claimsstore.JWTClaimPostgres) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5134,16 +6038,16 @@ func (ec *executionContext) _RootOnBlockChainProof_blockNumber(ctx context.Conte
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "RootOnBlockChainProof",
+		Object:   "Edge",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.BlockNumber, nil
+		return ec.resolvers.Edge().From(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5161,7 +6065,7 @@ func (ec *executionContext) _RootOnBlockChainProof_blockNumber(ctx context.Conte
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _RootOnBlockChainProof_root(ctx context.Context, field graphql.CollectedField, obj *RootOnBlockChainProof) (ret graphql.Marshaler) {
+func (ec *executionContext) _Edge_to(ctx context.Context, field graphql.CollectedField, obj *claimsstore.JWTClaimPostgres) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5171,34 +6075,31 @@ func (ec *executionContext) _RootOnBlockChainProof_root(ctx context.Context, fie
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "RootOnBlockChainProof",
+		Object:   "Edge",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Root, nil
+		return ec.resolvers.Edge().To(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !ec.HasError(rctx) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _RootOnBlockChainProof_contractAddress(ctx context.Context, field graphql.CollectedField, obj *RootOnBlockChainProof) (ret graphql.Marshaler) {
+func (ec *executionContext) _Edge_type(ctx context.Context, field graphql.CollectedField, obj *claimsstore.JWTClaimPostgres) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5208,7 +6109,7 @@ func (ec *executionContext) _RootOnBlockChainProof_contractAddress(ctx context.C
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "RootOnBlockChainProof",
+		Object:   "Edge",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -5217,25 +6118,22 @@ func (ec *executionContext) _RootOnBlockChainProof_contractAddress(ctx context.C
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ContractAddress, nil
+		return obj.Type, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !ec.HasError(rctx) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
 	res := resTmp.(string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _RootOnBlockChainProof_committerAddress(ctx context.Context, field graphql.CollectedField, obj *RootOnBlockChainProof) (ret graphql.Marshaler) {
+func (ec *executionContext) _Edge_time(ctx context.Context, field graphql.CollectedField, obj *claimsstore.JWTClaimPostgres) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5245,16 +6143,16 @@ func (ec *executionContext) _RootOnBlockChainProof_committerAddress(ctx context.
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "RootOnBlockChainProof",
+		Object:   "Edge",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CommitterAddress, nil
+		return ec.resolvers.Edge().Time(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5272,7 +6170,7 @@ func (ec *executionContext) _RootOnBlockChainProof_committerAddress(ctx context.
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _RootOnBlockChainProof_txHash(ctx context.Context, field graphql.CollectedField, obj *RootOnBlockChainProof) (ret graphql.Marshaler) {
+func (ec *executionContext) _Edge_data(ctx context.Context, field graphql.CollectedField, obj *claimsstore.JWTClaimPostgres) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5282,7 +6180,7 @@ func (ec *executionContext) _RootOnBlockChainProof_txHash(ctx context.Context, f
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "RootOnBlockChainProof",
+		Object:   "Edge",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -5291,25 +6189,22 @@ func (ec *executionContext) _RootOnBlockChainProof_txHash(ctx context.Context, f
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TxHash, nil
+		return obj.Data, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !ec.HasError(rctx) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
 	res := resTmp.(string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___Directive_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
+func (ec *executionContext) _Edge_proof(ctx context.Context, field graphql.CollectedField, obj *claimsstore.JWTClaimPostgres) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5319,34 +6214,31 @@ func (ec *executionContext) ___Directive_name(ctx context.Context, field graphql
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__Directive",
+		Object:   "Edge",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return ec.resolvers.Edge().Proof(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !ec.HasError(rctx) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]Proof)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOProof2ᚕgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐProof(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___Directive_description(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
+func (ec *executionContext) _LinkedDataProof_type(ctx context.Context, field graphql.CollectedField, obj *linkeddata.Proof) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5356,7 +6248,7 @@ func (ec *executionContext) ___Directive_description(ctx context.Context, field
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__Directive",
+		Object:   "LinkedDataProof",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -5365,7 +6257,7 @@ func (ec *executionContext) ___Directive_description(ctx context.Context, field
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Description, nil
+		return obj.Type, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5380,7 +6272,7 @@ func (ec *executionContext) ___Directive_description(ctx context.Context, field
 	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___Directive_locations(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
+func (ec *executionContext) _LinkedDataProof_creator(ctx context.Context, field graphql.CollectedField, obj *linkeddata.Proof) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5390,7 +6282,7 @@ func (ec *executionContext) ___Directive_locations(ctx context.Context, field gr
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__Directive",
+		Object:   "LinkedDataProof",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -5399,25 +6291,22 @@ func (ec *executionContext) ___Directive_locations(ctx context.Context, field gr
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Locations, nil
+		return obj.Creator, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !ec.HasError(rctx) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.([]string)
+	res := resTmp.(string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalN__DirectiveLocation2ᚕstring(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___Directive_args(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
+func (ec *executionContext) _LinkedDataProof_created(ctx context.Context, field graphql.CollectedField, obj *linkeddata.Proof) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5427,7 +6316,7 @@ func (ec *executionContext) ___Directive_args(ctx context.Context, field graphql
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__Directive",
+		Object:   "LinkedDataProof",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -5436,25 +6325,22 @@ func (ec *executionContext) ___Directive_args(ctx context.Context, field graphql
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Args, nil
+		return obj.Created, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !ec.HasError(rctx) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.([]introspection.InputValue)
+	res := resTmp.(time.Time)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalN__InputValue2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐInputValue(ctx, field.Selections, res)
+	return ec.marshalOTime2timeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___EnumValue_name(ctx context.Context, field graphql.CollectedField, obj *introspection.EnumValue) (ret graphql.Marshaler) {
+func (ec *executionContext) _LinkedDataProof_proofValue(ctx context.Context, field graphql.CollectedField, obj *linkeddata.Proof) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5464,7 +6350,7 @@ func (ec *executionContext) ___EnumValue_name(ctx context.Context, field graphql
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__EnumValue",
+		Object:   "LinkedDataProof",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -5473,25 +6359,22 @@ func (ec *executionContext) ___EnumValue_name(ctx context.Context, field graphql
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return obj.ProofValue, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !ec.HasError(rctx) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
 	res := resTmp.(string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___EnumValue_description(ctx context.Context, field graphql.CollectedField, obj *introspection.EnumValue) (ret graphql.Marshaler) {
+func (ec *executionContext) _LinkedDataProof_domain(ctx context.Context, field graphql.CollectedField, obj *linkeddata.Proof) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5501,7 +6384,7 @@ func (ec *executionContext) ___EnumValue_description(ctx context.Context, field
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__EnumValue",
+		Object:   "LinkedDataProof",
 		Field:    field,
 		Args:     nil,
 		IsMethod: false,
@@ -5510,7 +6393,7 @@ func (ec *executionContext) ___EnumValue_description(ctx context.Context, field
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Description, nil
+		return obj.Domain, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5519,13 +6402,13 @@ func (ec *executionContext) ___EnumValue_description(ctx context.Context, field
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___EnumValue_isDeprecated(ctx context.Context, field graphql.CollectedField, obj *introspection.EnumValue) (ret graphql.Marshaler) {
+func (ec *executionContext) _LinkedDataProof_nonce(ctx context.Context, field graphql.CollectedField, obj *linkeddata.Proof) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5535,34 +6418,31 @@ func (ec *executionContext) ___EnumValue_isDeprecated(ctx context.Context, field
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__EnumValue",
+		Object:   "LinkedDataProof",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.IsDeprecated(), nil
+		return obj.Nonce, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !ec.HasError(rctx) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(*string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___EnumValue_deprecationReason(ctx context.Context, field graphql.CollectedField, obj *introspection.EnumValue) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_version(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5572,7 +6452,7 @@ func (ec *executionContext) ___EnumValue_deprecationReason(ctx context.Context,
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__EnumValue",
+		Object:   "Mutation",
 		Field:    field,
 		Args:     nil,
 		IsMethod: true,
@@ -5581,22 +6461,25 @@ func (ec *executionContext) ___EnumValue_deprecationReason(ctx context.Context,
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.DeprecationReason(), nil
+		return ec.resolvers.Mutation().Version(rctx)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___Field_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_didSave(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5606,34 +6489,38 @@ func (ec *executionContext) ___Field_name(ctx context.Context, field graphql.Col
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__Field",
+		Object:   "Mutation",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_didSave_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return ec.resolvers.Mutation().DidSave(rctx, args["in"].(DidSaveInput))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !ec.HasError(rctx) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*DidSaveResponse)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalODidSaveResponse2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidSaveResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___Field_description(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_didDeactivate(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5643,16 +6530,23 @@ func (ec *executionContext) ___Field_description(ctx context.Context, field grap
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__Field",
+		Object:   "Mutation",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_didDeactivate_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Description, nil
+		return ec.resolvers.Mutation().DidDeactivate(rctx, args["in"].(DidDeactivateInput))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5661,13 +6555,13 @@ func (ec *executionContext) ___Field_description(ctx context.Context, field grap
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*DidDeactivateResponse)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalODidDeactivateResponse2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDeactivateResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___Field_args(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_domainLinkageSave(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5677,34 +6571,38 @@ func (ec *executionContext) ___Field_args(ctx context.Context, field graphql.Col
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__Field",
+		Object:   "Mutation",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_domainLinkageSave_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Args, nil
+		return ec.resolvers.Mutation().DomainLinkageSave(rctx, args["in"].(DomainLinkageSaveInput))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !ec.HasError(rctx) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.([]introspection.InputValue)
+	res := resTmp.(*domainlinkage.Linkage)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalN__InputValue2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐInputValue(ctx, field.Selections, res)
+	return ec.marshalODomainLinkage2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdomainlinkageᚐLinkage(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___Field_type(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_domainLinkageVerify(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5714,34 +6612,38 @@ func (ec *executionContext) ___Field_type(ctx context.Context, field graphql.Col
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__Field",
+		Object:   "Mutation",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_domainLinkageVerify_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Type, nil
+		return ec.resolvers.Mutation().DomainLinkageVerify(rctx, args["in"].(DomainLinkageVerifyInput))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !ec.HasError(rctx) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Type)
+	res := resTmp.(*domainlinkage.Linkage)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalN__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+	return ec.marshalODomainLinkage2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdomainlinkageᚐLinkage(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___Field_isDeprecated(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_claimSave(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5751,34 +6653,38 @@ func (ec *executionContext) ___Field_isDeprecated(ctx context.Context, field gra
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__Field",
+		Object:   "Mutation",
 		Field:    field,
 		Args:     nil,
 		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_claimSave_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.IsDeprecated(), nil
+		return ec.resolvers.Mutation().ClaimSave(rctx, args["in"].(*ClaimSaveRequestInput))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !ec.HasError(rctx) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(*ClaimSaveResponse)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalOClaimSaveResponse2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐClaimSaveResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___Field_deprecationReason(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_addEdge(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5788,16 +6694,23 @@ func (ec *executionContext) ___Field_deprecationReason(ctx context.Context, fiel
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__Field",
+		Object:   "Mutation",
 		Field:    field,
 		Args:     nil,
 		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_addEdge_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.DeprecationReason(), nil
+		return ec.resolvers.Mutation().AddEdge(rctx, args["edgeJWT"].(*string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5806,13 +6719,13 @@ func (ec *executionContext) ___Field_deprecationReason(ctx context.Context, fiel
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*claimsstore.JWTClaimPostgres)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOEdge2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimsstoreᚐJWTClaimPostgres(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___InputValue_name(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_version(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5822,16 +6735,16 @@ func (ec *executionContext) ___InputValue_name(ctx context.Context, field graphq
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__InputValue",
+		Object:   "Query",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return ec.resolvers.Query().Version(rctx)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5849,7 +6762,7 @@ func (ec *executionContext) ___InputValue_name(ctx context.Context, field graphq
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___InputValue_description(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_didGet(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5859,16 +6772,23 @@ func (ec *executionContext) ___InputValue_description(ctx context.Context, field
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__InputValue",
+		Object:   "Query",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_didGet_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Description, nil
+		return ec.resolvers.Query().DidGet(rctx, args["in"].(*DidGetRequestInput))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5877,13 +6797,13 @@ func (ec *executionContext) ___InputValue_description(ctx context.Context, field
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*DidGetResponse)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalODidGetResponse2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidGetResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___InputValue_type(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_didSearch(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5893,34 +6813,38 @@ func (ec *executionContext) ___InputValue_type(ctx context.Context, field graphq
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__InputValue",
+		Object:   "Query",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_didSearch_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Type, nil
+		return ec.resolvers.Query().DidSearch(rctx, args["in"].(DidSearchInput))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !ec.HasError(rctx) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Type)
+	res := resTmp.(*DidSearchResponse)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalN__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+	return ec.marshalODidSearchResponse2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidSearchResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___InputValue_defaultValue(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_didDereference(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5930,16 +6854,23 @@ func (ec *executionContext) ___InputValue_defaultValue(ctx context.Context, fiel
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__InputValue",
+		Object:   "Query",
 		Field:    field,
 		Args:     nil,
-		IsMethod: false,
+		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_didDereference_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.DefaultValue, nil
+		return ec.resolvers.Query().DidDereference(rctx, args["in"].(DidDereferenceInput))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5948,13 +6879,13 @@ func (ec *executionContext) ___InputValue_defaultValue(ctx context.Context, fiel
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*DidDereferenceResponse)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalODidDereferenceResponse2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDereferenceResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___Schema_types(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_claimGet(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -5964,34 +6895,38 @@ func (ec *executionContext) ___Schema_types(ctx context.Context, field graphql.C
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__Schema",
+		Object:   "Query",
 		Field:    field,
 		Args:     nil,
 		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_claimGet_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Types(), nil
+		return ec.resolvers.Query().ClaimGet(rctx, args["in"].(*ClaimGetRequestInput))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !ec.HasError(rctx) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.([]introspection.Type)
+	res := resTmp.(*ClaimGetResponse)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalN__Type2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+	return ec.marshalOClaimGetResponse2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐClaimGetResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___Schema_queryType(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_claimProof(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -6001,34 +6936,38 @@ func (ec *executionContext) ___Schema_queryType(ctx context.Context, field graph
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__Schema",
+		Object:   "Query",
 		Field:    field,
 		Args:     nil,
 		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_claimProof_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.QueryType(), nil
+		return ec.resolvers.Query().ClaimProof(rctx, args["in"].(*ClaimProofRequestInput))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !ec.HasError(rctx) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Type)
+	res := resTmp.(*ClaimProofResponse)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalN__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+	return ec.marshalOClaimProofResponse2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐClaimProofResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___Schema_mutationType(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_findEdges(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -6038,31 +6977,41 @@ func (ec *executionContext) ___Schema_mutationType(ctx context.Context, field gr
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__Schema",
+		Object:   "Query",
 		Field:    field,
 		Args:     nil,
 		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_findEdges_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.MutationType(), nil
+		return ec.resolvers.Query().FindEdges(rctx, args["in"].(*FindEdgesInput))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Type)
+	res := resTmp.([]*claimsstore.JWTClaimPostgres)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+	return ec.marshalNEdge2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimsstoreᚐJWTClaimPostgres(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___Schema_subscriptionType(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_treeChanges(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -6072,16 +7021,23 @@ func (ec *executionContext) ___Schema_subscriptionType(ctx context.Context, fiel
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__Schema",
+		Object:   "Query",
 		Field:    field,
 		Args:     nil,
 		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_treeChanges_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.SubscriptionType(), nil
+		return ec.resolvers.Query().TreeChanges(rctx, args["in"].(TreeChangesInput))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6090,13 +7046,13 @@ func (ec *executionContext) ___Schema_subscriptionType(ctx context.Context, fiel
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Type)
+	res := resTmp.(*TreeChangesResponse)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+	return ec.marshalOTreeChangesResponse2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐTreeChangesResponse(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___Schema_directives(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_treeDiff(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -6106,34 +7062,38 @@ func (ec *executionContext) ___Schema_directives(ctx context.Context, field grap
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__Schema",
+		Object:   "Query",
 		Field:    field,
 		Args:     nil,
 		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_treeDiff_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Directives(), nil
+		return ec.resolvers.Query().TreeDiff(rctx, args["in"].(TreeDiffInput))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !ec.HasError(rctx) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.([]introspection.Directive)
+	res := resTmp.(*claims.TreeDiff)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalN__Directive2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐDirective(ctx, field.Selections, res)
+	return ec.marshalOTreeDiff2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimsᚐTreeDiff(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___Type_kind(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -6143,34 +7103,38 @@ func (ec *executionContext) ___Type_kind(ctx context.Context, field graphql.Coll
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__Type",
+		Object:   "Query",
 		Field:    field,
 		Args:     nil,
 		IsMethod: true,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query___type_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Kind(), nil
+		return ec.introspectType(args["name"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !ec.HasError(rctx) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*introspection.Type)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalN__TypeKind2string(ctx, field.Selections, res)
+	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___Type_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -6180,7 +7144,7 @@ func (ec *executionContext) ___Type_name(ctx context.Context, field graphql.Coll
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__Type",
+		Object:   "Query",
 		Field:    field,
 		Args:     nil,
 		IsMethod: true,
@@ -6189,7 +7153,7 @@ func (ec *executionContext) ___Type_name(ctx context.Context, field graphql.Coll
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name(), nil
+		return ec.introspectSchema()
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6198,13 +7162,13 @@ func (ec *executionContext) ___Type_name(ctx context.Context, field graphql.Coll
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*introspection.Schema)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___Type_description(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+func (ec *executionContext) _RootOnBlockChainProof_type(ctx context.Context, field graphql.CollectedField, obj *RootOnBlockChainProof) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -6214,31 +7178,34 @@ func (ec *executionContext) ___Type_description(ctx context.Context, field graph
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__Type",
+		Object:   "RootOnBlockChainProof",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Description(), nil
+		return obj.Type, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
 	res := resTmp.(string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___Type_fields(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+func (ec *executionContext) _RootOnBlockChainProof_blockNumber(ctx context.Context, field graphql.CollectedField, obj *RootOnBlockChainProof) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -6248,38 +7215,71 @@ func (ec *executionContext) ___Type_fields(ctx context.Context, field graphql.Co
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__Type",
+		Object:   "RootOnBlockChainProof",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field___Type_fields_args(ctx, rawArgs)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.BlockNumber, nil
+	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
-	rctx.Args = args
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _RootOnBlockChainProof_root(ctx context.Context, field graphql.CollectedField, obj *RootOnBlockChainProof) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "RootOnBlockChainProof",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Fields(args["includeDeprecated"].(bool)), nil
+		return obj.Root, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.([]introspection.Field)
+	res := resTmp.(string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalO__Field2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐField(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___Type_interfaces(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+func (ec *executionContext) _RootOnBlockChainProof_contractAddress(ctx context.Context, field graphql.CollectedField, obj *RootOnBlockChainProof) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -6289,31 +7289,34 @@ func (ec *executionContext) ___Type_interfaces(ctx context.Context, field graphq
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__Type",
+		Object:   "RootOnBlockChainProof",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Interfaces(), nil
+		return obj.ContractAddress, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.([]introspection.Type)
+	res := resTmp.(string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalO__Type2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___Type_possibleTypes(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+func (ec *executionContext) _RootOnBlockChainProof_committerAddress(ctx context.Context, field graphql.CollectedField, obj *RootOnBlockChainProof) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -6323,31 +7326,34 @@ func (ec *executionContext) ___Type_possibleTypes(ctx context.Context, field gra
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__Type",
+		Object:   "RootOnBlockChainProof",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PossibleTypes(), nil
+		return obj.CommitterAddress, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.([]introspection.Type)
+	res := resTmp.(string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalO__Type2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___Type_enumValues(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+func (ec *executionContext) _RootOnBlockChainProof_txHash(ctx context.Context, field graphql.CollectedField, obj *RootOnBlockChainProof) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -6357,38 +7363,71 @@ func (ec *executionContext) ___Type_enumValues(ctx context.Context, field graphq
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__Type",
+		Object:   "RootOnBlockChainProof",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field___Type_enumValues_args(ctx, rawArgs)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.TxHash, nil
+	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
-	rctx.Args = args
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeChange_id(ctx context.Context, field graphql.CollectedField, obj *claimsstore.TreeChange) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeChange",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.EnumValues(args["includeDeprecated"].(bool)), nil
+		return ec.resolvers.TreeChange().ID(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.([]introspection.EnumValue)
+	res := resTmp.(string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalO__EnumValue2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐEnumValue(ctx, field.Selections, res)
+	return ec.marshalNID2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___Type_inputFields(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+func (ec *executionContext) _TreeChange_did(ctx context.Context, field graphql.CollectedField, obj *claimsstore.TreeChange) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -6398,31 +7437,34 @@ func (ec *executionContext) ___Type_inputFields(ctx context.Context, field graph
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__Type",
+		Object:   "TreeChange",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.InputFields(), nil
+		return obj.DID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.([]introspection.InputValue)
+	res := resTmp.(string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalO__InputValue2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐInputValue(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) ___Type_ofType(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+func (ec *executionContext) _TreeChange_tree(ctx context.Context, field graphql.CollectedField, obj *claimsstore.TreeChange) (ret graphql.Marshaler) {
 	ctx = ec.Tracer.StartFieldExecution(ctx, field)
 	defer func() {
 		if r := recover(); r != nil {
@@ -6432,49 +7474,2423 @@ func (ec *executionContext) ___Type_ofType(ctx context.Context, field graphql.Co
 		ec.Tracer.EndFieldExecution(ctx)
 	}()
 	rctx := &graphql.ResolverContext{
-		Object:   "__Type",
+		Object:   "TreeChange",
 		Field:    field,
 		Args:     nil,
-		IsMethod: true,
+		IsMethod: false,
 	}
 	ctx = graphql.WithResolverContext(ctx, rctx)
 	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.OfType(), nil
+		return obj.Tree, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Type)
+	res := resTmp.(string)
 	rctx.Result = res
 	ctx = ec.Tracer.StartFieldChildExecution(ctx)
-	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-// endregion **************************** field.gotpl *****************************
-
-// region    **************************** input.gotpl *****************************
-
-func (ec *executionContext) unmarshalInputArticleMetadataContributorInput(ctx context.Context, obj interface{}) (ArticleMetadataContributorInput, error) {
-	var it ArticleMetadataContributorInput
-	var asMap = obj.(map[string]interface{})
-
+func (ec *executionContext) _TreeChange_claimType(ctx context.Context, field graphql.CollectedField, obj *claimsstore.TreeChange) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeChange",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ClaimType, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeChange_claimVersion(ctx context.Context, field graphql.CollectedField, obj *claimsstore.TreeChange) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeChange",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.TreeChange().ClaimVersion(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeChange_docType(ctx context.Context, field graphql.CollectedField, obj *claimsstore.TreeChange) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeChange",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.TreeChange().DocType(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*int)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeChange_contentHash(ctx context.Context, field graphql.CollectedField, obj *claimsstore.TreeChange) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeChange",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ContentHash, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeChange_operation(ctx context.Context, field graphql.CollectedField, obj *claimsstore.TreeChange) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeChange",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.TreeChange().Operation(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeChange_sender(ctx context.Context, field graphql.CollectedField, obj *claimsstore.TreeChange) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeChange",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Sender, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeChange_claimedSender(ctx context.Context, field graphql.CollectedField, obj *claimsstore.TreeChange) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeChange",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ClaimedSender, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeChange_didRoot(ctx context.Context, field graphql.CollectedField, obj *claimsstore.TreeChange) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeChange",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.DIDRoot, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeChange_createdAt(ctx context.Context, field graphql.CollectedField, obj *claimsstore.TreeChange) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeChange",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.TreeChange().CreatedAt(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeChangesResponse_changes(ctx context.Context, field graphql.CollectedField, obj *TreeChangesResponse) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeChangesResponse",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Changes, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*claimsstore.TreeChange)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNTreeChange2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimsstoreᚐTreeChange(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeChangesResponse_total(ctx context.Context, field graphql.CollectedField, obj *TreeChangesResponse) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeChangesResponse",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Total, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeDiff_did(ctx context.Context, field graphql.CollectedField, obj *claims.TreeDiff) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeDiff",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.DID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeDiff_fromRoot(ctx context.Context, field graphql.CollectedField, obj *claims.TreeDiff) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeDiff",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.TreeDiff().FromRoot(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeDiff_toRoot(ctx context.Context, field graphql.CollectedField, obj *claims.TreeDiff) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeDiff",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.TreeDiff().ToRoot(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeDiff_registrations(ctx context.Context, field graphql.CollectedField, obj *claims.TreeDiff) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeDiff",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Registrations, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*claims.TreeDiffChange)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNTreeDiffChange2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimsᚐTreeDiffChange(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeDiff_revocations(ctx context.Context, field graphql.CollectedField, obj *claims.TreeDiff) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeDiff",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Revocations, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*claims.TreeDiffChange)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNTreeDiffChange2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimsᚐTreeDiffChange(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeDiff_keys(ctx context.Context, field graphql.CollectedField, obj *claims.TreeDiff) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeDiff",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Keys, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*claims.TreeDiffChange)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNTreeDiffChange2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimsᚐTreeDiffChange(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeDiff_documentVersions(ctx context.Context, field graphql.CollectedField, obj *claims.TreeDiff) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeDiff",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.DocumentVersions, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*claims.TreeDiffChange)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNTreeDiffChange2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimsᚐTreeDiffChange(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeDiff_unknown(ctx context.Context, field graphql.CollectedField, obj *claims.TreeDiff) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeDiff",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Unknown, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*claims.TreeDiffChange)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNTreeDiffChange2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimsᚐTreeDiffChange(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeDiffChange_type(ctx context.Context, field graphql.CollectedField, obj *claims.TreeDiffChange) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeDiffChange",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.TreeDiffChange().Type(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeDiffChange_entry(ctx context.Context, field graphql.CollectedField, obj *claims.TreeDiffChange) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeDiffChange",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Entry, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeDiffChange_docType(ctx context.Context, field graphql.CollectedField, obj *claims.TreeDiffChange) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeDiffChange",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.TreeDiffChange().DocType(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*int)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeDiffChange_contentHash(ctx context.Context, field graphql.CollectedField, obj *claims.TreeDiffChange) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeDiffChange",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ContentHash, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeDiffChange_publicKey(ctx context.Context, field graphql.CollectedField, obj *claims.TreeDiffChange) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeDiffChange",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.PublicKey, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeDiffChange_docVersion(ctx context.Context, field graphql.CollectedField, obj *claims.TreeDiffChange) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeDiffChange",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.TreeDiffChange().DocVersion(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*int)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeDiffChange_documentHash(ctx context.Context, field graphql.CollectedField, obj *claims.TreeDiffChange) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeDiffChange",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.DocumentHash, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeDiffChange_claimType(ctx context.Context, field graphql.CollectedField, obj *claims.TreeDiffChange) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeDiffChange",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ClaimType, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeDiffChange_credential(ctx context.Context, field graphql.CollectedField, obj *claims.TreeDiffChange) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeDiffChange",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.TreeDiffChange().Credential(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*claimtypes.ContentCredential)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOClaim2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimtypesᚐContentCredential(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeDiffChange_credentialRaw(ctx context.Context, field graphql.CollectedField, obj *claims.TreeDiffChange) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeDiffChange",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.TreeDiffChange().CredentialRaw(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _TreeDiffChange_jwt(ctx context.Context, field graphql.CollectedField, obj *claims.TreeDiffChange) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "TreeDiffChange",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.JWT, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___Directive_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__Directive",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Name, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___Directive_description(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__Directive",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Description, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___Directive_locations(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__Directive",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Locations, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalN__DirectiveLocation2ᚕstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___Directive_args(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__Directive",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Args, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]introspection.InputValue)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalN__InputValue2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐInputValue(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___EnumValue_name(ctx context.Context, field graphql.CollectedField, obj *introspection.EnumValue) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__EnumValue",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Name, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___EnumValue_description(ctx context.Context, field graphql.CollectedField, obj *introspection.EnumValue) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__EnumValue",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Description, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___EnumValue_isDeprecated(ctx context.Context, field graphql.CollectedField, obj *introspection.EnumValue) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__EnumValue",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.IsDeprecated(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___EnumValue_deprecationReason(ctx context.Context, field graphql.CollectedField, obj *introspection.EnumValue) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__EnumValue",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.DeprecationReason(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___Field_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__Field",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Name, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___Field_description(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__Field",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Description, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___Field_args(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__Field",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Args, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]introspection.InputValue)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalN__InputValue2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐInputValue(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___Field_type(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__Field",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Type, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*introspection.Type)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalN__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___Field_isDeprecated(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__Field",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.IsDeprecated(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___Field_deprecationReason(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__Field",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.DeprecationReason(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___InputValue_name(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__InputValue",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Name, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___InputValue_description(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__InputValue",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Description, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___InputValue_type(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__InputValue",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Type, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*introspection.Type)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalN__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___InputValue_defaultValue(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__InputValue",
+		Field:    field,
+		Args:     nil,
+		IsMethod: false,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.DefaultValue, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___Schema_types(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__Schema",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Types(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]introspection.Type)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalN__Type2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___Schema_queryType(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__Schema",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.QueryType(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*introspection.Type)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalN__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___Schema_mutationType(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__Schema",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.MutationType(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*introspection.Type)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___Schema_subscriptionType(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__Schema",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.SubscriptionType(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*introspection.Type)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___Schema_directives(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__Schema",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Directives(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]introspection.Directive)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalN__Directive2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐDirective(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___Type_kind(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__Type",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Kind(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !ec.HasError(rctx) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalN__TypeKind2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___Type_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__Type",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Name(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___Type_description(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__Type",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Description(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___Type_fields(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__Type",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field___Type_fields_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Fields(args["includeDeprecated"].(bool)), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]introspection.Field)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalO__Field2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐField(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___Type_interfaces(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__Type",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Interfaces(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]introspection.Type)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalO__Type2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___Type_possibleTypes(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__Type",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.PossibleTypes(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]introspection.Type)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalO__Type2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___Type_enumValues(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__Type",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field___Type_enumValues_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	rctx.Args = args
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.EnumValues(args["includeDeprecated"].(bool)), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]introspection.EnumValue)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalO__EnumValue2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐEnumValue(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___Type_inputFields(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__Type",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.InputFields(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]introspection.InputValue)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalO__InputValue2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐInputValue(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) ___Type_ofType(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+	ctx = ec.Tracer.StartFieldExecution(ctx, field)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+		ec.Tracer.EndFieldExecution(ctx)
+	}()
+	rctx := &graphql.ResolverContext{
+		Object:   "__Type",
+		Field:    field,
+		Args:     nil,
+		IsMethod: true,
+	}
+	ctx = graphql.WithResolverContext(ctx, rctx)
+	ctx = ec.Tracer.StartFieldResolverExecution(ctx, rctx)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.OfType(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*introspection.Type)
+	rctx.Result = res
+	ctx = ec.Tracer.StartFieldChildExecution(ctx)
+	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+}
+
+// endregion **************************** field.gotpl *****************************
+
+// region    **************************** input.gotpl *****************************
+
+func (ec *executionContext) unmarshalInputArticleMetadataContributorInput(ctx context.Context, obj interface{}) (ArticleMetadataContributorInput, error) {
+	var it ArticleMetadataContributorInput
+	var asMap = obj.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "role":
+			var err error
+			it.Role, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "name":
+			var err error
+			it.Name, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputArticleMetadataImageInput(ctx context.Context, obj interface{}) (ArticleMetadataImageInput, error) {
+	var it ArticleMetadataImageInput
+	var asMap = obj.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "url":
+			var err error
+			it.URL, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "hash":
+			var err error
+			it.Hash, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "h":
+			var err error
+			it.H, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "w":
+			var err error
+			it.W, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputArticleMetadataInput(ctx context.Context, obj interface{}) (ArticleMetadataInput, error) {
+	var it ArticleMetadataInput
+	var asMap = obj.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "title":
+			var err error
+			it.Title, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "revisionContentHash":
+			var err error
+			it.RevisionContentHash, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "revisionContentURL":
+			var err error
+			it.RevisionContentURL, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "canonicalURL":
+			var err error
+			it.CanonicalURL, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "slug":
+			var err error
+			it.Slug, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "description":
+			var err error
+			it.Description, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "contributors":
+			var err error
+			it.Contributors, err = ec.unmarshalOArticleMetadataContributorInput2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐArticleMetadataContributorInput(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "images":
+			var err error
+			it.Images, err = ec.unmarshalOArticleMetadataImageInput2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐArticleMetadataImageInput(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "tags":
+			var err error
+			it.Tags, err = ec.unmarshalOString2ᚕᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "primaryTag":
+			var err error
+			it.PrimaryTag, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "revisionDate":
+			var err error
+			it.RevisionDate, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "originalPublishDate":
+			var err error
+			it.OriginalPublishDate, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "opinion":
+			var err error
+			it.Opinion, err = ec.unmarshalOBoolean2ᚖbool(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "civilSchemaVersion":
+			var err error
+			it.CivilSchemaVersion, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputClaimCredentialSchemaInput(ctx context.Context, obj interface{}) (ClaimCredentialSchemaInput, error) {
+	var it ClaimCredentialSchemaInput
+	var asMap = obj.(map[string]interface{})
+
 	for k, v := range asMap {
 		switch k {
-		case "role":
+		case "id":
 			var err error
-			it.Role, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			it.ID, err = ec.unmarshalNString2string(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "name":
+		case "type":
 			var err error
-			it.Name, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			it.Type, err = ec.unmarshalNString2string(ctx, v)
 			if err != nil {
 				return it, err
 			}
@@ -6484,33 +9900,99 @@ func (ec *executionContext) unmarshalInputArticleMetadataContributorInput(ctx co
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputArticleMetadataImageInput(ctx context.Context, obj interface{}) (ArticleMetadataImageInput, error) {
-	var it ArticleMetadataImageInput
+func (ec *executionContext) unmarshalInputClaimCredentialSubjectInput(ctx context.Context, obj interface{}) (ClaimCredentialSubjectInput, error) {
+	var it ClaimCredentialSubjectInput
 	var asMap = obj.(map[string]interface{})
 
 	for k, v := range asMap {
 		switch k {
-		case "url":
+		case "id":
 			var err error
-			it.URL, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			it.ID, err = ec.unmarshalNString2string(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "hash":
+		case "metadata":
 			var err error
-			it.Hash, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			it.Metadata, err = ec.unmarshalNArticleMetadataInput2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐArticleMetadataInput(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "h":
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputClaimGetRequestInput(ctx context.Context, obj interface{}) (ClaimGetRequestInput, error) {
+	var it ClaimGetRequestInput
+	var asMap = obj.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "did":
 			var err error
-			it.H, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			it.Did, err = ec.unmarshalNString2string(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "w":
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputClaimInput(ctx context.Context, obj interface{}) (ClaimInput, error) {
+	var it ClaimInput
+	var asMap = obj.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "context":
 			var err error
-			it.W, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			it.Context, err = ec.unmarshalNString2ᚕstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "type":
+			var err error
+			it.Type, err = ec.unmarshalNString2ᚕstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "credentialSubject":
+			var err error
+			it.CredentialSubject, err = ec.unmarshalNClaimCredentialSubjectInput2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐClaimCredentialSubjectInput(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "issuer":
+			var err error
+			it.Issuer, err = ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "holder":
+			var err error
+			it.Holder, err = ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "credentialSchema":
+			var err error
+			it.CredentialSchema, err = ec.unmarshalNClaimCredentialSchemaInput2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐClaimCredentialSchemaInput(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "issuanceDate":
+			var err error
+			it.IssuanceDate, err = ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "proof":
+			var err error
+			it.Proof, err = ec.unmarshalNLinkedDataProofInput2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐLinkedDataProofInput(ctx, v)
 			if err != nil {
 				return it, err
 			}
@@ -6520,93 +10002,195 @@ func (ec *executionContext) unmarshalInputArticleMetadataImageInput(ctx context.
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputArticleMetadataInput(ctx context.Context, obj interface{}) (ArticleMetadataInput, error) {
-	var it ArticleMetadataInput
+func (ec *executionContext) unmarshalInputClaimProofRequestInput(ctx context.Context, obj interface{}) (ClaimProofRequestInput, error) {
+	var it ClaimProofRequestInput
 	var asMap = obj.(map[string]interface{})
 
 	for k, v := range asMap {
 		switch k {
-		case "title":
+		case "claim":
 			var err error
-			it.Title, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			it.Claim, err = ec.unmarshalOClaimInput2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐClaimInput(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "revisionContentHash":
+		case "claimJson":
 			var err error
-			it.RevisionContentHash, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			it.ClaimJSON, err = ec.unmarshalOString2ᚖstring(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "revisionContentURL":
+		case "did":
 			var err error
-			it.RevisionContentURL, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			it.Did, err = ec.unmarshalNString2string(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "canonicalURL":
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputClaimSaveRequestInput(ctx context.Context, obj interface{}) (ClaimSaveRequestInput, error) {
+	var it ClaimSaveRequestInput
+	var asMap = obj.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "claim":
 			var err error
-			it.CanonicalURL, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			it.Claim, err = ec.unmarshalOClaimInput2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐClaimInput(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "slug":
+		case "claimJson":
 			var err error
-			it.Slug, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			it.ClaimJSON, err = ec.unmarshalOString2ᚖstring(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "description":
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputDidDeactivateInput(ctx context.Context, obj interface{}) (DidDeactivateInput, error) {
+	var it DidDeactivateInput
+	var asMap = obj.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "did":
 			var err error
-			it.Description, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			it.Did, err = ec.unmarshalNString2string(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "contributors":
+		case "revokeClaims":
 			var err error
-			it.Contributors, err = ec.unmarshalOArticleMetadataContributorInput2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐArticleMetadataContributorInput(ctx, v)
+			it.RevokeClaims, err = ec.unmarshalOBoolean2ᚖbool(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "images":
+		case "proof":
 			var err error
-			it.Images, err = ec.unmarshalOArticleMetadataImageInput2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐArticleMetadataImageInput(ctx, v)
+			it.Proof, err = ec.unmarshalNLinkedDataProofInput2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐLinkedDataProofInput(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "tags":
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputDidDereferenceInput(ctx context.Context, obj interface{}) (DidDereferenceInput, error) {
+	var it DidDereferenceInput
+	var asMap = obj.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "didUrl":
 			var err error
-			it.Tags, err = ec.unmarshalOString2ᚕᚖstring(ctx, v)
+			it.DidURL, err = ec.unmarshalNString2string(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "primaryTag":
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputDidDocAuthenticationInput(ctx context.Context, obj interface{}) (DidDocAuthenticationInput, error) {
+	var it DidDocAuthenticationInput
+	var asMap = obj.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "publicKey":
 			var err error
-			it.PrimaryTag, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			it.PublicKey, err = ec.unmarshalNDidDocPublicKeyInput2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDocPublicKeyInput(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "revisionDate":
+		case "idOnly":
 			var err error
-			it.RevisionDate, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			it.IDOnly, err = ec.unmarshalOBoolean2ᚖbool(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "originalPublishDate":
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputDidDocPublicKeyInput(ctx context.Context, obj interface{}) (DidDocPublicKeyInput, error) {
+	var it DidDocPublicKeyInput
+	var asMap = obj.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "id":
+			var err error
+			it.ID, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "type":
+			var err error
+			it.Type, err = ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "controller":
+			var err error
+			it.Controller, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "publicKeyPem":
+			var err error
+			it.PublicKeyPem, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "publicKeyJwk":
+			var err error
+			it.PublicKeyJwk, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "publicKeyHex":
+			var err error
+			it.PublicKeyHex, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "publicKeyBase64":
+			var err error
+			it.PublicKeyBase64, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "publicKeyBase58":
 			var err error
-			it.OriginalPublishDate, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			it.PublicKeyBase58, err = ec.unmarshalOString2ᚖstring(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "opinion":
+		case "publicKeyMultibase":
 			var err error
-			it.Opinion, err = ec.unmarshalOBoolean2ᚖbool(ctx, v)
+			it.PublicKeyMultibase, err = ec.unmarshalOString2ᚖstring(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "civilSchemaVersion":
+		case "ethereumAddress":
 			var err error
-			it.CivilSchemaVersion, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			it.EthereumAddress, err = ec.unmarshalOString2ᚖstring(ctx, v)
 			if err != nil {
 				return it, err
 			}
@@ -6616,8 +10200,8 @@ func (ec *executionContext) unmarshalInputArticleMetadataInput(ctx context.Conte
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputClaimCredentialSchemaInput(ctx context.Context, obj interface{}) (ClaimCredentialSchemaInput, error) {
-	var it ClaimCredentialSchemaInput
+func (ec *executionContext) unmarshalInputDidDocServiceInput(ctx context.Context, obj interface{}) (DidDocServiceInput, error) {
+	var it DidDocServiceInput
 	var asMap = obj.(map[string]interface{})
 
 	for k, v := range asMap {
@@ -6634,27 +10218,21 @@ func (ec *executionContext) unmarshalInputClaimCredentialSchemaInput(ctx context
 			if err != nil {
 				return it, err
 			}
-		}
-	}
-
-	return it, nil
-}
-
-func (ec *executionContext) unmarshalInputClaimCredentialSubjectInput(ctx context.Context, obj interface{}) (ClaimCredentialSubjectInput, error) {
-	var it ClaimCredentialSubjectInput
-	var asMap = obj.(map[string]interface{})
-
-	for k, v := range asMap {
-		switch k {
-		case "id":
+		case "description":
 			var err error
-			it.ID, err = ec.unmarshalNString2string(ctx, v)
+			it.Description, err = ec.unmarshalOString2ᚖstring(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "metadata":
+		case "publicKey":
 			var err error
-			it.Metadata, err = ec.unmarshalNArticleMetadataInput2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐArticleMetadataInput(ctx, v)
+			it.PublicKey, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "serviceEndpoint":
+			var err error
+			it.ServiceEndpoint, err = ec.unmarshalNAnyValue2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋutilsᚐAnyValue(ctx, v)
 			if err != nil {
 				return it, err
 			}
@@ -6664,15 +10242,15 @@ func (ec *executionContext) unmarshalInputClaimCredentialSubjectInput(ctx contex
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputClaimGetRequestInput(ctx context.Context, obj interface{}) (ClaimGetRequestInput, error) {
-	var it ClaimGetRequestInput
+func (ec *executionContext) unmarshalInputDidGetRequestInput(ctx context.Context, obj interface{}) (DidGetRequestInput, error) {
+	var it DidGetRequestInput
 	var asMap = obj.(map[string]interface{})
 
 	for k, v := range asMap {
 		switch k {
 		case "did":
 			var err error
-			it.Did, err = ec.unmarshalNString2string(ctx, v)
+			it.Did, err = ec.unmarshalOString2ᚖstring(ctx, v)
 			if err != nil {
 				return it, err
 			}
@@ -6682,57 +10260,51 @@ func (ec *executionContext) unmarshalInputClaimGetRequestInput(ctx context.Conte
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputClaimInput(ctx context.Context, obj interface{}) (ClaimInput, error) {
-	var it ClaimInput
+func (ec *executionContext) unmarshalInputDidSaveInput(ctx context.Context, obj interface{}) (DidSaveInput, error) {
+	var it DidSaveInput
 	var asMap = obj.(map[string]interface{})
 
 	for k, v := range asMap {
 		switch k {
-		case "context":
-			var err error
-			it.Context, err = ec.unmarshalNString2ᚕstring(ctx, v)
-			if err != nil {
-				return it, err
-			}
-		case "type":
+		case "did":
 			var err error
-			it.Type, err = ec.unmarshalNString2ᚕstring(ctx, v)
+			it.Did, err = ec.unmarshalOString2ᚖstring(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "credentialSubject":
+		case "publicKeys":
 			var err error
-			it.CredentialSubject, err = ec.unmarshalNClaimCredentialSubjectInput2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐClaimCredentialSubjectInput(ctx, v)
+			it.PublicKeys, err = ec.unmarshalODidDocPublicKeyInput2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDocPublicKeyInput(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "issuer":
+		case "authentications":
 			var err error
-			it.Issuer, err = ec.unmarshalNString2string(ctx, v)
+			it.Authentications, err = ec.unmarshalODidDocAuthenticationInput2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDocAuthenticationInput(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "holder":
+		case "services":
 			var err error
-			it.Holder, err = ec.unmarshalNString2string(ctx, v)
+			it.Services, err = ec.unmarshalODidDocServiceInput2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDocServiceInput(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "credentialSchema":
+		case "version":
 			var err error
-			it.CredentialSchema, err = ec.unmarshalNClaimCredentialSchemaInput2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐClaimCredentialSchemaInput(ctx, v)
+			it.Version, err = ec.unmarshalOInt2ᚖint(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "issuanceDate":
+		case "proof":
 			var err error
-			it.IssuanceDate, err = ec.unmarshalNString2string(ctx, v)
+			it.Proof, err = ec.unmarshalNLinkedDataProofInput2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐLinkedDataProofInput(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "proof":
+		case "proofs":
 			var err error
-			it.Proof, err = ec.unmarshalNLinkedDataProofInput2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐLinkedDataProofInput(ctx, v)
+			it.Proofs, err = ec.unmarshalOLinkedDataProofInput2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐLinkedDataProofInput(ctx, v)
 			if err != nil {
 				return it, err
 			}
@@ -6742,27 +10314,27 @@ func (ec *executionContext) unmarshalInputClaimInput(ctx context.Context, obj in
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputClaimProofRequestInput(ctx context.Context, obj interface{}) (ClaimProofRequestInput, error) {
-	var it ClaimProofRequestInput
+func (ec *executionContext) unmarshalInputDidSearchInput(ctx context.Context, obj interface{}) (DidSearchInput, error) {
+	var it DidSearchInput
 	var asMap = obj.(map[string]interface{})
 
 	for k, v := range asMap {
 		switch k {
-		case "claim":
+		case "publicKeyHex":
 			var err error
-			it.Claim, err = ec.unmarshalOClaimInput2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐClaimInput(ctx, v)
+			it.PublicKeyHex, err = ec.unmarshalOString2ᚖstring(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "claimJson":
+		case "ethereumAddress":
 			var err error
-			it.ClaimJSON, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			it.EthereumAddress, err = ec.unmarshalOString2ᚖstring(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "did":
+		case "serviceEndpoint":
 			var err error
-			it.Did, err = ec.unmarshalNString2string(ctx, v)
+			it.ServiceEndpoint, err = ec.unmarshalOString2ᚖstring(ctx, v)
 			if err != nil {
 				return it, err
 			}
@@ -6772,21 +10344,15 @@ func (ec *executionContext) unmarshalInputClaimProofRequestInput(ctx context.Con
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputClaimSaveRequestInput(ctx context.Context, obj interface{}) (ClaimSaveRequestInput, error) {
-	var it ClaimSaveRequestInput
+func (ec *executionContext) unmarshalInputDomainLinkageSaveInput(ctx context.Context, obj interface{}) (DomainLinkageSaveInput, error) {
+	var it DomainLinkageSaveInput
 	var asMap = obj.(map[string]interface{})
 
 	for k, v := range asMap {
 		switch k {
-		case "claim":
-			var err error
-			it.Claim, err = ec.unmarshalOClaimInput2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐClaimInput(ctx, v)
-			if err != nil {
-				return it, err
-			}
-		case "claimJson":
+		case "jwt":
 			var err error
-			it.ClaimJSON, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			it.Jwt, err = ec.unmarshalNString2string(ctx, v)
 			if err != nil {
 				return it, err
 			}
@@ -6796,15 +10362,21 @@ func (ec *executionContext) unmarshalInputClaimSaveRequestInput(ctx context.Cont
 	return it, nil
 }
 
-func (ec *executionContext) unmarshalInputDidGetRequestInput(ctx context.Context, obj interface{}) (DidGetRequestInput, error) {
-	var it DidGetRequestInput
+func (ec *executionContext) unmarshalInputDomainLinkageVerifyInput(ctx context.Context, obj interface{}) (DomainLinkageVerifyInput, error) {
+	var it DomainLinkageVerifyInput
 	var asMap = obj.(map[string]interface{})
 
 	for k, v := range asMap {
 		switch k {
 		case "did":
 			var err error
-			it.Did, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			it.Did, err = ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "origin":
+			var err error
+			it.Origin, err = ec.unmarshalNString2string(ctx, v)
 			if err != nil {
 				return it, err
 			}
@@ -6886,6 +10458,78 @@ func (ec *executionContext) unmarshalInputLinkedDataProofInput(ctx context.Conte
 	return it, nil
 }
 
+func (ec *executionContext) unmarshalInputTreeChangesInput(ctx context.Context, obj interface{}) (TreeChangesInput, error) {
+	var it TreeChangesInput
+	var asMap = obj.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "did":
+			var err error
+			it.Did, err = ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "offset":
+			var err error
+			it.Offset, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "limit":
+			var err error
+			it.Limit, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputTreeDiffInput(ctx context.Context, obj interface{}) (TreeDiffInput, error) {
+	var it TreeDiffInput
+	var asMap = obj.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "did":
+			var err error
+			it.Did, err = ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "fromRoot":
+			var err error
+			it.FromRoot, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "fromCommit":
+			var err error
+			it.FromCommit, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "toRoot":
+			var err error
+			it.ToRoot, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "toCommit":
+			var err error
+			it.ToCommit, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
 // endregion **************************** input.gotpl *****************************
 
 // region    ************************** interface.gotpl ***************************
@@ -7351,6 +10995,70 @@ func (ec *executionContext) _ContentClaimCredentialSubject(ctx context.Context,
 	return out
 }
 
+var didDeactivateResponseImplementors = []string{"DidDeactivateResponse"}
+
+func (ec *executionContext) _DidDeactivateResponse(ctx context.Context, sel ast.SelectionSet, obj *DidDeactivateResponse) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.RequestContext, sel, didDeactivateResponseImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("DidDeactivateResponse")
+		case "did":
+			out.Values[i] = ec._DidDeactivateResponse_did(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "deactivatedAt":
+			out.Values[i] = ec._DidDeactivateResponse_deactivatedAt(ctx, field, obj)
+		case "revokedClaims":
+			out.Values[i] = ec._DidDeactivateResponse_revokedClaims(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var didDereferenceResponseImplementors = []string{"DidDereferenceResponse"}
+
+func (ec *executionContext) _DidDereferenceResponse(ctx context.Context, sel ast.SelectionSet, obj *DidDereferenceResponse) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.RequestContext, sel, didDereferenceResponseImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("DidDereferenceResponse")
+		case "doc":
+			out.Values[i] = ec._DidDereferenceResponse_doc(ctx, field, obj)
+		case "publicKey":
+			out.Values[i] = ec._DidDereferenceResponse_publicKey(ctx, field, obj)
+		case "service":
+			out.Values[i] = ec._DidDereferenceResponse_service(ctx, field, obj)
+		case "serviceEndpoint":
+			out.Values[i] = ec._DidDereferenceResponse_serviceEndpoint(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
 var didDocAuthenticationImplementors = []string{"DidDocAuthentication"}
 
 func (ec *executionContext) _DidDocAuthentication(ctx context.Context, sel ast.SelectionSet, obj *did.DocAuthenicationWrapper) graphql.Marshaler {
@@ -7542,8 +11250,27 @@ func (ec *executionContext) _DidDocument(ctx context.Context, sel ast.SelectionS
 			})
 		case "publicKeys":
 			out.Values[i] = ec._DidDocument_publicKeys(ctx, field, obj)
+		case "verificationMethods":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._DidDocument_verificationMethods(ctx, field, obj)
+				return res
+			})
 		case "authentications":
 			out.Values[i] = ec._DidDocument_authentications(ctx, field, obj)
+		case "assertionMethods":
+			out.Values[i] = ec._DidDocument_assertionMethods(ctx, field, obj)
+		case "keyAgreements":
+			out.Values[i] = ec._DidDocument_keyAgreements(ctx, field, obj)
+		case "capabilityInvocations":
+			out.Values[i] = ec._DidDocument_capabilityInvocations(ctx, field, obj)
+		case "capabilityDelegations":
+			out.Values[i] = ec._DidDocument_capabilityDelegations(ctx, field, obj)
 		case "services":
 			out.Values[i] = ec._DidDocument_services(ctx, field, obj)
 		case "created":
@@ -7578,6 +11305,69 @@ func (ec *executionContext) _DidGetResponse(ctx context.Context, sel ast.Selecti
 			out.Values[i] = ec._DidGetResponse_doc(ctx, field, obj)
 		case "docRaw":
 			out.Values[i] = ec._DidGetResponse_docRaw(ctx, field, obj)
+		case "version":
+			out.Values[i] = ec._DidGetResponse_version(ctx, field, obj)
+		case "linkedDomains":
+			out.Values[i] = ec._DidGetResponse_linkedDomains(ctx, field, obj)
+		case "deactivated":
+			out.Values[i] = ec._DidGetResponse_deactivated(ctx, field, obj)
+		case "deactivatedAt":
+			out.Values[i] = ec._DidGetResponse_deactivatedAt(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var didSaveResponseImplementors = []string{"DidSaveResponse"}
+
+func (ec *executionContext) _DidSaveResponse(ctx context.Context, sel ast.SelectionSet, obj *DidSaveResponse) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.RequestContext, sel, didSaveResponseImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("DidSaveResponse")
+		case "doc":
+			out.Values[i] = ec._DidSaveResponse_doc(ctx, field, obj)
+		case "docRaw":
+			out.Values[i] = ec._DidSaveResponse_docRaw(ctx, field, obj)
+		case "version":
+			out.Values[i] = ec._DidSaveResponse_version(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var didSearchResponseImplementors = []string{"DidSearchResponse"}
+
+func (ec *executionContext) _DidSearchResponse(ctx context.Context, sel ast.SelectionSet, obj *DidSearchResponse) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.RequestContext, sel, didSearchResponseImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("DidSearchResponse")
+		case "docs":
+			out.Values[i] = ec._DidSearchResponse_docs(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -7589,21 +11379,48 @@ func (ec *executionContext) _DidGetResponse(ctx context.Context, sel ast.Selecti
 	return out
 }
 
-var didSaveResponseImplementors = []string{"DidSaveResponse"}
+var domainLinkageImplementors = []string{"DomainLinkage"}
 
-func (ec *executionContext) _DidSaveResponse(ctx context.Context, sel ast.SelectionSet, obj *DidSaveResponse) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.RequestContext, sel, didSaveResponseImplementors)
+func (ec *executionContext) _DomainLinkage(ctx context.Context, sel ast.SelectionSet, obj *domainlinkage.Linkage) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.RequestContext, sel, domainLinkageImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("DidSaveResponse")
-		case "doc":
-			out.Values[i] = ec._DidSaveResponse_doc(ctx, field, obj)
-		case "docRaw":
-			out.Values[i] = ec._DidSaveResponse_docRaw(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("DomainLinkage")
+		case "did":
+			out.Values[i] = ec._DomainLinkage_did(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "origin":
+			out.Values[i] = ec._DomainLinkage_origin(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "hosted":
+			out.Values[i] = ec._DomainLinkage_hosted(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "expires":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._DomainLinkage_expires(ctx, field, obj)
+				return res
+			})
+		case "jwt":
+			out.Values[i] = ec._DomainLinkage_jwt(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -7755,6 +11572,14 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
+		case "didSave":
+			out.Values[i] = ec._Mutation_didSave(ctx, field)
+		case "didDeactivate":
+			out.Values[i] = ec._Mutation_didDeactivate(ctx, field)
+		case "domainLinkageSave":
+			out.Values[i] = ec._Mutation_domainLinkageSave(ctx, field)
+		case "domainLinkageVerify":
+			out.Values[i] = ec._Mutation_domainLinkageVerify(ctx, field)
 		case "claimSave":
 			out.Values[i] = ec._Mutation_claimSave(ctx, field)
 		case "addEdge":
@@ -7810,6 +11635,28 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 				res = ec._Query_didGet(ctx, field)
 				return res
 			})
+		case "didSearch":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_didSearch(ctx, field)
+				return res
+			})
+		case "didDereference":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_didDereference(ctx, field)
+				return res
+			})
 		case "claimGet":
 			field := field
 			out.Concurrently(i, func() (res graphql.Marshaler) {
@@ -7846,6 +11693,28 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 				}
 				return res
 			})
+		case "treeChanges":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_treeChanges(ctx, field)
+				return res
+			})
+		case "treeDiff":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_treeDiff(ctx, field)
+				return res
+			})
 		case "__type":
 			out.Values[i] = ec._Query___type(ctx, field)
 		case "__schema":
@@ -7900,8 +11769,327 @@ func (ec *executionContext) _RootOnBlockChainProof(ctx context.Context, sel ast.
 		case "txHash":
 			out.Values[i] = ec._RootOnBlockChainProof_txHash(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				invalids++
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var treeChangeImplementors = []string{"TreeChange"}
+
+func (ec *executionContext) _TreeChange(ctx context.Context, sel ast.SelectionSet, obj *claimsstore.TreeChange) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.RequestContext, sel, treeChangeImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("TreeChange")
+		case "id":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._TreeChange_id(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			})
+		case "did":
+			out.Values[i] = ec._TreeChange_did(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "tree":
+			out.Values[i] = ec._TreeChange_tree(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "claimType":
+			out.Values[i] = ec._TreeChange_claimType(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "claimVersion":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._TreeChange_claimVersion(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			})
+		case "docType":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._TreeChange_docType(ctx, field, obj)
+				return res
+			})
+		case "contentHash":
+			out.Values[i] = ec._TreeChange_contentHash(ctx, field, obj)
+		case "operation":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._TreeChange_operation(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			})
+		case "sender":
+			out.Values[i] = ec._TreeChange_sender(ctx, field, obj)
+		case "claimedSender":
+			out.Values[i] = ec._TreeChange_claimedSender(ctx, field, obj)
+		case "didRoot":
+			out.Values[i] = ec._TreeChange_didRoot(ctx, field, obj)
+		case "createdAt":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._TreeChange_createdAt(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			})
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var treeChangesResponseImplementors = []string{"TreeChangesResponse"}
+
+func (ec *executionContext) _TreeChangesResponse(ctx context.Context, sel ast.SelectionSet, obj *TreeChangesResponse) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.RequestContext, sel, treeChangesResponseImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("TreeChangesResponse")
+		case "changes":
+			out.Values[i] = ec._TreeChangesResponse_changes(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "total":
+			out.Values[i] = ec._TreeChangesResponse_total(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var treeDiffImplementors = []string{"TreeDiff"}
+
+func (ec *executionContext) _TreeDiff(ctx context.Context, sel ast.SelectionSet, obj *claims.TreeDiff) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.RequestContext, sel, treeDiffImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("TreeDiff")
+		case "did":
+			out.Values[i] = ec._TreeDiff_did(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "fromRoot":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._TreeDiff_fromRoot(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			})
+		case "toRoot":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._TreeDiff_toRoot(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			})
+		case "registrations":
+			out.Values[i] = ec._TreeDiff_registrations(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "revocations":
+			out.Values[i] = ec._TreeDiff_revocations(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "keys":
+			out.Values[i] = ec._TreeDiff_keys(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "documentVersions":
+			out.Values[i] = ec._TreeDiff_documentVersions(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "unknown":
+			out.Values[i] = ec._TreeDiff_unknown(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var treeDiffChangeImplementors = []string{"TreeDiffChange"}
+
+func (ec *executionContext) _TreeDiffChange(ctx context.Context, sel ast.SelectionSet, obj *claims.TreeDiffChange) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.RequestContext, sel, treeDiffChangeImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("TreeDiffChange")
+		case "type":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._TreeDiffChange_type(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			})
+		case "entry":
+			out.Values[i] = ec._TreeDiffChange_entry(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
 			}
+		case "docType":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._TreeDiffChange_docType(ctx, field, obj)
+				return res
+			})
+		case "contentHash":
+			out.Values[i] = ec._TreeDiffChange_contentHash(ctx, field, obj)
+		case "publicKey":
+			out.Values[i] = ec._TreeDiffChange_publicKey(ctx, field, obj)
+		case "docVersion":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._TreeDiffChange_docVersion(ctx, field, obj)
+				return res
+			})
+		case "documentHash":
+			out.Values[i] = ec._TreeDiffChange_documentHash(ctx, field, obj)
+		case "claimType":
+			out.Values[i] = ec._TreeDiffChange_claimType(ctx, field, obj)
+		case "credential":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._TreeDiffChange_credential(ctx, field, obj)
+				return res
+			})
+		case "credentialRaw":
+			field := field
+			out.Concurrently(i, func() (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._TreeDiffChange_credentialRaw(ctx, field, obj)
+				return res
+			})
+		case "jwt":
+			out.Values[i] = ec._TreeDiffChange_jwt(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -8158,6 +12346,15 @@ func (ec *executionContext) ___Type(ctx context.Context, sel ast.SelectionSet, o
 
 // region    ***************************** type.gotpl *****************************
 
+func (ec *executionContext) unmarshalNAnyValue2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋutilsᚐAnyValue(ctx context.Context, v interface{}) (utils.AnyValue, error) {
+	var res utils.AnyValue
+	return res, res.UnmarshalGQL(v)
+}
+
+func (ec *executionContext) marshalNAnyValue2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋutilsᚐAnyValue(ctx context.Context, sel ast.SelectionSet, v utils.AnyValue) graphql.Marshaler {
+	return v
+}
+
 func (ec *executionContext) marshalNArticleMetadata2githubᚗcomᚋjoincivilᚋgoᚑcommonᚋpkgᚋarticleᚐMetadata(ctx context.Context, sel ast.SelectionSet, v article.Metadata) graphql.Marshaler {
 	return ec._ArticleMetadata(ctx, sel, &v)
 }
@@ -8254,18 +12451,139 @@ func (ec *executionContext) marshalNContentClaimCredentialSubject2ᚖgithubᚗco
 	return ec._ContentClaimCredentialSubject(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalNDidDeactivateInput2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDeactivateInput(ctx context.Context, v interface{}) (DidDeactivateInput, error) {
+	return ec.unmarshalInputDidDeactivateInput(ctx, v)
+}
+
+func (ec *executionContext) unmarshalNDidDereferenceInput2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDereferenceInput(ctx context.Context, v interface{}) (DidDereferenceInput, error) {
+	return ec.unmarshalInputDidDereferenceInput(ctx, v)
+}
+
 func (ec *executionContext) marshalNDidDocAuthentication2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocAuthenicationWrapper(ctx context.Context, sel ast.SelectionSet, v did.DocAuthenicationWrapper) graphql.Marshaler {
 	return ec._DidDocAuthentication(ctx, sel, &v)
 }
 
+func (ec *executionContext) unmarshalNDidDocAuthenticationInput2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDocAuthenticationInput(ctx context.Context, v interface{}) (DidDocAuthenticationInput, error) {
+	return ec.unmarshalInputDidDocAuthenticationInput(ctx, v)
+}
+
+func (ec *executionContext) unmarshalNDidDocAuthenticationInput2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDocAuthenticationInput(ctx context.Context, v interface{}) (*DidDocAuthenticationInput, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := ec.unmarshalNDidDocAuthenticationInput2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDocAuthenticationInput(ctx, v)
+	return &res, err
+}
+
 func (ec *executionContext) marshalNDidDocPublicKey2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocPublicKey(ctx context.Context, sel ast.SelectionSet, v did.DocPublicKey) graphql.Marshaler {
 	return ec._DidDocPublicKey(ctx, sel, &v)
 }
 
+func (ec *executionContext) marshalNDidDocPublicKey2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocPublicKey(ctx context.Context, sel ast.SelectionSet, v *did.DocPublicKey) graphql.Marshaler {
+	if v == nil {
+		if !ec.HasError(graphql.GetResolverContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	return ec._DidDocPublicKey(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalNDidDocPublicKeyInput2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDocPublicKeyInput(ctx context.Context, v interface{}) (DidDocPublicKeyInput, error) {
+	return ec.unmarshalInputDidDocPublicKeyInput(ctx, v)
+}
+
+func (ec *executionContext) unmarshalNDidDocPublicKeyInput2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDocPublicKeyInput(ctx context.Context, v interface{}) (*DidDocPublicKeyInput, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := ec.unmarshalNDidDocPublicKeyInput2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDocPublicKeyInput(ctx, v)
+	return &res, err
+}
+
 func (ec *executionContext) marshalNDidDocService2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocService(ctx context.Context, sel ast.SelectionSet, v did.DocService) graphql.Marshaler {
 	return ec._DidDocService(ctx, sel, &v)
 }
 
+func (ec *executionContext) unmarshalNDidDocServiceInput2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDocServiceInput(ctx context.Context, v interface{}) (DidDocServiceInput, error) {
+	return ec.unmarshalInputDidDocServiceInput(ctx, v)
+}
+
+func (ec *executionContext) unmarshalNDidDocServiceInput2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDocServiceInput(ctx context.Context, v interface{}) (*DidDocServiceInput, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := ec.unmarshalNDidDocServiceInput2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDocServiceInput(ctx, v)
+	return &res, err
+}
+
+func (ec *executionContext) marshalNDidDocument2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocument(ctx context.Context, sel ast.SelectionSet, v did.Document) graphql.Marshaler {
+	return ec._DidDocument(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNDidDocument2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocument(ctx context.Context, sel ast.SelectionSet, v []*did.Document) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		rctx := &graphql.ResolverContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithResolverContext(ctx, rctx)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNDidDocument2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocument(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+	return ret
+}
+
+func (ec *executionContext) marshalNDidDocument2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocument(ctx context.Context, sel ast.SelectionSet, v *did.Document) graphql.Marshaler {
+	if v == nil {
+		if !ec.HasError(graphql.GetResolverContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	return ec._DidDocument(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalNDidSaveInput2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidSaveInput(ctx context.Context, v interface{}) (DidSaveInput, error) {
+	return ec.unmarshalInputDidSaveInput(ctx, v)
+}
+
+func (ec *executionContext) unmarshalNDidSearchInput2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidSearchInput(ctx context.Context, v interface{}) (DidSearchInput, error) {
+	return ec.unmarshalInputDidSearchInput(ctx, v)
+}
+
+func (ec *executionContext) unmarshalNDomainLinkageSaveInput2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDomainLinkageSaveInput(ctx context.Context, v interface{}) (DomainLinkageSaveInput, error) {
+	return ec.unmarshalInputDomainLinkageSaveInput(ctx, v)
+}
+
+func (ec *executionContext) unmarshalNDomainLinkageVerifyInput2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDomainLinkageVerifyInput(ctx context.Context, v interface{}) (DomainLinkageVerifyInput, error) {
+	return ec.unmarshalInputDomainLinkageVerifyInput(ctx, v)
+}
+
 func (ec *executionContext) marshalNEdge2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimsstoreᚐJWTClaimPostgres(ctx context.Context, sel ast.SelectionSet, v claimsstore.JWTClaimPostgres) graphql.Marshaler {
 	return ec._Edge(ctx, sel, &v)
 }
@@ -8384,10 +12702,112 @@ func (ec *executionContext) marshalNProof2githubᚗcomᚋjoincivilᚋidᚑhubᚋ
 		}
 		return graphql.Null
 	}
-	return ec._Proof(ctx, sel, v)
+	return ec._Proof(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNProof2ᚕgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐProof(ctx context.Context, sel ast.SelectionSet, v []Proof) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		rctx := &graphql.ResolverContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithResolverContext(ctx, rctx)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNProof2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐProof(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+	return ret
+}
+
+func (ec *executionContext) unmarshalNString2string(ctx context.Context, v interface{}) (string, error) {
+	return graphql.UnmarshalString(v)
+}
+
+func (ec *executionContext) marshalNString2string(ctx context.Context, sel ast.SelectionSet, v string) graphql.Marshaler {
+	res := graphql.MarshalString(v)
+	if res == graphql.Null {
+		if !ec.HasError(graphql.GetResolverContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+	}
+	return res
+}
+
+func (ec *executionContext) unmarshalNString2ᚕstring(ctx context.Context, v interface{}) ([]string, error) {
+	var vSlice []interface{}
+	if v != nil {
+		if tmp1, ok := v.([]interface{}); ok {
+			vSlice = tmp1
+		} else {
+			vSlice = []interface{}{v}
+		}
+	}
+	var err error
+	res := make([]string, len(vSlice))
+	for i := range vSlice {
+		res[i], err = ec.unmarshalNString2string(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalNString2ᚕstring(ctx context.Context, sel ast.SelectionSet, v []string) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	for i := range v {
+		ret[i] = ec.marshalNString2string(ctx, sel, v[i])
+	}
+
+	return ret
+}
+
+func (ec *executionContext) unmarshalNString2ᚖstring(ctx context.Context, v interface{}) (*string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := ec.unmarshalNString2string(ctx, v)
+	return &res, err
+}
+
+func (ec *executionContext) marshalNString2ᚖstring(ctx context.Context, sel ast.SelectionSet, v *string) graphql.Marshaler {
+	if v == nil {
+		if !ec.HasError(graphql.GetResolverContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	return ec.marshalNString2string(ctx, sel, *v)
 }
 
-func (ec *executionContext) marshalNProof2ᚕgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐProof(ctx context.Context, sel ast.SelectionSet, v []Proof) graphql.Marshaler {
+func (ec *executionContext) marshalNTreeChange2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimsstoreᚐTreeChange(ctx context.Context, sel ast.SelectionSet, v claimsstore.TreeChange) graphql.Marshaler {
+	return ec._TreeChange(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNTreeChange2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimsstoreᚐTreeChange(ctx context.Context, sel ast.SelectionSet, v []*claimsstore.TreeChange) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
 	isLen1 := len(v) == 1
@@ -8411,7 +12831,7 @@ func (ec *executionContext) marshalNProof2ᚕgithubᚗcomᚋjoincivilᚋidᚑhub
 			if !isLen1 {
 				defer wg.Done()
 			}
-			ret[i] = ec.marshalNProof2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐProof(ctx, sel, v[i])
+			ret[i] = ec.marshalNTreeChange2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimsstoreᚐTreeChange(ctx, sel, v[i])
 		}
 		if isLen1 {
 			f(i)
@@ -8424,65 +12844,73 @@ func (ec *executionContext) marshalNProof2ᚕgithubᚗcomᚋjoincivilᚋidᚑhub
 	return ret
 }
 
-func (ec *executionContext) unmarshalNString2string(ctx context.Context, v interface{}) (string, error) {
-	return graphql.UnmarshalString(v)
-}
-
-func (ec *executionContext) marshalNString2string(ctx context.Context, sel ast.SelectionSet, v string) graphql.Marshaler {
-	res := graphql.MarshalString(v)
-	if res == graphql.Null {
+func (ec *executionContext) marshalNTreeChange2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimsstoreᚐTreeChange(ctx context.Context, sel ast.SelectionSet, v *claimsstore.TreeChange) graphql.Marshaler {
+	if v == nil {
 		if !ec.HasError(graphql.GetResolverContext(ctx)) {
 			ec.Errorf(ctx, "must not be null")
 		}
+		return graphql.Null
 	}
-	return res
+	return ec._TreeChange(ctx, sel, v)
 }
 
-func (ec *executionContext) unmarshalNString2ᚕstring(ctx context.Context, v interface{}) ([]string, error) {
-	var vSlice []interface{}
-	if v != nil {
-		if tmp1, ok := v.([]interface{}); ok {
-			vSlice = tmp1
-		} else {
-			vSlice = []interface{}{v}
-		}
-	}
-	var err error
-	res := make([]string, len(vSlice))
-	for i := range vSlice {
-		res[i], err = ec.unmarshalNString2string(ctx, vSlice[i])
-		if err != nil {
-			return nil, err
-		}
-	}
-	return res, nil
+func (ec *executionContext) unmarshalNTreeChangesInput2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐTreeChangesInput(ctx context.Context, v interface{}) (TreeChangesInput, error) {
+	return ec.unmarshalInputTreeChangesInput(ctx, v)
 }
 
-func (ec *executionContext) marshalNString2ᚕstring(ctx context.Context, sel ast.SelectionSet, v []string) graphql.Marshaler {
+func (ec *executionContext) marshalNTreeDiffChange2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimsᚐTreeDiffChange(ctx context.Context, sel ast.SelectionSet, v claims.TreeDiffChange) graphql.Marshaler {
+	return ec._TreeDiffChange(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNTreeDiffChange2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimsᚐTreeDiffChange(ctx context.Context, sel ast.SelectionSet, v []*claims.TreeDiffChange) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
-	for i := range v {
-		ret[i] = ec.marshalNString2string(ctx, sel, v[i])
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
 	}
+	for i := range v {
+		i := i
+		rctx := &graphql.ResolverContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithResolverContext(ctx, rctx)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNTreeDiffChange2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimsᚐTreeDiffChange(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
 
-	return ret
-}
-
-func (ec *executionContext) unmarshalNString2ᚖstring(ctx context.Context, v interface{}) (*string, error) {
-	if v == nil {
-		return nil, nil
 	}
-	res, err := ec.unmarshalNString2string(ctx, v)
-	return &res, err
+	wg.Wait()
+	return ret
 }
 
-func (ec *executionContext) marshalNString2ᚖstring(ctx context.Context, sel ast.SelectionSet, v *string) graphql.Marshaler {
+func (ec *executionContext) marshalNTreeDiffChange2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimsᚐTreeDiffChange(ctx context.Context, sel ast.SelectionSet, v *claims.TreeDiffChange) graphql.Marshaler {
 	if v == nil {
 		if !ec.HasError(graphql.GetResolverContext(ctx)) {
 			ec.Errorf(ctx, "must not be null")
 		}
 		return graphql.Null
 	}
-	return ec.marshalNString2string(ctx, sel, *v)
+	return ec._TreeDiffChange(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalNTreeDiffInput2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐTreeDiffInput(ctx context.Context, v interface{}) (TreeDiffInput, error) {
+	return ec.unmarshalInputTreeDiffInput(ctx, v)
 }
 
 func (ec *executionContext) marshalN__Directive2githubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐDirective(ctx context.Context, sel ast.SelectionSet, v introspection.Directive) graphql.Marshaler {
@@ -8910,6 +13338,10 @@ func (ec *executionContext) marshalOBoolean2ᚖbool(ctx context.Context, sel ast
 	return ec.marshalOBoolean2bool(ctx, sel, *v)
 }
 
+func (ec *executionContext) marshalOClaim2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimtypesᚐContentCredential(ctx context.Context, sel ast.SelectionSet, v claimtypes.ContentCredential) graphql.Marshaler {
+	return ec._Claim(ctx, sel, &v)
+}
+
 func (ec *executionContext) marshalOClaim2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimtypesᚐContentCredential(ctx context.Context, sel ast.SelectionSet, v []*claimtypes.ContentCredential) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -8950,6 +13382,13 @@ func (ec *executionContext) marshalOClaim2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑ
 	return ret
 }
 
+func (ec *executionContext) marshalOClaim2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimtypesᚐContentCredential(ctx context.Context, sel ast.SelectionSet, v *claimtypes.ContentCredential) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._Claim(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalOClaimGetRequestInput2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐClaimGetRequestInput(ctx context.Context, v interface{}) (ClaimGetRequestInput, error) {
 	return ec.unmarshalInputClaimGetRequestInput(ctx, v)
 }
@@ -9031,6 +13470,28 @@ func (ec *executionContext) marshalOClaimSaveResponse2ᚖgithubᚗcomᚋjoincivi
 	return ec._ClaimSaveResponse(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalODidDeactivateResponse2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDeactivateResponse(ctx context.Context, sel ast.SelectionSet, v DidDeactivateResponse) graphql.Marshaler {
+	return ec._DidDeactivateResponse(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalODidDeactivateResponse2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDeactivateResponse(ctx context.Context, sel ast.SelectionSet, v *DidDeactivateResponse) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._DidDeactivateResponse(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalODidDereferenceResponse2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDereferenceResponse(ctx context.Context, sel ast.SelectionSet, v DidDereferenceResponse) graphql.Marshaler {
+	return ec._DidDereferenceResponse(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalODidDereferenceResponse2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDereferenceResponse(ctx context.Context, sel ast.SelectionSet, v *DidDereferenceResponse) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._DidDereferenceResponse(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalODidDocAuthentication2ᚕgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocAuthenicationWrapper(ctx context.Context, sel ast.SelectionSet, v []did.DocAuthenicationWrapper) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -9071,6 +13532,26 @@ func (ec *executionContext) marshalODidDocAuthentication2ᚕgithubᚗcomᚋjoinc
 	return ret
 }
 
+func (ec *executionContext) unmarshalODidDocAuthenticationInput2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDocAuthenticationInput(ctx context.Context, v interface{}) ([]*DidDocAuthenticationInput, error) {
+	var vSlice []interface{}
+	if v != nil {
+		if tmp1, ok := v.([]interface{}); ok {
+			vSlice = tmp1
+		} else {
+			vSlice = []interface{}{v}
+		}
+	}
+	var err error
+	res := make([]*DidDocAuthenticationInput, len(vSlice))
+	for i := range vSlice {
+		res[i], err = ec.unmarshalNDidDocAuthenticationInput2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDocAuthenticationInput(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
 func (ec *executionContext) marshalODidDocPublicKey2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocPublicKey(ctx context.Context, sel ast.SelectionSet, v did.DocPublicKey) graphql.Marshaler {
 	return ec._DidDocPublicKey(ctx, sel, &v)
 }
@@ -9115,6 +13596,46 @@ func (ec *executionContext) marshalODidDocPublicKey2ᚕgithubᚗcomᚋjoincivil
 	return ret
 }
 
+func (ec *executionContext) marshalODidDocPublicKey2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocPublicKey(ctx context.Context, sel ast.SelectionSet, v []*did.DocPublicKey) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		rctx := &graphql.ResolverContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithResolverContext(ctx, rctx)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNDidDocPublicKey2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocPublicKey(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+	return ret
+}
+
 func (ec *executionContext) marshalODidDocPublicKey2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocPublicKey(ctx context.Context, sel ast.SelectionSet, v *did.DocPublicKey) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -9122,6 +13643,30 @@ func (ec *executionContext) marshalODidDocPublicKey2ᚖgithubᚗcomᚋjoincivil
 	return ec._DidDocPublicKey(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalODidDocPublicKeyInput2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDocPublicKeyInput(ctx context.Context, v interface{}) ([]*DidDocPublicKeyInput, error) {
+	var vSlice []interface{}
+	if v != nil {
+		if tmp1, ok := v.([]interface{}); ok {
+			vSlice = tmp1
+		} else {
+			vSlice = []interface{}{v}
+		}
+	}
+	var err error
+	res := make([]*DidDocPublicKeyInput, len(vSlice))
+	for i := range vSlice {
+		res[i], err = ec.unmarshalNDidDocPublicKeyInput2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDocPublicKeyInput(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalODidDocService2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocService(ctx context.Context, sel ast.SelectionSet, v did.DocService) graphql.Marshaler {
+	return ec._DidDocService(ctx, sel, &v)
+}
+
 func (ec *executionContext) marshalODidDocService2ᚕgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocService(ctx context.Context, sel ast.SelectionSet, v []did.DocService) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -9162,6 +13707,33 @@ func (ec *executionContext) marshalODidDocService2ᚕgithubᚗcomᚋjoincivilᚋ
 	return ret
 }
 
+func (ec *executionContext) marshalODidDocService2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocService(ctx context.Context, sel ast.SelectionSet, v *did.DocService) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._DidDocService(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalODidDocServiceInput2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDocServiceInput(ctx context.Context, v interface{}) ([]*DidDocServiceInput, error) {
+	var vSlice []interface{}
+	if v != nil {
+		if tmp1, ok := v.([]interface{}); ok {
+			vSlice = tmp1
+		} else {
+			vSlice = []interface{}{v}
+		}
+	}
+	var err error
+	res := make([]*DidDocServiceInput, len(vSlice))
+	for i := range vSlice {
+		res[i], err = ec.unmarshalNDidDocServiceInput2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidDocServiceInput(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
 func (ec *executionContext) marshalODidDocument2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdidᚐDocument(ctx context.Context, sel ast.SelectionSet, v did.Document) graphql.Marshaler {
 	return ec._DidDocument(ctx, sel, &v)
 }
@@ -9196,6 +13768,39 @@ func (ec *executionContext) marshalODidGetResponse2ᚖgithubᚗcomᚋjoincivil
 	return ec._DidGetResponse(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalODidSaveResponse2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidSaveResponse(ctx context.Context, sel ast.SelectionSet, v DidSaveResponse) graphql.Marshaler {
+	return ec._DidSaveResponse(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalODidSaveResponse2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidSaveResponse(ctx context.Context, sel ast.SelectionSet, v *DidSaveResponse) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._DidSaveResponse(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalODidSearchResponse2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidSearchResponse(ctx context.Context, sel ast.SelectionSet, v DidSearchResponse) graphql.Marshaler {
+	return ec._DidSearchResponse(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalODidSearchResponse2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐDidSearchResponse(ctx context.Context, sel ast.SelectionSet, v *DidSearchResponse) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._DidSearchResponse(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalODomainLinkage2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdomainlinkageᚐLinkage(ctx context.Context, sel ast.SelectionSet, v domainlinkage.Linkage) graphql.Marshaler {
+	return ec._DomainLinkage(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalODomainLinkage2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋdomainlinkageᚐLinkage(ctx context.Context, sel ast.SelectionSet, v *domainlinkage.Linkage) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._DomainLinkage(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalOEdge2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimsstoreᚐJWTClaimPostgres(ctx context.Context, sel ast.SelectionSet, v claimsstore.JWTClaimPostgres) graphql.Marshaler {
 	return ec._Edge(ctx, sel, &v)
 }
@@ -9253,6 +13858,26 @@ func (ec *executionContext) marshalOLinkedDataProof2ᚖgithubᚗcomᚋjoincivil
 	return ec._LinkedDataProof(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalOLinkedDataProofInput2ᚕᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐLinkedDataProofInput(ctx context.Context, v interface{}) ([]*LinkedDataProofInput, error) {
+	var vSlice []interface{}
+	if v != nil {
+		if tmp1, ok := v.([]interface{}); ok {
+			vSlice = tmp1
+		} else {
+			vSlice = []interface{}{v}
+		}
+	}
+	var err error
+	res := make([]*LinkedDataProofInput, len(vSlice))
+	for i := range vSlice {
+		res[i], err = ec.unmarshalNLinkedDataProofInput2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐLinkedDataProofInput(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
 func (ec *executionContext) marshalOProof2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐProof(ctx context.Context, sel ast.SelectionSet, v Proof) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -9320,7 +13945,7 @@ func (ec *executionContext) unmarshalOString2ᚕstring(ctx context.Context, v in
 	var err error
 	res := make([]string, len(vSlice))
 	for i := range vSlice {
-		res[i], err = ec.unmarshalOString2string(ctx, vSlice[i])
+		res[i], err = ec.unmarshalNString2string(ctx, vSlice[i])
 		if err != nil {
 			return nil, err
 		}
@@ -9334,7 +13959,7 @@ func (ec *executionContext) marshalOString2ᚕstring(ctx context.Context, sel as
 	}
 	ret := make(graphql.Array, len(v))
 	for i := range v {
-		ret[i] = ec.marshalOString2string(ctx, sel, v[i])
+		ret[i] = ec.marshalNString2string(ctx, sel, v[i])
 	}
 
 	return ret
@@ -9410,6 +14035,28 @@ func (ec *executionContext) marshalOTime2ᚖtimeᚐTime(ctx context.Context, sel
 	return ec.marshalOTime2timeᚐTime(ctx, sel, *v)
 }
 
+func (ec *executionContext) marshalOTreeChangesResponse2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐTreeChangesResponse(ctx context.Context, sel ast.SelectionSet, v TreeChangesResponse) graphql.Marshaler {
+	return ec._TreeChangesResponse(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalOTreeChangesResponse2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋgraphqlᚐTreeChangesResponse(ctx context.Context, sel ast.SelectionSet, v *TreeChangesResponse) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._TreeChangesResponse(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalOTreeDiff2githubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimsᚐTreeDiff(ctx context.Context, sel ast.SelectionSet, v claims.TreeDiff) graphql.Marshaler {
+	return ec._TreeDiff(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalOTreeDiff2ᚖgithubᚗcomᚋjoincivilᚋidᚑhubᚋpkgᚋclaimsᚐTreeDiff(ctx context.Context, sel ast.SelectionSet, v *claims.TreeDiff) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._TreeDiff(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalO__EnumValue2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐEnumValue(ctx context.Context, sel ast.SelectionSet, v []introspection.EnumValue) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
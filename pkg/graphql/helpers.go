@@ -5,7 +5,11 @@ import (
 	"time"
 
 	"github.com/joincivil/go-common/pkg/article"
+	didlib "github.com/ockam-network/did"
+
 	"github.com/joincivil/id-hub/pkg/claimtypes"
+	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/did/ethuri"
 	"github.com/joincivil/id-hub/pkg/linkeddata"
 	"github.com/joincivil/id-hub/pkg/utils"
 	"github.com/pkg/errors"
@@ -173,3 +177,157 @@ func ConvertInputProof(in *LinkedDataProofInput) (*linkeddata.Proof, error) {
 
 	return ldp, nil
 }
+
+// ConvertInputDidSave converts the input of didSave to the params of an ethuri
+// document create or update. Key ids are kept if every key and authentication
+// has one, otherwise the key fragments are generated.
+func ConvertInputDidSave(in *DidSaveInput) (*ethuri.CreateOrUpdateParams, error) {
+	params := &ethuri.CreateOrUpdateParams{
+		Did:              in.Did,
+		PublicKeys:       []did.DocPublicKey{},
+		Auths:            []did.DocAuthenicationWrapper{},
+		Services:         []did.DocService{},
+		KeepKeyFragments: true,
+	}
+
+	for _, inKey := range in.PublicKeys {
+		pk, err := ConvertInputPublicKey(inKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to convert public key")
+		}
+		if pk.ID == nil {
+			params.KeepKeyFragments = false
+		}
+		params.PublicKeys = append(params.PublicKeys, *pk)
+	}
+
+	for _, inAuth := range in.Authentications {
+		pk, err := ConvertInputPublicKey(inAuth.PublicKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to convert authentication")
+		}
+		if pk.ID == nil {
+			params.KeepKeyFragments = false
+		}
+		auth := did.DocAuthenicationWrapper{DocPublicKey: *pk}
+		if inAuth.IDOnly != nil {
+			auth.IDOnly = *inAuth.IDOnly
+		}
+		params.Auths = append(params.Auths, auth)
+	}
+
+	for _, inSrv := range in.Services {
+		srvID, err := didlib.Parse(inSrv.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to parse service id")
+		}
+		srv := did.DocService{
+			ID:              *srvID,
+			Type:            inSrv.Type,
+			Description:     utils.StrOrEmptyStr(inSrv.Description),
+			PublicKey:       utils.StrOrEmptyStr(inSrv.PublicKey),
+			ServiceEndpoint: inSrv.ServiceEndpoint.Value,
+		}
+		err = srv.PopulateServiceEndpointVals()
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid service endpoint")
+		}
+		params.Services = append(params.Services, srv)
+	}
+
+	proof, err := ConvertInputProof(in.Proof)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to convert proof")
+	}
+	params.Proof = proof
+	for _, inProof := range in.Proofs {
+		proof, err = ConvertInputProof(inProof)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to convert proofs")
+		}
+		params.Proofs = append(params.Proofs, *proof)
+	}
+	if in.Version != nil {
+		if *in.Version < 0 {
+			return nil, errors.New("version can not be negative")
+		}
+		params.Version = uint(*in.Version)
+	}
+	return params, nil
+}
+
+// ConvertInputDidDeactivate converts the input of didDeactivate to the params of
+// an ethuri document deactivation
+func ConvertInputDidDeactivate(in *DidDeactivateInput) (*ethuri.DeactivateParams, error) {
+	proof, err := ConvertInputProof(in.Proof)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to convert proof")
+	}
+	params := &ethuri.DeactivateParams{
+		Did:   in.Did,
+		Proof: proof,
+	}
+	if in.RevokeClaims != nil {
+		params.RevokeClaims = *in.RevokeClaims
+	}
+	return params, nil
+}
+
+// ConvertInputDidSearch converts the input of didSearch to the kind and value
+// of an ethuri document search, exactly one value has to be given
+func ConvertInputDidSearch(in *DidSearchInput) (ethuri.IndexKind, string, error) {
+	var kind ethuri.IndexKind
+	var value string
+	given := 0
+	if in.PublicKeyHex != nil {
+		kind, value = ethuri.IndexKindPublicKeyHex, *in.PublicKeyHex
+		given++
+	}
+	if in.EthereumAddress != nil {
+		kind, value = ethuri.IndexKindEthereumAddress, *in.EthereumAddress
+		given++
+	}
+	if in.ServiceEndpoint != nil {
+		kind, value = ethuri.IndexKindServiceEndpoint, *in.ServiceEndpoint
+		given++
+	}
+	if given != 1 {
+		return "", "", errors.New("exactly one of publicKeyHex, ethereumAddress or serviceEndpoint is required")
+	}
+	if value == "" {
+		return "", "", errors.New("search value is empty")
+	}
+	return kind, value, nil
+}
+
+// ConvertInputPublicKey converts public key input to a did document public key
+func ConvertInputPublicKey(in *DidDocPublicKeyInput) (*did.DocPublicKey, error) {
+	if in == nil {
+		return nil, errors.New("no public key given")
+	}
+	pk := &did.DocPublicKey{
+		Type:               linkeddata.SuiteType(in.Type),
+		PublicKeyPem:       in.PublicKeyPem,
+		PublicKeyJwk:       in.PublicKeyJwk,
+		PublicKeyHex:       in.PublicKeyHex,
+		PublicKeyBase64:    in.PublicKeyBase64,
+		PublicKeyBase58:    in.PublicKeyBase58,
+		PublicKeyMultibase: in.PublicKeyMultibase,
+		EthereumAddress:    in.EthereumAddress,
+	}
+	if in.ID != nil && *in.ID != "" {
+		id, err := didlib.Parse(*in.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to parse key id")
+		}
+		pk.ID = id
+	}
+	if in.Controller != nil && *in.Controller != "" {
+		controller, err := didlib.Parse(*in.Controller)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to parse key controller")
+		}
+		pk.Controller = controller
+	}
+	return pk, nil
+}
@@ -51,7 +51,7 @@ func (r *mutationResolver) AddEdge(ctx context.Context, edgeJwt *string) (*claim
 		return nil, errors.Wrap(err, "couldn't parse sender did")
 	}
 
-	token, err := r.JWTService.AddJWTClaim(*edgeJwt, senderDID)
+	token, err := r.JWTService.AddJWTClaim(ctx, *edgeJwt, senderDID)
 	if err != nil {
 		return nil, errors.Wrap(err, "AddEdge couldn't add jwt")
 	}
@@ -78,7 +78,7 @@ func (r *edgeResolver) Time(ctx context.Context, obj *claimsstore.JWTClaimPostgr
 
 // Proof gets a proof for the edge
 func (r *edgeResolver) Proof(ctx context.Context, obj *claimsstore.JWTClaimPostgres) ([]Proof, error) {
-	proof, err := r.JWTService.GenerateProof(obj.JWT)
+	proof, err := r.JWTService.GenerateProof(ctx, obj.JWT)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "edge resolver couldnt generate the proof")
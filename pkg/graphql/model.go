@@ -2,19 +2,28 @@ package graphql
 
 import (
 	"encoding/json"
+	"time"
 
 	log "github.com/golang/glog"
 	"github.com/joincivil/id-hub/pkg/claimtypes"
 	"github.com/joincivil/id-hub/pkg/did"
 )
 
-// DidGetResponse represents the GraphQL response for DidGet
+// DidGetResponse represents the GraphQL response for DidGet. The doc of a
+// deactivated DID is nil.
 type DidGetResponse struct {
-	Doc *did.Document `json:"doc"`
+	Doc           *did.Document `json:"doc"`
+	LinkedDomains []string      `json:"linkedDomains"`
+	Version       *int          `json:"version"`
+	Deactivated   *bool         `json:"deactivated"`
+	DeactivatedAt *time.Time    `json:"deactivatedAt"`
 }
 
 // DocRaw returns the raw JSON string for the docRaw field
 func (d *DidGetResponse) DocRaw() *string {
+	if d.Doc == nil {
+		return nil
+	}
 	bys, err := json.Marshal(d.Doc)
 	if err != nil {
 		log.Errorf("Error marshalling doc: err: %v", err)
@@ -26,7 +35,8 @@ func (d *DidGetResponse) DocRaw() *string {
 
 // DidSaveResponse represents the GraphQL response for DidSave
 type DidSaveResponse struct {
-	Doc *did.Document `json:"doc"`
+	Doc     *did.Document `json:"doc"`
+	Version *int          `json:"version"`
 }
 
 // DocRaw returns the raw JSON string for the docRaw field
@@ -6,7 +6,10 @@ import (
 	"time"
 
 	"github.com/joincivil/go-common/pkg/article"
+	"github.com/joincivil/id-hub/pkg/claimsstore"
 	"github.com/joincivil/id-hub/pkg/claimtypes"
+	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/utils"
 )
 
 type Proof interface {
@@ -101,10 +104,88 @@ type ContentClaimCredentialSubject struct {
 	Metadata *article.Metadata `json:"metadata"`
 }
 
+type DidDeactivateInput struct {
+	Did          string                `json:"did"`
+	RevokeClaims *bool                 `json:"revokeClaims"`
+	Proof        *LinkedDataProofInput `json:"proof"`
+}
+
+type DidDeactivateResponse struct {
+	Did           string     `json:"did"`
+	DeactivatedAt *time.Time `json:"deactivatedAt"`
+	RevokedClaims int        `json:"revokedClaims"`
+}
+
+type DidDereferenceInput struct {
+	DidURL string `json:"didUrl"`
+}
+
+type DidDereferenceResponse struct {
+	Doc             *did.Document     `json:"doc"`
+	PublicKey       *did.DocPublicKey `json:"publicKey"`
+	Service         *did.DocService   `json:"service"`
+	ServiceEndpoint *string           `json:"serviceEndpoint"`
+}
+
+type DidDocAuthenticationInput struct {
+	PublicKey *DidDocPublicKeyInput `json:"publicKey"`
+	IDOnly    *bool                 `json:"idOnly"`
+}
+
+type DidDocPublicKeyInput struct {
+	ID                 *string `json:"id"`
+	Type               string  `json:"type"`
+	Controller         *string `json:"controller"`
+	PublicKeyPem       *string `json:"publicKeyPem"`
+	PublicKeyJwk       *string `json:"publicKeyJwk"`
+	PublicKeyHex       *string `json:"publicKeyHex"`
+	PublicKeyBase64    *string `json:"publicKeyBase64"`
+	PublicKeyBase58    *string `json:"publicKeyBase58"`
+	PublicKeyMultibase *string `json:"publicKeyMultibase"`
+	EthereumAddress    *string `json:"ethereumAddress"`
+}
+
+type DidDocServiceInput struct {
+	ID              string         `json:"id"`
+	Type            string         `json:"type"`
+	Description     *string        `json:"description"`
+	PublicKey       *string        `json:"publicKey"`
+	ServiceEndpoint utils.AnyValue `json:"serviceEndpoint"`
+}
+
 type DidGetRequestInput struct {
 	Did *string `json:"did"`
 }
 
+type DidSaveInput struct {
+	Did             *string                      `json:"did"`
+	PublicKeys      []*DidDocPublicKeyInput      `json:"publicKeys"`
+	Authentications []*DidDocAuthenticationInput `json:"authentications"`
+	Services        []*DidDocServiceInput        `json:"services"`
+	Version         *int                         `json:"version"`
+	Proof           *LinkedDataProofInput        `json:"proof"`
+	Proofs          []*LinkedDataProofInput      `json:"proofs"`
+}
+
+type DidSearchInput struct {
+	PublicKeyHex    *string `json:"publicKeyHex"`
+	EthereumAddress *string `json:"ethereumAddress"`
+	ServiceEndpoint *string `json:"serviceEndpoint"`
+}
+
+type DidSearchResponse struct {
+	Docs []*did.Document `json:"docs"`
+}
+
+type DomainLinkageSaveInput struct {
+	Jwt string `json:"jwt"`
+}
+
+type DomainLinkageVerifyInput struct {
+	Did    string `json:"did"`
+	Origin string `json:"origin"`
+}
+
 type FindEdgesInput struct {
 	FromDid []*string `json:"fromDID"`
 	ToDid   []*string `json:"toDID"`
@@ -129,3 +210,22 @@ type RootOnBlockChainProof struct {
 }
 
 func (RootOnBlockChainProof) IsProof() {}
+
+type TreeChangesInput struct {
+	Did    string `json:"did"`
+	Offset *int   `json:"offset"`
+	Limit  *int   `json:"limit"`
+}
+
+type TreeChangesResponse struct {
+	Changes []*claimsstore.TreeChange `json:"changes"`
+	Total   int                       `json:"total"`
+}
+
+type TreeDiffInput struct {
+	Did        string  `json:"did"`
+	FromRoot   *string `json:"fromRoot"`
+	FromCommit *string `json:"fromCommit"`
+	ToRoot     *string `json:"toRoot"`
+	ToCommit   *string `json:"toCommit"`
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/joincivil/id-hub/pkg/claims"
 	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/did/ethuri"
+	"github.com/joincivil/id-hub/pkg/domainlinkage"
 )
 
 const (
@@ -29,9 +31,11 @@ var (
 
 // Resolver is the main GraphQL resolver
 type Resolver struct {
-	DidService   *did.Service
-	ClaimService *claims.Service
-	JWTService   *claims.JWTService
+	DidService           *did.Service
+	ClaimService         *claims.Service
+	JWTService           *claims.JWTService
+	DomainLinkageService *domainlinkage.Service
+	EthURIService        *ethuri.Service
 }
 
 // Version returns the version of the GraphQL API
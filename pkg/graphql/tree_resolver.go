@@ -0,0 +1,177 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/iden3/go-iden3-core/merkletree"
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+
+	log "github.com/golang/glog"
+
+	"github.com/joincivil/id-hub/pkg/claims"
+	"github.com/joincivil/id-hub/pkg/claimsstore"
+	"github.com/joincivil/id-hub/pkg/claimtypes"
+)
+
+// TreeChange returns a tree change resolver
+func (r *Resolver) TreeChange() TreeChangeResolver {
+	return &treeChangeResolver{r}
+}
+
+// TreeChanges returns a page of the changes to the trees of a did
+func (r *queryResolver) TreeChanges(ctx context.Context, in TreeChangesInput) (*TreeChangesResponse, error) {
+	d, err := didlib.Parse(in.Did)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing did in tree changes")
+	}
+	offset := 0
+	if in.Offset != nil {
+		offset = *in.Offset
+	}
+	limit := 0
+	if in.Limit != nil {
+		limit = *in.Limit
+	}
+
+	changes, total, err := r.ClaimService.GetTreeChanges(d, offset, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting tree changes")
+	}
+	return &TreeChangesResponse{Changes: changes, Total: total}, nil
+}
+
+// TreeDiff returns a tree diff resolver
+func (r *Resolver) TreeDiff() TreeDiffResolver {
+	return &treeDiffResolver{r}
+}
+
+// TreeDiffChange returns a tree diff change resolver
+func (r *Resolver) TreeDiffChange() TreeDiffChangeResolver {
+	return &treeDiffChangeResolver{r}
+}
+
+// TreeDiff returns the claims added to the tree of a did between two roots
+func (r *queryResolver) TreeDiff(ctx context.Context, in TreeDiffInput) (*claims.TreeDiff, error) {
+	d, err := didlib.Parse(in.Did)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing did in tree diff")
+	}
+	from, err := r.diffRoot(d, in.FromRoot, in.FromCommit)
+	if err != nil {
+		return nil, errors.Wrap(err, "error finding the from root")
+	}
+	to, err := r.diffRoot(d, in.ToRoot, in.ToCommit)
+	if err != nil {
+		return nil, errors.Wrap(err, "error finding the to root")
+	}
+	return r.JWTService.DiffDIDRoots(d, from, to)
+}
+
+func (r *queryResolver) diffRoot(d *didlib.DID, root *string, commit *string) (*merkletree.Hash, error) {
+	if root != nil && commit != nil {
+		return nil, errors.New("only one of root or commit can be given")
+	}
+	if root != nil {
+		return claims.ParseTreeRoot(*root)
+	}
+	if commit != nil {
+		return r.ClaimService.DIDRootAtCommit(d, *commit)
+	}
+	return nil, nil
+}
+
+type treeChangeResolver struct{ *Resolver }
+
+// ID resolves the id of the change
+func (r *treeChangeResolver) ID(ctx context.Context, obj *claimsstore.TreeChange) (string, error) {
+	return strconv.FormatUint(obj.ID, 10), nil
+}
+
+// ClaimVersion resolves the version of the claim
+func (r *treeChangeResolver) ClaimVersion(ctx context.Context, obj *claimsstore.TreeChange) (int, error) {
+	return int(obj.ClaimVersion), nil
+}
+
+// DocType resolves the doc type of a registered document
+func (r *treeChangeResolver) DocType(ctx context.Context, obj *claimsstore.TreeChange) (*int, error) {
+	if obj.Operation != claimsstore.TreeChangeRegister && obj.Operation != claimsstore.TreeChangeRevoke {
+		return nil, nil
+	}
+	docType := int(obj.DocType)
+	return &docType, nil
+}
+
+// Operation resolves the operation of the change
+func (r *treeChangeResolver) Operation(ctx context.Context, obj *claimsstore.TreeChange) (string, error) {
+	return string(obj.Operation), nil
+}
+
+// CreatedAt resolves the time of the change
+func (r *treeChangeResolver) CreatedAt(ctx context.Context, obj *claimsstore.TreeChange) (string, error) {
+	return obj.CreatedAt.UTC().Format(time.RFC3339), nil
+}
+
+type treeDiffResolver struct{ *Resolver }
+
+// FromRoot resolves the hex of the from root
+func (r *treeDiffResolver) FromRoot(ctx context.Context, obj *claims.TreeDiff) (string, error) {
+	return obj.From.Hex(), nil
+}
+
+// ToRoot resolves the hex of the to root
+func (r *treeDiffResolver) ToRoot(ctx context.Context, obj *claims.TreeDiff) (string, error) {
+	return obj.To.Hex(), nil
+}
+
+type treeDiffChangeResolver struct{ *Resolver }
+
+// Type resolves the type of the change
+func (r *treeDiffChangeResolver) Type(ctx context.Context, obj *claims.TreeDiffChange) (string, error) {
+	return string(obj.Type), nil
+}
+
+// DocType resolves the doc type of a registration or revocation
+func (r *treeDiffChangeResolver) DocType(ctx context.Context, obj *claims.TreeDiffChange) (*int, error) {
+	if obj.Type != claims.TreeDiffRegistration && obj.Type != claims.TreeDiffRevocation {
+		return nil, nil
+	}
+	docType := int(obj.DocType)
+	return &docType, nil
+}
+
+// DocVersion resolves the version of a did document version
+func (r *treeDiffChangeResolver) DocVersion(ctx context.Context, obj *claims.TreeDiffChange) (*int, error) {
+	if obj.Type != claims.TreeDiffDocumentVersion {
+		return nil, nil
+	}
+	docVersion := int(obj.DocVersion)
+	return &docVersion, nil
+}
+
+// Credential resolves the content credential registered
+func (r *treeDiffChangeResolver) Credential(ctx context.Context, obj *claims.TreeDiffChange) (
+	*claimtypes.ContentCredential, error) {
+	cred, ok := obj.Credential.(*claimtypes.ContentCredential)
+	if !ok {
+		return nil, nil
+	}
+	return cred, nil
+}
+
+// CredentialRaw resolves the credential registered as json
+func (r *treeDiffChangeResolver) CredentialRaw(ctx context.Context, obj *claims.TreeDiffChange) (*string, error) {
+	if obj.Credential == nil {
+		return nil, nil
+	}
+	bys, err := json.Marshal(obj.Credential)
+	if err != nil {
+		log.Errorf("Error marshalling credential: err: %v", err)
+		return nil, nil
+	}
+	credRaw := string(bys)
+	return &credRaw, nil
+}
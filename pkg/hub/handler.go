@@ -0,0 +1,36 @@
+package hub
+
+import (
+	"encoding/json"
+	"net/http"
+
+	log "github.com/golang/glog"
+)
+
+// Handler serves the signed hub metadata
+type Handler struct {
+	service *Service
+}
+
+// NewHandler returns a new hub Handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// GetMetadataHandler serves GET /.well-known/idhub
+func (h *Handler) GetMetadataHandler(w http.ResponseWriter, r *http.Request) {
+	metadata, err := h.service.GetSignedMetadata()
+	if err != nil {
+		log.Errorf("Error getting the hub metadata: err: %v", err)
+		http.Error(w, "unable to get the hub metadata", http.StatusInternalServerError)
+		return
+	}
+	js, err := json.Marshal(metadata)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(js)
+}
@@ -0,0 +1,223 @@
+package hub_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/joincivil/go-common/pkg/lock"
+
+	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/did/ethuri"
+	"github.com/joincivil/id-hub/pkg/hub"
+)
+
+type memoryIdentityPersister struct {
+	identity *hub.StoredIdentity
+}
+
+func (p *memoryIdentityPersister) GetIdentity() (*hub.StoredIdentity, error) {
+	if p.identity == nil {
+		return nil, hub.ErrNoHubIdentity
+	}
+	return p.identity, nil
+}
+
+func (p *memoryIdentityPersister) SaveIdentity(identity *hub.StoredIdentity) error {
+	p.identity = identity
+	return nil
+}
+
+type testCommitters []string
+
+func (c testCommitters) GetCommitterAddresses() ([]string, error) {
+	return c, nil
+}
+
+func TestLoadOrCreateIdentity(t *testing.T) {
+	persister := &memoryIdentityPersister{}
+	didPersister := &ethuri.InMemoryPersister{}
+	privKey, _ := crypto.GenerateKey()
+
+	identity, err := hub.LoadOrCreateIdentity(persister, didPersister, privKey, lock.NewLocalDLock())
+	if err != nil {
+		t.Fatalf("Should have created the identity: err: %v", err)
+	}
+	if identity.DID.Method != "ethuri" {
+		t.Errorf("Should have generated an ethuri did: %v", identity.DID.String())
+	}
+	doc, err := didPersister.GetDocument(identity.DID)
+	if err != nil || doc == nil {
+		t.Fatalf("Should have saved the hub did document: err: %v", err)
+	}
+	if identity.Address() != crypto.PubkeyToAddress(privKey.PublicKey) {
+		t.Errorf("Should have used the hub key")
+	}
+
+	loaded, err := hub.LoadOrCreateIdentity(persister, didPersister, privKey, lock.NewLocalDLock())
+	if err != nil {
+		t.Fatalf("Should have loaded the identity: err: %v", err)
+	}
+	if loaded.DID.String() != identity.DID.String() {
+		t.Errorf("Should have loaded the same did: %v != %v", loaded.DID.String(), identity.DID.String())
+	}
+
+	otherKey, _ := crypto.GenerateKey()
+	_, err = hub.LoadOrCreateIdentity(persister, didPersister, otherKey, lock.NewLocalDLock())
+	if err != hub.ErrHubKeyMismatch {
+		t.Errorf("Should have failed with a different key: err: %v", err)
+	}
+}
+
+type testDLock struct {
+	lockErr error
+	locked  []string
+	held    bool
+}
+
+func (l *testDLock) Lock(key string, expireMillis *int) error {
+	if l.lockErr != nil {
+		return l.lockErr
+	}
+	l.locked = append(l.locked, key)
+	l.held = true
+	return nil
+}
+
+func (l *testDLock) Unlock(key string) error {
+	l.held = false
+	return nil
+}
+
+func TestLoadOrCreateIdentityLock(t *testing.T) {
+	persister := &memoryIdentityPersister{}
+	privKey, _ := crypto.GenerateKey()
+
+	_, err := hub.LoadOrCreateIdentity(persister, &ethuri.InMemoryPersister{}, privKey,
+		&testDLock{lockErr: lock.ErrNoLockObtained})
+	if err == nil || persister.identity != nil {
+		t.Errorf("Should not have created the identity without the lock: err: %v", err)
+	}
+
+	dlock := &testDLock{}
+	_, err = hub.LoadOrCreateIdentity(persister, &ethuri.InMemoryPersister{}, privKey, dlock)
+	if err != nil {
+		t.Fatalf("Should have created the identity: err: %v", err)
+	}
+	if len(dlock.locked) != 1 || dlock.held {
+		t.Errorf("Should have held the lock while creating the identity: %v", dlock.locked)
+	}
+}
+
+func TestEnsureService(t *testing.T) {
+	didPersister := &ethuri.InMemoryPersister{}
+	privKey, _ := crypto.GenerateKey()
+	identity, err := hub.LoadOrCreateIdentity(&memoryIdentityPersister{}, didPersister, privKey, lock.NewLocalDLock())
+	if err != nil {
+		t.Fatalf("Should have created the identity: err: %v", err)
+	}
+
+	err = identity.EnsureService(didPersister, "https://old.example.com")
+	if err != nil {
+		t.Fatalf("Should have added the hub service: err: %v", err)
+	}
+	err = identity.EnsureService(didPersister, "https://hub.example.com")
+	if err != nil {
+		t.Fatalf("Should have updated the hub service: err: %v", err)
+	}
+	doc, _ := didPersister.GetDocument(identity.DID)
+	if len(doc.Services) != 1 || doc.Services[0].Type != hub.ServiceType {
+		t.Fatalf("Should have had a single hub service: %v", doc.Services)
+	}
+
+	didService := did.NewService([]did.Resolver{ethuri.NewService(didPersister)})
+	result, err := didService.Dereference(context.Background(), identity.DID.String()+"?service=hub&relativeRef=/v1/query")
+	if err != nil {
+		t.Fatalf("Should have dereferenced the hub service: err: %v", err)
+	}
+	if result.ServiceEndpoint == nil || *result.ServiceEndpoint != "https://hub.example.com/v1/query" {
+		t.Errorf("Should have resolved the hub endpoint: %v", result.ServiceEndpoint)
+	}
+}
+
+func TestSignedMetadata(t *testing.T) {
+	didPersister := &ethuri.InMemoryPersister{}
+	privKey, _ := crypto.GenerateKey()
+	identity, err := hub.LoadOrCreateIdentity(&memoryIdentityPersister{}, didPersister, privKey, lock.NewLocalDLock())
+	if err != nil {
+		t.Fatalf("Should have created the identity: err: %v", err)
+	}
+	doc, _ := didPersister.GetDocument(identity.DID)
+
+	committers := testCommitters{"0x39eeD73fb1D3f5C4fE2B40Fb1B7E6c8f8B5DBc6b"}
+	service := hub.NewService(identity, committers, hub.MetadataConfig{
+		ContractAddress: "0x1e4bbB26B6C17C1D0b5C1Ed4fBC6A4cD4d5A3f0C",
+		BaseURL:         "https://hub.example.com/",
+	})
+	metadata, err := service.GetSignedMetadata()
+	if err != nil {
+		t.Fatalf("Should have signed the metadata: err: %v", err)
+	}
+	if metadata.ID != identity.DID.String() || len(metadata.CommitterAddresses) != 1 ||
+		metadata.ContractAddress != "0x1e4bbB26B6C17C1D0b5C1Ed4fBC6A4cD4d5A3f0C" {
+		t.Errorf("Should have set the metadata: %+v", metadata.Metadata)
+	}
+	if metadata.Endpoints["graphql"] != "https://hub.example.com/v1/query" {
+		t.Errorf("Should have prefixed the endpoints with the base url: %v", metadata.Endpoints)
+	}
+	if len(metadata.CredentialTypes) == 0 {
+		t.Errorf("Should have listed the credential types")
+	}
+
+	err = metadata.Verify(doc)
+	if err != nil {
+		t.Errorf("Should have verified the metadata: err: %v", err)
+	}
+
+	// The signature survives a round trip through json
+	js, _ := json.Marshal(metadata)
+	fromJSON := &hub.SignedMetadata{}
+	err = json.Unmarshal(js, fromJSON)
+	if err != nil {
+		t.Fatalf("Should have unmarshalled the metadata: err: %v", err)
+	}
+	err = fromJSON.Verify(doc)
+	if err != nil {
+		t.Errorf("Should have verified the metadata from json: err: %v", err)
+	}
+
+	fromJSON.ContractAddress = "0x0000000000000000000000000000000000000000"
+	err = fromJSON.Verify(doc)
+	if err != hub.ErrMetadataBadSignature {
+		t.Errorf("Should have failed to verify changed metadata: err: %v", err)
+	}
+}
+
+func TestGetMetadataHandler(t *testing.T) {
+	privKey, _ := crypto.GenerateKey()
+	identity, err := hub.LoadOrCreateIdentity(&memoryIdentityPersister{}, &ethuri.InMemoryPersister{}, privKey, lock.NewLocalDLock())
+	if err != nil {
+		t.Fatalf("Should have created the identity: err: %v", err)
+	}
+	handler := hub.NewHandler(hub.NewService(identity, nil, hub.MetadataConfig{}))
+
+	rec := httptest.NewRecorder()
+	handler.GetMetadataHandler(rec, httptest.NewRequest(http.MethodGet, hub.WellKnownPath, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Should have served the metadata: %v", rec.Code)
+	}
+	metadata := &hub.SignedMetadata{}
+	err = json.Unmarshal(rec.Body.Bytes(), metadata)
+	if err != nil {
+		t.Fatalf("Should have returned json: err: %v", err)
+	}
+	if metadata.ID != identity.DID.String() || metadata.Proof == nil {
+		t.Errorf("Should have returned the signed metadata: %+v", metadata)
+	}
+	if metadata.Endpoints["metadata"] != hub.WellKnownPath {
+		t.Errorf("Should have relative endpoints without a base url: %v", metadata.Endpoints)
+	}
+}
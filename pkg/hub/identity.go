@@ -0,0 +1,222 @@
+package hub
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	log "github.com/golang/glog"
+	"github.com/jinzhu/gorm"
+	"github.com/joincivil/go-common/pkg/lock"
+	"github.com/joincivil/go-common/pkg/numbers"
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/did/ethuri"
+	"github.com/joincivil/id-hub/pkg/linkeddata"
+)
+
+const (
+	// ServiceFragment is the fragment of the service of the hub in its did
+	// document, the hub endpoints are found with ?service=hub
+	ServiceFragment = "hub"
+	// ServiceType is the type of the service of the hub in its did document
+	ServiceType = "IdentityHub"
+
+	// identityLockKey is the dlock key held while the hub did is generated
+	identityLockKey = "hub_identity"
+)
+
+var (
+	// ErrNoHubIdentity is returned when the hub has not generated its did yet
+	ErrNoHubIdentity = errors.New("the hub has no identity")
+	// ErrHubKeyMismatch is returned when the hub key is not the key the hub did
+	// was generated with
+	ErrHubKeyMismatch = errors.New("the hub key does not match the key of the hub did")
+
+	identityLockExpirationMillis = numbers.IntToPtr(1000 * 30)
+)
+
+// StoredIdentity is the GORM model for the did of the hub and the address of the
+// key it was generated with
+type StoredIdentity struct {
+	DID        string `gorm:"column:did;primary_key"`
+	KeyAddress string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// TableName sets the table name for the hub identity
+func (StoredIdentity) TableName() string {
+	return "hub_identity"
+}
+
+// IdentityPersister stores the identity of the hub
+type IdentityPersister interface {
+	// GetIdentity returns the identity of the hub or ErrNoHubIdentity
+	GetIdentity() (*StoredIdentity, error)
+	// SaveIdentity saves the identity of the hub
+	SaveIdentity(identity *StoredIdentity) error
+}
+
+// IdentityPGPersister stores the identity of the hub in postgres
+type IdentityPGPersister struct {
+	db *gorm.DB
+}
+
+// NewIdentityPGPersister returns a new IdentityPGPersister
+func NewIdentityPGPersister(db *gorm.DB) *IdentityPGPersister {
+	return &IdentityPGPersister{db: db}
+}
+
+// GetIdentity returns the identity of the hub
+func (p *IdentityPGPersister) GetIdentity() (*StoredIdentity, error) {
+	identity := &StoredIdentity{}
+	err := p.db.Order("created_at asc").First(identity).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, ErrNoHubIdentity
+	} else if err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+// SaveIdentity saves the identity of the hub
+func (p *IdentityPGPersister) SaveIdentity(identity *StoredIdentity) error {
+	return p.db.Create(identity).Error
+}
+
+// Identity is the did of the hub and the key that controls it
+type Identity struct {
+	DID     *didlib.DID
+	privKey *ecdsa.PrivateKey
+}
+
+// LoadOrCreateIdentity returns the identity of the hub. On first boot it
+// generates a new ethuri did with privKey as its first key, saves the did
+// document with didPersister and the identity with persister. dlock is held
+// while the identity is loaded or created, so instances booting at the same time
+// create a single did.
+func LoadOrCreateIdentity(persister IdentityPersister, didPersister ethuri.Persister,
+	privKey *ecdsa.PrivateKey, dlock lock.DLock) (*Identity, error) {
+	err := dlock.Lock(identityLockKey, identityLockExpirationMillis)
+	if err != nil {
+		return nil, errors.Wrap(err, "loadorcreateidentity.lock")
+	}
+	defer func() {
+		if lerr := dlock.Unlock(identityLockKey); lerr != nil {
+			log.Infof("loadorcreateidentity.unlock: err: %v", lerr)
+		}
+	}()
+	return loadOrCreateIdentity(persister, didPersister, privKey)
+}
+
+func loadOrCreateIdentity(persister IdentityPersister, didPersister ethuri.Persister,
+	privKey *ecdsa.PrivateKey) (*Identity, error) {
+	address := crypto.PubkeyToAddress(privKey.PublicKey)
+
+	stored, err := persister.GetIdentity()
+	if err == nil {
+		if common.HexToAddress(stored.KeyAddress) != address {
+			return nil, ErrHubKeyMismatch
+		}
+		hubDID, err := didlib.Parse(stored.DID)
+		if err != nil {
+			return nil, errors.Wrap(err, "loadorcreateidentity.parse")
+		}
+		return &Identity{DID: hubDID, privKey: privKey}, nil
+	} else if err != ErrNoHubIdentity {
+		return nil, errors.Wrap(err, "loadorcreateidentity.getidentity")
+	}
+
+	pubKeyHex := hex.EncodeToString(crypto.FromECDSAPub(&privKey.PublicKey))
+	doc, err := ethuri.GenerateNewDocument(&did.DocPublicKey{
+		Type:         linkeddata.SuiteTypeSecp256k1Verification,
+		PublicKeyHex: &pubKeyHex,
+	}, true, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "loadorcreateidentity.generatenewdocument")
+	}
+	err = didPersister.SaveDocument(doc, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "loadorcreateidentity.savedocument")
+	}
+	err = persister.SaveIdentity(&StoredIdentity{
+		DID:        doc.ID.String(),
+		KeyAddress: address.Hex(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "loadorcreateidentity.saveidentity")
+	}
+	log.Infof("Generated hub did: %v", doc.ID.String())
+	return &Identity{DID: &doc.ID, privKey: privKey}, nil
+}
+
+// EnsureService adds a service with the base url of the hub to the did document
+// of the hub, or updates the endpoint of the service if the base url changed
+func (i *Identity) EnsureService(didPersister ethuri.Persister, baseURL string) error {
+	version, err := didPersister.GetLatestDocumentVersion(i.DID)
+	if err != nil {
+		return errors.Wrap(err, "ensureservice.getlatestdocumentversion")
+	}
+	doc := version.Document
+
+	services := make([]did.DocService, 0, len(doc.Services))
+	for _, srv := range doc.Services {
+		if srv.ID.Fragment != ServiceFragment {
+			services = append(services, srv)
+			continue
+		}
+		if srv.ServiceEndpointURI != nil && *srv.ServiceEndpointURI == baseURL {
+			return nil
+		}
+	}
+	doc.Services = services
+
+	srvID := did.CopyDID(i.DID)
+	srvID.Fragment = ServiceFragment
+	err = doc.AddService(&did.DocService{
+		ID:                 *srvID,
+		Type:               ServiceType,
+		ServiceEndpoint:    baseURL,
+		ServiceEndpointURI: &baseURL,
+	})
+	if err != nil {
+		return errors.Wrap(err, "ensureservice.addservice")
+	}
+	err = didPersister.SaveDocument(doc, version.Version)
+	if err != nil {
+		return errors.Wrap(err, "ensureservice.savedocument")
+	}
+	log.Infof("Set hub service endpoint: %v", baseURL)
+	return nil
+}
+
+// KeyID returns the id of the key of the hub in its did document
+func (i *Identity) KeyID() string {
+	keyID := did.CopyDID(i.DID)
+	keyID.Fragment = "keys-1"
+	return keyID.String()
+}
+
+// Address returns the address of the key of the hub
+func (i *Identity) Address() common.Address {
+	return crypto.PubkeyToAddress(i.privKey.PublicKey)
+}
+
+// Sign signs the hash with the key of the hub
+func (i *Identity) Sign(hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, i.privKey)
+}
+
+// SignJWT returns a jwt of claims signed with the key of the hub. The key id is
+// set in the kid header so verifiers can find it in the hub did document.
+func (i *Identity) SignJWT(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = i.KeyID()
+	return token.SignedString(i.privKey)
+}
@@ -0,0 +1,186 @@
+package hub
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/claimtypes"
+	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/linkeddata"
+)
+
+const (
+	// WellKnownPath is the path the signed hub metadata is served at
+	WellKnownPath = "/.well-known/idhub"
+)
+
+var (
+	// ErrMetadataBadSignature is returned when the hub metadata is not signed by
+	// a key of the hub did
+	ErrMetadataBadSignature = errors.New("the hub metadata signature does not match the hub did")
+
+	// supportedCredentialTypes are the credential types the hub registers
+	supportedCredentialTypes = []string{
+		string(claimtypes.ContentCredentialType),
+		string(claimtypes.LicenseCredentialType),
+	}
+
+	// defaultEndpoints are the paths of the apis of the hub
+	defaultEndpoints = map[string]string{
+		"graphql":     "/v1/query",
+		"merkletree":  "/v1/merkletree",
+		"treeHead":    "/v1/merkletree/treehead",
+		"consistency": "/v1/merkletree/consistency",
+		"metadata":    WellKnownPath,
+		"identifiers": "/1.0/identifiers",
+	}
+)
+
+// CommitterSource returns the addresses that commit the roots of the hub, it
+// is implemented by claims.RootService
+type CommitterSource interface {
+	GetCommitterAddresses() ([]string, error)
+}
+
+// Metadata describes the hub so clients can check proofs came from it
+type Metadata struct {
+	ID                 string            `json:"id"`
+	CommitterAddresses []string          `json:"committerAddresses"`
+	ContractAddress    string            `json:"contractAddress"`
+	TreeHeadSigner     string            `json:"treeHeadSigner,omitempty"`
+	CredentialTypes    []string          `json:"credentialTypes"`
+	Endpoints          map[string]string `json:"endpoints"`
+	Updated            time.Time         `json:"updated"`
+}
+
+// SignedMetadata is the hub metadata with a proof signed by the key of the hub did
+type SignedMetadata struct {
+	Metadata
+	Proof *linkeddata.Proof `json:"proof"`
+}
+
+// MetadataConfig is the part of the hub metadata that comes from the config
+type MetadataConfig struct {
+	ContractAddress string
+	TreeHeadSigner  string
+	// BaseURL is prepended to the endpoints, they are relative paths if empty
+	BaseURL string
+}
+
+// Service builds and signs the hub metadata
+type Service struct {
+	identity   *Identity
+	committers CommitterSource
+	config     MetadataConfig
+}
+
+// NewService returns a new hub Service
+func NewService(identity *Identity, committers CommitterSource, config MetadataConfig) *Service {
+	return &Service{
+		identity:   identity,
+		committers: committers,
+		config:     config,
+	}
+}
+
+// Identity returns the identity of the hub
+func (s *Service) Identity() *Identity {
+	return s.identity
+}
+
+// GetSignedMetadata returns the current hub metadata signed by the hub
+func (s *Service) GetSignedMetadata() (*SignedMetadata, error) {
+	committers := []string{}
+	if s.committers != nil {
+		var err error
+		committers, err = s.committers.GetCommitterAddresses()
+		if err != nil {
+			return nil, errors.Wrap(err, "getsignedmetadata.getcommitteraddresses")
+		}
+	}
+
+	endpoints := make(map[string]string, len(defaultEndpoints))
+	for name, path := range defaultEndpoints {
+		endpoints[name] = strings.TrimSuffix(s.config.BaseURL, "/") + path
+	}
+
+	metadata := Metadata{
+		ID:                 s.identity.DID.String(),
+		CommitterAddresses: committers,
+		ContractAddress:    s.config.ContractAddress,
+		TreeHeadSigner:     s.config.TreeHeadSigner,
+		CredentialTypes:    supportedCredentialTypes,
+		Endpoints:          endpoints,
+		Updated:            time.Now().UTC().Truncate(time.Second),
+	}
+	hash, err := metadataHash(&metadata)
+	if err != nil {
+		return nil, errors.Wrap(err, "getsignedmetadata.metadatahash")
+	}
+	sig, err := s.identity.Sign(hash)
+	if err != nil {
+		return nil, errors.Wrap(err, "getsignedmetadata.sign")
+	}
+	return &SignedMetadata{
+		Metadata: metadata,
+		Proof: &linkeddata.Proof{
+			Type:       string(linkeddata.SuiteTypeSecp256k1Signature),
+			Creator:    s.identity.KeyID(),
+			Created:    metadata.Updated,
+			ProofValue: hex.EncodeToString(sig),
+		},
+	}, nil
+}
+
+// Verify checks the metadata is signed by the key in its proof and that the key
+// is an assertion method of the did document of the hub
+func (m *SignedMetadata) Verify(hubDoc *did.Document) error {
+	if m.Proof == nil || hubDoc.ID.String() != m.ID {
+		return ErrMetadataBadSignature
+	}
+	keyID, err := didlib.Parse(m.Proof.Creator)
+	if err != nil || did.MethodIDOnly(keyID) != did.MethodIDOnly(&hubDoc.ID) {
+		return ErrMetadataBadSignature
+	}
+	key, err := hubDoc.GetRelationshipKeyFromFragment(did.RelationshipAssertionMethod, keyID.Fragment)
+	if err != nil {
+		return ErrMetadataBadSignature
+	}
+	pubKey, err := key.AsEcdsaPubKey()
+	if err != nil {
+		return errors.Wrap(err, "verify.asecdsapubkey")
+	}
+
+	hash, err := metadataHash(&m.Metadata)
+	if err != nil {
+		return errors.Wrap(err, "verify.metadatahash")
+	}
+	sig, err := hex.DecodeString(m.Proof.ProofValue)
+	if err != nil {
+		return errors.Wrap(err, "verify.decodestring")
+	}
+	sigPubKey, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return ErrMetadataBadSignature
+	}
+	if crypto.PubkeyToAddress(*sigPubKey) != crypto.PubkeyToAddress(*pubKey) {
+		return ErrMetadataBadSignature
+	}
+	return nil
+}
+
+// metadataHash is the keccak256 of the json of the metadata, the fields are
+// marshalled in struct order and map keys sorted so the json is stable
+func metadataHash(metadata *Metadata) ([]byte, error) {
+	js, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Keccak256(js), nil
+}
@@ -29,6 +29,12 @@ func RunCLI() error {
 		*cmdGenerateNewKey(),
 		*cmdGenerateGqlCreds(),
 		*cmdSignDummyJWT(),
+		*cmdTreeCheck(),
+		*cmdTreeGC(),
+		*cmdTreeRebuild(),
+		*cmdTreeDiff(),
+		*cmdMonitor(),
+		*cmdDIDReindex(),
 	}
 
 	return app.Run(os.Args)
@@ -104,7 +110,8 @@ func cmdGenerateDID() *cli.Command {
 			if err != nil {
 				log.Errorf("Error initializing GORM: err: %v", err)
 			} else {
-				grm.AutoMigrate(&ethuri.PostgresDocument{})
+				grm.AutoMigrate(&ethuri.PostgresDocument{}, &ethuri.PostgresDocumentVersion{},
+					&ethuri.PostgresDocumentIndex{})
 				persister = ethuri.NewPostgresPersister(grm)
 			}
 		}
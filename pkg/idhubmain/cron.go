@@ -40,18 +40,33 @@ func (s *RootCron) StartCron(config *utils.IDHubConfig) error {
 		log.Fatalf("error initializing eth helper: %v", err)
 	}
 
-	treeStore := initTreePersister(db)
+	// With leveldb the hub process commits the roots
+	treeStore, _, err := initSharedTreePersister(config, db, "commitroot")
+	if err != nil {
+		log.Fatalf("error initializing tree persister: %v", err)
+	}
 
-	rootService, err := initRootService(config, ethHelper, treeStore, persister)
+	rootService, err := initRootService(config, ethHelper, treeStore, persister, true)
 	if err != nil {
 		log.Fatalf("error initializing root service: %v", err)
 	}
 
-	s.cr = cron.New()
 	s.RunCronProcess(rootService)
-	// Start up the cron to run it periodically
+	err = s.Start(config, rootService)
+	if err != nil {
+		return err
+	}
+
+	// Blocks here while the cron process runs
+	select {}
+}
+
+// Start runs the root commits periodically on the cron schedule of the config,
+// without blocking
+func (s *RootCron) Start(config *utils.IDHubConfig, rootService *claims.RootService) error {
+	s.cr = cron.New()
 	log.Infof("Cron config: %v", config.CronConfig)
-	_, err = s.cr.AddFunc(config.CronConfig, func() {
+	_, err := s.cr.AddFunc(config.CronConfig, func() {
 		s.RunCronProcess(rootService)
 	})
 	if err != nil {
@@ -61,9 +76,7 @@ func (s *RootCron) StartCron(config *utils.IDHubConfig) error {
 
 	s.cr.Start()
 	s.CheckCron()
-
-	// Blocks here while the cron process runs
-	select {}
+	return nil
 }
 
 // RunCronProcess executes the scheduled code
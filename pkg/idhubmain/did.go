@@ -4,42 +4,141 @@ import (
 	"time"
 
 	"github.com/allegro/bigcache"
+	"github.com/ethereum/go-ethereum/common"
+	log "github.com/golang/glog"
+	"github.com/gomodule/redigo/redis"
 	"github.com/jinzhu/gorm"
+	"github.com/joincivil/go-common/pkg/eth"
+	"github.com/joincivil/go-common/pkg/lock"
 	"github.com/pkg/errors"
 
 	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/did/ethr"
 	"github.com/joincivil/id-hub/pkg/did/ethuri"
+	"github.com/joincivil/id-hub/pkg/did/key"
+	"github.com/joincivil/id-hub/pkg/did/web"
 	"github.com/joincivil/id-hub/pkg/utils"
 )
 
-var (
-	bigCacheConfig = bigcache.Config{
-		Shards:             1024,
-		LifeWindow:         2 * time.Second,
-		CleanWindow:        3 * time.Second,
-		MaxEntriesInWindow: 1000 * 10 * 60,
-		MaxEntrySize:       500,
-		Verbose:            true,
-		HardMaxCacheSize:   16384,
-	}
+const (
+	// ethURILocalCacheLifeWindow is how long ethuri documents are cached in
+	// process without redis, which bounds how long other instances return a
+	// document after it was saved
+	ethURILocalCacheLifeWindow = 30 * time.Second
 )
 
 func initEthURIResolver(db *gorm.DB) (*ethuri.Service, error) {
+	// Documents saved before the index table existed are indexed with the
+	// did-reindex command
+	db.AutoMigrate(ethuri.PostgresDocumentVersion{}, ethuri.PostgresDocumentIndex{})
 	didPersister := ethuri.NewPostgresPersister(db)
+	// Documents saved before versions were kept get their current version
+	err := didPersister.BackfillDocumentVersions()
+	if err != nil {
+		return nil, err
+	}
 	didService := ethuri.NewService(didPersister)
 	return didService, nil
 }
 
-func initHTTPUniversalResolver(config *utils.IDHubConfig) (*did.HTTPUniversalResolver, error) {
-	bcache, err := bigcache.NewBigCache(bigCacheConfig)
+func initKeyResolver() *key.Resolver {
+	return key.NewResolver()
+}
+
+func initWebResolver() (*web.Resolver, error) {
+	bcache, err := bigcache.NewBigCache(did.DefaultBigCacheConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "webresolver.newbigcache")
+	}
+	return web.NewResolver(nil, did.NewBigCacheResolverCache(bcache)), nil
+}
+
+// initEthrResolver returns the did:ethr resolver reading the registry with the
+// eth helper, or nil if no registry address is set
+func initEthrResolver(config *utils.IDHubConfig, ethHelper *eth.Helper) (*ethr.Resolver, error) {
+	if config.EthrRegistryAddress == "" {
+		log.Errorf("No ethr registry address set, disabling native did:ethr resolution")
+		return nil, nil
+	}
+	bcache, err := bigcache.NewBigCache(did.DefaultBigCacheConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "ethrresolver.newbigcache")
+	}
+	return ethr.NewResolver(
+		common.HexToAddress(config.EthrRegistryAddress),
+		ethHelper.Blockchain,
+		config.EthrNetwork,
+		did.NewBigCacheResolverCache(bcache),
+	)
+}
+
+// initDidCache returns the DID resolver cache shared through redis, or nil if
+// there are no redis hosts or the first host can't be reached
+func initDidCache(config *utils.IDHubConfig) *did.RedisResolverCache {
+	if len(config.RedisHosts) == 0 {
+		return nil
+	}
+	pool := lock.NewRedisDLockPool(config.RedisHosts[0], poolMaxIdle, poolMaxActive, nil)
+	_, err := redis.DoWithTimeout(pool.Get(), 500*time.Millisecond, "PING")
 	if err != nil {
-		return nil, errors.Wrap(err, "universalresolver.newbigcache")
+		log.Errorf("unable to reach redis host for did cache: %v", config.RedisHosts[0])
+		return nil
+	}
+
+	cacheConfig := did.DefaultRedisResolverCacheConfig
+	if config.DidCacheExpirySecs != nil {
+		cacheConfig.ExpirySecs = *config.DidCacheExpirySecs
+	}
+	if config.DidCacheNotFoundExpirySecs != nil {
+		cacheConfig.NotFoundExpirySecs = *config.DidCacheNotFoundExpirySecs
+	}
+	methodExpirySecs := map[string]int{}
+	for method, secs := range cacheConfig.MethodExpirySecs {
+		methodExpirySecs[method] = secs
+	}
+	for method, secs := range config.DidCacheMethodExpirySecs {
+		methodExpirySecs[method] = secs
+	}
+	cacheConfig.MethodExpirySecs = methodExpirySecs
+
+	log.Infof("Using redis did cache")
+	return did.NewRedisResolverCache(pool, cacheConfig)
+}
+
+// initEthURICache returns the cache of the ethuri resolver, the shared cache if
+// given or an in process cache otherwise. Documents are only invalidated in
+// process on the instance that saved them, so they expire sooner than in the
+// shared cache.
+func initEthURICache(sharedCache did.ResolverCache) (did.ResolverCache, error) {
+	if sharedCache != nil {
+		return sharedCache, nil
+	}
+	cacheConfig := did.DefaultBigCacheConfig
+	cacheConfig.LifeWindow = ethURILocalCacheLifeWindow
+	cacheConfig.CleanWindow = ethURILocalCacheLifeWindow
+	bcache, err := bigcache.NewBigCache(cacheConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "ethuricache.newbigcache")
+	}
+	return did.NewBigCacheResolverCache(bcache), nil
+}
+
+// initHTTPUniversalResolver returns the universal resolver wrapped with a
+// cached resolver, caching in the shared cache if given or in process otherwise
+func initHTTPUniversalResolver(config *utils.IDHubConfig,
+	sharedCache did.ResolverCache) (*did.CachedResolver, error) {
+	cache := sharedCache
+	if cache == nil {
+		bcache, err := bigcache.NewBigCache(did.DefaultBigCacheConfig)
+		if err != nil {
+			return nil, errors.Wrap(err, "universalresolver.newbigcache")
+		}
+		cache = did.NewBigCacheResolverCache(bcache)
 	}
-	cache := did.NewBigCacheResolverCache(bcache)
 	resolver := did.NewHTTPUniversalResolver(
 		config.DidUniversalResolverHost,
 		config.DidUniversalResolverPort,
-		cache,
+		nil,
 	)
-	return resolver, nil
+	return did.NewCachedResolver(resolver, cache), nil
 }
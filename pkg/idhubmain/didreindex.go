@@ -0,0 +1,47 @@
+package idhubmain
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+
+	"github.com/joincivil/id-hub/pkg/did/ethuri"
+	"github.com/joincivil/id-hub/pkg/utils"
+)
+
+// cmdDIDReindex rebuilds the search index of every ethuri did document, used
+// to index the documents saved before the index existed
+func cmdDIDReindex() *cli.Command {
+	cmdFn := func(c *cli.Context) error {
+		config := &utils.IDHubConfig{}
+		err := config.PopulateFromEnv()
+		if err != nil {
+			return errors.Wrap(err, "didreindex.populatefromenv")
+		}
+
+		db, err := initGorm(config)
+		if err != nil {
+			return errors.Wrap(err, "didreindex.initgorm")
+		}
+		defer db.Close() // nolint: errcheck
+
+		err = db.AutoMigrate(ethuri.PostgresDocumentIndex{}).Error
+		if err != nil {
+			return errors.Wrap(err, "didreindex.automigrate")
+		}
+		err = ethuri.NewPostgresPersister(db).ReindexDocuments()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("reindexed the did documents\n")
+		return nil
+	}
+
+	return &cli.Command{
+		Name:    "did-reindex",
+		Aliases: []string{"i"},
+		Usage:   "Rebuilds the search index of the did documents",
+		Action:  cmdFn,
+	}
+}
@@ -0,0 +1,105 @@
+package idhubmain
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	log "github.com/golang/glog"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/claims"
+	"github.com/joincivil/id-hub/pkg/did/ethuri"
+	"github.com/joincivil/id-hub/pkg/didjwt"
+	"github.com/joincivil/id-hub/pkg/domainlinkage"
+	"github.com/joincivil/id-hub/pkg/hub"
+	"github.com/joincivil/id-hub/pkg/utils"
+)
+
+const (
+	// hubDomainLinkageValidity is how long the domain linkage credentials the hub
+	// issues for its hosted domains are valid
+	hubDomainLinkageValidity = 365 * 24 * time.Hour
+)
+
+// initHubService loads the identity of the hub, generating its did on first
+// boot, and returns the service for the signed hub metadata. Returns nil if the
+// hub private key is not set, the hub then has no did and serves no metadata.
+func initHubService(db *gorm.DB, config *utils.IDHubConfig,
+	rootService *claims.RootService) (*hub.Service, error) {
+	// The hub did has its own key, not the key root commits are sent with
+	hubKey := strings.TrimPrefix(config.HubPrivateKey, "0x")
+	if hubKey == "" {
+		log.Warningf("No hub private key set, not generating the hub did or serving %v, "+
+			"set IDHUB_HUB_PRIVATE_KEY to a new key to enable them", hub.WellKnownPath)
+		return nil, nil
+	}
+	if strings.EqualFold(hubKey, strings.TrimPrefix(config.EthereumDefaultPrivateKey, "0x")) {
+		return nil, errors.New("inithubservice: the hub private key has to differ from the default Ethereum key")
+	}
+	privKey, err := crypto.HexToECDSA(hubKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "inithubservice.hextoecdsa")
+	}
+
+	db.AutoMigrate(hub.StoredIdentity{})
+	didPersister := ethuri.NewPostgresPersister(db)
+	identity, err := hub.LoadOrCreateIdentity(
+		hub.NewIdentityPGPersister(db),
+		didPersister,
+		privKey,
+		initDLock(config),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "inithubservice.loadorcreateidentity")
+	}
+	if config.HubBaseURL != "" {
+		err = identity.EnsureService(didPersister, config.HubBaseURL)
+		if err != nil {
+			return nil, errors.Wrap(err, "inithubservice.ensureservice")
+		}
+	}
+
+	metadataConfig := hub.MetadataConfig{
+		ContractAddress: config.RootCommitsAddress,
+		BaseURL:         config.HubBaseURL,
+	}
+	// The tree heads are signed with the key of the hub did
+	metadataConfig.TreeHeadSigner = claims.NewTreeHeadSigner(privKey).Address().Hex()
+
+	var committers hub.CommitterSource
+	if rootService != nil {
+		committers = rootService
+	}
+	return hub.NewService(identity, committers, metadataConfig), nil
+}
+
+// initDomainLinkageService returns the service for domain linkage credentials
+// and issues the credentials linking the hub did to its hosted domains, if the
+// hub has a did
+func initDomainLinkageService(db *gorm.DB, config *utils.IDHubConfig, didJWTService *didjwt.Service,
+	hubService *hub.Service) (*domainlinkage.Service, error) {
+	db.AutoMigrate(domainlinkage.Linkage{})
+	service, err := domainlinkage.NewService(
+		domainlinkage.NewPGPersister(db),
+		didJWTService,
+		domainlinkage.NewHTTPFetcher(),
+		config.HostedDomains,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "initdomainlinkageservice.newservice")
+	}
+	if hubService == nil {
+		if len(config.HostedDomains) > 0 {
+			log.Warningf("No hub did, not issuing domain linkage credentials for the hosted domains")
+		}
+		return service, nil
+	}
+	err = service.EnsureIssued(context.Background(), hubService.Identity(), hubDomainLinkageValidity)
+	if err != nil {
+		return nil, errors.Wrap(err, "initdomainlinkageservice.ensureissued")
+	}
+	return service, nil
+}
@@ -4,14 +4,24 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/didip/tollbooth"
+	"github.com/didip/tollbooth_chi"
 	"github.com/go-chi/chi"
 	log "github.com/golang/glog"
+	"github.com/pkg/errors"
+
 	"github.com/joincivil/id-hub/pkg/merkletree"
+	"github.com/joincivil/id-hub/pkg/utils"
 )
 
 // RunMerkleTreeServer starts the merkle tree service server
 func RunMerkleTreeServer() error {
 	config := populateConfig()
+	if config.PersisterType == utils.PersisterTypeLevelDB {
+		return errors.New(
+			"the merkle tree server can not be used with the leveldb persister, it can only be opened by the hub process",
+		)
+	}
 
 	// init GORM
 	db, err := initGorm(config)
@@ -22,16 +32,29 @@ func RunMerkleTreeServer() error {
 
 	router := basicHTTPSetup()
 
-	_, _, claimsService, didJWTService := initServices(db, config)
+	// Only serves the root commits, the tree heads are signed where the roots are committed
+	_, _, claimsService, didJWTService, rootService, _ := initServices(db, config, false)
 
 	mtservice := merkletree.NewService(didJWTService, claimsService)
 
 	handler := merkletree.NewHandler(mtservice)
+	treeHeadHandler := merkletree.NewTreeHeadHandler(rootService)
+
+	// Keyed on the connection address only, the forwarded headers are set by the
+	// client and would let it pick a new key for every request
+	consistencyLimiter := tollbooth.NewLimiter(1, nil) // 1 req/sec max
+	consistencyLimiter.SetIPLookups([]string{"RemoteAddr"})
 
 	router.Route(fmt.Sprintf("/%v/merkletree", "v1"), func(r chi.Router) {
 		r.Get("/proof/{credential}", handler.GetProofHandler)
+		r.Get("/docversion/{did}/{version}", handler.GetDocumentVersionProofHandler)
 		r.Post("/", handler.AddHandler)
 		r.Put("/revoke", handler.RevokeHandler)
+		r.Get("/treehead", treeHeadHandler.GetLatestTreeHeadHandler)
+		r.Get("/treehead/{root}", treeHeadHandler.GetTreeHeadHandler)
+		// Consistency proofs walk the root tree at both roots
+		r.With(tollbooth_chi.LimitHandler(consistencyLimiter)).
+			Get("/consistency/{first}/{second}", treeHeadHandler.GetConsistencyProofHandler)
 	})
 
 	gqlURL := fmt.Sprintf(":%v", config.GqlPort)
@@ -0,0 +1,168 @@
+package idhubmain
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	log "github.com/golang/glog"
+	stan "github.com/nats-io/stan.go"
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+
+	"github.com/joincivil/id-hub/pkg/claims"
+	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/didjwt"
+	"github.com/joincivil/id-hub/pkg/monitor"
+	"github.com/joincivil/id-hub/pkg/utils"
+)
+
+const (
+	monitorClientID = "id-hub-monitor"
+)
+
+// cmdMonitor runs a transparency monitor that follows the root claims of dids
+// and alerts when entries that are not in the allow-list are added to their trees
+func cmdMonitor() *cli.Command {
+	didFlag := cli.StringSliceFlag{
+		Name:  "did, d",
+		Usage: "DID to monitor, can be repeated",
+	}
+	allowListFlag := cli.StringFlag{
+		Name:  "allowlist, a",
+		Usage: "File with the expected content hashes and hex public keys, one per line",
+	}
+	webhookFlag := cli.StringFlag{
+		Name:  "webhook, w",
+		Usage: "URL to post alerts to as json, alerts are logged if not set",
+	}
+	intervalFlag := cli.IntFlag{
+		Name:  "interval, i",
+		Usage: "Seconds between checks for new root commits",
+		Value: 60,
+	}
+	allFlag := cli.BoolFlag{
+		Name:  "all",
+		Usage: "Check every version of the root claims instead of only the ones after the current version",
+	}
+	streamFlag := cli.BoolFlag{
+		Name:  "stream, s",
+		Usage: "Also check the current trees on every add and revoke in the pubsub stream",
+	}
+
+	cmdFn := func(c *cli.Context) error {
+		if len(c.StringSlice("did")) == 0 {
+			return errors.New("at least one --did is required")
+		}
+		if c.String("allowlist") == "" {
+			return errors.New("--allowlist is required")
+		}
+
+		config := &utils.IDHubConfig{}
+		err := config.PopulateFromEnv()
+		if err != nil {
+			return errors.Wrap(err, "monitor.populatefromenv")
+		}
+
+		db, err := initGorm(config)
+		if err != nil {
+			return errors.Wrap(err, "monitor.initgorm")
+		}
+		defer db.Close() // nolint: errcheck
+
+		treeStore, rootClaimIndex, err := initSharedTreePersister(config, db, "monitor")
+		if err != nil {
+			return errors.Wrap(err, "monitor.inittreepersister")
+		}
+		resolver, err := initHTTPUniversalResolver(config, nil)
+		if err != nil {
+			return errors.Wrap(err, "monitor.inithttpuniversalresolver")
+		}
+		ethURIResolver, err := initEthURIResolver(db)
+		if err != nil {
+			return errors.Wrap(err, "monitor.initethuriresolver")
+		}
+		didService := initDidService([]did.Resolver{resolver, ethURIResolver})
+		didJWTService := didjwt.NewService(didService)
+		claimService, err := initClaimsService(treeStore, rootClaimIndex, initSignedClaimPersister(db),
+			initRevocationPersister(db), didService, nil, initDLock(config))
+		if err != nil {
+			return errors.Wrap(err, "monitor.initclaimsservice")
+		}
+		jwtService := claims.NewJWTService(didJWTService, initJWTClaimPersister(db, didJWTService),
+			claimService, nil)
+
+		var alerter monitor.Alerter = &monitor.LogAlerter{}
+		if c.String("webhook") != "" {
+			alerter = monitor.NewWebhookAlerter(c.String("webhook"))
+		}
+		m := monitor.NewMonitor(claimService, jwtService, alerter, monitor.FileAllowList(c.String("allowlist")))
+
+		dids := c.StringSlice("did")
+		for _, d := range dids {
+			userDid, err := didlib.Parse(d)
+			if err != nil {
+				return errors.Wrapf(err, "monitor.parse: did: %v", d)
+			}
+			err = m.Track(userDid, c.Bool("all"))
+			if err != nil {
+				return errors.Wrapf(err, "monitor.track: did: %v", d)
+			}
+		}
+
+		if c.Bool("stream") {
+			natsURL := config.NatsURL
+			if natsURL == "" {
+				natsURL = stan.DefaultNatsURL
+			}
+			sc, err := stan.Connect(config.NatsID, monitorClientID, stan.NatsURL(natsURL))
+			if err != nil {
+				return errors.Wrap(err, "monitor.connect")
+			}
+			defer sc.Close() // nolint: errcheck
+			check := func(msg *stan.Msg) {
+				if _, err := m.Check(nil); err != nil {
+					log.Errorf("Error checking the trees after %v: err: %v", msg.Subject, err)
+				}
+			}
+			for _, d := range dids {
+				for _, op := range []string{"add", "revoke"} {
+					_, err = sc.Subscribe(fmt.Sprintf("%s.%s.%s", config.NatsPrefix, d, op), check)
+					if err != nil {
+						return errors.Wrap(err, "monitor.subscribe")
+					}
+				}
+			}
+		}
+
+		stop := make(chan struct{})
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		go func() {
+			<-signals
+			close(stop)
+		}()
+
+		fmt.Printf("monitoring %v dids\n", len(dids))
+		m.FollowCommits(initRootClaimPersister(db), time.Duration(c.Int("interval"))*time.Second, stop)
+		return nil
+	}
+
+	return &cli.Command{
+		Name:    "monitor",
+		Aliases: []string{"m"},
+		Usage:   "Monitors the trees of dids and alerts on entries that are not in the allow-list",
+		Flags: []cli.Flag{
+			didFlag,
+			allowListFlag,
+			webhookFlag,
+			intervalFlag,
+			allFlag,
+			streamFlag,
+		},
+		Action: cmdFn,
+	}
+}
@@ -0,0 +1,43 @@
+package idhubmain
+
+import (
+	"github.com/allegro/bigcache"
+	log "github.com/golang/glog"
+	"github.com/iden3/go-iden3-core/db"
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/go-common/pkg/lock"
+
+	"github.com/joincivil/id-hub/pkg/claimsstore"
+	"github.com/joincivil/id-hub/pkg/utils"
+)
+
+const (
+	nodeCacheNamespace = "idhub"
+)
+
+// initCachedTreePersister returns the tree persister wrapped with a node cache.
+// The cache is in process and, if enabled in the config, shared through redis.
+func initCachedTreePersister(config *utils.IDHubConfig,
+	treeStore db.Storage) (*claimsstore.CachedStore, error) {
+	bcache, err := bigcache.NewBigCache(claimsstore.DefaultNodeBigCacheConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "cachedtreepersister.newbigcache")
+	}
+	local := claimsstore.NewBigCacheNodeCache(bcache)
+
+	var shared claimsstore.NodeCache
+	if config.TreeNodeCacheShared && len(config.RedisHosts) > 0 {
+		log.Infof("Using redis node cache")
+		pool := lock.NewRedisDLockPool(config.RedisHosts[0], poolMaxIdle, poolMaxActive, nil)
+		shared = claimsstore.NewRedisNodeCache(
+			pool,
+			nodeCacheNamespace,
+			claimsstore.DefaultRedisNodeCacheExpirySecs,
+		)
+	}
+
+	store := claimsstore.NewCachedStore(treeStore, local, shared)
+	store.PublishStats(claimsstore.NodeCacheMetricsName)
+	return store, nil
+}
@@ -1,22 +1,54 @@
 package idhubmain
 
 import (
+	"github.com/iden3/go-iden3-core/db"
 	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+
+	log "github.com/golang/glog"
+
 	"github.com/joincivil/id-hub/pkg/claimsstore"
 	"github.com/joincivil/id-hub/pkg/didjwt"
 	"github.com/joincivil/id-hub/pkg/hedgehog"
+	"github.com/joincivil/id-hub/pkg/utils"
 )
 
 func initNodePersister(db *gorm.DB) *claimsstore.NodePGPersister {
 	persister := claimsstore.NewNodePGPersisterWithDB(db)
-	db.AutoMigrate(claimsstore.Node{})
+	db.AutoMigrate(claimsstore.Node{}, claimsstore.TreeChange{})
 	return persister
 }
 
-func initTreePersister(db *gorm.DB) *claimsstore.PGStore {
-	nodePersister := initNodePersister(db)
+// initTreePersister returns the storage for the merkle tree nodes and the index
+// of the root claims in it for the persister type in the config. leveldb only
+// replaces the node storage, everything else is still stored in gormDB.
+func initTreePersister(config *utils.IDHubConfig, gormDB *gorm.DB) (db.Storage,
+	claimsstore.RootClaimIndex, error) {
+	if config.PersisterType == utils.PersisterTypeLevelDB {
+		log.Infof("Using leveldb tree persister: %v", config.PersisterLeveldbPath)
+		persister, err := claimsstore.NewLevelDBStore(config.PersisterLeveldbPath)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "treepersister.newleveldbstore")
+		}
+		return persister, persister, nil
+	}
+	nodePersister := initNodePersister(gormDB)
 	persister := claimsstore.NewPGStore(nodePersister)
-	return persister
+	return persister, nodePersister, nil
+}
+
+// initSharedTreePersister returns the tree persister for the processes that run
+// next to the hub, the commands, the root commits cron and the merkle tree
+// server. The embedded leveldb can only be opened by the hub process.
+func initSharedTreePersister(config *utils.IDHubConfig, gormDB *gorm.DB, name string) (db.Storage,
+	claimsstore.RootClaimIndex, error) {
+	if config.PersisterType == utils.PersisterTypeLevelDB {
+		return nil, nil, errors.Errorf(
+			"%v can not be used with the leveldb persister, it can only be opened by the hub process",
+			name,
+		)
+	}
+	return initTreePersister(config, gormDB)
 }
 
 func initSignedClaimPersister(db *gorm.DB) *claimsstore.SignedClaimPGPersister {
@@ -25,6 +57,12 @@ func initSignedClaimPersister(db *gorm.DB) *claimsstore.SignedClaimPGPersister {
 	return persister
 }
 
+func initRevocationPersister(db *gorm.DB) *claimsstore.RevocationPGPersister {
+	persister := claimsstore.NewRevocationPGPersister(db)
+	db.AutoMigrate(claimsstore.Revocation{})
+	return persister
+}
+
 func initRootClaimPersister(db *gorm.DB) *claimsstore.RootCommitsPGPersister {
 	persister := claimsstore.NewRootCommitsPGPersister(db)
 	db.AutoMigrate(
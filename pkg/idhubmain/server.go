@@ -2,6 +2,7 @@ package idhubmain
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"net/http"
 
@@ -13,7 +14,9 @@ import (
 
 	"github.com/joincivil/id-hub/pkg/auth"
 	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/domainlinkage"
 	"github.com/joincivil/id-hub/pkg/graphql"
+	"github.com/joincivil/id-hub/pkg/hub"
 	"github.com/joincivil/id-hub/pkg/utils"
 
 	gqlgen "github.com/99designs/gqlgen/graphql"
@@ -31,15 +34,36 @@ var (
 	}
 )
 
-func initResolver(db *gorm.DB, config *utils.IDHubConfig) *graphql.Resolver {
+func initResolver(db *gorm.DB, config *utils.IDHubConfig) (*graphql.Resolver, *hub.Service) {
+	// The leveldb trees can only be opened by the hub, so it commits the roots
+	// instead of the commitroot cron
+	commitsRoots := config.PersisterType == utils.PersisterTypeLevelDB
+	jwtService, didService, claimsService, didJWTService, rootService, ethURIService := initServices(db, config,
+		commitsRoots)
 
-	jwtService, didService, claimsService, _ := initServices(db, config)
+	hubService, err := initHubService(db, config, rootService)
+	if err != nil {
+		log.Fatalf("error initializing hub service: %v", err)
+	}
+	domainLinkageService, err := initDomainLinkageService(db, config, didJWTService, hubService)
+	if err != nil {
+		log.Fatalf("error initializing domain linkage service: %v", err)
+	}
 
-	return &graphql.Resolver{
-		DidService:   didService,
-		ClaimService: claimsService,
-		JWTService:   jwtService,
+	if commitsRoots && rootService != nil {
+		err = (&RootCron{}).Start(config, rootService)
+		if err != nil {
+			log.Fatalf("error starting root commits: %v", err)
+		}
 	}
+
+	return &graphql.Resolver{
+		DidService:           didService,
+		ClaimService:         claimsService,
+		JWTService:           jwtService,
+		DomainLinkageService: domainLinkageService,
+		EthURIService:        ethURIService,
+	}, hubService
 }
 
 func basicHTTPSetup() chi.Router {
@@ -72,7 +96,7 @@ func RunServer() error {
 	}
 	// db.LogMode(true)
 
-	resolver := initResolver(db, config)
+	resolver, hubService := initResolver(db, config)
 	initHedgehog(db)
 
 	router := basicHTTPSetup()
@@ -114,6 +138,38 @@ func RunServer() error {
 
 	hedgehog.AddRoutes(hedgehog.Dependencies{Router: router, Db: db})
 
+	// The hub metadata is only served if the hub has a did
+	if hubService != nil {
+		router.Get(hub.WellKnownPath, hub.NewHandler(hubService).GetMetadataHandler)
+	}
+	router.Get(did.IdentifiersPath, did.NewHandler(resolver.DidService).GetIdentifierHandler)
+	router.Get(domainlinkage.WellKnownPath,
+		domainlinkage.NewHandler(resolver.DomainLinkageService).GetConfigurationHandler)
+
+	runAdminServer(config)
+
 	log.Infof("Starting up GraphQL services at %v", gqlURL)
 	return http.ListenAndServe(gqlURL, router)
 }
+
+// runAdminServer serves the internal endpoints on the admin port, which is not
+// to be exposed publicly, or nothing if the admin port is not set
+func runAdminServer(config *utils.IDHubConfig) {
+	if config.AdminPort == 0 {
+		log.Infof("No admin port set, not serving /debug/vars")
+		return
+	}
+	router := chi.NewRouter()
+	router.Use(middleware.Recoverer)
+	// Resolver metrics are published with expvar
+	router.Handle("/debug/vars", expvar.Handler())
+
+	adminURL := fmt.Sprintf(":%v", config.AdminPort)
+	log.Infof("Starting up admin server at %v", adminURL)
+	go func() {
+		err := http.ListenAndServe(adminURL, router)
+		if err != nil {
+			log.Errorf("error serving admin server: err: %v", err)
+		}
+	}()
+}
@@ -1,7 +1,11 @@
 package idhubmain
 
 import (
+	"strings"
+	"time"
+
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/crypto"
 	log "github.com/golang/glog"
 	"github.com/iden3/go-iden3-core/db"
 	"github.com/jinzhu/gorm"
@@ -10,18 +14,23 @@ import (
 	"github.com/joincivil/id-hub/pkg/claims"
 	"github.com/joincivil/id-hub/pkg/claimsstore"
 	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/did/ethuri"
 	"github.com/joincivil/id-hub/pkg/didjwt"
 	"github.com/joincivil/id-hub/pkg/pubsub"
 	"github.com/joincivil/id-hub/pkg/utils"
+	"github.com/pkg/errors"
 )
 
 func initDidService(resolvers []did.Resolver) *did.Service {
 	return did.NewService(resolvers)
 }
 
-func initClaimsService(treeStore *claimsstore.PGStore, signedClaimStore *claimsstore.SignedClaimPGPersister,
-	didService *did.Service, rootService *claims.RootService, dlock lock.DLock) (*claims.Service, error) {
-	return claims.NewService(treeStore, signedClaimStore, didService, rootService, dlock)
+func initClaimsService(treeStore db.Storage, rootClaimIndex claimsstore.RootClaimIndex,
+	signedClaimStore *claimsstore.SignedClaimPGPersister,
+	revocationStore *claimsstore.RevocationPGPersister, didService *did.Service,
+	rootService *claims.RootService, dlock lock.DLock) (*claims.Service, error) {
+	return claims.NewService(treeStore, rootClaimIndex, signedClaimStore, revocationStore,
+		didService, rootService, dlock)
 }
 
 func initJWTClaimService(didJWTService *didjwt.Service,
@@ -30,8 +39,11 @@ func initJWTClaimService(didJWTService *didjwt.Service,
 	return claims.NewJWTService(didJWTService, jwtPersister, claimService, natsService)
 }
 
-func initRootService(config *utils.IDHubConfig, ethHelper *eth.Helper,
-	treeStore db.Storage, persister *claimsstore.RootCommitsPGPersister) (*claims.RootService, error) {
+// initRootService returns the root service, with a tree head signer only if the
+// process commits roots so the hub key is not required by processes that only
+// read the root commits
+func initRootService(config *utils.IDHubConfig, ethHelper *eth.Helper, treeStore db.Storage,
+	persister *claimsstore.RootCommitsPGPersister, commitsRoots bool) (*claims.RootService, error) {
 	if config.RootCommitsAddress == "" {
 		log.Errorf("No root commits address set, disabling root commits access")
 		return nil, nil
@@ -45,13 +57,41 @@ func initRootService(config *utils.IDHubConfig, ethHelper *eth.Helper,
 	if err != nil {
 		return nil, err
 	}
-	return claims.NewRootService(treeStore, rootCommitter, persister)
+	if !commitsRoots {
+		return claims.NewRootService(treeStore, rootCommitter, persister, nil)
+	}
+	signer, err := initTreeHeadSigner(config)
+	if err != nil {
+		return nil, err
+	}
+	return claims.NewRootService(treeStore, rootCommitter, persister, signer)
 }
 
-func initServices(db *gorm.DB, config *utils.IDHubConfig) (*claims.JWTService, *did.Service, *claims.Service, *didjwt.Service) {
+// initTreeHeadSigner returns the signer for the tree heads of root commits, the
+// tree heads are signed with the key of the hub did
+func initTreeHeadSigner(config *utils.IDHubConfig) (*claims.TreeHeadSigner, error) {
+	if config.HubPrivateKey == "" {
+		return nil, errors.New("inittreeheadsigner: the hub private key is required to sign tree heads")
+	}
+	privKey, err := crypto.HexToECDSA(strings.TrimPrefix(config.HubPrivateKey, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "inittreeheadsigner.hextoecdsa")
+	}
+	return claims.NewTreeHeadSigner(privKey), nil
+}
+
+// initServices returns the services, commitsRoots is set if the process commits
+// the roots of the root tree
+func initServices(db *gorm.DB, config *utils.IDHubConfig, commitsRoots bool) (*claims.JWTService, *did.Service,
+	*claims.Service, *didjwt.Service, *claims.RootService, *ethuri.Service) {
 	// DID init
+	// Shared DID cache, if redis is available
+	var didCache did.ResolverCache
+	if redisCache := initDidCache(config); redisCache != nil {
+		didCache = redisCache
+	}
 	// Universal Resolver
-	resolver, err := initHTTPUniversalResolver(config)
+	resolver, err := initHTTPUniversalResolver(config, didCache)
 	if err != nil {
 		log.Fatalf("error initializing universal resolver")
 	}
@@ -60,6 +100,30 @@ func initServices(db *gorm.DB, config *utils.IDHubConfig) (*claims.JWTService, *
 	if err != nil {
 		log.Fatalf("error initializing ethuri resolver")
 	}
+	// Invalidate saved ethuri documents for all instances with the shared
+	// cache, or for this instance with the in process cache
+	ethURICache, err := initEthURICache(didCache)
+	if err != nil {
+		log.Fatalf("error initializing ethuri cache: %v", err)
+	}
+	ethURIResolver.SetCache(ethURICache)
+
+	// did:key Resolver
+	keyResolver := initKeyResolver()
+	// did:web Resolver
+	webResolver, err := initWebResolver()
+	if err != nil {
+		log.Fatalf("error initializing web resolver")
+	}
+	ethHelper, err := initETHHelper(config)
+	if err != nil {
+		log.Fatalf("error initializing eth helper: %v", err)
+	}
+	// did:ethr Resolver
+	ethrResolver, err := initEthrResolver(config, ethHelper)
+	if err != nil {
+		log.Fatalf("error initializing ethr resolver: %v", err)
+	}
 
 	sc, err := initializeNats(config)
 	if err != nil {
@@ -68,26 +132,43 @@ func initServices(db *gorm.DB, config *utils.IDHubConfig) (*claims.JWTService, *
 
 	// TODO(PN): Adding ethuri resolver during transition of enterprise clients
 	// to other DID methods. Once this occurs, should remove it.
-	didService := initDidService([]did.Resolver{resolver, ethURIResolver})
+	cachedEthURIResolver := did.NewCachedResolver(ethURIResolver, ethURICache)
+	resolvers := []did.Resolver{resolver, cachedEthURIResolver, keyResolver, webResolver}
+	if ethrResolver != nil {
+		resolvers = append(resolvers, ethrResolver)
+	}
+	didService := initDidService(resolvers)
+	if config.DidUniversalResolverTimeout != nil {
+		didService.SetResolverTimeout(
+			resolver,
+			time.Duration(*config.DidUniversalResolverTimeout)*time.Second,
+		)
+	}
 	didJWTService := didjwt.NewService(didService)
 
 	// Claims init
-	treePersister := initTreePersister(db)
+	treeStore, rootClaimIndex, err := initTreePersister(config, db)
+	if err != nil {
+		log.Fatalf("error initializing tree persister: %v", err)
+	}
+	treePersister, err := initCachedTreePersister(config, treeStore)
+	if err != nil {
+		log.Fatalf("error initializing tree persister: %v", err)
+	}
 	signedClaimPersister := initSignedClaimPersister(db)
+	revocationPersister := initRevocationPersister(db)
 	rootPersister := initRootClaimPersister(db)
 	jwtClaimPersister := initJWTClaimPersister(db, didJWTService)
-	ethHelper, err := initETHHelper(config)
-	if err != nil {
-		log.Fatalf("error initializing eth helper: %v", err)
-	}
-	rootService, err := initRootService(config, ethHelper, treePersister, rootPersister)
+	rootService, err := initRootService(config, ethHelper, treePersister, rootPersister, commitsRoots)
 	if err != nil {
 		log.Fatalf("error initializing root service: %v", err)
 	}
 	dlock := initDLock(config)
 	claimsService, err := initClaimsService(
 		treePersister,
+		rootClaimIndex,
 		signedClaimPersister,
+		revocationPersister,
 		didService,
 		rootService,
 		dlock,
@@ -95,6 +176,13 @@ func initServices(db *gorm.DB, config *utils.IDHubConfig) (*claims.JWTService, *
 	if err != nil {
 		log.Fatalf("error initializing claims service")
 	}
+	// Anchor every saved ethuri document version in the DID tree
+	ethURIResolver.SetDocumentAnchor(claimsService)
+	// Retry the versions that failed to anchor or were saved before anchoring
+	err = ethURIResolver.AnchorDocumentVersions()
+	if err != nil {
+		log.Errorf("error anchoring did document versions: err: %v", err)
+	}
 
 	jwtService := initJWTClaimService(
 		didJWTService,
@@ -103,5 +191,5 @@ func initServices(db *gorm.DB, config *utils.IDHubConfig) (*claims.JWTService, *
 		sc,
 	)
 
-	return jwtService, didService, claimsService, didJWTService
+	return jwtService, didService, claimsService, didJWTService, rootService, ethURIResolver
 }
@@ -0,0 +1,78 @@
+package idhubmain
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+
+	"github.com/joincivil/id-hub/pkg/claimsstore"
+	"github.com/joincivil/id-hub/pkg/utils"
+)
+
+// cmdTreeCheck checks the integrity of the merkle trees using the storage in the
+// idhub config and reports any dangling, orphaned or corrupt nodes
+func cmdTreeCheck() *cli.Command {
+	skipDocumentsFlag := cli.BoolFlag{
+		Name:  "skipdocs, s",
+		Usage: "Skip checking the registered documents are in signed_claims / jwt_claims",
+	}
+
+	cmdFn := func(c *cli.Context) error {
+		config := &utils.IDHubConfig{}
+		err := config.PopulateFromEnv()
+		if err != nil {
+			return errors.Wrap(err, "treecheck.populatefromenv")
+		}
+
+		db, err := initGorm(config)
+		if err != nil {
+			return errors.Wrap(err, "treecheck.initgorm")
+		}
+		defer db.Close() // nolint: errcheck
+
+		treeStore, _, err := initSharedTreePersister(config, db, "tree-check")
+		if err != nil {
+			return errors.Wrap(err, "treecheck.inittreepersister")
+		}
+		iterator, ok := treeStore.(claimsstore.TreeIterator)
+		if !ok {
+			return claimsstore.ErrTreeIteratorNotSupported
+		}
+
+		var docs claimsstore.DocumentIndex
+		if !c.Bool("skipdocs") {
+			docs = claimsstore.NewPGDocumentIndex(
+				claimsstore.NewSignedClaimPGPersister(db),
+				claimsstore.NewJWTClaimPGPersister(db, nil),
+			)
+		}
+
+		report, err := claimsstore.CheckTrees(iterator, docs)
+		if err != nil {
+			return err
+		}
+
+		for _, issue := range report.Issues {
+			fmt.Printf("%v\n", issue)
+		}
+		fmt.Printf("\ntrees: %v, nodes: %v, root claims: %v, documents: %v, issues: %v\n",
+			report.TreeCount, report.NodeCount, report.RootClaimCount,
+			report.DocumentCount, len(report.Issues))
+
+		if len(report.Issues) > 0 {
+			return errors.Errorf("found %v issues in the merkle trees", len(report.Issues))
+		}
+		return nil
+	}
+
+	return &cli.Command{
+		Name:    "tree-check",
+		Aliases: []string{"t"},
+		Usage:   "Checks the merkle trees for dangling, orphaned or corrupt nodes",
+		Flags: []cli.Flag{
+			skipDocumentsFlag,
+		},
+		Action: cmdFn,
+	}
+}
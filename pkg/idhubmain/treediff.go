@@ -0,0 +1,156 @@
+package idhubmain
+
+import (
+	"fmt"
+
+	"github.com/iden3/go-iden3-core/merkletree"
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+
+	"github.com/joincivil/id-hub/pkg/claims"
+	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/didjwt"
+	"github.com/joincivil/id-hub/pkg/utils"
+)
+
+// cmdTreeDiff prints the registrations, revocations, keys and document versions
+// added to the tree of a did between two roots or two root commits
+func cmdTreeDiff() *cli.Command {
+	didFlag := cli.StringFlag{
+		Name:  "did, d",
+		Usage: "DID of the tree to diff",
+	}
+	fromFlag := cli.StringFlag{
+		Name:  "from, f",
+		Usage: "Hex of the did tree root to diff from, defaults to the empty tree",
+	}
+	fromCommitFlag := cli.StringFlag{
+		Name:  "fromcommit",
+		Usage: "Hex of the committed root tree root to diff from",
+	}
+	toFlag := cli.StringFlag{
+		Name:  "to, t",
+		Usage: "Hex of the did tree root to diff to, defaults to the current root",
+	}
+	toCommitFlag := cli.StringFlag{
+		Name:  "tocommit",
+		Usage: "Hex of the committed root tree root to diff to",
+	}
+
+	cmdFn := func(c *cli.Context) error {
+		if c.String("did") == "" {
+			return errors.New("--did is required")
+		}
+		userDid, err := didlib.Parse(c.String("did"))
+		if err != nil {
+			return errors.Wrap(err, "treediff.parse")
+		}
+
+		config := &utils.IDHubConfig{}
+		err = config.PopulateFromEnv()
+		if err != nil {
+			return errors.Wrap(err, "treediff.populatefromenv")
+		}
+
+		db, err := initGorm(config)
+		if err != nil {
+			return errors.Wrap(err, "treediff.initgorm")
+		}
+		defer db.Close() // nolint: errcheck
+
+		treeStore, rootClaimIndex, err := initSharedTreePersister(config, db, "tree-diff")
+		if err != nil {
+			return errors.Wrap(err, "treediff.inittreepersister")
+		}
+		resolver, err := initHTTPUniversalResolver(config, nil)
+		if err != nil {
+			return errors.Wrap(err, "treediff.inithttpuniversalresolver")
+		}
+		ethURIResolver, err := initEthURIResolver(db)
+		if err != nil {
+			return errors.Wrap(err, "treediff.initethuriresolver")
+		}
+		didService := initDidService([]did.Resolver{resolver, ethURIResolver})
+		didJWTService := didjwt.NewService(didService)
+		// only reads the root commits, so no committer is needed
+		rootService, err := claims.NewRootService(treeStore, nil, initRootClaimPersister(db), nil)
+		if err != nil {
+			return errors.Wrap(err, "treediff.newrootservice")
+		}
+		claimService, err := initClaimsService(treeStore, rootClaimIndex, initSignedClaimPersister(db),
+			initRevocationPersister(db), didService, rootService, initDLock(config))
+		if err != nil {
+			return errors.Wrap(err, "treediff.initclaimsservice")
+		}
+		jwtService := claims.NewJWTService(didJWTService, initJWTClaimPersister(db, didJWTService),
+			claimService, nil)
+
+		from, err := treeDiffRoot(claimService, userDid, c.String("from"), c.String("fromcommit"))
+		if err != nil {
+			return errors.Wrap(err, "treediff.from")
+		}
+		to, err := treeDiffRoot(claimService, userDid, c.String("to"), c.String("tocommit"))
+		if err != nil {
+			return errors.Wrap(err, "treediff.to")
+		}
+
+		diff, err := jwtService.DiffDIDRoots(userDid, from, to)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("did: %v\nfrom: %v\nto: %v\n\n", diff.DID, diff.From.Hex(), diff.To.Hex())
+		for _, change := range diff.Keys {
+			fmt.Printf("key: %v\n", change.PublicKey)
+		}
+		for _, changes := range [][]*claims.TreeDiffChange{diff.Registrations, diff.Revocations} {
+			for _, change := range changes {
+				fmt.Printf("%v: doc type: %v, hash: %v\n", change.Type, change.DocType, change.ContentHash)
+				if change.Credential != nil {
+					fmt.Printf("  credential: %+v\n", change.Credential)
+				}
+				if change.JWT != "" {
+					fmt.Printf("  jwt: %v\n", change.JWT)
+				}
+			}
+		}
+		for _, change := range diff.DocumentVersions {
+			fmt.Printf("document version: %v, hash: %v\n", change.DocVersion, change.DocumentHash)
+		}
+		for _, change := range diff.Unknown {
+			fmt.Printf("unknown: claim type: %v, entry: %v\n", change.ClaimType, change.Entry)
+		}
+		fmt.Printf("\nkeys: %v, registrations: %v, revocations: %v, document versions: %v, unknown: %v\n",
+			len(diff.Keys), len(diff.Registrations), len(diff.Revocations), len(diff.DocumentVersions),
+			len(diff.Unknown))
+		return nil
+	}
+
+	return &cli.Command{
+		Name:    "tree-diff",
+		Aliases: []string{"d"},
+		Usage:   "Lists the claims added to a did tree between two roots",
+		Flags: []cli.Flag{
+			didFlag,
+			fromFlag,
+			fromCommitFlag,
+			toFlag,
+			toCommitFlag,
+		},
+		Action: cmdFn,
+	}
+}
+
+func treeDiffRoot(claimService *claims.Service, userDid *didlib.DID, root string,
+	commit string) (*merkletree.Hash, error) {
+	if root != "" && commit != "" {
+		return nil, errors.New("only one of a root or a commit can be given")
+	}
+	if root != "" {
+		return claims.ParseTreeRoot(root)
+	}
+	if commit != "" {
+		return claimService.DIDRootAtCommit(userDid, commit)
+	}
+	return nil, nil
+}
@@ -0,0 +1,116 @@
+package idhubmain
+
+import (
+	"fmt"
+
+	"github.com/iden3/go-iden3-core/merkletree"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+
+	"github.com/joincivil/id-hub/pkg/claimsstore"
+	"github.com/joincivil/id-hub/pkg/utils"
+)
+
+// cmdTreeGC deletes the merkle tree nodes that are not reachable from the
+// current roots or the retained committed roots
+func cmdTreeGC() *cli.Command {
+	dryRunFlag := cli.BoolFlag{
+		Name:  "dryrun, n",
+		Usage: "Only report the nodes that would be deleted and the space reclaimed",
+	}
+	retainFlag := cli.IntFlag{
+		Name:  "retain, r",
+		Usage: "Number of the latest committed roots to retain, 0 retains all of them. " +
+			"The trees of older commits are deleted, so the did roots at those commits, " +
+			"tree-diff from them and consistency proofs from them fail afterwards, " +
+			"so it requires --force.",
+		Value: 0,
+	}
+	forceFlag := cli.BoolFlag{
+		Name:  "force",
+		Usage: "Delete the trees of the commits not retained, breaking the consistency proofs from them",
+	}
+	batchSizeFlag := cli.IntFlag{
+		Name:  "batch, b",
+		Usage: "Number of nodes to delete at a time",
+		Value: claimsstore.DefaultTreeGCBatchSize,
+	}
+
+	cmdFn := func(c *cli.Context) error {
+		config := &utils.IDHubConfig{}
+		err := config.PopulateFromEnv()
+		if err != nil {
+			return errors.Wrap(err, "treegc.populatefromenv")
+		}
+
+		// Consistency proofs of the signed tree heads need the trees of every commit
+		if c.Int("retain") > 0 && !c.Bool("force") && !c.Bool("dryrun") {
+			return errors.New(
+				"--retain breaks the consistency proofs from the commits it deletes, " +
+					"use --force to delete them",
+			)
+		}
+
+		db, err := initGorm(config)
+		if err != nil {
+			return errors.Wrap(err, "treegc.initgorm")
+		}
+		defer db.Close() // nolint: errcheck
+
+		treeStore, _, err := initSharedTreePersister(config, db, "tree-gc")
+		if err != nil {
+			return errors.Wrap(err, "treegc.inittreepersister")
+		}
+		iterator, ok := treeStore.(claimsstore.TreeIterator)
+		if !ok {
+			return claimsstore.ErrTreeIteratorNotSupported
+		}
+		deleter, ok := treeStore.(claimsstore.TreeNodeDeleter)
+		if !ok {
+			return claimsstore.ErrTreeNodeDeleterNotSupported
+		}
+
+		commits, err := initRootClaimPersister(db).GetLatestCommits(c.Int("retain"))
+		if err != nil {
+			return errors.Wrap(err, "treegc.getlatestcommits")
+		}
+		retained := make([]merkletree.Hash, len(commits))
+		for i, commit := range commits {
+			err = retained[i].UnmarshalText([]byte(commit.Root))
+			if err != nil {
+				return errors.Wrapf(err, "treegc.unmarshaltext: root: %v", commit.Root)
+			}
+		}
+
+		report, err := claimsstore.CollectTreeGarbage(iterator, deleter, claimsstore.TreeGCOptions{
+			RetainedRoots: retained,
+			DryRun:        c.Bool("dryrun"),
+			BatchSize:     c.Int("batch"),
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, issue := range report.Issues {
+			fmt.Printf("%v\n", issue)
+		}
+		fmt.Printf("\ntrees: %v, nodes: %v, retained roots: %v, reachable: %v\n",
+			report.TreeCount, report.NodeCount, len(retained), report.ReachableCount)
+		fmt.Printf("reclaimable: %v nodes, %v bytes, deleted: %v nodes\n",
+			report.ReclaimableCount, report.ReclaimableBytes, report.DeletedCount)
+		return nil
+	}
+
+	return &cli.Command{
+		Name:    "tree-gc",
+		Aliases: []string{"c"},
+		Usage:   "Deletes merkle tree nodes that are not reachable from any retained root",
+		Flags: []cli.Flag{
+			dryRunFlag,
+			retainFlag,
+			forceFlag,
+			batchSizeFlag,
+		},
+		Action: cmdFn,
+	}
+}
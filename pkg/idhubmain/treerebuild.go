@@ -0,0 +1,121 @@
+package idhubmain
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+
+	"github.com/joincivil/id-hub/pkg/claims"
+	"github.com/joincivil/id-hub/pkg/claimsstore"
+	"github.com/joincivil/id-hub/pkg/did"
+	"github.com/joincivil/id-hub/pkg/did/ethuri"
+	"github.com/joincivil/id-hub/pkg/didjwt"
+	"github.com/joincivil/id-hub/pkg/utils"
+)
+
+// cmdTreeRebuild rebuilds the merkle trees from the credential tables, the did
+// document keys and the revocation ledger into a fresh prefix or leveldb and
+// compares the rebuilt root with the latest root commit
+func cmdTreeRebuild() *cli.Command {
+	prefixFlag := cli.StringFlag{
+		Name:  "prefix, p",
+		Usage: "Rebuild into the configured tree storage with this prefix",
+	}
+	levelDBFlag := cli.StringFlag{
+		Name:  "leveldb, l",
+		Usage: "Rebuild into a new leveldb at this path",
+	}
+
+	cmdFn := func(c *cli.Context) error {
+		if (c.String("prefix") == "") == (c.String("leveldb") == "") {
+			return errors.New("one of --prefix or --leveldb is required")
+		}
+
+		config := &utils.IDHubConfig{}
+		err := config.PopulateFromEnv()
+		if err != nil {
+			return errors.Wrap(err, "treerebuild.populatefromenv")
+		}
+
+		db, err := initGorm(config)
+		if err != nil {
+			return errors.Wrap(err, "treerebuild.initgorm")
+		}
+		defer db.Close() // nolint: errcheck
+
+		treeStore, _, err := initSharedTreePersister(config, db, "tree-rebuild")
+		if err != nil {
+			return errors.Wrap(err, "treerebuild.inittreepersister")
+		}
+		target := treeStore.WithPrefix([]byte(c.String("prefix")))
+		if c.String("leveldb") != "" {
+			store, err := claimsstore.NewLevelDBStore(c.String("leveldb"))
+			if err != nil {
+				return errors.Wrap(err, "treerebuild.newleveldbstore")
+			}
+			defer store.Close()
+			target = store
+		}
+
+		resolver, err := initHTTPUniversalResolver(config, nil)
+		if err != nil {
+			return errors.Wrap(err, "treerebuild.inithttpuniversalresolver")
+		}
+		ethURIResolver, err := initEthURIResolver(db)
+		if err != nil {
+			return errors.Wrap(err, "treerebuild.initethuriresolver")
+		}
+		didService := initDidService([]did.Resolver{resolver, ethURIResolver})
+		source := claims.NewPGRebuildSource(
+			claimsstore.NewSignedClaimPGPersister(db),
+			claimsstore.NewJWTClaimPGPersister(db, didjwt.NewService(didService)),
+			initRevocationPersister(db),
+			ethuri.NewPostgresPersister(db),
+			didService,
+		)
+
+		report, err := claims.RebuildTrees(source, target)
+		if err != nil {
+			return err
+		}
+		for _, skipped := range report.Skipped {
+			fmt.Printf("skipped: %v\n", skipped)
+		}
+		fmt.Printf("\ndids: %v, keys: %v, documents: %v, revocations: %v, document versions: %v, "+
+			"root claims: %v\n", report.DIDCount, report.KeyCount, report.DocumentCount,
+			report.RevocationCount, report.DocumentVersionCount, report.RootClaimCount)
+		fmt.Printf("rebuilt root: %v\n", report.Root.Hex())
+
+		commit, err := initRootClaimPersister(db).GetLatest()
+		if err != nil || commit.Root == "" {
+			fmt.Printf("no root commit to compare with\n")
+			return nil
+		}
+		err = report.CompareWithCommit(commit, treeStore)
+		if err != nil {
+			fmt.Printf("could not compare the did trees with the committed root tree: %v\n", err)
+		}
+		for _, mismatch := range report.Mismatches {
+			fmt.Printf("mismatch: %v\n", mismatch)
+		}
+		fmt.Printf("committed root: %v, block: %v\n", commit.Root, commit.BlockNumber)
+
+		if !report.Faithful {
+			return errors.New("the rebuilt root does not match the latest root commit")
+		}
+		fmt.Printf("the rebuilt root matches the latest root commit\n")
+		return nil
+	}
+
+	return &cli.Command{
+		Name:    "tree-rebuild",
+		Aliases: []string{"r"},
+		Usage:   "Rebuilds the merkle trees from the credential tables and compares them with the latest root commit",
+		Flags: []cli.Flag{
+			prefixFlag,
+			levelDBFlag,
+		},
+		Action: cmdFn,
+	}
+}
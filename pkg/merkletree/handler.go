@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi"
 )
@@ -33,7 +34,7 @@ func (h *Handler) AddHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = h.service.AddEntry(p.Credential, p.Sender)
+	_, err = h.service.AddEntry(r.Context(), p.Credential, p.Sender)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -46,6 +47,7 @@ func (h *Handler) AddHandler(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) RevokeHandler(w http.ResponseWriter, r *http.Request) {
 	var p = struct {
 		Credential string `json:"credential"`
+		Sender     string `json:"sender"`
 	}{}
 
 	err := json.NewDecoder(r.Body).Decode(&p)
@@ -54,7 +56,7 @@ func (h *Handler) RevokeHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.service.RevokeEntry(p.Credential)
+	err = h.service.RevokeEntry(r.Context(), p.Credential, p.Sender)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -66,7 +68,7 @@ func (h *Handler) RevokeHandler(w http.ResponseWriter, r *http.Request) {
 // GetProofHandler returns a proof for a given credential
 func (h *Handler) GetProofHandler(w http.ResponseWriter, r *http.Request) {
 	credential := chi.URLParam(r, "credential")
-	proof, err := h.service.GenerateProof(credential)
+	proof, err := h.service.GenerateProof(r.Context(), credential)
 	if err != nil {
 		// TODO(walfly): make this more nuanced in the way it chooses a status code
 		http.Error(w, err.Error(), http.StatusNotFound)
@@ -82,3 +84,33 @@ func (h *Handler) GetProofHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	_, _ = w.Write(js)
 }
+
+// GetDocumentVersionProofHandler returns a proof that a version of a did
+// document was current at the root given in the root query param, or at the
+// latest root
+func (h *Handler) GetDocumentVersionProofHandler(w http.ResponseWriter, r *http.Request) {
+	version, err := strconv.ParseUint(chi.URLParam(r, "version"), 10, 32)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	proof, err := h.service.GenerateDocumentVersionProof(
+		chi.URLParam(r, "did"),
+		uint32(version),
+		r.URL.Query().Get("root"),
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	js, err := json.Marshal(proof)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(js)
+}
@@ -1,6 +1,7 @@
 package merkletree
 
 import (
+	"context"
 	"github.com/joincivil/id-hub/pkg/claims"
 	"github.com/joincivil/id-hub/pkg/claimtypes"
 	"github.com/joincivil/id-hub/pkg/didjwt"
@@ -24,8 +25,8 @@ func NewService(didJWTService *didjwt.Service,
 	}
 }
 
-func (s *Service) getIssuer(tokenString string) (*didlib.DID, error) {
-	token, err := s.didJWTService.ParseJWT(tokenString)
+func (s *Service) getIssuer(ctx context.Context, tokenString string) (*didlib.DID, error) {
+	token, err := s.didJWTService.ParseJWT(ctx, tokenString)
 	if err != nil {
 		return nil, errors.Wrap(err, "AddEntry failed to parse token")
 	}
@@ -34,8 +35,8 @@ func (s *Service) getIssuer(tokenString string) (*didlib.DID, error) {
 }
 
 // AddEntry adds a new jwt claim to it's issuers tree
-func (s *Service) AddEntry(tokenString string, sender string) (string, error) {
-	issuer, err := s.getIssuer(tokenString)
+func (s *Service) AddEntry(ctx context.Context, tokenString string, sender string) (string, error) {
+	issuer, err := s.getIssuer(ctx, tokenString)
 	claimtype := claimtypes.JWTDocType
 	if err != nil {
 		issuer, err = didlib.Parse(sender)
@@ -45,7 +46,9 @@ func (s *Service) AddEntry(tokenString string, sender string) (string, error) {
 		}
 	}
 
-	didMT, err := s.claimService.BuildDIDMt(issuer)
+	// The requests are not authenticated, so the sender is only claimed
+	claimService := s.claimService.WithClaimedSender(sender)
+	didMT, err := claimService.BuildDIDMt(issuer)
 	if err != nil {
 		return "", errors.Wrap(err, "AddEntry error building didmt")
 	}
@@ -66,12 +69,12 @@ func (s *Service) AddEntry(tokenString string, sender string) (string, error) {
 		return "", errors.Wrap(err, "AddEntry error creating registered document claim")
 	}
 
-	err = didMT.Add(claim.Entry())
+	err = claimService.AddToDIDTree(didMT, issuer, claim.Entry())
 	if err != nil {
 		return "", errors.Wrap(err, "AddEntry add claim to did mt")
 	}
 
-	err = s.claimService.AddNewRootClaim(issuer)
+	err = claimService.AddNewRootClaim(issuer)
 	if err != nil {
 		return "", errors.Wrap(err, "AddEntry.addnewrootclaim")
 	}
@@ -90,9 +93,10 @@ func (s *Service) makeRegisteredDocClaimFromJWT(token string,
 	return claimtypes.NewClaimRegisteredDocument(hash34, issuer, claimtypes.JWTDocType)
 }
 
-// RevokeEntry takes a token and revokes it in the merkle tree
-func (s *Service) RevokeEntry(tokenString string) error {
-	token, err := s.didJWTService.ParseJWT(tokenString)
+// RevokeEntry takes a token and revokes it in the merkle tree, the revocation
+// is recorded as made by sender
+func (s *Service) RevokeEntry(ctx context.Context, tokenString string, sender string) error {
+	token, err := s.didJWTService.ParseJWT(ctx, tokenString)
 	if err != nil {
 		return errors.Wrap(err, "RevokeJWTClaim couldn't parse token")
 	}
@@ -102,26 +106,15 @@ func (s *Service) RevokeEntry(tokenString string) error {
 		return errors.Wrap(err, "RevokeJWTClaim error parsing issuer did")
 	}
 
-	didMt, err := s.claimService.BuildDIDMt(issuer)
-	if err != nil {
-		return errors.Wrap(err, "RevokeJWTClaim.builddidMt")
-	}
-
 	regDocClaim, err := s.makeRegisteredDocClaimFromJWT(tokenString, issuer)
 	if err != nil {
 		return errors.Wrap(err, "RevokeJWTClaim couldn't make reg doc claim")
 	}
 
-	regDocClaim.Version = 1
-
-	err = didMt.Add(regDocClaim.Entry())
-	if err != nil {
-		return errors.Wrap(err, "RevokeJWTClaim.add")
-	}
-
-	err = s.claimService.AddNewRootClaim(issuer)
+	claimService := s.claimService.WithClaimedSender(sender)
+	err = claimService.RevokeRegisteredDocument(regDocClaim, issuer)
 	if err != nil {
-		return errors.Wrap(err, "RevokeJWTClaim.addnewrootclaim")
+		return errors.Wrap(err, "RevokeJWTClaim.revokeregistereddocument")
 	}
 
 	return nil
@@ -129,8 +122,8 @@ func (s *Service) RevokeEntry(tokenString string) error {
 }
 
 // GenerateProof creates a proof from a jwt
-func (s *Service) GenerateProof(tokenString string) (*claims.MTProof, error) {
-	token, err := s.didJWTService.ParseJWT(tokenString)
+func (s *Service) GenerateProof(ctx context.Context, tokenString string) (*claims.MTProof, error) {
+	token, err := s.didJWTService.ParseJWT(ctx, tokenString)
 	if err != nil {
 		return nil, errors.Wrap(err, "GenerateProof couldn't parse token")
 	}
@@ -147,3 +140,15 @@ func (s *Service) GenerateProof(tokenString string) (*claims.MTProof, error) {
 
 	return s.claimService.GenerateProofRegistedDocument(regDocClaim, issuer)
 }
+
+// GenerateDocumentVersionProof creates a proof that a version of an ethuri did
+// document was current at a root, or at the latest root if root is empty
+func (s *Service) GenerateDocumentVersionProof(didStr string, version uint32,
+	root string) (*claims.DocumentVersionProof, error) {
+	d, err := didlib.Parse(didStr)
+	if err != nil {
+		return nil, errors.Wrap(err, "GenerateDocumentVersionProof couldn't parse did")
+	}
+
+	return s.claimService.GenerateProofDocumentVersion(d, version, root)
+}
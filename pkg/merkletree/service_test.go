@@ -1,6 +1,7 @@
 package merkletree_test
 
 import (
+	"context"
 	"encoding/hex"
 	"testing"
 
@@ -61,13 +62,13 @@ func TestMerkletreeService(t *testing.T) {
 		t.Errorf("unable to create jwt string: %v", err)
 	}
 
-	_, err = merkleTreeService.AddEntry(tokenS, userDID.String())
+	_, err = merkleTreeService.AddEntry(context.Background(), tokenS, userDID.String())
 
 	if err != nil {
 		t.Errorf("failed to add jwt: %v", err)
 	}
 
-	proofBeforeCommit, err := merkleTreeService.GenerateProof(tokenS)
+	proofBeforeCommit, err := merkleTreeService.GenerateProof(context.Background(), tokenS)
 	if err != nil {
 		t.Errorf("error generating proof: %v", err)
 	}
@@ -81,7 +82,7 @@ func TestMerkletreeService(t *testing.T) {
 		t.Errorf("error committing root: %v", err)
 	}
 
-	proof, err := merkleTreeService.GenerateProof(tokenS)
+	proof, err := merkleTreeService.GenerateProof(context.Background(), tokenS)
 	if err != nil {
 		t.Errorf("error generating proof: %v", err)
 	}
@@ -145,12 +146,12 @@ func TestMerkletreeService(t *testing.T) {
 		t.Errorf("couldn't verify root tree proof")
 	}
 
-	err = merkleTreeService.RevokeEntry(tokenS)
+	err = merkleTreeService.RevokeEntry(context.Background(), tokenS, userDID.String())
 	if err != nil {
 		t.Errorf("couldn't revoke claim")
 	}
 
-	_, err = merkleTreeService.GenerateProof(tokenS)
+	_, err = merkleTreeService.GenerateProof(context.Background(), tokenS)
 	if err == nil {
 		t.Errorf("it should error if the claim is revoked")
 	}
@@ -161,8 +162,9 @@ func setupConnection() (*gorm.DB, error) {
 	if err != nil {
 		return nil, err
 	}
-	db.DropTable(&ethuri.PostgresDocument{}, &claimsstore.RootCommit{}, &claimsstore.Node{})
-	err = db.AutoMigrate(&ethuri.PostgresDocument{}, &claimsstore.SignedClaimPostgres{}, &claimsstore.Node{}, &claimsstore.RootCommit{}, &claimsstore.JWTClaimPostgres{}).Error
+	db.DropTable(&ethuri.PostgresDocument{}, &ethuri.PostgresDocumentVersion{}, &ethuri.PostgresDocumentIndex{}, &claimsstore.RootCommit{}, &claimsstore.Node{}, &claimsstore.Revocation{}, &claimsstore.TreeChange{})
+	err = db.AutoMigrate(&ethuri.PostgresDocument{}, &ethuri.PostgresDocumentVersion{}, &ethuri.PostgresDocumentIndex{}, &claimsstore.SignedClaimPostgres{}, &claimsstore.Node{}, &claimsstore.RootCommit{}, &claimsstore.JWTClaimPostgres{},
+		&claimsstore.Revocation{}, &claimsstore.TreeChange{}).Error
 	if err != nil {
 		return nil, err
 	}
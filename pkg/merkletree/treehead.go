@@ -0,0 +1,88 @@
+package merkletree
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	log "github.com/golang/glog"
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/claims"
+)
+
+// TreeHeadHandler handles requests for the signed tree heads of the root tree
+// and the consistency proofs between them
+type TreeHeadHandler struct {
+	rootService *claims.RootService
+}
+
+// NewTreeHeadHandler returns a new tree head handler
+func NewTreeHeadHandler(rootService *claims.RootService) *TreeHeadHandler {
+	return &TreeHeadHandler{
+		rootService: rootService,
+	}
+}
+
+// GetLatestTreeHeadHandler returns the signed tree head of the latest root commit
+func (h *TreeHeadHandler) GetLatestTreeHeadHandler(w http.ResponseWriter, r *http.Request) {
+	if h.rootService == nil {
+		http.Error(w, "no root service", http.StatusServiceUnavailable)
+		return
+	}
+	treeHead, err := h.rootService.GetLatestSignedTreeHead()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	writeJSON(w, treeHead)
+}
+
+// GetTreeHeadHandler returns the signed tree head of a root commit
+func (h *TreeHeadHandler) GetTreeHeadHandler(w http.ResponseWriter, r *http.Request) {
+	if h.rootService == nil {
+		http.Error(w, "no root service", http.StatusServiceUnavailable)
+		return
+	}
+	treeHead, err := h.rootService.GetSignedTreeHead(chi.URLParam(r, "root"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	writeJSON(w, treeHead)
+}
+
+// GetConsistencyProofHandler returns a proof that the second root commit
+// contains every root claim of the first
+func (h *TreeHeadHandler) GetConsistencyProofHandler(w http.ResponseWriter, r *http.Request) {
+	if h.rootService == nil {
+		http.Error(w, "no root service", http.StatusServiceUnavailable)
+		return
+	}
+	proof, err := h.rootService.ConsistencyProof(chi.URLParam(r, "first"), chi.URLParam(r, "second"))
+	switch errors.Cause(err) {
+	case nil:
+	case claims.ErrInvalidTreeRoot:
+		http.Error(w, "invalid root", http.StatusBadRequest)
+		return
+	case claims.ErrRootCommitNotFound:
+		http.Error(w, "root commit not found", http.StatusNotFound)
+		return
+	default:
+		log.Errorf("Error generating consistency proof: err: %v", err)
+		http.Error(w, "error generating the consistency proof", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, proof)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	js, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(js)
+}
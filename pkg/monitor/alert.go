@@ -0,0 +1,82 @@
+package monitor
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	log "github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultWebhookTimeout = 10 * time.Second
+)
+
+// Alert is an unexpected change to the tree of a tracked did
+type Alert struct {
+	DID         string `json:"did"`
+	Version     uint32 `json:"version"`
+	FromRoot    string `json:"fromRoot"`
+	ToRoot      string `json:"toRoot"`
+	Type        string `json:"type"`
+	DocType     uint32 `json:"docType,omitempty"`
+	ContentHash string `json:"contentHash,omitempty"`
+	PublicKey   string `json:"publicKey,omitempty"`
+	// DocVersion and DocumentHash are set for did document versions
+	DocVersion   uint32 `json:"docVersion,omitempty"`
+	DocumentHash string `json:"documentHash,omitempty"`
+	// ClaimType is the hex of the claim type of unknown claims
+	ClaimType string `json:"claimType,omitempty"`
+	JWT       string `json:"jwt,omitempty"`
+	Message   string `json:"message"`
+	Time      int64  `json:"time"`
+}
+
+// Alerter sends alerts
+type Alerter interface {
+	Alert(alert *Alert) error
+}
+
+// LogAlerter writes alerts to the log
+type LogAlerter struct{}
+
+// Alert logs the alert
+func (a *LogAlerter) Alert(alert *Alert) error {
+	log.Warningf("ALERT: %v: did: %v, version: %v, type: %v, hash: %v, key: %v, doc hash: %v, claim type: %v",
+		alert.Message, alert.DID, alert.Version, alert.Type, alert.ContentHash, alert.PublicKey,
+		alert.DocumentHash, alert.ClaimType)
+	return nil
+}
+
+// WebhookAlerter posts alerts as json to a webhook
+type WebhookAlerter struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookAlerter returns a new WebhookAlerter
+func NewWebhookAlerter(url string) *WebhookAlerter {
+	return &WebhookAlerter{
+		url:    url,
+		client: &http.Client{Timeout: defaultWebhookTimeout},
+	}
+}
+
+// Alert posts the alert to the webhook
+func (a *WebhookAlerter) Alert(alert *Alert) error {
+	js, err := json.Marshal(alert)
+	if err != nil {
+		return errors.Wrap(err, "alert.marshal")
+	}
+	resp, err := a.client.Post(a.url, "application/json", bytes.NewReader(js))
+	if err != nil {
+		return errors.Wrap(err, "alert.post")
+	}
+	defer resp.Body.Close() // nolint: errcheck
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook returned status %v", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+package monitor
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/golang/glog"
+	"github.com/iden3/go-iden3-core/merkletree"
+	didlib "github.com/ockam-network/did"
+	"github.com/pkg/errors"
+
+	"github.com/joincivil/id-hub/pkg/claims"
+	"github.com/joincivil/id-hub/pkg/claimsstore"
+	"github.com/joincivil/id-hub/pkg/claimtypes"
+	"github.com/joincivil/id-hub/pkg/did"
+)
+
+const (
+	// AlertTypeRewrite is the type of an alert for a did tree root that does not
+	// contain every claim of the previous root
+	AlertTypeRewrite = "rewrite"
+)
+
+// RootClaimReader reads the versions of the root claims of dids in the root
+// tree, it is implemented by claims.Service
+type RootClaimReader interface {
+	GetDIDRootClaim(userDid *didlib.DID, version uint32,
+		root *merkletree.Hash) (*claimtypes.ClaimSetRootKeyDID, error)
+}
+
+// TreeDiffer diffs two roots of a did tree, it is implemented by claims.Service
+// and claims.JWTService
+type TreeDiffer interface {
+	DiffDIDRoots(userDid *didlib.DID, from *merkletree.Hash, to *merkletree.Hash) (*claims.TreeDiff, error)
+}
+
+// CommitSource returns the latest root commit, it is implemented by
+// claimsstore.RootCommitsPGPersister
+type CommitSource interface {
+	GetLatest() (*claimsstore.RootCommit, error)
+}
+
+// AllowList returns the content hashes, hex public keys and did document hashes
+// expected to be added to the tracked did trees
+type AllowList interface {
+	Expected() (map[string]bool, error)
+}
+
+// StaticAllowList is a fixed allow-list
+type StaticAllowList []string
+
+// Expected returns the values in the list
+func (l StaticAllowList) Expected() (map[string]bool, error) {
+	return allowListValues(l), nil
+}
+
+// FileAllowList is an allow-list read from a file with one value per line, it is
+// read on every check so values can be added while the monitor runs. Lines
+// starting with # are ignored.
+type FileAllowList string
+
+// Expected returns the values in the file
+func (l FileAllowList) Expected() (map[string]bool, error) {
+	file, err := os.Open(string(l))
+	if err != nil {
+		return nil, errors.Wrap(err, "expected.open")
+	}
+	defer file.Close() // nolint: errcheck
+
+	values := []string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		values = append(values, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "expected.scan")
+	}
+	return allowListValues(values), nil
+}
+
+func allowListValues(values []string) map[string]bool {
+	expected := make(map[string]bool, len(values))
+	for _, v := range values {
+		expected[strings.ToLower(strings.TrimPrefix(v, "0x"))] = true
+	}
+	return expected
+}
+
+// Monitor follows the root claims of tracked dids and alerts when entries that
+// are not in the allow-list are added to their trees
+type Monitor struct {
+	rootClaims RootClaimReader
+	differ     TreeDiffer
+	alerter    Alerter
+	allowList  AllowList
+
+	mutex   sync.Mutex
+	tracked []*trackedDID
+}
+
+// trackedDID is the last root claim version of a did that was checked in the
+// current root tree and in the committed root trees. They are kept apart, a
+// version seen in the current tree is checked again once it is committed.
+type trackedDID struct {
+	did       *didlib.DID
+	current   *trackedRoot
+	committed *trackedRoot
+}
+
+// trackedRoot is the next root claim version to check and the did tree root of
+// the last one checked
+type trackedRoot struct {
+	nextVersion uint32
+	root        *merkletree.Hash
+}
+
+// NewMonitor returns a new Monitor
+func NewMonitor(rootClaims RootClaimReader, differ TreeDiffer, alerter Alerter,
+	allowList AllowList) *Monitor {
+	return &Monitor{
+		rootClaims: rootClaims,
+		differ:     differ,
+		alerter:    alerter,
+		allowList:  allowList,
+	}
+}
+
+// Track starts monitoring a did. If fromStart is true every version of its root
+// claim is checked, otherwise only the versions after the current one.
+func (m *Monitor) Track(userDid *didlib.DID, fromStart bool) error {
+	userDid = &didlib.DID{Method: userDid.Method, ID: userDid.ID}
+	start := trackedRoot{root: &merkletree.HashZero}
+	if !fromStart {
+		for {
+			claim, err := m.rootClaims.GetDIDRootClaim(userDid, start.nextVersion, nil)
+			if err == merkletree.ErrEntryIndexNotFound {
+				break
+			} else if err != nil {
+				return errors.Wrap(err, "track.getdidrootclaim")
+			}
+			root := claim.RootKey
+			start.root = &root
+			start.nextVersion++
+		}
+	}
+	current := start
+	committed := start
+	tracked := &trackedDID{did: userDid, current: &current, committed: &committed}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.tracked = append(m.tracked, tracked)
+	return nil
+}
+
+// Check verifies the new root claim versions of the tracked dids in the root
+// tree at root, or in the current root tree if root is nil, and sends an alert
+// for every unexpected entry. Returns the alerts sent. Checks of the current
+// root tree and of committed roots track the versions checked separately.
+func (m *Monitor) Check(root *merkletree.Hash) ([]*Alert, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	expected, err := m.allowList.Expected()
+	if err != nil {
+		return nil, errors.Wrap(err, "check.expected")
+	}
+	alerts := []*Alert{}
+	for _, tracked := range m.tracked {
+		state := tracked.committed
+		if root == nil {
+			state = tracked.current
+		}
+		didAlerts, err := m.checkDID(tracked.did, state, root, expected)
+		alerts = append(alerts, didAlerts...)
+		if err != nil {
+			return alerts, errors.Wrapf(err, "check.checkdid: did: %v", tracked.did.String())
+		}
+	}
+	return alerts, nil
+}
+
+func (m *Monitor) checkDID(userDid *didlib.DID, tracked *trackedRoot, root *merkletree.Hash,
+	expected map[string]bool) ([]*Alert, error) {
+	alerts := []*Alert{}
+	for {
+		claim, err := m.rootClaims.GetDIDRootClaim(userDid, tracked.nextVersion, root)
+		if err == merkletree.ErrEntryIndexNotFound {
+			return alerts, nil
+		} else if err != nil {
+			return alerts, errors.Wrap(err, "checkdid.getdidrootclaim")
+		}
+		next := claim.RootKey
+
+		diff, err := m.differ.DiffDIDRoots(userDid, tracked.root, &next)
+		if err == claims.ErrTreeDiffRootNotAncestor {
+			alerts = append(alerts, m.alert(&Alert{
+				DID:      did.MethodIDOnly(userDid),
+				Version:  claim.Version,
+				FromRoot: tracked.root.Hex(),
+				ToRoot:   next.Hex(),
+				Type:     AlertTypeRewrite,
+				Message:  "the root claim does not contain every entry of the previous version",
+			}))
+		} else if err != nil {
+			return alerts, errors.Wrap(err, "checkdid.diffdidroots")
+		} else {
+			for _, changes := range [][]*claims.TreeDiffChange{diff.Keys, diff.Registrations,
+				diff.Revocations, diff.DocumentVersions, diff.Unknown} {
+				for _, change := range changes {
+					if change.Type != claims.TreeDiffUnknown && (expected[change.ContentHash] ||
+						expected[change.PublicKey] || expected[change.DocumentHash]) {
+						continue
+					}
+					alerts = append(alerts, m.alert(&Alert{
+						DID:          did.MethodIDOnly(userDid),
+						Version:      claim.Version,
+						FromRoot:     tracked.root.Hex(),
+						ToRoot:       next.Hex(),
+						Type:         string(change.Type),
+						DocType:      change.DocType,
+						ContentHash:  change.ContentHash,
+						PublicKey:    change.PublicKey,
+						DocVersion:   change.DocVersion,
+						DocumentHash: change.DocumentHash,
+						ClaimType:    change.ClaimType,
+						JWT:          change.JWT,
+						Message:      alertMessage(change),
+					}))
+				}
+			}
+		}
+
+		tracked.root = &next
+		tracked.nextVersion++
+	}
+}
+
+func alertMessage(change *claims.TreeDiffChange) string {
+	if change.Type == claims.TreeDiffUnknown {
+		return "an entry of an unknown claim type was added"
+	}
+	return "an entry that is not in the allow-list was added"
+}
+
+func (m *Monitor) alert(alert *Alert) *Alert {
+	alert.Time = time.Now().Unix()
+	err := m.alerter.Alert(alert)
+	if err != nil {
+		log.Errorf("Error sending alert for %v: err: %v", alert.DID, err)
+	}
+	return alert
+}
+
+// FollowCommits checks the tracked dids at every new root commit, polling
+// commits every interval until stop is closed
+func (m *Monitor) FollowCommits(commits CommitSource, interval time.Duration, stop <-chan struct{}) {
+	lastRoot := ""
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		commit, err := commits.GetLatest()
+		if err != nil {
+			log.Errorf("Error getting the latest root commit: err: %v", err)
+		} else if commit.Root != "" && commit.Root != lastRoot {
+			root, err := claims.ParseTreeRoot(commit.Root)
+			if err != nil {
+				log.Errorf("Error parsing root commit %v: err: %v", commit.Root, err)
+			} else if _, err = m.Check(root); err != nil {
+				log.Errorf("Error checking root commit %v: err: %v", commit.Root, err)
+			} else {
+				log.Infof("Checked root commit %v, block: %v", commit.Root, commit.BlockNumber)
+				lastRoot = commit.Root
+			}
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
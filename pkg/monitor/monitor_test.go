@@ -0,0 +1,260 @@
+package monitor_test
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iden3/go-iden3-core/merkletree"
+	"github.com/joincivil/go-common/pkg/lock"
+	didlib "github.com/ockam-network/did"
+
+	"github.com/joincivil/id-hub/pkg/claims"
+	"github.com/joincivil/id-hub/pkg/claimsstore"
+	"github.com/joincivil/id-hub/pkg/claimtypes"
+	"github.com/joincivil/id-hub/pkg/monitor"
+)
+
+const (
+	expectedHash   = "1b20b1e2a9f0d8c7e6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d9c8b7a6f5e4d3c2b1"
+	unexpectedHash = "1b20b1e2a9f000c7e6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d9c8b7a6f5e4d3c2b1"
+)
+
+type testAlerter struct {
+	alerts []*monitor.Alert
+}
+
+func (a *testAlerter) Alert(alert *monitor.Alert) error {
+	a.alerts = append(a.alerts, alert)
+	return nil
+}
+
+type testCommitSource struct {
+	commit *claimsstore.RootCommit
+}
+
+func (s *testCommitSource) GetLatest() (*claimsstore.RootCommit, error) {
+	return s.commit, nil
+}
+
+func makeTestService(t *testing.T) (*claims.Service, *claimsstore.LevelDBStore, func()) {
+	dir, err := ioutil.TempDir("", "monitortest")
+	if err != nil {
+		t.Fatalf("Should have created a temp dir: err: %v", err)
+	}
+	store, err := claimsstore.NewLevelDBStore(dir)
+	if err != nil {
+		t.Fatalf("Should have opened the store: err: %v", err)
+	}
+	claimService, err := claims.NewService(store, store, nil, nil, nil, nil, lock.NewLocalDLock())
+	if err != nil {
+		t.Fatalf("Should have created the service: err: %v", err)
+	}
+	return claimService, store, func() {
+		store.Close()
+		os.RemoveAll(dir) // nolint: errcheck
+	}
+}
+
+func registerDocument(t *testing.T, claimService *claims.Service, userDid *didlib.DID, mHash string) {
+	hashb, _ := hex.DecodeString(mHash)
+	hash34 := [34]byte{}
+	copy(hash34[:], hashb)
+	claim, err := claimtypes.NewClaimRegisteredDocument(hash34, userDid, claimtypes.JWTDocType)
+	if err != nil {
+		t.Fatalf("Should have made the claim: err: %v", err)
+	}
+	addClaim(t, claimService, userDid, claim.Entry())
+}
+
+func addClaim(t *testing.T, claimService *claims.Service, userDid *didlib.DID, entry *merkletree.Entry) {
+	didMt, err := claimService.BuildDIDMt(userDid)
+	if err != nil {
+		t.Fatalf("Should have built the did tree: err: %v", err)
+	}
+	err = claimService.AddToDIDTree(didMt, userDid, entry)
+	if err != nil {
+		t.Fatalf("Should have added the claim: err: %v", err)
+	}
+	err = claimService.AddNewRootClaim(userDid)
+	if err != nil {
+		t.Fatalf("Should have added the root claim: err: %v", err)
+	}
+}
+
+func TestMonitorCheck(t *testing.T) {
+	claimService, store, cleanup := makeTestService(t)
+	defer cleanup()
+
+	userDid, _ := didlib.Parse("did:ethuri:e7ab0c43-d9fe-4a61-87a3-3fa99ce879e1")
+	secKey, _ := crypto.GenerateKey()
+	err := claimService.CreateTreeForDIDWithPks(userDid, []*ecdsa.PublicKey{&secKey.PublicKey})
+	if err != nil {
+		t.Fatalf("Should have added the keys: err: %v", err)
+	}
+
+	alerter := &testAlerter{}
+	m := monitor.NewMonitor(claimService, claimService, alerter, monitor.StaticAllowList{expectedHash})
+	err = m.Track(userDid, false)
+	if err != nil {
+		t.Fatalf("Should have tracked the did: err: %v", err)
+	}
+	alerts, err := m.Check(nil)
+	if err != nil || len(alerts) != 0 {
+		t.Errorf("Should not have alerted on the existing tree: %v, err: %v", alerts, err)
+	}
+
+	registerDocument(t, claimService, userDid, expectedHash)
+	rootMt, err := merkletree.NewMerkleTree(store.WithPrefix(claimsstore.PrefixRootMerkleTree), 150)
+	if err != nil {
+		t.Fatalf("Should have opened the root tree: err: %v", err)
+	}
+	committedRoot := rootMt.RootKey()
+	registerDocument(t, claimService, userDid, unexpectedHash)
+
+	alerts, err = m.Check(committedRoot)
+	if err != nil || len(alerts) != 0 {
+		t.Errorf("Should not have alerted on the expected document: %v, err: %v", alerts, err)
+	}
+	alerts, err = m.Check(nil)
+	if err != nil {
+		t.Fatalf("Should have checked the tree: err: %v", err)
+	}
+	if len(alerts) != 1 || len(alerter.alerts) != 1 {
+		t.Fatalf("Should have alerted once: %v", alerts)
+	}
+	if alerts[0].ContentHash != unexpectedHash || alerts[0].Type != string(claims.TreeDiffRegistration) ||
+		alerts[0].Version != 2 {
+		t.Errorf("Should have alerted on the unexpected document: %+v", alerts[0])
+	}
+
+	alerts, err = m.Check(nil)
+	if err != nil || len(alerts) != 0 {
+		t.Errorf("Should not have alerted twice: %v, err: %v", alerts, err)
+	}
+
+	// The version seen in the current tree is checked again once committed
+	rootMt, err = merkletree.NewMerkleTree(store.WithPrefix(claimsstore.PrefixRootMerkleTree), 150)
+	if err != nil {
+		t.Fatalf("Should have opened the root tree: err: %v", err)
+	}
+	alerts, err = m.Check(rootMt.RootKey())
+	if err != nil || len(alerts) != 1 || alerts[0].ContentHash != unexpectedHash {
+		t.Errorf("Should have alerted on the committed unexpected document: %v, err: %v", alerts, err)
+	}
+
+	fromStart := monitor.NewMonitor(claimService, claimService, &testAlerter{},
+		monitor.StaticAllowList{expectedHash, unexpectedHash})
+	err = fromStart.Track(userDid, true)
+	if err != nil {
+		t.Fatalf("Should have tracked the did: err: %v", err)
+	}
+	alerts, err = fromStart.Check(nil)
+	if err != nil || len(alerts) != 1 || alerts[0].Type != string(claims.TreeDiffKey) {
+		t.Errorf("Should have alerted on the key from the start of the tree: %v, err: %v", alerts, err)
+	}
+
+	// Anchored document versions not in the allow-list are alerted on
+	docVersion, err := claimtypes.NewClaimDIDDocumentVersionIndex(userDid, 1)
+	if err != nil {
+		t.Fatalf("Should have made the document version: err: %v", err)
+	}
+	docVersion.DocumentHash[0] = 0xab
+	addClaim(t, claimService, userDid, docVersion.Entry())
+	alerts, err = m.Check(nil)
+	if err != nil || len(alerts) != 1 || alerts[0].Type != string(claims.TreeDiffDocumentVersion) ||
+		alerts[0].DocVersion != 1 || alerts[0].DocumentHash != hex.EncodeToString(docVersion.DocumentHash[:]) {
+		t.Errorf("Should have alerted on the document version: %v, err: %v", alerts, err)
+	}
+}
+
+func TestMonitorFollowCommits(t *testing.T) {
+	claimService, store, cleanup := makeTestService(t)
+	defer cleanup()
+
+	userDid, _ := didlib.Parse("did:ethuri:e7ab0c43-d9fe-4a61-87a3-3fa99ce879e1")
+	alerter := &testAlerter{}
+	m := monitor.NewMonitor(claimService, claimService, alerter, monitor.StaticAllowList{})
+	err := m.Track(userDid, false)
+	if err != nil {
+		t.Fatalf("Should have tracked the did: err: %v", err)
+	}
+
+	registerDocument(t, claimService, userDid, unexpectedHash)
+	rootMt, err := merkletree.NewMerkleTree(store.WithPrefix(claimsstore.PrefixRootMerkleTree), 150)
+	if err != nil {
+		t.Fatalf("Should have opened the root tree: err: %v", err)
+	}
+	commits := &testCommitSource{commit: &claimsstore.RootCommit{Root: rootMt.RootKey().Hex()}}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		m.FollowCommits(commits, time.Hour, stop)
+		close(done)
+	}()
+	time.Sleep(200 * time.Millisecond)
+	close(stop)
+	<-done
+
+	if len(alerter.alerts) != 1 || alerter.alerts[0].ContentHash != unexpectedHash {
+		t.Errorf("Should have alerted on the committed document: %v", alerter.alerts)
+	}
+}
+
+func TestFileAllowList(t *testing.T) {
+	dir, err := ioutil.TempDir("", "allowlisttest")
+	if err != nil {
+		t.Fatalf("Should have created a temp dir: err: %v", err)
+	}
+	defer os.RemoveAll(dir) // nolint: errcheck
+	path := filepath.Join(dir, "allowlist")
+	err = ioutil.WriteFile(path, []byte("# expected\n0x"+expectedHash+"\n\n"), 0600)
+	if err != nil {
+		t.Fatalf("Should have written the allow-list: err: %v", err)
+	}
+
+	expected, err := monitor.FileAllowList(path).Expected()
+	if err != nil {
+		t.Fatalf("Should have read the allow-list: err: %v", err)
+	}
+	if len(expected) != 1 || !expected[expectedHash] {
+		t.Errorf("Should have read the expected hash: %v", expected)
+	}
+}
+
+func TestWebhookAlerter(t *testing.T) {
+	received := make(chan *monitor.Alert, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		alert := &monitor.Alert{}
+		err := json.NewDecoder(r.Body).Decode(alert)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- alert
+	}))
+	defer server.Close()
+
+	err := monitor.NewWebhookAlerter(server.URL).Alert(&monitor.Alert{DID: "did:ethuri:123", ContentHash: unexpectedHash})
+	if err != nil {
+		t.Fatalf("Should have posted the alert: err: %v", err)
+	}
+	alert := <-received
+	if alert.DID != "did:ethuri:123" || alert.ContentHash != unexpectedHash {
+		t.Errorf("Should have received the alert: %+v", alert)
+	}
+
+	err = monitor.NewWebhookAlerter(server.URL + "/missing\x7f").Alert(&monitor.Alert{})
+	if err == nil {
+		t.Errorf("Should have failed to post to a bad url")
+	}
+}
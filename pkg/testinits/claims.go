@@ -3,6 +3,7 @@ package testinits
 import (
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/jinzhu/gorm"
 	"github.com/joincivil/go-common/pkg/lock"
 	"github.com/joincivil/id-hub/pkg/claims"
@@ -18,7 +19,11 @@ func MakeService(db *gorm.DB, didService *did.Service,
 	rootCommitStore := claimsstore.NewRootCommitsPGPersister(db)
 	dlock := lock.NewLocalDLock()
 	committer := &claims.FakeRootCommitter{CurrentBlockNumber: big.NewInt(1)}
-	rootService, _ := claims.NewRootService(treeStore, committer, rootCommitStore)
-	claimService, err := claims.NewService(treeStore, signedClaimStore, didService, rootService, dlock)
+	signerKey, _ := crypto.GenerateKey()
+	signer := claims.NewTreeHeadSigner(signerKey)
+	rootService, _ := claims.NewRootService(treeStore, committer, rootCommitStore, signer)
+	revocationStore := claimsstore.NewRevocationPGPersister(db)
+	claimService, err := claims.NewService(treeStore, nodepersister, signedClaimStore, revocationStore,
+		didService, rootService, dlock)
 	return claimService, rootService, err
 }
@@ -49,7 +49,7 @@ func AddDID(ethURI *ethuri.Service, cService *claims.Service) (*didlib.DID, *ecd
 	if err != nil {
 		return nil, nil, err
 	}
-	if err := ethURI.SaveDocument(didDoc); err != nil {
+	if err := ethURI.SaveDocument(didDoc, 0); err != nil {
 		return nil, nil, err
 	}
 
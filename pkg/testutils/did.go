@@ -66,5 +66,13 @@ func BuildTestDocument() *did.Document {
 
 	doc.Authentications = []did.DocAuthenicationWrapper{aw1, aw2}
 
+	// Assertion methods
+	am1 := did.DocAuthenicationWrapper{}
+	d5, _ := didlib.Parse(pk1ID)
+	am1.ID = d5
+	am1.IDOnly = true
+
+	doc.AssertionMethods = []did.DocAuthenicationWrapper{am1}
+
 	return doc
 }
@@ -12,37 +12,60 @@ import (
 
 const (
 	envVarPrefixIDHub = "idhub"
+
+	// PersisterTypeNameLevelDB is the persister type name for PersisterTypeLevelDB
+	PersisterTypeNameLevelDB = "leveldb"
+	// PersisterTypeLevelDB is a persister that stores the merkle trees in an
+	// embedded leveldb instead of PostgreSQL
+	PersisterTypeLevelDB ccfg.PersisterType = ccfg.PersisterTypePostgresql + 1
 )
 
 // IDHubConfig is the master config for the ID Hub API derived from environment
 // variables.
 type IDHubConfig struct {
 	GqlPort                   int    `required:"true" desc:"Sets the ID Hub GraphQL port"`
+	AdminPort                 int    `split_words:"true" desc:"Sets the internal admin port serving /debug/vars, not served if not set"`
 	RootCommitsAddress        string `required:"true" split_words:"true" desc:"address where root commits are stored"`
 	EthereumDefaultPrivateKey string `required:"true" split_words:"true" desc:"Private key to use when sending Ethereum transactions"`
 	EthAPIURL                 string `required:"true" envconfig:"eth_api_url" desc:"Ethereum API address"`
+	HubPrivateKey             string `split_words:"true" desc:"Private key of the hub did and its tree heads, has to differ from the default Ethereum private key"`
+	HubBaseURL                string `envconfig:"hub_base_url" desc:"Public base URL of the hub for the endpoints in the hub metadata"`
+
+	HostedDomains []string `split_words:"true" desc:"Domains hosted by the hub that serve its did-configuration.json"`
+
+	EthrRegistryAddress string `split_words:"true" desc:"Address of the ERC-1056 registry for did:ethr dids, did:ethr is not resolved natively if not set"`
+	EthrNetwork         string `split_words:"true" desc:"Network name of did:ethr dids with a network, like rinkeby in did:ethr:rinkeby:0x..."`
 
 	CronConfig string `envconfig:"cron_config" desc:"Cron config string * * * * *"`
 
 	PersisterType             ccfg.PersisterType `ignored:"true"`
-	PersisterTypeName         string             `split_words:"true" required:"true" desc:"Sets the persister type to use"`
-	PersisterPostgresAddress  string             `split_words:"true" desc:"If persister type is Postgresql, sets the address"`
-	PersisterPostgresPort     int                `split_words:"true" desc:"If persister type is Postgresql, sets the port"`
-	PersisterPostgresDbname   string             `split_words:"true" desc:"If persister type is Postgresql, sets the database name"`
-	PersisterPostgresUser     string             `split_words:"true" desc:"If persister type is Postgresql, sets the database user"`
-	PersisterPostgresPw       string             `split_words:"true" desc:"If persister type is Postgresql, sets the database password"`
-	PersisterPostgresMaxConns *int               `split_words:"true" desc:"If persister type is Postgresql, sets the max conns in pool"`
-	PersisterPostgresMaxIdle  *int               `split_words:"true" desc:"If persister type is Postgresql, sets the max idle conns in pool"`
-	PersisterPostgresConnLife *int               `split_words:"true" desc:"If persister type is Postgresql, sets the max conn lifetime in secs"`
+	PersisterTypeName         string             `split_words:"true" required:"true" desc:"Sets the persister type to use, leveldb only moves the merkle tree nodes out of Postgresql"`
+	PersisterPostgresAddress  string             `split_words:"true" desc:"If persister type is Postgresql or leveldb, sets the address"`
+	PersisterPostgresPort     int                `split_words:"true" desc:"If persister type is Postgresql or leveldb, sets the port"`
+	PersisterPostgresDbname   string             `split_words:"true" desc:"If persister type is Postgresql or leveldb, sets the database name"`
+	PersisterPostgresUser     string             `split_words:"true" desc:"If persister type is Postgresql or leveldb, sets the database user"`
+	PersisterPostgresPw       string             `split_words:"true" desc:"If persister type is Postgresql or leveldb, sets the database password"`
+	PersisterPostgresMaxConns *int               `split_words:"true" desc:"If persister type is Postgresql or leveldb, sets the max conns in pool"`
+	PersisterPostgresMaxIdle  *int               `split_words:"true" desc:"If persister type is Postgresql or leveldb, sets the max idle conns in pool"`
+	PersisterPostgresConnLife *int               `split_words:"true" desc:"If persister type is Postgresql or leveldb, sets the max conn lifetime in secs"`
+	PersisterLeveldbPath      string             `split_words:"true" desc:"If persister type is leveldb, sets the directory of the merkle tree nodes db"`
 
 	RedisHosts []string `split_words:"true" desc:"List of Redis host:port for caching and locking"`
 
+	TreeNodeCacheShared bool `split_words:"true" desc:"Shares the merkle tree node cache between instances using the first Redis host"`
+
 	NatsPersisterDriver string `split_words:"true" desc:"the driver for nats persistence"`
 	NatsID              string `envconfig:"nats_id" desc:"the id of the nats server"`
+	NatsURL             string `envconfig:"nats_url" desc:"the url of the nats server for the monitor, defaults to nats://127.0.0.1:4222"`
 	NatsPrefix          string `split_words:"true" desc:"the prefix for every nats message"`
 
-	DidUniversalResolverHost *string `split_words:"true" desc:"Sets the host for the universal DID resolver"`
-	DidUniversalResolverPort *int    `split_words:"true" desc:"Sets the port for the universal DID resolver"`
+	DidUniversalResolverHost    *string `split_words:"true" desc:"Sets the host for the universal DID resolver"`
+	DidUniversalResolverPort    *int    `split_words:"true" desc:"Sets the port for the universal DID resolver"`
+	DidUniversalResolverTimeout *int    `split_words:"true" desc:"Sets the timeout in secs of calls to the universal DID resolver"`
+
+	DidCacheExpirySecs         *int           `split_words:"true" desc:"Sets the expiry in secs of DID documents in the redis DID cache"`
+	DidCacheMethodExpirySecs   map[string]int `split_words:"true" desc:"Sets the expiry in secs of DID documents in the redis DID cache by method, as method:secs"`
+	DidCacheNotFoundExpirySecs *int           `split_words:"true" desc:"Sets the expiry in secs of not found DIDs in the redis DID cache, 0 disables"`
 }
 
 // OutputUsage prints the usage string to os.Stdout
@@ -73,6 +96,10 @@ func (c *IDHubConfig) PopulateFromEnv() error {
 }
 
 func (c *IDHubConfig) populatePersisterType() error {
+	if c.PersisterTypeName == PersisterTypeNameLevelDB {
+		c.PersisterType = PersisterTypeLevelDB
+		return nil
+	}
 	var err error
 	c.PersisterType, err = ccfg.PersisterTypeFromName(c.PersisterTypeName)
 	return err
@@ -80,7 +107,8 @@ func (c *IDHubConfig) populatePersisterType() error {
 
 func (c *IDHubConfig) validatePersister() error {
 	var err error
-	if c.PersisterType == ccfg.PersisterTypePostgresql {
+	// leveldb only stores the merkle tree nodes, everything else is still in Postgresql
+	if c.PersisterType == ccfg.PersisterTypePostgresql || c.PersisterType == PersisterTypeLevelDB {
 		err = validatePostgresqlPersisterParams(
 			c.PersisterPostgresAddress,
 			c.PersisterPostgresPort,
@@ -90,6 +118,9 @@ func (c *IDHubConfig) validatePersister() error {
 			return err
 		}
 	}
+	if c.PersisterType == PersisterTypeLevelDB && c.PersisterLeveldbPath == "" {
+		return errors.New("leveldb path required")
+	}
 	return nil
 }
 
@@ -72,6 +72,42 @@ func TestIDHubConfig(t *testing.T) {
 	}
 }
 
+func TestIDHubConfigLevelDB(t *testing.T) {
+	setEnvironmentVariables()
+	_ = os.Setenv("IDHUB_PERSISTER_TYPE_NAME", utils.PersisterTypeNameLevelDB)
+	defer os.Setenv("IDHUB_PERSISTER_TYPE_NAME", "postgresql") // nolint: errcheck
+
+	config := &utils.IDHubConfig{}
+	err := config.PopulateFromEnv()
+	if err == nil {
+		t.Error("Should have failed without a leveldb path")
+	}
+
+	_ = os.Setenv("IDHUB_PERSISTER_LEVELDB_PATH", "/tmp/idhub")
+	defer os.Unsetenv("IDHUB_PERSISTER_LEVELDB_PATH") // nolint: errcheck
+
+	config = &utils.IDHubConfig{}
+	err = config.PopulateFromEnv()
+	if err != nil {
+		t.Errorf("Failed to populate from environment: err: %v", err)
+	}
+	if config.PersisterType != utils.PersisterTypeLevelDB {
+		t.Error("Should have gotten leveldb for persister type")
+	}
+	if config.PersisterLeveldbPath != "/tmp/idhub" {
+		t.Error("Should have gotten leveldb path")
+	}
+
+	_ = os.Unsetenv("IDHUB_PERSISTER_POSTGRES_ADDRESS")
+	defer os.Setenv("IDHUB_PERSISTER_POSTGRES_ADDRESS", "localhost") // nolint: errcheck
+
+	config = &utils.IDHubConfig{}
+	err = config.PopulateFromEnv()
+	if err == nil {
+		t.Error("Should have failed without a postgresql address")
+	}
+}
+
 func TestIDHubConfigUsage(t *testing.T) {
 	config := &utils.IDHubConfig{}
 	config.OutputUsage()
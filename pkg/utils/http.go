@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrNonPublicAddress is returned when a public client dials an address that is
+	// not public
+	ErrNonPublicAddress = errors.New("dialing a non-public address is not allowed")
+
+	nonPublicNetworks = parseCIDRs(
+		"0.0.0.0/8",      // this network
+		"10.0.0.0/8",     // private
+		"100.64.0.0/10",  // carrier-grade nat
+		"127.0.0.0/8",    // loopback
+		"169.254.0.0/16", // link-local
+		"172.16.0.0/12",  // private
+		"192.0.0.0/24",   // ietf protocol assignments
+		"192.168.0.0/16", // private
+		"198.18.0.0/15",  // benchmarking
+		"224.0.0.0/4",    // multicast
+		"240.0.0.0/4",    // reserved
+		"::/128",         // unspecified
+		"::1/128",        // loopback
+		"fc00::/7",       // unique local
+		"fe80::/10",      // link-local
+		"ff00::/8",       // multicast
+	)
+)
+
+// NewPublicHTTPClient returns an http client with timeout that only connects to
+// public addresses. The address is checked after the host is resolved, so hosts
+// resolving to loopback, private or link-local addresses are refused too.
+// Proxies from the environment are not used.
+func NewPublicHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: publicAddressControl,
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+}
+
+// IsPublicIP returns true if the ip is not a loopback, private, link-local,
+// multicast or reserved address
+func IsPublicIP(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func publicAddressControl(network string, address string, c syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.Wrap(err, "publicaddresscontrol.splithostport")
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !IsPublicIP(ip) {
+		return errors.Wrapf(ErrNonPublicAddress, "address: %v", address)
+	}
+	return nil
+}
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
@@ -0,0 +1,39 @@
+package utils_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joincivil/id-hub/pkg/utils"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	for _, ip := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1",
+		"169.254.169.254", "0.0.0.0", "::1", "fe80::1", "fd00::1", "::ffff:127.0.0.1"} {
+		if utils.IsPublicIP(net.ParseIP(ip)) {
+			t.Errorf("Should not have allowed %v", ip)
+		}
+	}
+	for _, ip := range []string{"8.8.8.8", "93.184.216.34", "2606:2800:220:1::1"} {
+		if !utils.IsPublicIP(net.ParseIP(ip)) {
+			t.Errorf("Should have allowed %v", ip)
+		}
+	}
+}
+
+func TestPublicHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := utils.NewPublicHTTPClient(5 * time.Second)
+	_, err := client.Get(server.URL)
+	if err == nil || !strings.Contains(err.Error(), utils.ErrNonPublicAddress.Error()) {
+		t.Errorf("Should not have connected to a loopback address: err: %v", err)
+	}
+}